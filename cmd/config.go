@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/profiler"
+	"github.com/leandro-lugaresi/hub"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"google.golang.org/api/option"
@@ -21,8 +22,10 @@ import (
 	"github.com/traPtitech/traQ/service/fcm"
 	"github.com/traPtitech/traQ/service/imaging"
 	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/relay"
 	"github.com/traPtitech/traQ/service/search"
 	"github.com/traPtitech/traQ/service/variable"
+	"github.com/traPtitech/traQ/service/webpush"
 	"github.com/traPtitech/traQ/utils/storage"
 )
 
@@ -92,6 +95,27 @@ type Config struct {
 		Password string `mapstructure:"password" yaml:"password"`
 	} `mapstructure:"es" yaml:"es"`
 
+	// Search 検索エンジン設定
+	Search struct {
+		// Engine 検索エンジン (default: "")
+		// 	"": es.urlが設定されている場合はElasticsearch、そうでない場合は検索無効
+		// 	mariadb: MariaDBのFULLTEXTインデックスを用いた組み込み検索エンジン
+		Engine string `mapstructure:"engine" yaml:"engine"`
+	} `mapstructure:"search" yaml:"search"`
+
+	// Relay 複数インスタンス間のイベント中継設定
+	Relay struct {
+		// Redis Redis Pub/Sub設定
+		Redis struct {
+			// Addr アドレス (host:port) 空の場合は単一インスタンスとして動作します (default: "")
+			Addr string `mapstructure:"addr" yaml:"addr"`
+			// Password パスワード (default: "")
+			Password string `mapstructure:"password" yaml:"password"`
+			// Channel Pub/Subのチャンネル名 (default: "traq.relay")
+			Channel string `mapstructure:"channel" yaml:"channel"`
+		} `mapstructure:"redis" yaml:"redis"`
+	} `mapstructure:"relay" yaml:"relay"`
+
 	// Storage ファイルストレージ設定
 	Storage struct {
 		// Type ストレージタイプ (default: local)
@@ -180,6 +204,16 @@ type Config struct {
 		} `mapstructure:"serviceAccount" yaml:"serviceAccount"`
 	} `mapstructure:"firebase" yaml:"firebase"`
 
+	// WebPush Web Push (VAPID) 設定
+	WebPush struct {
+		// VAPIDPrivateKey VAPID秘密鍵 (URL-safe Base64) 空の場合はWeb Pushを使用しません (default: "")
+		//
+		// traQ vapid-keygen で生成できます
+		VAPIDPrivateKey string `mapstructure:"vapidPrivateKey" yaml:"vapidPrivateKey"`
+		// Subject VAPIDのsubject (mailto:またはhttps:のURL) 空の場合はoriginを使用します (default: "")
+		Subject string `mapstructure:"subject" yaml:"subject"`
+	} `mapstructure:"webPush" yaml:"webPush"`
+
 	// OAuth2 OAuth2認可サーバー設定
 	OAuth2 struct {
 		// IsRefreshEnabled リフレッシュトークンを有効にするかどうか (default: false)
@@ -273,6 +307,10 @@ func init() {
 	viper.SetDefault("es.url", "")
 	viper.SetDefault("es.username", "elastic")
 	viper.SetDefault("es.password", "password")
+	viper.SetDefault("search.engine", "")
+	viper.SetDefault("relay.redis.addr", "")
+	viper.SetDefault("relay.redis.password", "")
+	viper.SetDefault("relay.redis.channel", "traq.relay")
 	viper.SetDefault("storage.type", "local")
 	viper.SetDefault("storage.local.dir", "./storage")
 	viper.SetDefault("storage.swift.username", "")
@@ -295,6 +333,8 @@ func init() {
 	viper.SetDefault("gcp.serviceAccount.file", "")
 	viper.SetDefault("gcp.stackdriver.profiler.enabled", false)
 	viper.SetDefault("firebase.serviceAccount.file", "")
+	viper.SetDefault("webPush.vapidPrivateKey", "")
+	viper.SetDefault("webPush.subject", "")
 	viper.SetDefault("oauth2.isRefreshEnabled", false)
 	viper.SetDefault("oauth2.accessTokenExp", 60*60*24*365)
 	viper.SetDefault("externalAuthentication.enabled", false)
@@ -431,16 +471,41 @@ func initStackdriverProfiler(c *Config) error {
 	}, option.WithCredentialsFile(c.GCP.ServiceAccount.File))
 }
 
-func newFCMClientIfAvailable(repo repository.Repository, logger *zap.Logger, unreadCounter counter.UnreadMessageCounter, file variable.FirebaseCredentialsFilePathString) (fcm.Client, error) {
+func newFCMClientIfAvailable(repo repository.Repository, logger *zap.Logger, unreadCounter counter.UnreadMessageCounter, file variable.FirebaseCredentialsFilePathString, webPushConfig webpush.Config) (fcm.Client, error) {
+	var clients []fcm.Client
 	if len(file) > 0 {
-		return fcm.NewClientWithCredentialsFile(repo, logger, unreadCounter, file)
+		c, err := fcm.NewClientWithCredentialsFile(repo, logger, unreadCounter, file)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, c)
+	}
+	if webPushConfig.Valid() {
+		c, err := webpush.NewClient(repo, logger, unreadCounter, webPushConfig)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, c)
+	}
+	if len(clients) == 0 {
+		return fcm.NewNullClient(), nil
+	}
+	return fcm.NewMultiClient(clients...), nil
+}
+
+func newRelayIfAvailable(logger *zap.Logger, config relay.RedisConfig) (relay.Relay, error) {
+	if len(config.Addr) > 0 {
+		return relay.NewRedisRelay(config, logger)
 	}
-	return fcm.NewNullClient(), nil
+	return relay.NewNullRelay(), nil
 }
 
-func initSearchServiceIfAvailable(mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger, config search.ESEngineConfig) (search.Engine, error) {
+func initSearchServiceIfAvailable(db *gorm.DB, hub *hub.Hub, mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger, config search.ESEngineConfig, mariadbConfig search.MariaDBEngineConfig) (search.Engine, error) {
+	if mariadbConfig.Enabled {
+		return search.NewMariaDBEngine(db, hub, mm, cm, repo, logger)
+	}
 	if len(config.URL) > 0 {
-		return search.NewESEngine(mm, cm, repo, logger, config)
+		return search.NewESEngine(hub, mm, cm, repo, logger, config)
 	}
 	return search.NewNullEngine(), nil
 }
@@ -461,6 +526,31 @@ func provideESEngineConfig(c *Config) search.ESEngineConfig {
 	}
 }
 
+func provideMariaDBEngineConfig(c *Config) search.MariaDBEngineConfig {
+	return search.MariaDBEngineConfig{
+		Enabled: c.Search.Engine == "mariadb",
+	}
+}
+
+func provideWebPushConfig(c *Config) webpush.Config {
+	subject := c.WebPush.Subject
+	if len(subject) == 0 {
+		subject = c.Origin
+	}
+	return webpush.Config{
+		VAPIDPrivateKey: c.WebPush.VAPIDPrivateKey,
+		Subject:         subject,
+	}
+}
+
+func provideRelayRedisConfig(c *Config) relay.RedisConfig {
+	return relay.RedisConfig{
+		Addr:     c.Relay.Redis.Addr,
+		Password: c.Relay.Redis.Password,
+		Channel:  c.Relay.Redis.Channel,
+	}
+}
+
 func provideImageProcessorConfig(c *Config) imaging.Config {
 	return imaging.Config{
 		MaxPixels:        c.Imaging.MaxPixels,
@@ -529,6 +619,11 @@ func provideRouterExternalAuthConfig(c *Config) router.ExternalAuthConfig {
 }
 
 func provideRouterConfig(c *Config) *router.Config {
+	var vapidPublicKey string
+	if len(c.WebPush.VAPIDPrivateKey) > 0 {
+		// 不正な鍵の場合はWeb Pushクライアントの初期化時にエラーになる
+		vapidPublicKey, _ = webpush.VAPIDPublicKey(c.WebPush.VAPIDPrivateKey)
+	}
 	return &router.Config{
 		Development:      c.DevMode,
 		Version:          Version,
@@ -540,5 +635,6 @@ func provideRouterConfig(c *Config) *router.Config {
 		IsRefreshEnabled: c.OAuth2.IsRefreshEnabled,
 		SkyWaySecretKey:  c.SkyWay.SecretKey,
 		ExternalAuth:     provideRouterExternalAuthConfig(c),
+		VAPIDPublicKey:   vapidPublicKey,
 	}
 }
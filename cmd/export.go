@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/utils/archive"
+	"github.com/traPtitech/traQ/utils/gormzap"
+)
+
+// exportCommand アーカイブ書き出しコマンド
+func exportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export <dir>",
+		Short: "export channels, messages, stamps, pins, clips, user groups and files to an archive directory",
+		Long: "Export channels, messages, stamps, pins, clips, user groups and files to an archive directory (JSONL + files).\n" +
+			"The archive can be loaded into another instance with the import command.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			// Logger
+			logger := getCLILogger()
+			defer logger.Sync()
+
+			// Database
+			db, err := c.getDatabase()
+			if err != nil {
+				logger.Fatal("failed to connect database", zap.Error(err))
+			}
+			db.Logger = gormzap.New(logger.Named("gorm"))
+			sqlDB, err := db.DB()
+			if err != nil {
+				logger.Fatal("failed to get *sql.DB", zap.Error(err))
+			}
+			defer sqlDB.Close()
+
+			// FileStorage
+			fs, err := c.getFileStorage()
+			if err != nil {
+				logger.Fatal("failed to setup file storage", zap.Error(err))
+			}
+
+			if err := archive.Export(db, fs, args[0], c.Origin, logger); err != nil {
+				logger.Fatal("failed to export", zap.Error(err))
+			}
+			logger.Info("finished exporting", zap.String("dir", args[0]))
+		},
+	}
+}
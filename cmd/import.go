@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/utils/archive"
+	"github.com/traPtitech/traQ/utils/gormzap"
+)
+
+// importCommand アーカイブ読み込みコマンド
+func importCommand() *cobra.Command {
+	var opts archive.ImportOptions
+
+	cmd := cobra.Command{
+		Use:   "import <dir>",
+		Short: "import an archive directory created by the export command",
+		Long: "Import an archive directory created by the export command into an instance which has no messages.\n" +
+			"All IDs are reassigned. Users, stamps and user groups with the same name are reused.\n" +
+			"Imported users have no password and the user role unless --keep-roles is specified, and imported bot users are deactivated.\n" +
+			"The import is done in a single transaction, so it can be retried after a failure.\n" +
+			"Run this command while traQ server is stopped.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			// Logger
+			logger := getCLILogger()
+			defer logger.Sync()
+
+			// Database
+			db, err := c.getDatabase()
+			if err != nil {
+				logger.Fatal("failed to connect database", zap.Error(err))
+			}
+			db.Logger = gormzap.New(logger.Named("gorm"))
+			sqlDB, err := db.DB()
+			if err != nil {
+				logger.Fatal("failed to get *sql.DB", zap.Error(err))
+			}
+			defer sqlDB.Close()
+
+			// FileStorage
+			fs, err := c.getFileStorage()
+			if err != nil {
+				logger.Fatal("failed to setup file storage", zap.Error(err))
+			}
+
+			if err := archive.Import(db, fs, args[0], c.Origin, opts, logger); err != nil {
+				logger.Fatal("failed to import", zap.Error(err))
+			}
+			logger.Info("finished importing", zap.String("dir", args[0]))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opts.KeepRoles, "keep-roles", false, "keep the roles of users in the archive (e.g. admin) if the role exists in this instance")
+
+	return &cmd
+}
+
+// importSlackCommand Slackエクスポート読み込みコマンド
+func importSlackCommand() *cobra.Command {
+	var (
+		parentChannel string
+		convertOnly   string
+	)
+
+	cmd := cobra.Command{
+		Use:   "import-slack <zip>",
+		Short: "import a Slack workspace export zip",
+		Long: "Import a Slack workspace export zip (channels, users, messages, reactions) into an instance which has no messages.\n" +
+			"Slack users are imported as placeholder users without password (users with the same name are reused).\n" +
+			"Mentions and channel links are converted into embeddings, and emojis and reactions into stamps which exist in the instance.\n" +
+			"Attached files are not imported. Run this command while traQ server is stopped.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			// Logger
+			logger := getCLILogger()
+			defer logger.Sync()
+
+			// Slackエクスポートをアーカイブに変換
+			dir := convertOnly
+			if len(dir) == 0 {
+				tmp, err := os.MkdirTemp("", "traq-slack-")
+				if err != nil {
+					logger.Fatal("failed to create temporary directory", zap.Error(err))
+				}
+				defer os.RemoveAll(tmp)
+				dir = filepath.Join(tmp, "archive")
+			}
+			logger.Info("converting slack export...")
+			if err := archive.ConvertSlack(args[0], dir, archive.SlackOptions{ParentChannel: parentChannel}, logger); err != nil {
+				logger.Fatal("failed to convert slack export", zap.Error(err))
+			}
+			if len(convertOnly) > 0 {
+				logger.Info("finished converting", zap.String("dir", dir))
+				return
+			}
+
+			// Database
+			db, err := c.getDatabase()
+			if err != nil {
+				logger.Fatal("failed to connect database", zap.Error(err))
+			}
+			db.Logger = gormzap.New(logger.Named("gorm"))
+			sqlDB, err := db.DB()
+			if err != nil {
+				logger.Fatal("failed to get *sql.DB", zap.Error(err))
+			}
+			defer sqlDB.Close()
+
+			// FileStorage
+			fs, err := c.getFileStorage()
+			if err != nil {
+				logger.Fatal("failed to setup file storage", zap.Error(err))
+			}
+
+			if err := archive.Import(db, fs, dir, c.Origin, archive.ImportOptions{}, logger); err != nil {
+				logger.Fatal("failed to import", zap.Error(err))
+			}
+			logger.Info("finished importing")
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&parentChannel, "parent-channel", "", "name of the root channel to place imported channels under (default: root)")
+	flags.StringVar(&convertOnly, "convert-only", "", "only convert the zip into an archive directory at the specified path, which can be imported later with the import command")
+
+	return &cmd
+}
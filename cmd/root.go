@@ -73,6 +73,10 @@ func init() {
 		confCommand(),
 		fileCommand(),
 		stampCommand(),
+		exportCommand(),
+		importCommand(),
+		importSlackCommand(),
+		vapidKeygenCommand(),
 		versionCommand(),
 		healthcheckCommand(),
 	)
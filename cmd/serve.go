@@ -193,6 +193,9 @@ func (s *Server) Start(address string) error {
 		}
 	}()
 	s.SS.StampThrottler.Start()
+	s.SS.Scheduler.Start()
+	s.SS.AuditRecorder.Start()
+	s.SS.OutgoingWebhook.Start()
 	return s.Router.Start(address)
 }
 
@@ -218,6 +221,16 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		s.L.Info("Bot shutdown")
 		return err
 	})
+	eg.Go(func() error {
+		err := s.SS.Scheduler.Shutdown(ctx)
+		s.L.Info("Scheduler shutdown")
+		return err
+	})
+	eg.Go(func() error {
+		err := s.SS.OutgoingWebhook.Shutdown(ctx)
+		s.L.Info("Outgoing webhook shutdown")
+		return err
+	})
 	eg.Go(func() error {
 		err := s.SS.OGP.Shutdown()
 		s.L.Info("OGP shutdown")
@@ -238,5 +251,11 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		s.L.Info("Message manager shutdown")
 		return err
 	})
-	return eg.Wait()
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	// 他ノードへの状態の通知が終わってから中継を停止する
+	err := s.SS.Relay.Close()
+	s.L.Info("Relay shutdown")
+	return err
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router"
 	"github.com/traPtitech/traQ/service"
+	"github.com/traPtitech/traQ/service/audit"
 	"github.com/traPtitech/traQ/service/bot"
 	botWS "github.com/traPtitech/traQ/service/bot/ws"
 	"github.com/traPtitech/traQ/service/channel"
@@ -22,7 +23,9 @@ import (
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/notification"
 	"github.com/traPtitech/traQ/service/ogp"
+	"github.com/traPtitech/traQ/service/outgoingwebhook"
 	rbac2 "github.com/traPtitech/traQ/service/rbac"
+	"github.com/traPtitech/traQ/service/scheduler"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/webrtcv3"
 	"github.com/traPtitech/traQ/service/ws"
@@ -31,6 +34,7 @@ import (
 
 func newServer(hub *hub.Hub, db *gorm.DB, repo repository.Repository, fs storage.FileStorage, logger *zap.Logger, c *Config) (*Server, error) {
 	wire.Build(
+		audit.NewRecorder,
 		bot.NewService,
 		channel.InitChannelManager,
 		file.InitFileManager,
@@ -43,19 +47,25 @@ func newServer(hub *hub.Hub, db *gorm.DB, repo repository.Repository, fs storage
 		imaging.NewProcessor,
 		notification.NewService,
 		ogp.NewServiceImpl,
+		outgoingwebhook.NewService,
 		rbac2.New,
+		scheduler.NewScheduler,
 		viewer.NewManager,
 		webrtcv3.NewManager,
 		ws.NewStreamer,
 		botWS.NewStreamer,
 		router.Setup,
 		newFCMClientIfAvailable,
+		newRelayIfAvailable,
 		initSearchServiceIfAvailable,
 		provideServerOriginString,
 		provideFirebaseCredentialsFilePathString,
 		provideImageProcessorConfig,
 		provideRouterConfig,
 		provideESEngineConfig,
+		provideMariaDBEngineConfig,
+		provideRelayRedisConfig,
+		provideWebPushConfig,
 		wire.Struct(new(service.Services), "*"),
 		wire.Struct(new(Server), "*"),
 		wire.Bind(new(repository.ChannelRepository), new(repository.Repository)),
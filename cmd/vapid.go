@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/traPtitech/traQ/service/webpush"
+)
+
+// vapidKeygenCommand Web Push用VAPID鍵生成コマンド
+func vapidKeygenCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "vapid-keygen",
+		Short: "Generate a VAPID key pair for Web Push",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			priv, pub, err := webpush.GenerateVAPIDKeys()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("private key (webPush.vapidPrivateKey): %s\n", priv)
+			fmt.Printf("public key: %s\n", pub)
+			return nil
+		},
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router"
 	"github.com/traPtitech/traQ/service"
+	"github.com/traPtitech/traQ/service/audit"
 	"github.com/traPtitech/traQ/service/bot"
 	"github.com/traPtitech/traQ/service/bot/ws"
 	"github.com/traPtitech/traQ/service/channel"
@@ -21,7 +22,9 @@ import (
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/notification"
 	"github.com/traPtitech/traQ/service/ogp"
+	"github.com/traPtitech/traQ/service/outgoingwebhook"
 	"github.com/traPtitech/traQ/service/rbac"
+	"github.com/traPtitech/traQ/service/scheduler"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/webrtcv3"
 	ws2 "github.com/traPtitech/traQ/service/ws"
@@ -37,14 +40,19 @@ import (
 // Injectors from serve_wire.go:
 
 func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storage.FileStorage, logger *zap.Logger, c2 *Config) (*Server, error) {
-	manager, err := channel.InitChannelManager(repo, logger)
+	redisConfig := provideRelayRedisConfig(c2)
+	relayRelay, err := newRelayIfAvailable(logger, redisConfig)
 	if err != nil {
 		return nil, err
 	}
-	webrtcv3Manager := webrtcv3.NewManager(hub2)
-	streamer := ws.NewStreamer(hub2, webrtcv3Manager, logger)
+	manager, err := channel.InitChannelManager(repo, relayRelay, logger)
+	if err != nil {
+		return nil, err
+	}
+	webrtcv3Manager := webrtcv3.NewManager(hub2, relayRelay)
+	streamer := ws.NewStreamer(hub2, webrtcv3Manager, relayRelay, logger)
 	botService := bot.NewService(repo, manager, hub2, streamer, logger)
-	onlineCounter := counter.NewOnlineCounter(hub2)
+	onlineCounter := counter.NewOnlineCounter(hub2, relayRelay)
 	unreadMessageCounter, err := counter.NewUnreadMessageCounter(db, hub2)
 	if err != nil {
 		return nil, err
@@ -57,13 +65,14 @@ func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storag
 	if err != nil {
 		return nil, err
 	}
-	messageManager, err := message.NewMessageManager(repo, manager, logger)
+	messageManager, err := message.NewMessageManager(repo, manager, relayRelay, logger)
 	if err != nil {
 		return nil, err
 	}
 	stampThrottler := exevent.NewStampThrottler(hub2, messageManager)
 	firebaseCredentialsFilePathString := provideFirebaseCredentialsFilePathString(c2)
-	client, err := newFCMClientIfAvailable(repo, logger, unreadMessageCounter, firebaseCredentialsFilePathString)
+	webpushConfig := provideWebPushConfig(c2)
+	client, err := newFCMClientIfAvailable(repo, logger, unreadMessageCounter, firebaseCredentialsFilePathString, webpushConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -73,24 +82,29 @@ func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storag
 	if err != nil {
 		return nil, err
 	}
-	viewerManager := viewer.NewManager(hub2)
-	wsStreamer := ws2.NewStreamer(hub2, viewerManager, webrtcv3Manager, logger)
+	viewerManager := viewer.NewManager(hub2, relayRelay)
+	wsStreamer := ws2.NewStreamer(hub2, viewerManager, webrtcv3Manager, relayRelay, logger)
 	serverOriginString := provideServerOriginString(c2)
-	notificationService := notification.NewService(repo, manager, messageManager, fileManager, hub2, logger, client, wsStreamer, viewerManager, serverOriginString)
-	ogpService, err := ogp.NewServiceImpl(repo, logger)
+	rbacRBAC, err := rbac.New(repo, relayRelay, logger)
 	if err != nil {
 		return nil, err
 	}
-	rbacRBAC, err := rbac.New(repo)
+	notificationService := notification.NewService(repo, manager, messageManager, fileManager, hub2, logger, client, wsStreamer, viewerManager, serverOriginString, rbacRBAC)
+	ogpService, err := ogp.NewServiceImpl(repo, logger)
 	if err != nil {
 		return nil, err
 	}
 	esEngineConfig := provideESEngineConfig(c2)
-	engine, err := initSearchServiceIfAvailable(messageManager, manager, repo, logger, esEngineConfig)
+	mariaDBEngineConfig := provideMariaDBEngineConfig(c2)
+	engine, err := initSearchServiceIfAvailable(db, hub2, messageManager, manager, repo, logger, esEngineConfig, mariaDBEngineConfig)
 	if err != nil {
 		return nil, err
 	}
+	schedulerScheduler := scheduler.NewScheduler(repo, manager, messageManager, rbacRBAC, logger)
+	recorder := audit.NewRecorder(hub2, repo, logger)
+	outgoingwebhookService := outgoingwebhook.NewService(hub2, repo, logger)
 	services := &service.Services{
+		AuditRecorder:        recorder,
 		BOT:                  botService,
 		ChannelManager:       manager,
 		OnlineCounter:        onlineCounter,
@@ -104,7 +118,10 @@ func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storag
 		MessageManager:       messageManager,
 		Notification:         notificationService,
 		OGP:                  ogpService,
+		OutgoingWebhook:      outgoingwebhookService,
 		RBAC:                 rbacRBAC,
+		Relay:                relayRelay,
+		Scheduler:            schedulerScheduler,
 		Search:               engine,
 		ViewerManager:        viewerManager,
 		WebRTCv3:             webrtcv3Manager,
@@ -97,6 +97,20 @@ const (
 	// 		message: *model.Message
 	// 		old_message: *model.Message
 	MessageUpdated = "message.updated"
+	// MessageComponentsUpdated メッセージのコンポーネントが更新された
+	// 	Fields:
+	// 		message_id: uuid.UUID
+	// 		message: *model.Message
+	MessageComponentsUpdated = "message.components_updated"
+	// MessageComponentInteracted メッセージのコンポーネントが操作された
+	// 	Fields:
+	// 		message_id: uuid.UUID
+	// 		channel_id: uuid.UUID
+	// 		bot_user_id: uuid.UUID
+	// 		user_id: uuid.UUID
+	// 		component: model.MessageComponent
+	// 		values: []string
+	MessageComponentInteracted = "message.component_interacted"
 	// MessageDeleted メッセージが削除された
 	// 	Fields:
 	// 		message_id: uuid.UUID
@@ -139,6 +153,30 @@ const (
 	// 		message: *model.Message
 	// 		cited_ids: []uuid.UUID	引用されたメッセージのIDの配列
 	MessageCited = "message.cited"
+	// MessageReported メッセージが通報された
+	// 	Fields:
+	// 		report_id: uuid.UUID
+	// 		report: *model.MessageReport
+	// 		message_id: uuid.UUID
+	MessageReported = "message.reported"
+	// ScheduledMessageFailed 予約投稿メッセージの投稿に失敗した
+	// 	Fields:
+	// 		scheduled_message_id: uuid.UUID
+	// 		scheduled_message: *model.ScheduledMessage
+	// 		user_id: uuid.UUID	予約したユーザーのID
+	ScheduledMessageFailed = "scheduled_message.failed"
+	// MessageReplied スレッドにメッセージが返信された
+	// 	Fields:
+	// 		message_id: uuid.UUID	返信メッセージのID
+	// 		message: *model.Message
+	// 		parent_message_id: uuid.UUID	スレッドの親メッセージのID
+	MessageReplied = "message.replied"
+	// MessagesMoved メッセージが別のチャンネルに移動された
+	// 	Fields:
+	// 		message_ids: []uuid.UUID
+	// 		from_channel_id: uuid.UUID
+	// 		to_channel_id: uuid.UUID
+	MessagesMoved = "message.moved"
 
 	// ChannelCreated チャンネルが作成された
 	// 	Fields:
@@ -161,6 +199,7 @@ const (
 	// 	Fields:
 	// 		channel_id: uuid.UUID
 	// 		private: bool
+	// 		deleted_unreads: []*model.Unread
 	ChannelDeleted = "channel.deleted"
 	// ChannelRead チャンネルのメッセージが既読された
 	// 	Fields:
@@ -188,6 +227,12 @@ const (
 	// 		channel_id: uuid.UUID
 	//    subscriber_ids: []uuid.UUID
 	ChannelSubscribersChanged = "channel.subscribers_changed"
+	// ChannelMembersChanged プライベートチャンネルのメンバーが変化した
+	// 	Fields:
+	// 		channel_id: uuid.UUID
+	// 		added_ids: []uuid.UUID
+	// 		removed_ids: []uuid.UUID
+	ChannelMembersChanged = "channel.members_changed"
 
 	// StampCreated スタンプが作成された
 	// 	Fields:
@@ -267,6 +312,17 @@ const (
 	// 		bot_id: uuid.UUID
 	// 		channel_id: uuid.UUID
 	BotLeft = "bot.left"
+	// BotCommandInvoked Botのコマンドが実行された
+	// 	Fields:
+	// 		invocation: *model.BotCommandInvocation
+	// 		command: *model.BotCommand
+	// 		args: map[string]interface{}
+	BotCommandInvoked = "bot.command_invoked"
+	// BotEventDeliveriesRequeued Botのデッドレターが再送待ちに戻された
+	// 	Fields:
+	// 		bot_id: uuid.UUID
+	// 		count: int
+	BotEventDeliveriesRequeued = "bot.event_deliveries_requeued"
 
 	// UserWebRTCv3StateChanged ユーザーのWebRTCの状態が変化した
 	// 	Fields:
@@ -331,6 +387,16 @@ const (
 	// 		clip_folder_message: *model.ClipFolderMessage
 	ClipFolderMessageAdded = "clip_folder_message.added"
 
+	// AuditActionPerformed 監査ログの記録対象の操作が行われた
+	// 	Fields:
+	// 		action: model.AuditAction
+	// 		actor_id: uuid.UUID	操作したユーザーのID (未ログインの場合はuuid.Nil)
+	// 		target: string	操作対象のID、またはロール名
+	// 		detail: model.AuditLogDetail
+	// 		ip_address: string
+	// 		datetime: time.Time
+	AuditActionPerformed = "audit.action_performed"
+
 	// MessageStampsUpdated メッセージに押されているスタンプが変化した。このイベントはスロットリングされています
 	// 	Fields:
 	// 		message_id: uuid.UUID
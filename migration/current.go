@@ -45,6 +45,27 @@ func Migrations() []*gormigrate.Migration {
 		v32(), // ユーザーの表示名上限を32文字に
 		v33(), // 未読テーブルにチャンネルIDカラムを追加 / インデックス類の更新 / 不要なレコードの削除
 		v34(), // 未読テーブルのcreated_atカラムをメッセージテーブルを元に更新 / カラム名を変更
+		v35(), // メッセージにスレッドの親メッセージIDを追加
+		v36(), // メッセージ通報に対応状態を追加
+		v37(), // 組み込み検索エンジン用のメッセージインデックス
+		v38(), // 予約投稿メッセージテーブル、予約投稿メッセージパーミッションの付与
+		v39(), // archived_messagesテーブルをmessage_revisionsにリネーム
+		v40(), // 通知設定の拡張
+		v41(), // Web Push購読テーブル、Web Push購読登録パーミッションの付与
+		v42(), // チャンネルロールテーブル、チャンネル権限上書きテーブル
+		v43(), // プライベートチャンネルメンバー変更パーミッションの付与
+		v44(), // 監査ログテーブル
+		v45(), // 外部送信Webhookテーブル、外部送信Webhook配送ログテーブル
+		v46(), // Webhookテーブルにメッセージテンプレートカラムを追加
+		v47(), // Botコマンドテーブル、Botコマンド実行記録テーブル、Botコマンド管理パーミッションの付与
+		v48(), // メッセージコンポーネントテーブル
+		v49(), // Botイベント配送アウトボックステーブル
+		v50(), // Botイベント署名シークレット
+		v51(), // Botイベント購読フィルター
+		v52(), // 予約投稿メッセージに投稿処理開始日時を追加
+		v53(), // Botイベント配送に再送処理権の期限を追加
+		v54(), // 外部送信Webhook配送アウトボックステーブル
+		v55(), // Botコマンド名の一意制約をBotごとに変更
 	}
 }
 
@@ -53,11 +74,17 @@ func Migrations() []*gormigrate.Migration {
 // 最新のスキーマの全テーブルのモデル構造体を記述すること
 func AllTables() []interface{} {
 	return []interface{}{
+		&model.AuditLog{},
 		&model.ChannelEvent{},
+		&model.ChannelRole{},
+		&model.ChannelPermissionOverride{},
 		&model.UserRole{},
 		&model.RolePermission{},
 		&model.DMChannelMapping{},
 		&model.ChannelLatestMessage{},
+		&model.BotCommandInvocation{},
+		&model.BotCommand{},
+		&model.BotEventDelivery{},
 		&model.BotEventLog{},
 		&model.BotJoinChannel{},
 		&model.Bot{},
@@ -65,20 +92,27 @@ func AllTables() []interface{} {
 		&model.OAuth2Authorize{},
 		&model.OAuth2Token{},
 		&model.MessageReport{},
+		&model.MessageSearchIndex{},
+		&model.ScheduledMessage{},
+		&model.OutgoingWebhookDelivery{},
+		&model.OutgoingWebhookDeliveryLog{},
+		&model.OutgoingWebhook{},
 		&model.WebhookBot{},
 		&model.Stamp{},
 		&model.UsersTag{},
 		&model.Unread{},
 		&model.Star{},
 		&model.Device{},
+		&model.WebPushSubscription{},
 		&model.Pin{},
+		&model.MessageComponents{},
 		&model.FileACLEntry{},
 		&model.FileThumbnail{},
 		&model.FileMeta{},
 		&model.UsersPrivateChannel{},
 		&model.UserSubscribeChannel{},
 		&model.Tag{},
-		&model.ArchivedMessage{},
+		&model.MessageRevision{},
 		&model.ClipFolderMessage{},
 		&model.Message{},
 		&model.StampPalette{},
@@ -89,6 +123,9 @@ func AllTables() []interface{} {
 		&model.UserProfile{},
 		&model.Channel{},
 		&model.ClipFolder{},
+		&model.UserNotificationKeyword{},
+		&model.UserGroupMentionMute{},
+		&model.UserChannelPushMute{},
 		&model.UserSettings{},
 		&model.User{},
 		&model.MessageStamp{},
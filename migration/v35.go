@@ -0,0 +1,19 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// v35 メッセージにスレッドの親メッセージIDを追加
+func v35() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "35",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.Exec("ALTER TABLE `messages` ADD COLUMN `parent_message_id` char(36) DEFAULT NULL AFTER `text`").Error; err != nil {
+				return err
+			}
+			return db.Exec("ALTER TABLE `messages` ADD KEY `idx_messages_parent_message_id_deleted_at_created_at` (`parent_message_id`, `deleted_at`, `created_at`)").Error
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v36 メッセージ通報に対応状態を追加
+func v36() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "36",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v36MessageReport{})
+		},
+	}
+}
+
+type v36MessageReport struct {
+	ID        uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	MessageID uuid.UUID              `gorm:"type:char(36);not null;uniqueIndex:message_reporter"`
+	Reporter  uuid.UUID              `gorm:"type:char(36);not null;uniqueIndex:message_reporter"`
+	Reason    string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	Status    string                 `gorm:"type:varchar(10);not null;default:'pending';index"`
+	HandledBy optional.Of[uuid.UUID] `gorm:"type:char(36)"`
+	HandledAt optional.Of[time.Time] `gorm:"precision:6"`
+	CreatedAt time.Time              `gorm:"precision:6;index"`
+	DeletedAt gorm.DeletedAt         `gorm:"precision:6"`
+}
+
+func (*v36MessageReport) TableName() string {
+	return "message_reports"
+}
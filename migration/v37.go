@@ -0,0 +1,39 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v37 組み込み検索エンジン用のメッセージインデックステーブルを追加
+func v37() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "37",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v37MessageSearchIndex{})
+		},
+	}
+}
+
+type v37MessageSearchIndex struct {
+	MessageID      uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID         uuid.UUID `gorm:"type:char(36);not null;index"`
+	ChannelID      uuid.UUID `gorm:"type:char(36);not null;index"`
+	IsPublic       bool      `gorm:"type:boolean;not null;default:false"`
+	Bot            bool      `gorm:"type:boolean;not null;default:false"`
+	Tokens         string    `gorm:"type:mediumtext;not null;index:idx_message_search_indices_tokens,class:FULLTEXT"`
+	HasURL         bool      `gorm:"type:boolean;not null;default:false"`
+	HasAttachments bool      `gorm:"type:boolean;not null;default:false"`
+	HasImage       bool      `gorm:"type:boolean;not null;default:false"`
+	HasVideo       bool      `gorm:"type:boolean;not null;default:false"`
+	HasAudio       bool      `gorm:"type:boolean;not null;default:false"`
+	CreatedAt      time.Time `gorm:"precision:6;autoCreateTime:false;index"`
+	UpdatedAt      time.Time `gorm:"precision:6;autoUpdateTime:false;index"`
+}
+
+func (*v37MessageSearchIndex) TableName() string {
+	return "message_search_indices"
+}
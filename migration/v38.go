@@ -0,0 +1,69 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v38 予約投稿メッセージテーブル、予約投稿メッセージパーミッションの付与
+func v38() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "38",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v38ScheduledMessage{}); err != nil {
+				return err
+			}
+
+			addedRolePermissions := map[string][]string{
+				"read": {
+					"get_scheduled_message",
+				},
+				"write": {
+					"create_scheduled_message",
+					"edit_scheduled_message",
+					"delete_scheduled_message",
+				},
+			}
+			for role, perms := range addedRolePermissions {
+				for _, perm := range perms {
+					if err := db.Create(&v38RolePermission{Role: role, Permission: perm}).Error; err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v38RolePermission struct {
+	Role       string `gorm:"type:varchar(30);not null;primaryKey"`
+	Permission string `gorm:"type:varchar(30);not null;primaryKey"`
+}
+
+func (*v38RolePermission) TableName() string {
+	return "user_role_permissions"
+}
+
+type v38ScheduledMessage struct {
+	ID          uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	UserID      uuid.UUID              `gorm:"type:char(36);not null;index"`
+	ChannelID   optional.Of[uuid.UUID] `gorm:"type:char(36)"`
+	DMUserID    optional.Of[uuid.UUID] `gorm:"type:char(36)"`
+	Content     string                 `gorm:"type:text;not null"`
+	ScheduledAt time.Time              `gorm:"precision:6;index:idx_scheduled_messages_status_scheduled_at,priority:2"`
+	Status      string                 `gorm:"type:varchar(10);not null;default:'pending';index:idx_scheduled_messages_status_scheduled_at,priority:1"`
+	MessageID   optional.Of[uuid.UUID] `gorm:"type:char(36)"`
+	Error       string                 `gorm:"type:text;not null"`
+	CreatedAt   time.Time              `gorm:"precision:6"`
+	UpdatedAt   time.Time              `gorm:"precision:6"`
+}
+
+func (*v38ScheduledMessage) TableName() string {
+	return "scheduled_messages"
+}
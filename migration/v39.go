@@ -0,0 +1,19 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// v39 archived_messagesテーブルをmessage_revisionsにリネーム
+func v39() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "39",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.Exec("ALTER TABLE archived_messages RENAME TO message_revisions").Error; err != nil {
+				return err
+			}
+			return db.Exec("ALTER TABLE message_revisions RENAME INDEX idx_archived_messages_message_id TO idx_message_revisions_message_id").Error
+		},
+	}
+}
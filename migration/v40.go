@@ -0,0 +1,81 @@
+package migration
+
+import (
+	"fmt"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v40 通知設定の拡張 (おやすみモード・通知キーワード・グループメンションのミュート・チャンネルごとのプッシュ通知設定)
+func v40() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "40",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(
+				&v40UserSettings{},
+				&v40UserNotificationKeyword{},
+				&v40UserGroupMentionMute{},
+				&v40UserChannelPushMute{},
+			); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"user_notification_keywords", "user_notification_keywords_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+				{"user_group_mention_mutes", "user_group_mention_mutes_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+				{"user_group_mention_mutes", "user_group_mention_mutes_group_id_user_groups_id_foreign", "group_id", "user_groups(id)", "CASCADE", "CASCADE"},
+				{"user_channel_push_mutes", "user_channel_push_mutes_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+				{"user_channel_push_mutes", "user_channel_push_mutes_channel_id_channels_id_foreign", "channel_id", "channels(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v40UserSettings struct {
+	UserID              uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	NotifyCitation      bool      `gorm:"type:boolean;not null;default:false"`
+	DoNotDisturbEnabled bool      `gorm:"type:boolean;not null;default:false;index"`
+	DoNotDisturbStart   string    `gorm:"type:varchar(5);not null;default:''"`
+	DoNotDisturbEnd     string    `gorm:"type:varchar(5);not null;default:''"`
+	TimeZone            string    `gorm:"type:varchar(64);not null;default:''"`
+}
+
+func (*v40UserSettings) TableName() string {
+	return "user_settings"
+}
+
+type v40UserNotificationKeyword struct {
+	UserID  uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Keyword string    `gorm:"type:varchar(50);not null;primaryKey"`
+}
+
+func (*v40UserNotificationKeyword) TableName() string {
+	return "user_notification_keywords"
+}
+
+type v40UserGroupMentionMute struct {
+	UserID  uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	GroupID uuid.UUID `gorm:"type:char(36);not null;primaryKey;index"`
+}
+
+func (*v40UserGroupMentionMute) TableName() string {
+	return "user_group_mention_mutes"
+}
+
+type v40UserChannelPushMute struct {
+	UserID    uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	ChannelID uuid.UUID `gorm:"type:char(36);not null;primaryKey;index"`
+}
+
+func (*v40UserChannelPushMute) TableName() string {
+	return "user_channel_push_mutes"
+}
@@ -0,0 +1,67 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v41 Web Push購読テーブル、Web Push購読登録パーミッションの付与
+func v41() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "41",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v41WebPushSubscription{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"webpush_subscriptions", "webpush_subscriptions_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+
+			addedRolePermissions := map[string][]string{
+				"write": {
+					"register_webpush_subscription",
+				},
+			}
+			for role, perms := range addedRolePermissions {
+				for _, perm := range perms {
+					if err := db.Create(&v41RolePermission{Role: role, Permission: perm}).Error; err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v41RolePermission struct {
+	Role       string `gorm:"type:varchar(30);not null;primaryKey"`
+	Permission string `gorm:"type:varchar(30);not null;primaryKey"`
+}
+
+func (*v41RolePermission) TableName() string {
+	return "user_role_permissions"
+}
+
+type v41WebPushSubscription struct {
+	Endpoint  string    `gorm:"type:varchar(500);not null;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;index"`
+	P256dh    string    `gorm:"type:varchar(100);not null"`
+	Auth      string    `gorm:"type:varchar(50);not null"`
+	CreatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v41WebPushSubscription) TableName() string {
+	return "webpush_subscriptions"
+}
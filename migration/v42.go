@@ -0,0 +1,57 @@
+package migration
+
+import (
+	"fmt"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v42 チャンネルロールテーブル、チャンネル権限上書きテーブル
+func v42() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "42",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v42ChannelRole{}, &v42ChannelPermissionOverride{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"channel_roles", "channel_roles_channel_id_channels_id_foreign", "channel_id", "channels(id)", "CASCADE", "CASCADE"},
+				{"channel_roles", "channel_roles_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+				{"channel_roles", "channel_roles_role_user_roles_name_foreign", "role", "user_roles(name)", "CASCADE", "CASCADE"},
+				{"channel_permission_overrides", "channel_permission_overrides_channel_id_channels_id_foreign", "channel_id", "channels(id)", "CASCADE", "CASCADE"},
+				{"channel_permission_overrides", "channel_permission_overrides_role_user_roles_name_foreign", "role", "user_roles(name)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v42ChannelRole struct {
+	ChannelID uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;primaryKey;index"`
+	Role      string    `gorm:"type:varchar(30);not null;index"`
+}
+
+func (*v42ChannelRole) TableName() string {
+	return "channel_roles"
+}
+
+type v42ChannelPermissionOverride struct {
+	ChannelID  uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Role       string    `gorm:"type:varchar(30);not null;primaryKey"`
+	Permission string    `gorm:"type:varchar(30);not null;primaryKey"`
+	Allow      bool      `gorm:"type:boolean;not null"`
+}
+
+func (*v42ChannelPermissionOverride) TableName() string {
+	return "channel_permission_overrides"
+}
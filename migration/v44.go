@@ -0,0 +1,35 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v44 監査ログテーブル
+func v44() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "44",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v44AuditLog{})
+		},
+	}
+}
+
+type v44AuditLog struct {
+	ID        uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	Action    string                 `gorm:"type:varchar(50);not null;index:idx_audit_logs_action_created_at,priority:1"`
+	ActorID   optional.Of[uuid.UUID] `gorm:"type:char(36);index:idx_audit_logs_actor_id_created_at,priority:1"`
+	Target    string                 `gorm:"type:varchar(64);not null;index:idx_audit_logs_target_created_at,priority:1"`
+	Detail    string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	IPAddress string                 `gorm:"type:varchar(45);not null;default:''"`
+	CreatedAt time.Time              `gorm:"precision:6;index:idx_audit_logs_created_at;index:idx_audit_logs_action_created_at,priority:2;index:idx_audit_logs_actor_id_created_at,priority:2;index:idx_audit_logs_target_created_at,priority:2"`
+}
+
+func (*v44AuditLog) TableName() string {
+	return "audit_logs"
+}
@@ -0,0 +1,70 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v45 外部送信Webhookテーブル、外部送信Webhook配送ログテーブル
+func v45() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "45",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v45OutgoingWebhook{}, &v45OutgoingWebhookDeliveryLog{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"outgoing_webhooks", "outgoing_webhooks_creator_id_users_id_foreign", "creator_id", "users(id)", "CASCADE", "CASCADE"},
+				{"outgoing_webhooks", "outgoing_webhooks_channel_id_channels_id_foreign", "channel_id", "channels(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v45OutgoingWebhook struct {
+	ID          uuid.UUID      `gorm:"type:char(36);not null;primaryKey"`
+	Name        string         `gorm:"type:varchar(32);not null"`
+	Description string         `gorm:"type:text;not null"`
+	ChannelID   uuid.UUID      `gorm:"type:char(36);not null;index"`
+	URL         string         `gorm:"type:text;not null"`
+	Secret      string         `gorm:"type:text;not null"`
+	Events      string         `gorm:"type:text;not null"`
+	CreatorID   uuid.UUID      `gorm:"type:char(36);not null;index"`
+	CreatedAt   time.Time      `gorm:"precision:6"`
+	UpdatedAt   time.Time      `gorm:"precision:6"`
+	DeletedAt   gorm.DeletedAt `gorm:"precision:6"`
+}
+
+func (*v45OutgoingWebhook) TableName() string {
+	return "outgoing_webhooks"
+}
+
+type v45OutgoingWebhookDeliveryLog struct {
+	ID         uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	DeliveryID uuid.UUID `gorm:"type:char(36);not null;index"`
+	WebhookID  uuid.UUID `gorm:"type:char(36);not null;index:webhook_id_date_time_idx"`
+	Event      string    `gorm:"type:varchar(30);not null"`
+	Body       string    `gorm:"type:text"`
+	Attempt    int       `gorm:"not null;default:1"`
+	Result     string    `gorm:"type:char(2);not null"`
+	Error      string    `gorm:"type:text"`
+	Code       int       `gorm:"not null;default:0"`
+	Latency    int64     `gorm:"not null;default:0"`
+	DateTime   time.Time `gorm:"precision:6;index:webhook_id_date_time_idx"`
+}
+
+func (*v45OutgoingWebhookDeliveryLog) TableName() string {
+	return "outgoing_webhook_delivery_logs"
+}
@@ -0,0 +1,26 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v46 Webhookテーブルにメッセージテンプレートカラムを追加
+func v46() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "46",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v46WebhookBot{})
+		},
+	}
+}
+
+type v46WebhookBot struct {
+	ID       uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Template string    `gorm:"type:text;not null"`
+}
+
+func (*v46WebhookBot) TableName() string {
+	return "webhook_bots"
+}
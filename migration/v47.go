@@ -0,0 +1,90 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v47 Botコマンドテーブル、Botコマンド実行記録テーブル、Botコマンド管理パーミッションの付与
+func v47() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "47",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v47BotCommand{}, &v47BotCommandInvocation{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"bot_commands", "bot_commands_bot_id_bots_id_foreign", "bot_id", "bots(id)", "CASCADE", "CASCADE"},
+				{"bot_command_invocations", "bot_command_invocations_command_id_bot_commands_id_foreign", "command_id", "bot_commands(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+
+			addedRolePermissions := map[string][]string{
+				"user": {
+					"manage_bot_commands",
+				},
+				"bot": {
+					"manage_bot_commands",
+				},
+				"manage_bot": {
+					"manage_bot_commands",
+				},
+			}
+			for role, perms := range addedRolePermissions {
+				for _, perm := range perms {
+					if err := db.Create(&v47RolePermission{Role: role, Permission: perm}).Error; err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v47BotCommand struct {
+	ID          uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	BotID       uuid.UUID `gorm:"type:char(36);not null;index"`
+	Name        string    `gorm:"type:varchar(32);not null;unique"`
+	Description string    `gorm:"type:text;not null"`
+	Args        string    `gorm:"type:text;not null"`
+	CreatedAt   time.Time `gorm:"precision:6"`
+	UpdatedAt   time.Time `gorm:"precision:6"`
+}
+
+func (*v47BotCommand) TableName() string {
+	return "bot_commands"
+}
+
+type v47BotCommandInvocation struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	CommandID uuid.UUID `gorm:"type:char(36);not null;index"`
+	BotID     uuid.UUID `gorm:"type:char(36);not null"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null"`
+	ChannelID uuid.UUID `gorm:"type:char(36);not null"`
+	Text      string    `gorm:"type:text;not null"`
+	CreatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v47BotCommandInvocation) TableName() string {
+	return "bot_command_invocations"
+}
+
+type v47RolePermission struct {
+	Role       string `gorm:"type:varchar(30);not null;primaryKey"`
+	Permission string `gorm:"type:varchar(30);not null;primaryKey"`
+}
+
+func (*v47RolePermission) TableName() string {
+	return "user_role_permissions"
+}
@@ -0,0 +1,33 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v48 メッセージコンポーネントテーブル
+func v48() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "48",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v48MessageComponents{}); err != nil {
+				return err
+			}
+			return db.Exec("ALTER TABLE message_components ADD CONSTRAINT message_components_message_id_messages_id_foreign FOREIGN KEY (message_id) REFERENCES messages(id) ON DELETE CASCADE ON UPDATE CASCADE").Error
+		},
+	}
+}
+
+type v48MessageComponents struct {
+	MessageID  uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Components string    `gorm:"type:text;not null"`
+	CreatedAt  time.Time `gorm:"precision:6"`
+	UpdatedAt  time.Time `gorm:"precision:6"`
+}
+
+func (*v48MessageComponents) TableName() string {
+	return "message_components"
+}
@@ -0,0 +1,39 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v49 Botイベント配送アウトボックステーブル
+func v49() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "49",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v49BotEventDelivery{}); err != nil {
+				return err
+			}
+			return db.Exec("ALTER TABLE bot_event_deliveries ADD CONSTRAINT bot_event_deliveries_bot_id_bots_id_foreign FOREIGN KEY (bot_id) REFERENCES bots(id) ON DELETE CASCADE ON UPDATE CASCADE").Error
+		},
+	}
+}
+
+type v49BotEventDelivery struct {
+	ID            uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	BotID         uuid.UUID `gorm:"type:char(36);not null;index:bot_id_status_idx"`
+	Event         string    `gorm:"type:varchar(30);not null"`
+	Body          string    `gorm:"type:text;not null"`
+	Status        string    `gorm:"type:varchar(10);not null;index:bot_id_status_idx"`
+	Attempts      int       `gorm:"not null;default:0"`
+	LastError     string    `gorm:"type:text;not null"`
+	NextAttemptAt time.Time `gorm:"precision:6;index"`
+	CreatedAt     time.Time `gorm:"precision:6;index"`
+	UpdatedAt     time.Time `gorm:"precision:6"`
+}
+
+func (*v49BotEventDelivery) TableName() string {
+	return "bot_event_deliveries"
+}
@@ -0,0 +1,33 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/random"
+)
+
+// v50 Botイベント署名シークレット
+func v50() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "50",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.Exec("ALTER TABLE `bots` ADD COLUMN `signing_secret` varchar(64) NOT NULL DEFAULT '' AFTER `verification_token`").Error; err != nil {
+				return err
+			}
+
+			// 既存のBotにシークレットを発行
+			var ids []uuid.UUID
+			if err := db.Table("bots").Pluck("id", &ids).Error; err != nil {
+				return err
+			}
+			for _, id := range ids {
+				if err := db.Table("bots").Where("id = ?", id).Update("signing_secret", random.SecureAlphaNumeric(40)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,19 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// v51 Botイベント購読フィルター
+func v51() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "51",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.Exec("ALTER TABLE `bots` ADD COLUMN `event_filters` text NOT NULL AFTER `subscribe_events`").Error; err != nil {
+				return err
+			}
+			return db.Exec("UPDATE `bots` SET `event_filters` = '{}'").Error
+		},
+	}
+}
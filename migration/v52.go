@@ -0,0 +1,16 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// v52 予約投稿メッセージに投稿処理開始日時を追加
+func v52() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "52",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec("ALTER TABLE `scheduled_messages` ADD COLUMN `acquired_at` datetime(6) NULL AFTER `status`").Error
+		},
+	}
+}
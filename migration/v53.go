@@ -0,0 +1,16 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// v53 Botイベント配送に再送処理権の期限を追加
+func v53() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "53",
+		Migrate: func(db *gorm.DB) error {
+			return db.Exec("ALTER TABLE `bot_event_deliveries` ADD COLUMN `lease_until` datetime(6) NULL AFTER `next_attempt_at`").Error
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v54 外部送信Webhook配送アウトボックステーブル
+func v54() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "54",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v54OutgoingWebhookDelivery{}); err != nil {
+				return err
+			}
+			return db.Exec("ALTER TABLE outgoing_webhook_deliveries ADD CONSTRAINT outgoing_webhook_deliveries_webhook_id_outgoing_webhooks_id_foreign FOREIGN KEY (webhook_id) REFERENCES outgoing_webhooks(id) ON DELETE CASCADE ON UPDATE CASCADE").Error
+		},
+	}
+}
+
+type v54OutgoingWebhookDelivery struct {
+	ID            uuid.UUID  `gorm:"type:char(36);not null;primaryKey"`
+	WebhookID     uuid.UUID  `gorm:"type:char(36);not null;index"`
+	Event         string     `gorm:"type:varchar(30);not null"`
+	Body          string     `gorm:"type:text;not null"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt time.Time  `gorm:"precision:6;index"`
+	LeaseUntil    *time.Time `gorm:"precision:6"`
+	CreatedAt     time.Time  `gorm:"precision:6"`
+}
+
+func (*v54OutgoingWebhookDelivery) TableName() string {
+	return "outgoing_webhook_deliveries"
+}
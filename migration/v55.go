@@ -0,0 +1,19 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// v55 Botコマンド名の一意制約をBotごとに変更
+func v55() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "55",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.Exec("ALTER TABLE `bot_commands` ADD UNIQUE INDEX `bot_id_name` (`bot_id`, `name`)").Error; err != nil {
+				return err
+			}
+			return db.Exec("ALTER TABLE `bot_commands` DROP INDEX IF EXISTS `name`").Error
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// AuditAction 監査ログの操作の種類
+type AuditAction string
+
+const (
+	// AuditActionUserCreated ユーザー作成
+	AuditActionUserCreated = AuditAction("user.created")
+	// AuditActionUserUpdated ユーザー情報(ロール・アカウント状態を含む)変更
+	AuditActionUserUpdated = AuditAction("user.updated")
+	// AuditActionUserIconUpdated ユーザーアイコン変更
+	AuditActionUserIconUpdated = AuditAction("user.icon_updated")
+	// AuditActionUserPasswordChanged ユーザーパスワード変更
+	AuditActionUserPasswordChanged = AuditAction("user.password_changed")
+
+	// AuditActionUserRoleCreated ロール作成
+	AuditActionUserRoleCreated = AuditAction("user_role.created")
+	// AuditActionUserRoleUpdated ロール変更
+	AuditActionUserRoleUpdated = AuditAction("user_role.updated")
+	// AuditActionUserRoleDeleted ロール削除
+	AuditActionUserRoleDeleted = AuditAction("user_role.deleted")
+
+	// AuditActionChannelUpdated チャンネル情報変更
+	AuditActionChannelUpdated = AuditAction("channel.updated")
+	// AuditActionChannelDeleted チャンネル削除
+	AuditActionChannelDeleted = AuditAction("channel.deleted")
+	// AuditActionChannelMerged チャンネル統合
+	AuditActionChannelMerged = AuditAction("channel.merged")
+	// AuditActionChannelMessagesMoved チャンネル間のメッセージ移動
+	AuditActionChannelMessagesMoved = AuditAction("channel.messages_moved")
+	// AuditActionChannelMembersChanged プライベートチャンネルメンバー変更
+	AuditActionChannelMembersChanged = AuditAction("channel.members_changed")
+	// AuditActionChannelRolesChanged チャンネルロール変更
+	AuditActionChannelRolesChanged = AuditAction("channel.roles_changed")
+	// AuditActionChannelPermissionOverridesChanged チャンネル権限上書き設定変更
+	AuditActionChannelPermissionOverridesChanged = AuditAction("channel.permission_overrides_changed")
+
+	// AuditActionStampCreated スタンプ作成
+	AuditActionStampCreated = AuditAction("stamp.created")
+	// AuditActionStampUpdated スタンプ情報・画像変更
+	AuditActionStampUpdated = AuditAction("stamp.updated")
+	// AuditActionStampDeleted スタンプ削除
+	AuditActionStampDeleted = AuditAction("stamp.deleted")
+
+	// AuditActionWebhookCreated Webhook作成
+	AuditActionWebhookCreated = AuditAction("webhook.created")
+	// AuditActionWebhookUpdated Webhook情報変更
+	AuditActionWebhookUpdated = AuditAction("webhook.updated")
+	// AuditActionWebhookDeleted Webhook削除
+	AuditActionWebhookDeleted = AuditAction("webhook.deleted")
+
+	// AuditActionOutgoingWebhookCreated 外部送信Webhook作成
+	AuditActionOutgoingWebhookCreated = AuditAction("outgoing_webhook.created")
+	// AuditActionOutgoingWebhookUpdated 外部送信Webhook情報変更
+	AuditActionOutgoingWebhookUpdated = AuditAction("outgoing_webhook.updated")
+	// AuditActionOutgoingWebhookDeleted 外部送信Webhook削除
+	AuditActionOutgoingWebhookDeleted = AuditAction("outgoing_webhook.deleted")
+
+	// AuditActionBotCreated Bot作成
+	AuditActionBotCreated = AuditAction("bot.created")
+	// AuditActionBotUpdated Bot情報変更
+	AuditActionBotUpdated = AuditAction("bot.updated")
+	// AuditActionBotDeleted Bot削除
+	AuditActionBotDeleted = AuditAction("bot.deleted")
+	// AuditActionBotStateChanged Botの状態変更
+	AuditActionBotStateChanged = AuditAction("bot.state_changed")
+	// AuditActionBotTokenReissued Botのトークン再発行
+	AuditActionBotTokenReissued = AuditAction("bot.token_reissued")
+	// AuditActionBotEventsReplayed Botのデッドレター再送
+	AuditActionBotEventsReplayed = AuditAction("bot.events_replayed")
+
+	// AuditActionOAuth2ClientCreated OAuth2クライアント作成
+	AuditActionOAuth2ClientCreated = AuditAction("oauth2_client.created")
+	// AuditActionOAuth2ClientUpdated OAuth2クライアント情報変更
+	AuditActionOAuth2ClientUpdated = AuditAction("oauth2_client.updated")
+	// AuditActionOAuth2ClientDeleted OAuth2クライアント削除
+	AuditActionOAuth2ClientDeleted = AuditAction("oauth2_client.deleted")
+)
+
+// AuditLogDetail 監査ログ詳細
+type AuditLogDetail map[string]interface{}
+
+// Value database/sql/driver.Valuer 実装
+func (d AuditLogDetail) Value() (driver.Value, error) {
+	return json.MarshalToString(d)
+}
+
+// Scan database/sql.Scanner 実装
+func (d *AuditLogDetail) Scan(src interface{}) error {
+	*d = AuditLogDetail{}
+	switch s := src.(type) {
+	case nil:
+		return nil
+	case string:
+		return json.Unmarshal([]byte(s), d)
+	case []byte:
+		return json.Unmarshal(s, d)
+	default:
+		return errors.New("failed to scan AuditLogDetail")
+	}
+}
+
+// AuditLog 監査ログ
+type AuditLog struct {
+	ID     uuid.UUID   `gorm:"type:char(36);not null;primaryKey" json:"id"`
+	Action AuditAction `gorm:"type:varchar(50);not null;index:idx_audit_logs_action_created_at,priority:1" json:"action"`
+	// ActorID 操作したユーザーのUUID (未ログインでの操作の場合はnull)
+	ActorID optional.Of[uuid.UUID] `gorm:"type:char(36);index:idx_audit_logs_actor_id_created_at,priority:1" json:"actorId"`
+	// Target 操作対象のUUID、またはロール名
+	Target    string         `gorm:"type:varchar(64);not null;index:idx_audit_logs_target_created_at,priority:1" json:"target"`
+	Detail    AuditLogDetail `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL" json:"detail"`
+	IPAddress string         `gorm:"type:varchar(45);not null;default:''" json:"ipAddress"`
+	CreatedAt time.Time      `gorm:"precision:6;index:idx_audit_logs_created_at;index:idx_audit_logs_action_created_at,priority:2;index:idx_audit_logs_actor_id_created_at,priority:2;index:idx_audit_logs_target_created_at,priority:2" json:"createdAt"`
+}
+
+// TableName AuditLog構造体のテーブル名
+func (*AuditLog) TableName() string {
+	return "audit_logs"
+}
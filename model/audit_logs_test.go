@@ -0,0 +1,43 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLog_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "audit_logs", (&AuditLog{}).TableName())
+}
+
+func TestAuditLogDetail_Scan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		d := AuditLogDetail{"a": "b"}
+		assert.NoError(t, d.Scan(nil))
+		assert.Empty(t, d)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+		var d AuditLogDetail
+		assert.NoError(t, d.Scan(`{"role":"admin"}`))
+		assert.Equal(t, AuditLogDetail{"role": "admin"}, d)
+	})
+
+	t.Run("[]byte", func(t *testing.T) {
+		t.Parallel()
+		var d AuditLogDetail
+		assert.NoError(t, d.Scan([]byte(`{"role":"admin"}`)))
+		assert.Equal(t, AuditLogDetail{"role": "admin"}, d)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+		var d AuditLogDetail
+		assert.Error(t, d.Scan(1))
+	})
+}
@@ -39,21 +39,23 @@ const (
 
 // Bot Bot構造体
 type Bot struct {
-	ID                uuid.UUID      `gorm:"type:char(36);not null;primaryKey"`
-	BotUserID         uuid.UUID      `gorm:"type:char(36);not null;unique"`
-	Description       string         `gorm:"type:text;not null"`
-	VerificationToken string         `gorm:"type:varchar(30);not null"`
-	AccessTokenID     uuid.UUID      `gorm:"type:char(36);not null"`
-	PostURL           string         `gorm:"type:text;not null"`
-	SubscribeEvents   BotEventTypes  `gorm:"type:text;not null"`
-	Privileged        bool           `gorm:"type:boolean;not null;default:false"`
-	Mode              BotMode        `gorm:"type:varchar(30);not null"`
-	State             BotState       `gorm:"type:tinyint;not null;default:0"`
-	BotCode           string         `gorm:"type:varchar(30);not null;unique"`
-	CreatorID         uuid.UUID      `gorm:"type:char(36);not null"`
-	CreatedAt         time.Time      `gorm:"precision:6"`
-	UpdatedAt         time.Time      `gorm:"precision:6"`
-	DeletedAt         gorm.DeletedAt `gorm:"precision:6"`
+	ID                uuid.UUID       `gorm:"type:char(36);not null;primaryKey"`
+	BotUserID         uuid.UUID       `gorm:"type:char(36);not null;unique"`
+	Description       string          `gorm:"type:text;not null"`
+	VerificationToken string          `gorm:"type:varchar(30);not null"`
+	SigningSecret     string          `gorm:"type:varchar(64);not null;default:''"`
+	AccessTokenID     uuid.UUID       `gorm:"type:char(36);not null"`
+	PostURL           string          `gorm:"type:text;not null"`
+	SubscribeEvents   BotEventTypes   `gorm:"type:text;not null"`
+	EventFilters      BotEventFilters `gorm:"type:text;not null"`
+	Privileged        bool            `gorm:"type:boolean;not null;default:false"`
+	Mode              BotMode         `gorm:"type:varchar(30);not null"`
+	State             BotState        `gorm:"type:tinyint;not null;default:0"`
+	BotCode           string          `gorm:"type:varchar(30);not null;unique"`
+	CreatorID         uuid.UUID       `gorm:"type:char(36);not null"`
+	CreatedAt         time.Time       `gorm:"precision:6"`
+	UpdatedAt         time.Time       `gorm:"precision:6"`
+	DeletedAt         gorm.DeletedAt  `gorm:"precision:6"`
 
 	BotUser *User `gorm:"constraint:bots_bot_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:BotUserID"`
 	Creator *User `gorm:"constraint:bots_creator_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:CreatorID"`
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// BotCommandArgType Botコマンドの引数の型
+type BotCommandArgType string
+
+const (
+	// BotCommandArgTypeString 文字列
+	BotCommandArgTypeString BotCommandArgType = "string"
+	// BotCommandArgTypeNumber 数値
+	BotCommandArgTypeNumber BotCommandArgType = "number"
+	// BotCommandArgTypeBoolean 真偽値
+	BotCommandArgTypeBoolean BotCommandArgType = "boolean"
+	// BotCommandArgTypeUser ユーザー
+	BotCommandArgTypeUser BotCommandArgType = "user"
+)
+
+// Valid 有効な値かどうか
+func (t BotCommandArgType) Valid() bool {
+	switch t {
+	case BotCommandArgTypeString, BotCommandArgTypeNumber, BotCommandArgTypeBoolean, BotCommandArgTypeUser:
+		return true
+	default:
+		return false
+	}
+}
+
+// BotCommandArg Botコマンドの引数定義
+type BotCommandArg struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Type        BotCommandArgType `json:"type"`
+	Required    bool              `json:"required"`
+}
+
+// BotCommandArgs Botコマンドの引数定義の配列
+type BotCommandArgs []BotCommandArg
+
+// Value database/sql/driver.Valuer 実装
+func (args BotCommandArgs) Value() (driver.Value, error) {
+	if args == nil {
+		return "[]", nil
+	}
+	return json.MarshalToString(args)
+}
+
+// Scan database/sql.Scanner 実装
+func (args *BotCommandArgs) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*args = BotCommandArgs{}
+		return nil
+	case string:
+		return json.Unmarshal([]byte(s), args)
+	case []byte:
+		return json.Unmarshal(s, args)
+	default:
+		return errors.New("failed to scan BotCommandArgs")
+	}
+}
+
+// BotCommand Botのスラッシュコマンド
+//
+// コマンド名はBotごとに一意です
+type BotCommand struct {
+	ID          uuid.UUID      `gorm:"type:char(36);not null;primaryKey"                   json:"id"`
+	BotID       uuid.UUID      `gorm:"type:char(36);not null;index;uniqueIndex:bot_id_name" json:"botId"`
+	Name        string         `gorm:"type:varchar(32);not null;uniqueIndex:bot_id_name"    json:"name"`
+	Description string         `gorm:"type:text;not null"                                  json:"description"`
+	Args        BotCommandArgs `gorm:"type:text;not null"                                  json:"args"`
+	CreatedAt   time.Time      `gorm:"precision:6"                                         json:"createdAt"`
+	UpdatedAt   time.Time      `gorm:"precision:6"                                         json:"updatedAt"`
+
+	Bot *Bot `gorm:"constraint:bot_commands_bot_id_bots_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// TableName BotCommandのテーブル名
+func (*BotCommand) TableName() string {
+	return "bot_commands"
+}
+
+// BotCommandInvocation Botのスラッシュコマンドの実行記録
+//
+// Botはこの記録を元にコマンドに応答します
+type BotCommandInvocation struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey" json:"id"`
+	CommandID uuid.UUID `gorm:"type:char(36);not null;index"      json:"commandId"`
+	BotID     uuid.UUID `gorm:"type:char(36);not null"            json:"botId"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null"            json:"userId"`
+	ChannelID uuid.UUID `gorm:"type:char(36);not null"            json:"channelId"`
+	Text      string    `gorm:"type:text;not null"                json:"text"`
+	CreatedAt time.Time `gorm:"precision:6"                       json:"createdAt"`
+
+	Command *BotCommand `gorm:"constraint:bot_command_invocations_command_id_bot_commands_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:CommandID" json:"-"`
+}
+
+// TableName BotCommandInvocationのテーブル名
+func (*BotCommandInvocation) TableName() string {
+	return "bot_command_invocations"
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBotCommand_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "bot_commands", (&BotCommand{}).TableName())
+}
+
+func TestBotCommandInvocation_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "bot_command_invocations", (&BotCommandInvocation{}).TableName())
+}
+
+func TestBotCommandArgType_Valid(t *testing.T) {
+	t.Parallel()
+	assert.True(t, BotCommandArgTypeString.Valid())
+	assert.True(t, BotCommandArgTypeNumber.Valid())
+	assert.True(t, BotCommandArgTypeBoolean.Valid())
+	assert.True(t, BotCommandArgTypeUser.Valid())
+	assert.False(t, BotCommandArgType("channel").Valid())
+}
+
+func TestBotCommandArgs_Value(t *testing.T) {
+	t.Parallel()
+
+	v, err := BotCommandArgs(nil).Value()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "[]", v)
+	}
+
+	v, err = BotCommandArgs{{Name: "a", Type: BotCommandArgTypeString, Required: true}}.Value()
+	if assert.NoError(t, err) {
+		assert.Equal(t, `[{"name":"a","description":"","type":"string","required":true}]`, v)
+	}
+}
+
+func TestBotCommandArgs_Scan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		var s BotCommandArgs
+		assert.NoError(t, s.Scan(nil))
+		assert.Equal(t, BotCommandArgs{}, s)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+		var s BotCommandArgs
+		assert.NoError(t, s.Scan(`[{"name":"a","type":"number"}]`))
+		assert.Equal(t, BotCommandArgs{{Name: "a", Type: BotCommandArgTypeNumber}}, s)
+	})
+
+	t.Run("[]byte", func(t *testing.T) {
+		t.Parallel()
+		var s BotCommandArgs
+		assert.NoError(t, s.Scan([]byte(`[]`)))
+		assert.Equal(t, BotCommandArgs{}, s)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+		var s BotCommandArgs
+		assert.Error(t, s.Scan(1))
+	})
+}
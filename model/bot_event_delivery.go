@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// BotEventDeliveryStatus Botイベント配送の状態
+type BotEventDeliveryStatus string
+
+const (
+	// BotEventDeliveryPending 再送待ち
+	BotEventDeliveryPending BotEventDeliveryStatus = "pending"
+	// BotEventDeliverySending 再送中 (いずれかのインスタンスが処理権を得ている)
+	BotEventDeliverySending BotEventDeliveryStatus = "sending"
+	// BotEventDeliveryDead 再送上限に達した (デッドレター)
+	BotEventDeliveryDead BotEventDeliveryStatus = "dead"
+)
+
+// BotEventDelivery 配送に失敗したBotイベント (アウトボックス)
+//
+// 配送に成功したイベントは削除されます
+type BotEventDelivery struct {
+	ID            uuid.UUID              `gorm:"type:char(36);not null;primaryKey"                json:"id"`
+	BotID         uuid.UUID              `gorm:"type:char(36);not null;index:bot_id_status_idx"   json:"botId"`
+	Event         BotEventType           `gorm:"type:varchar(30);not null"                        json:"event"`
+	Body          string                 `gorm:"type:text;not null"                               json:"body"`
+	Status        BotEventDeliveryStatus `gorm:"type:varchar(10);not null;index:bot_id_status_idx" json:"status"`
+	Attempts      int                    `gorm:"not null;default:0"                               json:"attempts"`
+	LastError     string                 `gorm:"type:text;not null"                               json:"lastError"`
+	NextAttemptAt time.Time              `gorm:"precision:6;index"                                json:"nextAttemptAt"`
+	LeaseUntil    optional.Of[time.Time] `gorm:"precision:6"                                      json:"-"`
+	CreatedAt     time.Time              `gorm:"precision:6;index"                                json:"createdAt"`
+	UpdatedAt     time.Time              `gorm:"precision:6"                                      json:"updatedAt"`
+
+	Bot *Bot `gorm:"constraint:bot_event_deliveries_bot_id_bots_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// TableName BotEventDeliveryのテーブル名
+func (*BotEventDelivery) TableName() string {
+	return "bot_event_deliveries"
+}
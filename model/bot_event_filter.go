@@ -0,0 +1,81 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+// BotEventFilter Botイベントの購読フィルター
+//
+// 指定した全ての条件を満たすイベントのみがBotに送信されます
+type BotEventFilter struct {
+	// Channels 対象のチャンネルID。空の場合は全てのチャンネルが対象
+	Channels []uuid.UUID `json:"channels,omitempty"`
+	// Prefix メッセージ本文の接頭辞
+	Prefix string `json:"prefix,omitempty"`
+	// Pattern メッセージ本文にマッチする正規表現
+	Pattern string `json:"pattern,omitempty"`
+	// ExcludeBots Botが投稿したメッセージを除外するかどうか
+	ExcludeBots bool `json:"excludeBots,omitempty"`
+}
+
+// MatchChannel 指定したチャンネルがフィルターの対象かどうか
+func (f *BotEventFilter) MatchChannel(channelID uuid.UUID) bool {
+	if len(f.Channels) == 0 {
+		return true
+	}
+	for _, id := range f.Channels {
+		if id == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPrefix 指定したメッセージ本文が接頭辞の条件を満たすかどうか
+func (f *BotEventFilter) MatchPrefix(text string) bool {
+	return strings.HasPrefix(text, f.Prefix)
+}
+
+// BotEventFilters イベントタイプ毎の購読フィルター
+type BotEventFilters map[BotEventType]*BotEventFilter
+
+// Get 指定したイベントタイプのフィルターを返します。存在しない場合はnilを返します
+func (f BotEventFilters) Get(ev BotEventType) *BotEventFilter {
+	if f == nil {
+		return nil
+	}
+	return f[ev]
+}
+
+// Value database/sql/driver.Valuer 実装
+func (f BotEventFilters) Value() (driver.Value, error) {
+	if f == nil {
+		return "{}", nil
+	}
+	return json.MarshalToString(f)
+}
+
+// Scan database/sql.Scanner 実装
+func (f *BotEventFilters) Scan(src interface{}) error {
+	*f = BotEventFilters{}
+	switch s := src.(type) {
+	case nil:
+		return nil
+	case string:
+		if len(s) == 0 {
+			return nil
+		}
+		return json.Unmarshal([]byte(s), f)
+	case []byte:
+		if len(s) == 0 {
+			return nil
+		}
+		return json.Unmarshal(s, f)
+	default:
+		return errors.New("failed to scan BotEventFilters")
+	}
+}
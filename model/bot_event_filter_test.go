@@ -0,0 +1,89 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBotEventFilter_MatchChannel(t *testing.T) {
+	t.Parallel()
+
+	ch1 := uuid.NewV3(uuid.Nil, "c1")
+	ch2 := uuid.NewV3(uuid.Nil, "c2")
+
+	assert.True(t, (&BotEventFilter{}).MatchChannel(ch1))
+	assert.True(t, (&BotEventFilter{Channels: []uuid.UUID{ch1, ch2}}).MatchChannel(ch2))
+	assert.False(t, (&BotEventFilter{Channels: []uuid.UUID{ch2}}).MatchChannel(ch1))
+}
+
+func TestBotEventFilter_MatchPrefix(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, (&BotEventFilter{}).MatchPrefix("po"))
+	assert.True(t, (&BotEventFilter{Prefix: "!"}).MatchPrefix("!po"))
+	assert.False(t, (&BotEventFilter{Prefix: "!"}).MatchPrefix("po!"))
+}
+
+func TestBotEventFilters_Get(t *testing.T) {
+	t.Parallel()
+
+	f := &BotEventFilter{Prefix: "!"}
+	assert.Nil(t, BotEventFilters(nil).Get("MESSAGE_CREATED"))
+	assert.Nil(t, BotEventFilters{"MESSAGE_CREATED": f}.Get("MESSAGE_UPDATED"))
+	assert.Equal(t, f, BotEventFilters{"MESSAGE_CREATED": f}.Get("MESSAGE_CREATED"))
+}
+
+func TestBotEventFilters_Value(t *testing.T) {
+	t.Parallel()
+
+	v, err := BotEventFilters(nil).Value()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "{}", v)
+	}
+	v, err = BotEventFilters{"MESSAGE_CREATED": {Prefix: "!", ExcludeBots: true}}.Value()
+	if assert.NoError(t, err) {
+		assert.Equal(t, `{"MESSAGE_CREATED":{"prefix":"!","excludeBots":true}}`, v)
+	}
+}
+
+func TestBotEventFilters_Scan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		var f BotEventFilters
+		assert.NoError(t, f.Scan(nil))
+		assert.Empty(t, f)
+	})
+
+	t.Run("empty string", func(t *testing.T) {
+		t.Parallel()
+		var f BotEventFilters
+		assert.NoError(t, f.Scan(""))
+		assert.Empty(t, f)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+		var f BotEventFilters
+		if assert.NoError(t, f.Scan(`{"MESSAGE_CREATED":{"pattern":"^po"}}`)) {
+			assert.Equal(t, "^po", f.Get("MESSAGE_CREATED").Pattern)
+		}
+	})
+
+	t.Run("[]byte", func(t *testing.T) {
+		t.Parallel()
+		var f BotEventFilters
+		if assert.NoError(t, f.Scan([]byte(`{"MESSAGE_CREATED":{"excludeBots":true}}`))) {
+			assert.True(t, f.Get("MESSAGE_CREATED").ExcludeBots)
+		}
+	})
+
+	t.Run("other", func(t *testing.T) {
+		t.Parallel()
+		var f BotEventFilters
+		assert.Error(t, f.Scan(123))
+	})
+}
@@ -120,3 +120,8 @@ func TestBotEventTypes_MarshalJSON(t *testing.T) {
 	assert.NoError(t, err)
 	assert.ElementsMatch(t, []string{`"PING"`, `"PONG"`}, strings.Split(strings.Trim(string(b), "[]"), ","))
 }
+
+func TestBotEventDelivery_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "bot_event_deliveries", (&BotEventDelivery{}).TableName())
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// ChannelRole チャンネル単位でユーザーに付与されたロールの構造体
+type ChannelRole struct {
+	ChannelID uuid.UUID `gorm:"type:char(36);not null;primaryKey" json:"-"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;primaryKey;index" json:"userId"`
+	Role      string    `gorm:"type:varchar(30);not null;index" json:"role"`
+
+	Channel  *Channel  `gorm:"constraint:channel_roles_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	User     *User     `gorm:"constraint:channel_roles_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	UserRole *UserRole `gorm:"foreignKey:Role;references:Name;constraint:channel_roles_role_user_roles_name_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// TableName ChannelRole構造体のテーブル名
+func (*ChannelRole) TableName() string {
+	return "channel_roles"
+}
+
+// ChannelPermissionOverride チャンネル単位のロール権限の上書き設定の構造体
+type ChannelPermissionOverride struct {
+	ChannelID  uuid.UUID `gorm:"type:char(36);not null;primaryKey" json:"-"`
+	Role       string    `gorm:"type:varchar(30);not null;primaryKey" json:"role"`
+	Permission string    `gorm:"type:varchar(30);not null;primaryKey" json:"permission"`
+	// Allow trueの場合は許可、falseの場合は拒否
+	Allow bool `gorm:"type:boolean;not null" json:"allow"`
+
+	Channel  *Channel  `gorm:"constraint:channel_permission_overrides_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	UserRole *UserRole `gorm:"foreignKey:Role;references:Name;constraint:channel_permission_overrides_role_user_roles_name_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// TableName ChannelPermissionOverride構造体のテーブル名
+func (*ChannelPermissionOverride) TableName() string {
+	return "channel_permission_overrides"
+}
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelRole_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "channel_roles", (&ChannelRole{}).TableName())
+}
+
+func TestChannelPermissionOverride_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "channel_permission_overrides", (&ChannelPermissionOverride{}).TableName())
+}
@@ -12,9 +12,14 @@ import (
 const (
 	// DirectMessageChannelRootID ダイレクトメッセージチャンネルの親チャンネルID
 	DirectMessageChannelRootID = "aaaaaaaa-aaaa-4aaa-aaaa-aaaaaaaaaaaa"
+	// PrivateChannelRootID プライベートチャンネル(DMを除く)の親チャンネルID
+	PrivateChannelRootID = "bbbbbbbb-bbbb-4bbb-bbbb-bbbbbbbbbbbb"
 )
 
-var dmChannelRootUUID = uuid.Must(uuid.FromString(DirectMessageChannelRootID))
+var (
+	dmChannelRootUUID      = uuid.Must(uuid.FromString(DirectMessageChannelRootID))
+	privateChannelRootUUID = uuid.Must(uuid.FromString(PrivateChannelRootID))
+)
 
 // Channel チャンネルの構造体
 type Channel struct {
@@ -44,6 +49,11 @@ func (ch *Channel) IsDMChannel() bool {
 	return ch.ParentID == dmChannelRootUUID
 }
 
+// IsPrivateChannel DMを除くプライベートチャンネルかどうかを返します
+func (ch *Channel) IsPrivateChannel() bool {
+	return ch.ParentID == privateChannelRootUUID
+}
+
 // IsArchived アーカイブされているチャンネルかどうか
 func (ch *Channel) IsArchived() bool {
 	return !ch.IsVisible
@@ -181,6 +191,24 @@ const (
 	// 	userId    作成者UUID
 	// 	channelId チャンネルUUID
 	ChannelEventChildCreated = ChannelEventType("ChildCreated")
+	// ChannelEventMembersChanged チャンネルイベント プライベートチャンネルメンバー変更
+	//
+	// 	userId  変更者UUID
+	// 	added   追加されたユーザーのUUIDの配列
+	// 	removed 削除されたユーザーのUUIDの配列
+	ChannelEventMembersChanged = ChannelEventType("MembersChanged")
+	// ChannelEventChildDeleted チャンネルイベント 子チャンネル削除
+	//
+	// 	userId    削除者UUID
+	// 	channelId チャンネルUUID
+	ChannelEventChildDeleted = ChannelEventType("ChildDeleted")
+	// ChannelEventMessagesMoved チャンネルイベント メッセージ移動
+	//
+	// 	userId 移動者UUID
+	// 	from   移動元チャンネルUUID
+	// 	to     移動先チャンネルUUID
+	// 	count  移動したメッセージの数
+	ChannelEventMessagesMoved = ChannelEventType("MessagesMoved")
 )
 
 // ChannelEventDetail チャンネルイベント詳細
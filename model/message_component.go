@@ -0,0 +1,107 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// MessageComponentType メッセージコンポーネントの種類
+type MessageComponentType string
+
+const (
+	// MessageComponentTypeButton ボタン
+	MessageComponentTypeButton MessageComponentType = "button"
+	// MessageComponentTypeSelect セレクトメニュー
+	MessageComponentTypeSelect MessageComponentType = "select"
+)
+
+// Valid 有効な値かどうか
+func (t MessageComponentType) Valid() bool {
+	switch t {
+	case MessageComponentTypeButton, MessageComponentTypeSelect:
+		return true
+	default:
+		return false
+	}
+}
+
+// MessageComponentOption セレクトメニューの選択肢
+type MessageComponentOption struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// MessageComponent メッセージに添付されるボタン・セレクトメニュー
+type MessageComponent struct {
+	Type MessageComponentType `json:"type"`
+	// CustomID Botがコンポーネントを識別するためのID。メッセージ内で一意
+	CustomID string `json:"customId"`
+	// Label ボタンの表示文字列、或いはセレクトメニューのプレースホルダー
+	Label string `json:"label"`
+	// Style ボタンのスタイル (primary, secondary, danger)
+	Style    string                   `json:"style"`
+	Options  []MessageComponentOption `json:"options"`
+	Disabled bool                     `json:"disabled"`
+}
+
+// HasOption 指定した値の選択肢を持っているかどうか
+func (c *MessageComponent) HasOption(value string) bool {
+	for _, o := range c.Options {
+		if o.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// MessageComponentList メッセージコンポーネントの配列
+type MessageComponentList []MessageComponent
+
+// Find 指定したCustomIDのコンポーネントを返します。存在しない場合はnilを返します
+func (l MessageComponentList) Find(customID string) *MessageComponent {
+	for i := range l {
+		if l[i].CustomID == customID {
+			return &l[i]
+		}
+	}
+	return nil
+}
+
+// Value database/sql/driver.Valuer 実装
+func (l MessageComponentList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	return json.MarshalToString(l)
+}
+
+// Scan database/sql.Scanner 実装
+func (l *MessageComponentList) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*l = MessageComponentList{}
+		return nil
+	case string:
+		return json.Unmarshal([]byte(s), l)
+	case []byte:
+		return json.Unmarshal(s, l)
+	default:
+		return errors.New("failed to scan MessageComponentList")
+	}
+}
+
+// MessageComponents メッセージに添付されたコンポーネントのレコード
+type MessageComponents struct {
+	MessageID  uuid.UUID            `gorm:"type:char(36);not null;primaryKey"`
+	Components MessageComponentList `gorm:"type:text;not null"`
+	CreatedAt  time.Time            `gorm:"precision:6"`
+	UpdatedAt  time.Time            `gorm:"precision:6"`
+}
+
+// TableName MessageComponentsのテーブル名
+func (*MessageComponents) TableName() string {
+	return "message_components"
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageComponents_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "message_components", (&MessageComponents{}).TableName())
+}
+
+func TestMessageComponentType_Valid(t *testing.T) {
+	t.Parallel()
+	assert.True(t, MessageComponentTypeButton.Valid())
+	assert.True(t, MessageComponentTypeSelect.Valid())
+	assert.False(t, MessageComponentType("text").Valid())
+}
+
+func TestMessageComponent_HasOption(t *testing.T) {
+	t.Parallel()
+	c := &MessageComponent{
+		Type:    MessageComponentTypeSelect,
+		Options: []MessageComponentOption{{Label: "A", Value: "a"}, {Label: "B", Value: "b"}},
+	}
+	assert.True(t, c.HasOption("a"))
+	assert.False(t, c.HasOption("c"))
+	assert.False(t, (&MessageComponent{Type: MessageComponentTypeButton}).HasOption(""))
+}
+
+func TestMessageComponentList_Find(t *testing.T) {
+	t.Parallel()
+	l := MessageComponentList{{CustomID: "a"}, {CustomID: "b", Label: "B"}}
+	if c := l.Find("b"); assert.NotNil(t, c) {
+		assert.Equal(t, "B", c.Label)
+	}
+	assert.Nil(t, l.Find("c"))
+	assert.Nil(t, MessageComponentList(nil).Find("a"))
+}
+
+func TestMessageComponentList_Value(t *testing.T) {
+	t.Parallel()
+
+	v, err := MessageComponentList(nil).Value()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "[]", v)
+	}
+
+	v, err = MessageComponentList{{Type: MessageComponentTypeButton, CustomID: "ok", Label: "OK"}}.Value()
+	if assert.NoError(t, err) {
+		assert.Equal(t, `[{"type":"button","customId":"ok","label":"OK","style":"","options":null,"disabled":false}]`, v)
+	}
+}
+
+func TestMessageComponentList_Scan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		var l MessageComponentList
+		assert.NoError(t, l.Scan(nil))
+		assert.Equal(t, MessageComponentList{}, l)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+		var l MessageComponentList
+		assert.NoError(t, l.Scan(`[{"type":"button","customId":"ok"}]`))
+		assert.Equal(t, MessageComponentList{{Type: MessageComponentTypeButton, CustomID: "ok"}}, l)
+	})
+
+	t.Run("[]byte", func(t *testing.T) {
+		t.Parallel()
+		var l MessageComponentList
+		assert.NoError(t, l.Scan([]byte(`[]`)))
+		assert.Equal(t, MessageComponentList{}, l)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+		var l MessageComponentList
+		assert.Error(t, l.Scan(1))
+	})
+}
@@ -5,16 +5,43 @@ import (
 
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// MessageReportStatus メッセージ通報の対応状態
+type MessageReportStatus string
+
+const (
+	// MessageReportStatusPending 未対応
+	MessageReportStatusPending MessageReportStatus = "pending"
+	// MessageReportStatusResolved 対応済み
+	MessageReportStatusResolved MessageReportStatus = "resolved"
+	// MessageReportStatusDismissed 却下
+	MessageReportStatusDismissed MessageReportStatus = "dismissed"
 )
 
+// Valid 有効な値かどうか
+func (s MessageReportStatus) Valid() bool {
+	switch s {
+	case MessageReportStatusPending, MessageReportStatusResolved, MessageReportStatusDismissed:
+		return true
+	default:
+		return false
+	}
+}
+
 // MessageReport メッセージレポート構造体
 type MessageReport struct {
-	ID        uuid.UUID      `gorm:"type:char(36);not null;primaryKey"                   json:"id"`
-	MessageID uuid.UUID      `gorm:"type:char(36);not null;uniqueIndex:message_reporter" json:"messageId"`
-	Reporter  uuid.UUID      `gorm:"type:char(36);not null;uniqueIndex:message_reporter" json:"reporter"`
-	Reason    string         `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"                json:"reason"`
-	CreatedAt time.Time      `gorm:"precision:6;index"                                    json:"createdAt"`
-	DeletedAt gorm.DeletedAt `gorm:"precision:6"                                          json:"-"`
+	ID        uuid.UUID              `gorm:"type:char(36);not null;primaryKey"                   json:"id"`
+	MessageID uuid.UUID              `gorm:"type:char(36);not null;uniqueIndex:message_reporter" json:"messageId"`
+	Reporter  uuid.UUID              `gorm:"type:char(36);not null;uniqueIndex:message_reporter" json:"reporter"`
+	Reason    string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"                json:"reason"`
+	Status    MessageReportStatus    `gorm:"type:varchar(10);not null;default:'pending';index"    json:"status"`
+	HandledBy optional.Of[uuid.UUID] `gorm:"type:char(36)"                                       json:"handledBy"`
+	HandledAt optional.Of[time.Time] `gorm:"precision:6"                                          json:"handledAt"`
+	CreatedAt time.Time              `gorm:"precision:6;index"                                    json:"createdAt"`
+	DeletedAt gorm.DeletedAt         `gorm:"precision:6"                                          json:"-"`
 }
 
 // TableName MessageReport構造体のテーブル名
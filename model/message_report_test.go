@@ -10,3 +10,12 @@ func TestMessageReport_TableName(t *testing.T) {
 	t.Parallel()
 	assert.Equal(t, "message_reports", (&MessageReport{}).TableName())
 }
+
+func TestMessageReportStatus_Valid(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, MessageReportStatusPending.Valid())
+	assert.True(t, MessageReportStatusResolved.Valid())
+	assert.True(t, MessageReportStatusDismissed.Valid())
+	assert.False(t, MessageReportStatus("").Valid())
+}
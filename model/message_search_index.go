@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// MessageSearchIndex 組み込み検索エンジン用のメッセージインデックス
+type MessageSearchIndex struct {
+	MessageID      uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID         uuid.UUID `gorm:"type:char(36);not null;index"`
+	ChannelID      uuid.UUID `gorm:"type:char(36);not null;index"`
+	IsPublic       bool      `gorm:"type:boolean;not null;default:false"`
+	Bot            bool      `gorm:"type:boolean;not null;default:false"`
+	Tokens         string    `gorm:"type:mediumtext;not null;index:idx_message_search_indices_tokens,class:FULLTEXT"`
+	HasURL         bool      `gorm:"type:boolean;not null;default:false"`
+	HasAttachments bool      `gorm:"type:boolean;not null;default:false"`
+	HasImage       bool      `gorm:"type:boolean;not null;default:false"`
+	HasVideo       bool      `gorm:"type:boolean;not null;default:false"`
+	HasAudio       bool      `gorm:"type:boolean;not null;default:false"`
+	CreatedAt      time.Time `gorm:"precision:6;autoCreateTime:false;index"`
+	UpdatedAt      time.Time `gorm:"precision:6;autoUpdateTime:false;index"`
+}
+
+// TableName MessageSearchIndex構造体のテーブル名
+func (*MessageSearchIndex) TableName() string {
+	return "message_search_indices"
+}
@@ -0,0 +1,12 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageSearchIndex_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "message_search_indices", (&MessageSearchIndex{}).TableName())
+}
@@ -5,22 +5,30 @@ import (
 
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // Message データベースに格納するmessageの構造体
 type Message struct {
-	ID        uuid.UUID      `gorm:"type:char(36);not null;primaryKey"`
-	UserID    uuid.UUID      `gorm:"type:char(36);not null;"`
-	ChannelID uuid.UUID      `gorm:"type:char(36);not null;index:idx_messages_channel_id_deleted_at_created_at,priority:1"`
-	Text      string         `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
-	CreatedAt time.Time      `gorm:"precision:6;index;index:idx_messages_channel_id_deleted_at_created_at,priority:3;index:idx_messages_deleted_at_created_at,priority:2"`
-	UpdatedAt time.Time      `gorm:"precision:6;index:idx_messages_deleted_at_updated_at,priority:2"`
-	DeletedAt gorm.DeletedAt `gorm:"precision:6;index:idx_messages_channel_id_deleted_at_created_at,priority:2;index:idx_messages_deleted_at_created_at,priority:1;index:idx_messages_deleted_at_updated_at,priority:1"`
-
-	User    *User          `gorm:"constraint:messages_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Channel *Channel       `gorm:"constraint:messages_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Stamps  []MessageStamp `gorm:"constraint:messages_stamps_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignkey:MessageID"`
-	Pin     *Pin           `gorm:"constraint:pins_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;"`
+	ChannelID uuid.UUID `gorm:"type:char(36);not null;index:idx_messages_channel_id_deleted_at_created_at,priority:1"`
+	Text      string    `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	// ParentMessageID スレッドの親メッセージのID。スレッドへの返信でない場合は無効
+	ParentMessageID optional.Of[uuid.UUID] `gorm:"type:char(36);index:idx_messages_parent_message_id_deleted_at_created_at,priority:1"`
+	CreatedAt       time.Time              `gorm:"precision:6;index;index:idx_messages_channel_id_deleted_at_created_at,priority:3;index:idx_messages_deleted_at_created_at,priority:2;index:idx_messages_parent_message_id_deleted_at_created_at,priority:3"`
+	UpdatedAt       time.Time              `gorm:"precision:6;index:idx_messages_deleted_at_updated_at,priority:2"`
+	DeletedAt       gorm.DeletedAt         `gorm:"precision:6;index:idx_messages_channel_id_deleted_at_created_at,priority:2;index:idx_messages_deleted_at_created_at,priority:1;index:idx_messages_deleted_at_updated_at,priority:1;index:idx_messages_parent_message_id_deleted_at_created_at,priority:2"`
+
+	User       *User              `gorm:"constraint:messages_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Channel    *Channel           `gorm:"constraint:messages_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Stamps     []MessageStamp     `gorm:"constraint:messages_stamps_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignkey:MessageID"`
+	Pin        *Pin               `gorm:"constraint:pins_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Components *MessageComponents `gorm:"constraint:message_components_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:MessageID"`
+
+	// ReplyCount スレッドの返信メッセージ数。GetMessagesでプリロードした場合のみ設定される
+	ReplyCount int `gorm:"-"`
 }
 
 // TableName DBの名前を指定するメソッド
@@ -28,6 +36,18 @@ func (m Message) TableName() string {
 	return "messages"
 }
 
+// IsReply スレッドへの返信メッセージかどうか
+func (m *Message) IsReply() bool {
+	return m.ParentMessageID.Valid
+}
+
+// IsEdited 編集されたメッセージかどうか
+//
+// メッセージの行は本文の編集時にのみ更新されるため、作成日時と更新日時から判定します
+func (m *Message) IsEdited() bool {
+	return m.UpdatedAt.After(m.CreatedAt)
+}
+
 // ChannelLatestMessage チャンネル別最新メッセージ
 type ChannelLatestMessage struct {
 	ChannelID uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
@@ -58,16 +78,17 @@ func (unread *Unread) TableName() string {
 	return "unreads"
 }
 
-// ArchivedMessage 編集前のアーカイブ化されたメッセージの構造体
-type ArchivedMessage struct {
+// MessageRevision 編集前のメッセージの版の構造体
+type MessageRevision struct {
 	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
 	MessageID uuid.UUID `gorm:"type:char(36);not null;index"`
 	UserID    uuid.UUID `gorm:"type:char(36);not null"`
 	Text      string    `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
-	DateTime  time.Time `gorm:"precision:6"`
+	// DateTime この版の本文が投稿・編集された日時
+	DateTime time.Time `gorm:"precision:6"`
 }
 
-// TableName ArchivedMessage構造体のテーブル名
-func (am *ArchivedMessage) TableName() string {
-	return "archived_messages"
+// TableName MessageRevision構造体のテーブル名
+func (mr *MessageRevision) TableName() string {
+	return "message_revisions"
 }
@@ -2,6 +2,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -21,7 +22,15 @@ func TestChannelLatestMessage_TableName(t *testing.T) {
 	assert.Equal(t, "channel_latest_messages", (&ChannelLatestMessage{}).TableName())
 }
 
-func TestArchivedMessage_TableName(t *testing.T) {
+func TestMessageRevision_TableName(t *testing.T) {
 	t.Parallel()
-	assert.Equal(t, "archived_messages", (&ArchivedMessage{}).TableName())
+	assert.Equal(t, "message_revisions", (&MessageRevision{}).TableName())
+}
+
+func TestMessage_IsEdited(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	assert.False(t, (&Message{CreatedAt: now, UpdatedAt: now}).IsEdited())
+	assert.True(t, (&Message{CreatedAt: now, UpdatedAt: now.Add(time.Second)}).IsEdited())
 }
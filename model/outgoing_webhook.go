@@ -0,0 +1,178 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	jsonIter "github.com/json-iterator/go"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// OutgoingWebhookEvent 外部送信Webhookのイベントタイプ
+type OutgoingWebhookEvent string
+
+const (
+	// OutgoingWebhookEventMessageCreated メッセージが投稿された
+	OutgoingWebhookEventMessageCreated OutgoingWebhookEvent = "MESSAGE_CREATED"
+	// OutgoingWebhookEventMessageStamped メッセージにスタンプが押された
+	OutgoingWebhookEventMessageStamped OutgoingWebhookEvent = "MESSAGE_STAMPED"
+	// OutgoingWebhookEventMessagePinned メッセージがピン留めされた
+	OutgoingWebhookEventMessagePinned OutgoingWebhookEvent = "MESSAGE_PINNED"
+)
+
+// Valid 有効な値かどうか
+func (e OutgoingWebhookEvent) Valid() bool {
+	switch e {
+	case OutgoingWebhookEventMessageCreated, OutgoingWebhookEventMessageStamped, OutgoingWebhookEventMessagePinned:
+		return true
+	default:
+		return false
+	}
+}
+
+func (e OutgoingWebhookEvent) String() string {
+	return string(e)
+}
+
+// OutgoingWebhookEvents OutgoingWebhookEventのSet
+type OutgoingWebhookEvents map[OutgoingWebhookEvent]struct{}
+
+// OutgoingWebhookEventsFromArray 文字列の配列からOutgoingWebhookEventsを生成します
+func OutgoingWebhookEventsFromArray(arr []string) OutgoingWebhookEvents {
+	res := OutgoingWebhookEvents{}
+	for _, v := range arr {
+		if len(v) > 0 {
+			res[OutgoingWebhookEvent(v)] = struct{}{}
+		}
+	}
+	return res
+}
+
+// String スペース区切りで文字列に出力します
+func (set OutgoingWebhookEvents) String() string {
+	return strings.Join(set.Array(), " ")
+}
+
+// Contains 指定したイベントが含まれているかどうか
+func (set OutgoingWebhookEvents) Contains(ev OutgoingWebhookEvent) bool {
+	_, ok := set[ev]
+	return ok
+}
+
+// Array stringの配列に変換します
+func (set OutgoingWebhookEvents) Array() (r []string) {
+	r = make([]string, 0, len(set))
+	for s := range set {
+		r = append(r, s.String())
+	}
+	return r
+}
+
+// MarshalJSON encoding/json.Marshaler 実装
+func (set OutgoingWebhookEvents) MarshalJSON() ([]byte, error) {
+	return jsonIter.ConfigFastest.Marshal(set.Array())
+}
+
+// UnmarshalJSON encoding/json.Unmarshaler 実装
+func (set *OutgoingWebhookEvents) UnmarshalJSON(data []byte) error {
+	var arr []string
+	err := jsonIter.ConfigFastest.Unmarshal(data, &arr)
+	if err != nil {
+		return err
+	}
+	*set = OutgoingWebhookEventsFromArray(arr)
+	return nil
+}
+
+// Value database/sql/driver.Valuer 実装
+func (set OutgoingWebhookEvents) Value() (driver.Value, error) {
+	return set.String(), nil
+}
+
+// Scan database/sql.Scanner 実装
+func (set *OutgoingWebhookEvents) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*set = OutgoingWebhookEvents{}
+	case string:
+		*set = OutgoingWebhookEventsFromArray(strings.Split(s, " "))
+	case []byte:
+		*set = OutgoingWebhookEventsFromArray(strings.Split(string(s), " "))
+	default:
+		return errors.New("failed to scan OutgoingWebhookEvents")
+	}
+	return nil
+}
+
+// OutgoingWebhook 外部送信Webhook構造体
+//
+// 指定したチャンネルのイベントを外部のURLにPOSTします
+type OutgoingWebhook struct {
+	ID          uuid.UUID             `gorm:"type:char(36);not null;primaryKey" json:"id"`
+	Name        string                `gorm:"type:varchar(32);not null"         json:"name"`
+	Description string                `gorm:"type:text;not null"                json:"description"`
+	ChannelID   uuid.UUID             `gorm:"type:char(36);not null;index"      json:"channelId"`
+	URL         string                `gorm:"type:text;not null"                json:"url"`
+	Secret      string                `gorm:"type:text;not null"                json:"-"`
+	Events      OutgoingWebhookEvents `gorm:"type:text;not null"                json:"events"`
+	CreatorID   uuid.UUID             `gorm:"type:char(36);not null;index"      json:"ownerId"`
+	CreatedAt   time.Time             `gorm:"precision:6"                       json:"createdAt"`
+	UpdatedAt   time.Time             `gorm:"precision:6"                       json:"updatedAt"`
+	DeletedAt   gorm.DeletedAt        `gorm:"precision:6"                       json:"-"`
+
+	Creator *User    `gorm:"constraint:outgoing_webhooks_creator_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:CreatorID" json:"-"`
+	Channel *Channel `gorm:"constraint:outgoing_webhooks_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"                  json:"-"`
+}
+
+// TableName OutgoingWebhookのテーブル名
+func (*OutgoingWebhook) TableName() string {
+	return "outgoing_webhooks"
+}
+
+// OutgoingWebhookDeliveryLog 外部送信Webhookの配送ログ
+//
+// 配送の試行ごとに記録されます。再試行した場合はDeliveryIDが同じになります
+type OutgoingWebhookDeliveryLog struct {
+	ID         uuid.UUID            `gorm:"type:char(36);not null;primaryKey"                   json:"id"`
+	DeliveryID uuid.UUID            `gorm:"type:char(36);not null;index"                        json:"deliveryId"`
+	WebhookID  uuid.UUID            `gorm:"type:char(36);not null;index:webhook_id_date_time_idx" json:"webhookId"`
+	Event      OutgoingWebhookEvent `gorm:"type:varchar(30);not null"                           json:"event"`
+	Body       string               `gorm:"type:text"                                           json:"body"`
+	Attempt    int                  `gorm:"not null;default:1"                                  json:"attempt"`
+	Result     string               `gorm:"type:char(2);not null"                               json:"result"`
+	Error      string               `gorm:"type:text"                                           json:"error"`
+	Code       int                  `gorm:"not null;default:0"                                  json:"code"`
+	Latency    int64                `gorm:"not null;default:0"                                  json:"latency"`
+	DateTime   time.Time            `gorm:"precision:6;index:webhook_id_date_time_idx"          json:"dateTime"`
+}
+
+// TableName OutgoingWebhookDeliveryLogのテーブル名
+func (*OutgoingWebhookDeliveryLog) TableName() string {
+	return "outgoing_webhook_delivery_logs"
+}
+
+// OutgoingWebhookDelivery 再試行待ちの外部送信Webhookの配送 (アウトボックス)
+//
+// 配送に成功した、或いは試行回数の上限に達した配送は削除されます
+type OutgoingWebhookDelivery struct {
+	ID            uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	WebhookID     uuid.UUID              `gorm:"type:char(36);not null;index"`
+	Event         OutgoingWebhookEvent   `gorm:"type:varchar(30);not null"`
+	Body          string                 `gorm:"type:text;not null"`
+	Attempts      int                    `gorm:"not null;default:0"`
+	NextAttemptAt time.Time              `gorm:"precision:6;index"`
+	LeaseUntil    optional.Of[time.Time] `gorm:"precision:6"`
+	CreatedAt     time.Time              `gorm:"precision:6"`
+
+	Webhook *OutgoingWebhook `gorm:"constraint:outgoing_webhook_deliveries_webhook_id_outgoing_webhooks_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName OutgoingWebhookDeliveryのテーブル名
+func (*OutgoingWebhookDelivery) TableName() string {
+	return "outgoing_webhook_deliveries"
+}
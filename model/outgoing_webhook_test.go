@@ -0,0 +1,56 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutgoingWebhook_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "outgoing_webhooks", (&OutgoingWebhook{}).TableName())
+}
+
+func TestOutgoingWebhookDeliveryLog_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "outgoing_webhook_delivery_logs", (&OutgoingWebhookDeliveryLog{}).TableName())
+}
+
+func TestOutgoingWebhookEvent_Valid(t *testing.T) {
+	t.Parallel()
+	assert.True(t, OutgoingWebhookEventMessageCreated.Valid())
+	assert.True(t, OutgoingWebhookEventMessageStamped.Valid())
+	assert.True(t, OutgoingWebhookEventMessagePinned.Valid())
+	assert.False(t, OutgoingWebhookEvent("MESSAGE_DELETED").Valid())
+}
+
+func TestOutgoingWebhookEvents_Scan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		s := OutgoingWebhookEvents{OutgoingWebhookEventMessageCreated: {}}
+		assert.NoError(t, s.Scan(nil))
+		assert.Empty(t, s)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+		var s OutgoingWebhookEvents
+		assert.NoError(t, s.Scan("MESSAGE_CREATED MESSAGE_PINNED"))
+		assert.Equal(t, OutgoingWebhookEvents{OutgoingWebhookEventMessageCreated: {}, OutgoingWebhookEventMessagePinned: {}}, s)
+	})
+
+	t.Run("[]byte", func(t *testing.T) {
+		t.Parallel()
+		var s OutgoingWebhookEvents
+		assert.NoError(t, s.Scan([]byte("MESSAGE_STAMPED")))
+		assert.Equal(t, OutgoingWebhookEvents{OutgoingWebhookEventMessageStamped: {}}, s)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		t.Parallel()
+		var s OutgoingWebhookEvents
+		assert.Error(t, s.Scan(1))
+	})
+}
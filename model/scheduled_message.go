@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// ScheduledMessageStatus 予約投稿の状態
+type ScheduledMessageStatus string
+
+const (
+	// ScheduledMessageStatusPending 投稿待ち
+	ScheduledMessageStatusPending ScheduledMessageStatus = "pending"
+	// ScheduledMessageStatusSending 投稿処理中
+	ScheduledMessageStatusSending ScheduledMessageStatus = "sending"
+	// ScheduledMessageStatusSent 投稿済み
+	ScheduledMessageStatusSent ScheduledMessageStatus = "sent"
+	// ScheduledMessageStatusFailed 投稿失敗
+	ScheduledMessageStatusFailed ScheduledMessageStatus = "failed"
+	// ScheduledMessageStatusCanceled 取り消し済み
+	ScheduledMessageStatusCanceled ScheduledMessageStatus = "canceled"
+)
+
+// Valid 有効な値かどうか
+func (s ScheduledMessageStatus) Valid() bool {
+	switch s {
+	case ScheduledMessageStatusPending, ScheduledMessageStatusSending, ScheduledMessageStatusSent, ScheduledMessageStatusFailed, ScheduledMessageStatusCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// ScheduledMessage 予約投稿メッセージ構造体
+//
+// ChannelIDとDMUserIDのどちらか一方のみが有効な値を持ちます
+type ScheduledMessage struct {
+	ID          uuid.UUID              `gorm:"type:char(36);not null;primaryKey"                      json:"id"`
+	UserID      uuid.UUID              `gorm:"type:char(36);not null;index"                           json:"userId"`
+	ChannelID   optional.Of[uuid.UUID] `gorm:"type:char(36)"                                          json:"channelId"`
+	DMUserID    optional.Of[uuid.UUID] `gorm:"type:char(36)"                                          json:"dmUserId"`
+	Content     string                 `gorm:"type:text;not null"                                     json:"content"`
+	ScheduledAt time.Time              `gorm:"precision:6;index:idx_scheduled_messages_status_scheduled_at,priority:2" json:"scheduledAt"`
+	Status      ScheduledMessageStatus `gorm:"type:varchar(10);not null;default:'pending';index:idx_scheduled_messages_status_scheduled_at,priority:1" json:"status"`
+	AcquiredAt  optional.Of[time.Time] `gorm:"precision:6"                                            json:"-"`
+	MessageID   optional.Of[uuid.UUID] `gorm:"type:char(36)"                                          json:"messageId"`
+	Error       string                 `gorm:"type:text;not null"                                     json:"error"`
+	CreatedAt   time.Time              `gorm:"precision:6"                                            json:"createdAt"`
+	UpdatedAt   time.Time              `gorm:"precision:6"                                            json:"updatedAt"`
+}
+
+// TableName ScheduledMessage構造体のテーブル名
+func (*ScheduledMessage) TableName() string {
+	return "scheduled_messages"
+}
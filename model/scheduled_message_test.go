@@ -0,0 +1,23 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledMessage_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "scheduled_messages", (&ScheduledMessage{}).TableName())
+}
+
+func TestScheduledMessageStatus_Valid(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, ScheduledMessageStatusPending.Valid())
+	assert.True(t, ScheduledMessageStatusSending.Valid())
+	assert.True(t, ScheduledMessageStatusSent.Valid())
+	assert.True(t, ScheduledMessageStatusFailed.Valid())
+	assert.True(t, ScheduledMessageStatusCanceled.Valid())
+	assert.False(t, ScheduledMessageStatus("").Valid())
+}
@@ -1,11 +1,25 @@
 package model
 
-import "github.com/gofrs/uuid"
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
 
 // UserSettings ユーザー設定の構造体
 type UserSettings struct {
 	UserID         uuid.UUID `gorm:"type:char(36);not null;primaryKey;" json:"id"`
 	NotifyCitation bool      `gorm:"type:boolean" json:"notifyCitation"`
+	// DoNotDisturbEnabled おやすみモード(プッシュ通知を送らない時間帯)が有効かどうか
+	DoNotDisturbEnabled bool `gorm:"type:boolean;not null;default:false;index" json:"doNotDisturbEnabled"`
+	// DoNotDisturbStart おやすみモードの開始時刻 (HH:MM)
+	DoNotDisturbStart string `gorm:"type:varchar(5);not null;default:''" json:"doNotDisturbStart"`
+	// DoNotDisturbEnd おやすみモードの終了時刻 (HH:MM)
+	DoNotDisturbEnd string `gorm:"type:varchar(5);not null;default:''" json:"doNotDisturbEnd"`
+	// TimeZone おやすみモードの時刻のタイムゾーン (IANA Time Zone)
+	TimeZone string `gorm:"type:varchar(64);not null;default:''" json:"timeZone"`
 
 	User *User `gorm:"constraint:user_settings_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
@@ -19,3 +33,101 @@ func (us *UserSettings) TableName() string {
 func (us *UserSettings) IsNotifyCitationEnabled() bool {
 	return us.NotifyCitation
 }
+
+// IsDoNotDisturb 指定した時刻がおやすみモードの時間帯に含まれるかどうかを返します
+//
+// 開始時刻と終了時刻が同じ場合は終日おやすみモードとみなします。
+func (us *UserSettings) IsDoNotDisturb(t time.Time) bool {
+	if !us.DoNotDisturbEnabled {
+		return false
+	}
+	start, err := ParseClock(us.DoNotDisturbStart)
+	if err != nil {
+		return false
+	}
+	end, err := ParseClock(us.DoNotDisturbEnd)
+	if err != nil {
+		return false
+	}
+	t = t.In(loadLocation(us.TimeZone))
+	now := t.Hour()*60 + t.Minute()
+	switch {
+	case start == end:
+		return true
+	case start < end:
+		return start <= now && now < end
+	default: // 日付をまたぐ
+		return start <= now || now < end
+	}
+}
+
+// locationCache タイムゾーン名から*time.Locationへのキャッシュ
+var locationCache sync.Map
+
+// loadLocation タイムゾーンを取得します。不正なタイムゾーンの場合はUTCを返します
+//
+// time.LoadLocationは呼び出す度にタイムゾーンデータベースを読み込むため、読み込めたものはキャッシュします。
+func loadLocation(name string) *time.Location {
+	if loc, ok := locationCache.Load(name); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	locationCache.Store(name, loc)
+	return loc
+}
+
+// ParseClock "HH:MM"形式の時刻を0時からの経過分に変換します
+func ParseClock(s string) (int, error) {
+	if len(s) != 5 {
+		return 0, fmt.Errorf("invalid clock: %s", s)
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock: %s", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// UserNotificationKeyword ユーザーの通知キーワードの構造体
+type UserNotificationKeyword struct {
+	UserID  uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	Keyword string    `gorm:"type:varchar(50);not null;primaryKey"`
+
+	User *User `gorm:"constraint:user_notification_keywords_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName UserNotificationKeyword構造体のテーブル名
+func (*UserNotificationKeyword) TableName() string {
+	return "user_notification_keywords"
+}
+
+// UserGroupMentionMute ユーザーが通知を受け取らないグループメンションの構造体
+type UserGroupMentionMute struct {
+	UserID  uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	GroupID uuid.UUID `gorm:"type:char(36);not null;primaryKey;index"`
+
+	User  *User      `gorm:"constraint:user_group_mention_mutes_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Group *UserGroup `gorm:"constraint:user_group_mention_mutes_group_id_user_groups_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName UserGroupMentionMute構造体のテーブル名
+func (*UserGroupMentionMute) TableName() string {
+	return "user_group_mention_mutes"
+}
+
+// UserChannelPushMute ユーザーがプッシュ通知を受け取らない(WebSocketでのみ通知を受け取る)チャンネルの構造体
+type UserChannelPushMute struct {
+	UserID    uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	ChannelID uuid.UUID `gorm:"type:char(36);not null;primaryKey;index"`
+
+	User    *User    `gorm:"constraint:user_channel_push_mutes_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Channel *Channel `gorm:"constraint:user_channel_push_mutes_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName UserChannelPushMute構造体のテーブル名
+func (*UserChannelPushMute) TableName() string {
+	return "user_channel_push_mutes"
+}
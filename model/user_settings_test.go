@@ -0,0 +1,76 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserSettingsTableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "user_settings", (&UserSettings{}).TableName())
+}
+
+func TestUserNotificationKeywordTableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "user_notification_keywords", (&UserNotificationKeyword{}).TableName())
+}
+
+func TestUserGroupMentionMuteTableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "user_group_mention_mutes", (&UserGroupMentionMute{}).TableName())
+}
+
+func TestUserChannelPushMuteTableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "user_channel_push_mutes", (&UserChannelPushMute{}).TableName())
+}
+
+func TestUserSettings_IsDoNotDisturb(t *testing.T) {
+	t.Parallel()
+
+	at := func(h, m int) time.Time {
+		return time.Date(2023, 1, 1, h, m, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name string
+		us   UserSettings
+		t    time.Time
+		want bool
+	}{
+		{"disabled", UserSettings{DoNotDisturbStart: "00:00", DoNotDisturbEnd: "23:59"}, at(12, 0), false},
+		{"in range", UserSettings{DoNotDisturbEnabled: true, DoNotDisturbStart: "09:00", DoNotDisturbEnd: "17:00"}, at(12, 0), true},
+		{"start is inclusive", UserSettings{DoNotDisturbEnabled: true, DoNotDisturbStart: "09:00", DoNotDisturbEnd: "17:00"}, at(9, 0), true},
+		{"end is exclusive", UserSettings{DoNotDisturbEnabled: true, DoNotDisturbStart: "09:00", DoNotDisturbEnd: "17:00"}, at(17, 0), false},
+		{"overnight (night)", UserSettings{DoNotDisturbEnabled: true, DoNotDisturbStart: "22:00", DoNotDisturbEnd: "07:00"}, at(23, 30), true},
+		{"overnight (morning)", UserSettings{DoNotDisturbEnabled: true, DoNotDisturbStart: "22:00", DoNotDisturbEnd: "07:00"}, at(6, 59), true},
+		{"overnight (daytime)", UserSettings{DoNotDisturbEnabled: true, DoNotDisturbStart: "22:00", DoNotDisturbEnd: "07:00"}, at(12, 0), false},
+		{"all day", UserSettings{DoNotDisturbEnabled: true, DoNotDisturbStart: "00:00", DoNotDisturbEnd: "00:00"}, at(12, 0), true},
+		{"time zone", UserSettings{DoNotDisturbEnabled: true, DoNotDisturbStart: "22:00", DoNotDisturbEnd: "07:00", TimeZone: "Asia/Tokyo"}, at(14, 0), true},
+		{"unknown time zone", UserSettings{DoNotDisturbEnabled: true, DoNotDisturbStart: "22:00", DoNotDisturbEnd: "07:00", TimeZone: "Invalid/Zone"}, at(14, 0), false},
+		{"invalid clock", UserSettings{DoNotDisturbEnabled: true, DoNotDisturbStart: "25:00", DoNotDisturbEnd: "07:00"}, at(1, 0), false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.us.IsDoNotDisturb(tt.t))
+		})
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	t.Parallel()
+
+	for s, want := range map[string]int{"00:00": 0, "09:30": 570, "23:59": 1439} {
+		got, err := ParseClock(s)
+		if assert.NoError(t, err, s) {
+			assert.Equal(t, want, got, s)
+		}
+	}
+	for _, s := range []string{"", "9:30", "24:00", "12:60", "ab:cd", "12-30", "+1:30"} {
+		_, err := ParseClock(s)
+		assert.Error(t, err, s)
+	}
+}
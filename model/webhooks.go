@@ -14,6 +14,7 @@ type Webhook interface {
 	GetName() string
 	GetDescription() string
 	GetSecret() string
+	GetTemplate() string
 	GetChannelID() uuid.UUID
 	GetCreatorID() uuid.UUID
 	GetCreatedAt() time.Time
@@ -26,6 +27,7 @@ type WebhookBot struct {
 	BotUserID   uuid.UUID      `gorm:"type:char(36);not null;unique"`
 	Description string         `gorm:"type:text;not null"`
 	Secret      string         `gorm:"type:text;not null"`
+	Template    string         `gorm:"type:text;not null"`
 	ChannelID   uuid.UUID      `gorm:"type:char(36);not null"`
 	CreatorID   uuid.UUID      `gorm:"type:char(36);not null"`
 	CreatedAt   time.Time      `gorm:"precision:6"`
@@ -67,6 +69,11 @@ func (w *WebhookBot) GetSecret() string {
 	return w.Secret
 }
 
+// GetTemplate Webhookのメッセージテンプレートを返します
+func (w *WebhookBot) GetTemplate() string {
+	return w.Template
+}
+
 // GetChannelID Webhookのデフォルト投稿チャンネルのIDを返します
 func (w *WebhookBot) GetChannelID() uuid.UUID {
 	return w.ChannelID
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// WebPushSubscription Web Push購読の構造体
+type WebPushSubscription struct {
+	// Endpoint プッシュサービスのエンドポイントURL
+	Endpoint string    `gorm:"type:varchar(500);not null;primaryKey"`
+	UserID   uuid.UUID `gorm:"type:char(36);not null;index"`
+	// P256dh 購読者のECDH公開鍵 (URL-safe Base64)
+	P256dh string `gorm:"type:varchar(100);not null"`
+	// Auth 購読者の認証シークレット (URL-safe Base64)
+	Auth      string    `gorm:"type:varchar(50);not null"`
+	CreatedAt time.Time `gorm:"precision:6"`
+
+	User *User `gorm:"constraint:webpush_subscriptions_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName WebPushSubscription構造体のテーブル名
+func (*WebPushSubscription) TableName() string {
+	return "webpush_subscriptions"
+}
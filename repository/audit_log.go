@@ -0,0 +1,38 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// AuditLogsQuery GetAuditLogs用クエリ
+type AuditLogsQuery struct {
+	ActorID   optional.Of[uuid.UUID]
+	Target    optional.Of[string]
+	Action    optional.Of[model.AuditAction]
+	Since     optional.Of[time.Time]
+	Until     optional.Of[time.Time]
+	Inclusive bool
+	Limit     int
+	Offset    int
+	Asc       bool
+}
+
+// AuditLogRepository 監査ログリポジトリ
+type AuditLogRepository interface {
+	// CreateAuditLog 監査ログを記録します
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	CreateAuditLog(log *model.AuditLog) error
+	// GetAuditLogs 指定したクエリで監査ログを取得します
+	//
+	// 成功した場合、監査ログの配列と、更に取得できる監査ログがあるかどうかとnilを返します。正でないoffset, limitは無視されます。
+	// DBによるエラーを返すことがあります。
+	GetAuditLogs(query AuditLogsQuery) (logs []*model.AuditLog, more bool, err error)
+}
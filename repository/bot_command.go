@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// SetBotCommandArgs Botコマンド登録引数
+type SetBotCommandArgs struct {
+	Name        string
+	Description string
+	Args        model.BotCommandArgs
+}
+
+// CreateBotCommandInvocationArgs Botコマンド実行記録作成引数
+type CreateBotCommandInvocationArgs struct {
+	CommandID uuid.UUID
+	BotID     uuid.UUID
+	UserID    uuid.UUID
+	ChannelID uuid.UUID
+	Text      string
+}
+
+// BotCommandRepository Botコマンドリポジトリ
+type BotCommandRepository interface {
+	// SetBotCommands Botのコマンドを全て置き換えます
+	//
+	// 成功した場合、登録後のコマンドの配列とnilを返します。
+	// 同じBotのコマンドが同時に更新された場合、ErrAlreadyExistsを返すことがあります。
+	// 引数に問題がある場合、ArgumentErrorを返します。
+	// botIDにuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	SetBotCommands(botID uuid.UUID, commands []SetBotCommandArgs) ([]*model.BotCommand, error)
+	// GetBotCommands 指定したBotのコマンドを名前順に取得します
+	//
+	// 成功した場合、コマンドの配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetBotCommands(botIDs []uuid.UUID) ([]*model.BotCommand, error)
+	// GetBotCommandsByName 指定したBotの中から指定した名前のコマンドを取得します
+	//
+	// 成功した場合、コマンドの配列とnilを返します。存在しなかった場合は空の配列を返します。
+	// DBによるエラーを返すことがあります。
+	GetBotCommandsByName(name string, botIDs []uuid.UUID) ([]*model.BotCommand, error)
+	// CreateBotCommandInvocation Botコマンドの実行記録を作成します
+	//
+	// 成功した場合、実行記録とnilを返します。
+	// DBによるエラーを返すことがあります。
+	CreateBotCommandInvocation(args CreateBotCommandInvocationArgs) (*model.BotCommandInvocation, error)
+	// GetBotCommandInvocation 指定したBotコマンドの実行記録を取得します
+	//
+	// 成功した場合、実行記録とnilを返します。
+	// 存在しなかった場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetBotCommandInvocation(id uuid.UUID) (*model.BotCommandInvocation, error)
+}
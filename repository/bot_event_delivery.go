@@ -0,0 +1,60 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// BotEventDeliveryRepository Botイベント配送アウトボックスリポジトリ
+type BotEventDeliveryRepository interface {
+	// SaveBotEventDelivery Botイベント配送を作成、或いは更新します
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	SaveBotEventDelivery(d *model.BotEventDelivery) error
+	// DeleteBotEventDelivery 指定したBotイベント配送を削除します
+	//
+	// 成功した、或いは既に存在しない場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteBotEventDelivery(id uuid.UUID) error
+	// GetRetryableBotEventDeliveries 再送時刻を過ぎた、有効なHTTPモードのBotの再送待ちイベントを再送時刻の昇順で取得します
+	//
+	// 再送中のまま処理権の期限が切れたイベントも含みます。
+	// 成功した場合、Botイベント配送の配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetRetryableBotEventDeliveries(now time.Time, limit int) ([]*model.BotEventDelivery, error)
+	// ClaimBotEventDelivery 指定したBotイベント配送を再送中にし、leaseUntilまでの再送処理権を得ます
+	//
+	// 再送待ち、或いは処理権の期限が切れた再送中のイベントの場合のみ処理権を得られます。
+	// 処理権を得た場合、trueとnilを返します。他のインスタンスが処理中、或いは存在しない場合、falseとnilを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	ClaimBotEventDelivery(id uuid.UUID, leaseUntil time.Time) (bool, error)
+	// CountBotEventDeliveries 指定したBotの指定した状態のイベントの数を取得します
+	//
+	// 成功した場合、イベントの数とnilを返します。
+	// DBによるエラーを返すことがあります。
+	CountBotEventDeliveries(botID uuid.UUID, status model.BotEventDeliveryStatus) (int, error)
+	// GetBotEventDeliveries 指定したBotの指定した状態のイベントを作成日時の昇順で取得します
+	//
+	// 成功した場合、Botイベント配送の配列とnilを返します。負のoffset, limitは無視されます。
+	// 存在しないBotを指定した場合、空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetBotEventDeliveries(botID uuid.UUID, status model.BotEventDeliveryStatus, limit, offset int) ([]*model.BotEventDelivery, error)
+	// RequeueBotEventDeliveries 指定したBotのデッドレターを再送待ちに戻します
+	//
+	// 成功した場合、再送待ちに戻したイベントの数とnilを返します。
+	// idsが空の場合は、そのBotの全てのデッドレターを対象にします。
+	// botIDにuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	RequeueBotEventDeliveries(botID uuid.UUID, ids []uuid.UUID) (int, error)
+	// PurgeBotEventDeliveries 指定した時間以前に作成されたBotイベント配送を全て消去します
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	PurgeBotEventDeliveries(before time.Time) error
+}
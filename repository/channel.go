@@ -89,6 +89,14 @@ type ChannelRepository interface {
 	UpdateChannel(channelID uuid.UUID, args UpdateChannelArgs) (*model.Channel, error)
 	// ArchiveChannels 指定したチャンネルをアーカイブします
 	ArchiveChannels(ids []uuid.UUID) ([]*model.Channel, error)
+	// DeleteChannels 指定したチャンネルをメッセージと共に完全に削除します
+	//
+	// チャンネルへのWebhookは削除され、Webhookのユーザーは凍結されます。
+	// チャンネルにアップロードされたファイルのメタデータは同じトランザクションで削除され、削除したファイルを返します。
+	// ストレージ上のファイルの実体は削除されません。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteChannels(ids []uuid.UUID) ([]*model.FileMeta, error)
 	// GetChannel 指定したチャンネルを取得します
 	//
 	// 存在しないチャンネルを指定した場合、ErrNotFoundを返します。
@@ -101,6 +109,16 @@ type ChannelRepository interface {
 	GetDirectMessageChannelMapping(userID uuid.UUID) ([]*model.DMChannelMapping, error)
 	// GetPrivateChannelMemberIDs 指定したプライベートチャンネルのメンバーのUUIDを取得します
 	GetPrivateChannelMemberIDs(channelID uuid.UUID) ([]uuid.UUID, error)
+	// ChangePrivateChannelMembers 指定したプライベートチャンネルのメンバーを変更します
+	//
+	// channelIDにuuid.Nilを指定した場合、ErrNilIDを返します。
+	// 存在しないチャンネルを指定した場合、ErrNotFoundを返します。
+	// 変更後のメンバーがいなくなる場合、ErrForbiddenを返します。
+	// 既にメンバーであるユーザーの追加・メンバーでないユーザーの削除は無視されます。
+	// 存在しないユーザーを指定した場合は無視されます。
+	ChangePrivateChannelMembers(channelID uuid.UUID, add, remove []uuid.UUID) (added []uuid.UUID, removed []uuid.UUID, err error)
+	// GetPrivateChannelsByUser 指定したユーザーがメンバーのプライベートチャンネル(DMを除く)を全て取得します
+	GetPrivateChannelsByUser(userID uuid.UUID) ([]*model.Channel, error)
 	// ChangeChannelSubscription ユーザーのチャンネルの購読を変更します
 	//
 	// channelIDにuuid.Nilを指定した場合、ErrNilIDを返します。
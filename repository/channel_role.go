@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// ChannelRoleRepository チャンネルロール・チャンネル権限上書きリポジトリ
+type ChannelRoleRepository interface {
+	// GetChannelRoles 指定したチャンネルのチャンネルロールを全て取得します
+	//
+	// 成功した場合、チャンネルロールの配列とnilを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	GetChannelRoles(channelID uuid.UUID) ([]*model.ChannelRole, error)
+	// GetAllChannelRoles 全てのチャンネルロールを取得します
+	//
+	// 成功した場合、チャンネルロールの配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetAllChannelRoles() ([]*model.ChannelRole, error)
+	// SetChannelRoles 指定したチャンネルのチャンネルロールを置き換えます
+	//
+	// 成功した場合、nilを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	SetChannelRoles(channelID uuid.UUID, roles []*model.ChannelRole) error
+	// GetChannelPermissionOverrides 指定したチャンネルの権限上書き設定を全て取得します
+	//
+	// 成功した場合、権限上書き設定の配列とnilを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	GetChannelPermissionOverrides(channelID uuid.UUID) ([]*model.ChannelPermissionOverride, error)
+	// GetAllChannelPermissionOverrides 全てのチャンネルの権限上書き設定を取得します
+	//
+	// 成功した場合、権限上書き設定の配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetAllChannelPermissionOverrides() ([]*model.ChannelPermissionOverride, error)
+	// SetChannelPermissionOverrides 指定したチャンネルの権限上書き設定を置き換えます
+	//
+	// 成功した場合、nilを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	SetChannelPermissionOverrides(channelID uuid.UUID, overrides []*model.ChannelPermissionOverride) error
+}
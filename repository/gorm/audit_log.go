@@ -0,0 +1,71 @@
+package gorm
+
+import (
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+// CreateAuditLog implements AuditLogRepository interface.
+func (repo *Repository) CreateAuditLog(log *model.AuditLog) error {
+	if log.ID == uuid.Nil {
+		log.ID = uuid.Must(uuid.NewV4())
+	}
+	if log.Detail == nil {
+		log.Detail = model.AuditLogDetail{}
+	}
+	return repo.db.Create(log).Error
+}
+
+// GetAuditLogs implements AuditLogRepository interface.
+func (repo *Repository) GetAuditLogs(query repository.AuditLogsQuery) (logs []*model.AuditLog, more bool, err error) {
+	logs = make([]*model.AuditLog, 0)
+
+	tx := repo.db
+	if query.Asc {
+		tx = tx.Order("created_at")
+	} else {
+		tx = tx.Order("created_at DESC")
+	}
+
+	if query.ActorID.Valid {
+		tx = tx.Where("actor_id = ?", query.ActorID.V)
+	}
+	if query.Target.Valid {
+		tx = tx.Where("target = ?", query.Target.V)
+	}
+	if query.Action.Valid {
+		tx = tx.Where("action = ?", query.Action.V)
+	}
+
+	if query.Inclusive {
+		if query.Since.Valid {
+			tx = tx.Where("created_at >= ?", query.Since.V)
+		}
+		if query.Until.Valid {
+			tx = tx.Where("created_at <= ?", query.Until.V)
+		}
+	} else {
+		if query.Since.Valid {
+			tx = tx.Where("created_at > ?", query.Since.V)
+		}
+		if query.Until.Valid {
+			tx = tx.Where("created_at < ?", query.Until.V)
+		}
+	}
+
+	if query.Offset > 0 {
+		tx = tx.Offset(query.Offset)
+	}
+
+	if query.Limit > 0 {
+		err = tx.Limit(query.Limit + 1).Find(&logs).Error
+		if len(logs) > query.Limit {
+			return logs[:len(logs)-1], true, err
+		}
+	} else {
+		err = tx.Find(&logs).Error
+	}
+	return logs, false, err
+}
@@ -0,0 +1,111 @@
+package gorm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_CreateAuditLog(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common)
+
+	target := uuid.Must(uuid.NewV4()).String()
+	log := &model.AuditLog{
+		Action:    model.AuditActionUserUpdated,
+		ActorID:   optional.From(user.GetID()),
+		Target:    target,
+		Detail:    model.AuditLogDetail{"role": "admin"},
+		IPAddress: "192.0.2.1",
+	}
+	if assert.NoError(repo.CreateAuditLog(log)) {
+		assert.NotEqual(uuid.Nil, log.ID)
+
+		logs, _, err := repo.GetAuditLogs(repository.AuditLogsQuery{Target: optional.From(target)})
+		require.NoError(err)
+		if assert.Len(logs, 1) {
+			assert.Equal(log.ID, logs[0].ID)
+			assert.Equal(model.AuditActionUserUpdated, logs[0].Action)
+			assert.Equal(optional.From(user.GetID()), logs[0].ActorID)
+			assert.EqualValues("admin", logs[0].Detail["role"])
+			assert.Equal("192.0.2.1", logs[0].IPAddress)
+		}
+	}
+}
+
+func TestRepositoryImpl_GetAuditLogs(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common)
+	other := mustMakeUser(t, repo, rand)
+
+	target := uuid.Must(uuid.NewV4()).String()
+	base := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+	for i, l := range []*model.AuditLog{
+		{Action: model.AuditActionBotCreated, ActorID: optional.From(user.GetID()), Target: target},
+		{Action: model.AuditActionBotUpdated, ActorID: optional.From(user.GetID()), Target: target},
+		{Action: model.AuditActionBotUpdated, ActorID: optional.From(other.GetID()), Target: target},
+		{Action: model.AuditActionBotDeleted, ActorID: optional.From(other.GetID()), Target: target},
+	} {
+		l.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		if err := repo.CreateAuditLog(l); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("target", func(t *testing.T) {
+		t.Parallel()
+		logs, more, err := repo.GetAuditLogs(repository.AuditLogsQuery{Target: optional.From(target)})
+		if assert.NoError(t, err) {
+			assert.False(t, more)
+			if assert.Len(t, logs, 4) {
+				assert.Equal(t, model.AuditActionBotDeleted, logs[0].Action)
+			}
+		}
+	})
+
+	t.Run("actor", func(t *testing.T) {
+		t.Parallel()
+		logs, _, err := repo.GetAuditLogs(repository.AuditLogsQuery{ActorID: optional.From(other.GetID())})
+		if assert.NoError(t, err) {
+			assert.Len(t, logs, 2)
+		}
+	})
+
+	t.Run("action", func(t *testing.T) {
+		t.Parallel()
+		logs, _, err := repo.GetAuditLogs(repository.AuditLogsQuery{Target: optional.From(target), Action: optional.From(model.AuditActionBotUpdated)})
+		if assert.NoError(t, err) {
+			assert.Len(t, logs, 2)
+		}
+	})
+
+	t.Run("time range", func(t *testing.T) {
+		t.Parallel()
+		logs, _, err := repo.GetAuditLogs(repository.AuditLogsQuery{
+			Target:    optional.From(target),
+			Since:     optional.From(base.Add(time.Minute)),
+			Until:     optional.From(base.Add(2 * time.Minute)),
+			Inclusive: true,
+			Asc:       true,
+		})
+		if assert.NoError(t, err) && assert.Len(t, logs, 2) {
+			assert.Equal(t, model.AuditActionBotUpdated, logs[0].Action)
+			assert.Equal(t, user.GetID(), logs[0].ActorID.V)
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		t.Parallel()
+		logs, more, err := repo.GetAuditLogs(repository.AuditLogsQuery{Target: optional.From(target), Limit: 3})
+		if assert.NoError(t, err) {
+			assert.True(t, more)
+			assert.Len(t, logs, 3)
+		}
+	})
+}
@@ -36,9 +36,11 @@ func (repo *Repository) CreateBot(name, displayName, description string, iconFil
 		BotUserID:         uid,
 		Description:       description,
 		VerificationToken: random.SecureAlphaNumeric(30),
+		SigningSecret:     random.SecureAlphaNumeric(40),
 		PostURL:           webhookURL,
 		AccessTokenID:     tid,
 		SubscribeEvents:   model.BotEventTypes{},
+		EventFilters:      model.BotEventFilters{},
 		Privileged:        false,
 		Mode:              mode,
 		State:             state,
@@ -123,6 +125,9 @@ func (repo *Repository) UpdateBot(id uuid.UUID, args repository.UpdateBotArgs) e
 		if args.SubscribeEvents != nil {
 			changes["subscribe_events"] = args.SubscribeEvents
 		}
+		if args.EventFilters != nil {
+			changes["event_filters"] = args.EventFilters
+		}
 
 		if len(changes) > 0 {
 			if err := tx.Model(&b).Updates(changes).Error; err != nil {
@@ -291,6 +296,7 @@ func (repo *Repository) ReissueBotTokens(id uuid.UUID) (*model.Bot, error) {
 		}
 		bot.BotCode = random.AlphaNumeric(30)
 		bot.VerificationToken = random.SecureAlphaNumeric(30)
+		bot.SigningSecret = random.SecureAlphaNumeric(40)
 
 		if err := tx.Delete(&model.OAuth2Token{ID: bot.AccessTokenID}).Error; err != nil {
 			return err
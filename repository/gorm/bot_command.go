@@ -0,0 +1,185 @@
+package gorm
+
+import (
+	"fmt"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormutil"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+const (
+	maxBotCommands    = 100
+	maxBotCommandArgs = 10
+)
+
+// SetBotCommands implements BotCommandRepository interface.
+func (repo *Repository) SetBotCommands(botID uuid.UUID, commands []repository.SetBotCommandArgs) ([]*model.BotCommand, error) {
+	if botID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+	if err := validateBotCommands(commands); err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.BotCommand, len(commands))
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var current []*model.BotCommand
+		if err := tx.Where(&model.BotCommand{BotID: botID}).Find(&current).Error; err != nil {
+			return err
+		}
+		existing := make(map[string]*model.BotCommand, len(current))
+		for _, c := range current {
+			existing[c.Name] = c
+		}
+
+		// 実行記録を残すため、同名のコマンドはIDを維持して更新する
+		for i, c := range commands {
+			if cmd, ok := existing[c.Name]; ok {
+				delete(existing, c.Name)
+				cmd.Description = c.Description
+				cmd.Args = c.Args
+				if err := tx.Model(cmd).Updates(map[string]interface{}{
+					"description": cmd.Description,
+					"args":        cmd.Args,
+				}).Error; err != nil {
+					return err
+				}
+				result[i] = cmd
+				continue
+			}
+
+			cmd := &model.BotCommand{
+				ID:          uuid.Must(uuid.NewV4()),
+				BotID:       botID,
+				Name:        c.Name,
+				Description: c.Description,
+				Args:        c.Args,
+			}
+			if err := tx.Create(cmd).Error; err != nil {
+				if gormutil.IsMySQLDuplicatedRecordErr(err) {
+					return repository.ErrAlreadyExists
+				}
+				return err
+			}
+			result[i] = cmd
+		}
+
+		for _, cmd := range existing {
+			if err := tx.Delete(cmd).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetBotCommands implements BotCommandRepository interface.
+func (repo *Repository) GetBotCommands(botIDs []uuid.UUID) ([]*model.BotCommand, error) {
+	commands := make([]*model.BotCommand, 0)
+	if len(botIDs) == 0 {
+		return commands, nil
+	}
+	return commands, repo.db.Where("bot_id IN ?", botIDs).Order("name").Find(&commands).Error
+}
+
+// GetBotCommandsByName implements BotCommandRepository interface.
+func (repo *Repository) GetBotCommandsByName(name string, botIDs []uuid.UUID) ([]*model.BotCommand, error) {
+	commands := make([]*model.BotCommand, 0)
+	if len(name) == 0 || len(botIDs) == 0 {
+		return commands, nil
+	}
+	return commands, repo.db.Where("name = ? AND bot_id IN ?", name, botIDs).Find(&commands).Error
+}
+
+// CreateBotCommandInvocation implements BotCommandRepository interface.
+func (repo *Repository) CreateBotCommandInvocation(args repository.CreateBotCommandInvocationArgs) (*model.BotCommandInvocation, error) {
+	inv := &model.BotCommandInvocation{
+		ID:        uuid.Must(uuid.NewV4()),
+		CommandID: args.CommandID,
+		BotID:     args.BotID,
+		UserID:    args.UserID,
+		ChannelID: args.ChannelID,
+		Text:      args.Text,
+	}
+	if err := repo.db.Create(inv).Error; err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// GetBotCommandInvocation implements BotCommandRepository interface.
+func (repo *Repository) GetBotCommandInvocation(id uuid.UUID) (*model.BotCommandInvocation, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var inv model.BotCommandInvocation
+	if err := repo.db.Where(&model.BotCommandInvocation{ID: id}).Take(&inv).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &inv, nil
+}
+
+// validateBotCommands Botコマンドの定義として有効かどうかを検証します
+func validateBotCommands(commands []repository.SetBotCommandArgs) error {
+	if len(commands) > maxBotCommands {
+		return repository.ArgError("commands", fmt.Sprintf("the number of commands must be %d or less", maxBotCommands))
+	}
+	names := make(map[string]struct{}, len(commands))
+	for i, c := range commands {
+		field := fmt.Sprintf("commands[%d]", i)
+		if err := vd.Validate(c.Name, validator.BotCommandNameRuleRequired...); err != nil {
+			return repository.ArgError(field+".Name", err.Error())
+		}
+		if _, ok := names[c.Name]; ok {
+			return repository.ArgError(field+".Name", "duplicate command name: "+c.Name)
+		}
+		names[c.Name] = struct{}{}
+		if err := vd.Validate(c.Description, validator.BotCommandDescriptionRule...); err != nil {
+			return repository.ArgError(field+".Description", err.Error())
+		}
+		if err := validateBotCommandArgs(field+".Args", c.Args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateBotCommandArgs Botコマンドの引数定義として有効かどうかを検証します
+func validateBotCommandArgs(field string, args model.BotCommandArgs) error {
+	if len(args) > maxBotCommandArgs {
+		return repository.ArgError(field, fmt.Sprintf("the number of args must be %d or less", maxBotCommandArgs))
+	}
+	names := make(map[string]struct{}, len(args))
+	optional := false
+	for i, a := range args {
+		f := fmt.Sprintf("%s[%d]", field, i)
+		if err := vd.Validate(a.Name, validator.BotCommandArgNameRuleRequired...); err != nil {
+			return repository.ArgError(f+".Name", err.Error())
+		}
+		if _, ok := names[a.Name]; ok {
+			return repository.ArgError(f+".Name", "duplicate arg name: "+a.Name)
+		}
+		names[a.Name] = struct{}{}
+		if err := vd.Validate(a.Description, validator.BotCommandDescriptionRule...); err != nil {
+			return repository.ArgError(f+".Description", err.Error())
+		}
+		if !a.Type.Valid() {
+			return repository.ArgError(f+".Type", "unknown type: "+string(a.Type))
+		}
+		if a.Required && optional {
+			return repository.ArgError(f+".Required", "required args must precede optional args")
+		}
+		optional = optional || !a.Required
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/random"
+)
+
+func TestRepositoryImpl_SetBotCommands(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+		_, err := repo.SetBotCommands(uuid.Nil, nil)
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("invalid args", func(t *testing.T) {
+		t.Parallel()
+		b := mustMakeBot(t, repo, user.GetID())
+
+		cases := [][]repository.SetBotCommandArgs{
+			{{Name: "in valid"}},
+			{{Name: "dup"}, {Name: "dup"}},
+			{{Name: random.AlphaNumeric(20), Args: model.BotCommandArgs{{Name: "a", Type: "unknown"}}}},
+			{{Name: random.AlphaNumeric(20), Args: model.BotCommandArgs{{Name: "a", Type: model.BotCommandArgTypeString}, {Name: "a", Type: model.BotCommandArgTypeString}}}},
+			{{Name: random.AlphaNumeric(20), Args: model.BotCommandArgs{{Name: "a", Type: model.BotCommandArgTypeString}, {Name: "b", Type: model.BotCommandArgTypeString, Required: true}}}},
+		}
+		for _, c := range cases {
+			_, err := repo.SetBotCommands(b.ID, c)
+			assert.True(t, repository.IsArgError(err))
+		}
+	})
+
+	t.Run("same name in other bot", func(t *testing.T) {
+		t.Parallel()
+		b1 := mustMakeBot(t, repo, user.GetID())
+		b2 := mustMakeBot(t, repo, user.GetID())
+		name := random.AlphaNumeric(20)
+
+		_, err := repo.SetBotCommands(b1.ID, []repository.SetBotCommandArgs{{Name: name}})
+		require.NoError(t, err)
+		_, err = repo.SetBotCommands(b2.ID, []repository.SetBotCommandArgs{{Name: name}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		b := mustMakeBot(t, repo, user.GetID())
+		name1 := "a" + random.AlphaNumeric(20)
+		name2 := "b" + random.AlphaNumeric(20)
+
+		cmds, err := repo.SetBotCommands(b.ID, []repository.SetBotCommandArgs{
+			{Name: name1, Description: "first"},
+			{Name: name2, Args: model.BotCommandArgs{{Name: "n", Type: model.BotCommandArgTypeNumber, Required: true}}},
+		})
+		require.NoError(err)
+		require.Len(cmds, 2)
+		assert.Equal(name1, cmds[0].Name)
+		assert.Equal(b.ID, cmds[1].BotID)
+
+		// 同名のコマンドはIDを維持し、登録されなかったコマンドは削除される
+		updated, err := repo.SetBotCommands(b.ID, []repository.SetBotCommandArgs{
+			{Name: name2, Description: "updated"},
+		})
+		require.NoError(err)
+		require.Len(updated, 1)
+		assert.Equal(cmds[1].ID, updated[0].ID)
+
+		got, err := repo.GetBotCommands([]uuid.UUID{b.ID})
+		require.NoError(err)
+		if assert.Len(got, 1) {
+			assert.Equal(name2, got[0].Name)
+			assert.Equal("updated", got[0].Description)
+			assert.Empty(got[0].Args)
+		}
+	})
+}
+
+func TestRepositoryImpl_GetBotCommands(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common3)
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		cmds, err := repo.GetBotCommands(nil)
+		if assert.NoError(t, err) {
+			assert.Empty(t, cmds)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		b1 := mustMakeBot(t, repo, user.GetID())
+		b2 := mustMakeBot(t, repo, user.GetID())
+		b3 := mustMakeBot(t, repo, user.GetID())
+		_, err := repo.SetBotCommands(b1.ID, []repository.SetBotCommandArgs{{Name: "b" + random.AlphaNumeric(20)}})
+		require.NoError(err)
+		_, err = repo.SetBotCommands(b2.ID, []repository.SetBotCommandArgs{{Name: "a" + random.AlphaNumeric(20)}})
+		require.NoError(err)
+		_, err = repo.SetBotCommands(b3.ID, []repository.SetBotCommandArgs{{Name: random.AlphaNumeric(20)}})
+		require.NoError(err)
+
+		cmds, err := repo.GetBotCommands([]uuid.UUID{b1.ID, b2.ID})
+		require.NoError(err)
+		if assert.Len(cmds, 2) {
+			assert.Equal(b2.ID, cmds[0].BotID)
+			assert.Equal(b1.ID, cmds[1].BotID)
+		}
+	})
+}
+
+func TestRepositoryImpl_GetBotCommandsByName(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common3)
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		cmds, err := repo.GetBotCommandsByName("", []uuid.UUID{uuid.Must(uuid.NewV4())})
+		if assert.NoError(t, err) {
+			assert.Empty(t, cmds)
+		}
+		cmds, err = repo.GetBotCommandsByName(random.AlphaNumeric(20), nil)
+		if assert.NoError(t, err) {
+			assert.Empty(t, cmds)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		b1 := mustMakeBot(t, repo, user.GetID())
+		b2 := mustMakeBot(t, repo, user.GetID())
+		b3 := mustMakeBot(t, repo, user.GetID())
+		name := random.AlphaNumeric(20)
+		for _, b := range []*model.Bot{b1, b2, b3} {
+			_, err := repo.SetBotCommands(b.ID, []repository.SetBotCommandArgs{{Name: name}, {Name: random.AlphaNumeric(20)}})
+			require.NoError(err)
+		}
+
+		cmds, err := repo.GetBotCommandsByName(name, []uuid.UUID{b1.ID, b2.ID})
+		require.NoError(err)
+		if assert.Len(cmds, 2) {
+			assert.ElementsMatch([]uuid.UUID{b1.ID, b2.ID}, []uuid.UUID{cmds[0].BotID, cmds[1].BotID})
+			assert.Equal(name, cmds[0].Name)
+		}
+	})
+}
+
+func TestRepositoryImpl_BotCommandInvocation(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		_, err := repo.GetBotCommandInvocation(uuid.Nil)
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+		_, err = repo.GetBotCommandInvocation(uuid.Must(uuid.NewV4()))
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		b := mustMakeBot(t, repo, user.GetID())
+		cmds, err := repo.SetBotCommands(b.ID, []repository.SetBotCommandArgs{{Name: random.AlphaNumeric(20)}})
+		require.NoError(err)
+
+		inv, err := repo.CreateBotCommandInvocation(repository.CreateBotCommandInvocationArgs{
+			CommandID: cmds[0].ID,
+			BotID:     b.ID,
+			UserID:    user.GetID(),
+			ChannelID: channel.ID,
+			Text:      "/" + cmds[0].Name + " po",
+		})
+		require.NoError(err)
+
+		got, err := repo.GetBotCommandInvocation(inv.ID)
+		if assert.NoError(err) {
+			assert.Equal(cmds[0].ID, got.CommandID)
+			assert.Equal(b.ID, got.BotID)
+			assert.Equal(user.GetID(), got.UserID)
+			assert.Equal(channel.ID, got.ChannelID)
+			assert.Equal(inv.Text, got.Text)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormutil"
+)
+
+// SaveBotEventDelivery implements BotEventDeliveryRepository interface.
+func (repo *Repository) SaveBotEventDelivery(d *model.BotEventDelivery) error {
+	if d == nil || d.ID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.Save(d).Error
+}
+
+// DeleteBotEventDelivery implements BotEventDeliveryRepository interface.
+func (repo *Repository) DeleteBotEventDelivery(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return nil
+	}
+	return repo.db.Delete(&model.BotEventDelivery{ID: id}).Error
+}
+
+// GetRetryableBotEventDeliveries implements BotEventDeliveryRepository interface.
+func (repo *Repository) GetRetryableBotEventDeliveries(now time.Time, limit int) ([]*model.BotEventDelivery, error) {
+	deliveries := make([]*model.BotEventDelivery, 0)
+	return deliveries, repo.db.
+		Joins("INNER JOIN bots ON bots.id = bot_event_deliveries.bot_id AND bots.deleted_at IS NULL AND bots.state = ? AND bots.mode = ?", model.BotActive, model.BotModeHTTP).
+		Where("(bot_event_deliveries.status = ? AND bot_event_deliveries.next_attempt_at <= ?) OR (bot_event_deliveries.status = ? AND bot_event_deliveries.lease_until <= ?)", model.BotEventDeliveryPending, now, model.BotEventDeliverySending, now).
+		Order("bot_event_deliveries.next_attempt_at").
+		Scopes(gormutil.LimitAndOffset(limit, 0)).
+		Find(&deliveries).
+		Error
+}
+
+// ClaimBotEventDelivery implements BotEventDeliveryRepository interface.
+func (repo *Repository) ClaimBotEventDelivery(id uuid.UUID, leaseUntil time.Time) (bool, error) {
+	if id == uuid.Nil {
+		return false, repository.ErrNilID
+	}
+	result := repo.db.
+		Model(&model.BotEventDelivery{ID: id}).
+		Where("status = ? OR (status = ? AND lease_until <= ?)", model.BotEventDeliveryPending, model.BotEventDeliverySending, time.Now()).
+		Updates(map[string]interface{}{
+			"status":      model.BotEventDeliverySending,
+			"lease_until": leaseUntil,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountBotEventDeliveries implements BotEventDeliveryRepository interface.
+func (repo *Repository) CountBotEventDeliveries(botID uuid.UUID, status model.BotEventDeliveryStatus) (int, error) {
+	if botID == uuid.Nil {
+		return 0, nil
+	}
+	var count int64
+	if err := repo.db.
+		Model(&model.BotEventDelivery{}).
+		Where(&model.BotEventDelivery{BotID: botID, Status: status}).
+		Count(&count).
+		Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// GetBotEventDeliveries implements BotEventDeliveryRepository interface.
+func (repo *Repository) GetBotEventDeliveries(botID uuid.UUID, status model.BotEventDeliveryStatus, limit, offset int) ([]*model.BotEventDelivery, error) {
+	deliveries := make([]*model.BotEventDelivery, 0)
+	if botID == uuid.Nil {
+		return deliveries, nil
+	}
+	return deliveries, repo.db.
+		Where(&model.BotEventDelivery{BotID: botID, Status: status}).
+		Order("created_at").
+		Scopes(gormutil.LimitAndOffset(limit, offset)).
+		Find(&deliveries).
+		Error
+}
+
+// RequeueBotEventDeliveries implements BotEventDeliveryRepository interface.
+func (repo *Repository) RequeueBotEventDeliveries(botID uuid.UUID, ids []uuid.UUID) (int, error) {
+	if botID == uuid.Nil {
+		return 0, repository.ErrNilID
+	}
+	tx := repo.db.Model(&model.BotEventDelivery{}).Where(&model.BotEventDelivery{BotID: botID, Status: model.BotEventDeliveryDead})
+	if len(ids) > 0 {
+		tx = tx.Where("id IN ?", ids)
+	}
+	result := tx.Updates(map[string]interface{}{
+		"status":          model.BotEventDeliveryPending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+	})
+	return int(result.RowsAffected), result.Error
+}
+
+// PurgeBotEventDeliveries implements BotEventDeliveryRepository interface.
+func (repo *Repository) PurgeBotEventDeliveries(before time.Time) error {
+	return repo.db.Delete(&model.BotEventDelivery{}, "created_at < ?", before).Error
+}
@@ -0,0 +1,268 @@
+package gorm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+func TestRepositoryImpl_SaveBotEventDelivery(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+		assert.EqualError(t, repo.SaveBotEventDelivery(&model.BotEventDelivery{}), repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		b := mustMakeBot(t, repo, user.GetID())
+		d := mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryPending, time.Now())
+
+		d.Attempts = 2
+		d.LastError = "error"
+		require.NoError(repo.SaveBotEventDelivery(d))
+
+		ds, err := repo.GetBotEventDeliveries(b.ID, model.BotEventDeliveryPending, 0, 0)
+		require.NoError(err)
+		if assert.Len(ds, 1) {
+			assert.Equal(d.ID, ds[0].ID)
+			assert.Equal(2, ds[0].Attempts)
+			assert.Equal("error", ds[0].LastError)
+		}
+	})
+}
+
+func TestRepositoryImpl_DeleteBotEventDelivery(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, repo.DeleteBotEventDelivery(uuid.Nil))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		b := mustMakeBot(t, repo, user.GetID())
+		d := mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryPending, time.Now())
+
+		require.NoError(repo.DeleteBotEventDelivery(d.ID))
+		ds, err := repo.GetBotEventDeliveries(b.ID, model.BotEventDeliveryPending, 0, 0)
+		require.NoError(err)
+		assert.Len(ds, 0)
+	})
+}
+
+func TestRepositoryImpl_GetRetryableBotEventDeliveries(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common3)
+
+	b := mustMakeBot(t, repo, user.GetID())
+	paused := mustMakeBot(t, repo, user.GetID())
+	require.NoError(repo.ChangeBotState(paused.ID, model.BotPaused))
+
+	now := time.Now()
+	d1 := mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryPending, now.Add(-time.Minute))
+	d2 := mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryPending, now.Add(-2*time.Minute))
+	mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryPending, now.Add(time.Minute))
+	mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryDead, now.Add(-time.Minute))
+	mustMakeBotEventDelivery(t, repo, paused.ID, model.BotEventDeliveryPending, now.Add(-time.Minute))
+	// 処理権の期限が切れたものは再送対象
+	expired := mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryPending, now.Add(-3*time.Minute))
+	ok, err := repo.ClaimBotEventDelivery(expired.ID, now.Add(-time.Second))
+	require.NoError(err)
+	require.True(ok)
+	leased := mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryPending, now.Add(-3*time.Minute))
+	ok, err = repo.ClaimBotEventDelivery(leased.ID, now.Add(time.Minute))
+	require.NoError(err)
+	require.True(ok)
+
+	ds, err := repo.GetRetryableBotEventDeliveries(now, 1000)
+	require.NoError(err)
+	ids := make([]uuid.UUID, 0)
+	for _, d := range ds {
+		if d.BotID == paused.ID {
+			assert.Fail("paused bot's delivery was returned")
+		}
+		if d.BotID == b.ID {
+			ids = append(ids, d.ID)
+		}
+	}
+	assert.Equal([]uuid.UUID{expired.ID, d2.ID, d1.ID}, ids)
+}
+
+func TestRepositoryImpl_ClaimBotEventDelivery(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+		_, err := repo.ClaimBotEventDelivery(uuid.Nil, time.Now())
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		b := mustMakeBot(t, repo, user.GetID())
+		d := mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryPending, time.Now())
+
+		ok, err := repo.ClaimBotEventDelivery(d.ID, time.Now().Add(time.Minute))
+		require.NoError(err)
+		assert.True(ok)
+		// 処理権の期限内は他のインスタンスが処理権を得られない
+		ok, err = repo.ClaimBotEventDelivery(d.ID, time.Now().Add(time.Minute))
+		require.NoError(err)
+		assert.False(ok)
+		assert.EqualValues(1, count(t, getDB(repo).Model(&model.BotEventDelivery{}).Where(&model.BotEventDelivery{ID: d.ID, Status: model.BotEventDeliverySending})))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		b := mustMakeBot(t, repo, user.GetID())
+		d := mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryPending, time.Now())
+
+		ok, err := repo.ClaimBotEventDelivery(d.ID, time.Now().Add(-time.Second))
+		require.NoError(err)
+		require.True(ok)
+		ok, err = repo.ClaimBotEventDelivery(d.ID, time.Now().Add(time.Minute))
+		require.NoError(err)
+		assert.True(ok)
+	})
+
+	t.Run("dead", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		b := mustMakeBot(t, repo, user.GetID())
+		d := mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryDead, time.Now())
+
+		ok, err := repo.ClaimBotEventDelivery(d.ID, time.Now().Add(time.Minute))
+		require.NoError(err)
+		assert.False(ok)
+	})
+}
+
+func TestRepositoryImpl_CountBotEventDeliveries(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common3)
+
+	b := mustMakeBot(t, repo, user.GetID())
+	mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryPending, time.Now())
+	mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryPending, time.Now())
+	mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryDead, time.Now())
+
+	n, err := repo.CountBotEventDeliveries(b.ID, model.BotEventDeliveryPending)
+	require.NoError(err)
+	assert.Equal(2, n)
+
+	n, err = repo.CountBotEventDeliveries(uuid.Nil, model.BotEventDeliveryPending)
+	require.NoError(err)
+	assert.Equal(0, n)
+}
+
+func TestRepositoryImpl_GetBotEventDeliveries(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common3)
+
+	b := mustMakeBot(t, repo, user.GetID())
+	d1 := mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryDead, time.Now())
+	d2 := mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryDead, time.Now())
+	mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryPending, time.Now())
+
+	ds, err := repo.GetBotEventDeliveries(uuid.Nil, model.BotEventDeliveryDead, 0, 0)
+	require.NoError(err)
+	assert.Len(ds, 0)
+
+	ds, err = repo.GetBotEventDeliveries(b.ID, model.BotEventDeliveryDead, 0, 0)
+	require.NoError(err)
+	if assert.Len(ds, 2) {
+		assert.Equal(d1.ID, ds[0].ID)
+		assert.Equal(d2.ID, ds[1].ID)
+	}
+
+	ds, err = repo.GetBotEventDeliveries(b.ID, model.BotEventDeliveryDead, 1, 1)
+	require.NoError(err)
+	if assert.Len(ds, 1) {
+		assert.Equal(d2.ID, ds[0].ID)
+	}
+}
+
+func TestRepositoryImpl_RequeueBotEventDeliveries(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+		_, err := repo.RequeueBotEventDeliveries(uuid.Nil, nil)
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("specified ids", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		b := mustMakeBot(t, repo, user.GetID())
+		d1 := mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryDead, time.Now())
+		mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryDead, time.Now())
+
+		n, err := repo.RequeueBotEventDeliveries(b.ID, []uuid.UUID{d1.ID})
+		require.NoError(err)
+		assert.Equal(1, n)
+
+		ds, err := repo.GetBotEventDeliveries(b.ID, model.BotEventDeliveryPending, 0, 0)
+		require.NoError(err)
+		if assert.Len(ds, 1) {
+			assert.Equal(d1.ID, ds[0].ID)
+			assert.Equal(0, ds[0].Attempts)
+		}
+	})
+
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		b := mustMakeBot(t, repo, user.GetID())
+		mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryDead, time.Now())
+		mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryDead, time.Now())
+
+		n, err := repo.RequeueBotEventDeliveries(b.ID, nil)
+		require.NoError(err)
+		assert.Equal(2, n)
+
+		ds, err := repo.GetBotEventDeliveries(b.ID, model.BotEventDeliveryDead, 0, 0)
+		require.NoError(err)
+		assert.Len(ds, 0)
+	})
+}
+
+func TestRepositoryImpl_PurgeBotEventDeliveries(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user := setupWithUser(t, common3)
+
+	b := mustMakeBot(t, repo, user.GetID())
+	old := &model.BotEventDelivery{
+		ID:        uuid.Must(uuid.NewV4()),
+		BotID:     b.ID,
+		Event:     "PING",
+		Status:    model.BotEventDeliveryDead,
+		CreatedAt: time.Now().AddDate(-20, 0, 0),
+	}
+	require.NoError(repo.SaveBotEventDelivery(old))
+	d := mustMakeBotEventDelivery(t, repo, b.ID, model.BotEventDeliveryDead, time.Now())
+
+	require.NoError(repo.PurgeBotEventDeliveries(time.Now().AddDate(-10, 0, 0)))
+
+	ds, err := repo.GetBotEventDeliveries(b.ID, model.BotEventDeliveryDead, 0, 0)
+	require.NoError(err)
+	if assert.Len(ds, 1) {
+		assert.Equal(d.ID, ds[0].ID)
+	}
+}
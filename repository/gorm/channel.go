@@ -7,6 +7,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/leandro-lugaresi/hub"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/model"
@@ -186,6 +187,120 @@ func (repo *Repository) ArchiveChannels(ids []uuid.UUID) ([]*model.Channel, erro
 	return changed, nil
 }
 
+// DeleteChannels implements ChannelRepository interface.
+func (repo *Repository) DeleteChannels(ids []uuid.UUID) ([]*model.FileMeta, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	for _, id := range ids {
+		if id == uuid.Nil {
+			return nil, repository.ErrNilID
+		}
+	}
+
+	var (
+		chs      []*model.Channel
+		unreads  []*model.Unread
+		webhooks []*model.WebhookBot
+		files    []*model.FileMeta
+	)
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id IN ?", ids).Find(&chs).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("channel_id IN ?", ids).Find(&unreads).Error; err != nil {
+			return err
+		}
+
+		// チャンネルへのWebhookを削除
+		if err := tx.Where("channel_id IN ?", ids).Find(&webhooks).Error; err != nil {
+			return err
+		}
+		for _, w := range webhooks {
+			if err := tx.Model(&model.User{}).Where(&model.User{ID: w.BotUserID}).Update("status", model.UserAccountStatusDeactivated).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Unscoped().Where("channel_id IN ?", ids).Delete(&model.WebhookBot{}).Error; err != nil {
+			return err
+		}
+
+		// ホームチャンネルの設定を解除 (外部キー制約によってプロフィールが削除されるのを防ぐ)
+		if err := tx.Model(&model.UserProfile{}).Where("home_channel IN ?", ids).Update("home_channel", nil).Error; err != nil {
+			return err
+		}
+
+		// 外部キー制約の無いテーブルのレコードを削除
+		if err := tx.
+			Where("message_id IN (?)", tx.Unscoped().Model(&model.Message{}).Select("id").Where("channel_id IN ?", ids)).
+			Delete(&model.MessageRevision{}).
+			Error; err != nil {
+			return err
+		}
+		if err := tx.Where("channel_id IN ?", ids).Delete(&model.ChannelLatestMessage{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("channel_id IN ?", ids).Delete(&model.BotJoinChannel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("channel_id IN ?", ids).Delete(&model.ScheduledMessage{}).Error; err != nil {
+			return err
+		}
+
+		// チャンネルにアップロードされたファイルのメタデータを削除 (ストレージ上の実体は呼び出し側で削除する)
+		if err := tx.Scopes(filePreloads).Where("channel_id IN ?", ids).Find(&files).Error; err != nil {
+			return err
+		}
+		if len(files) > 0 {
+			fileIDs := make([]uuid.UUID, len(files))
+			for i, f := range files {
+				fileIDs[i] = f.ID
+			}
+			if err := tx.Where("id IN ?", fileIDs).Delete(&model.FileMeta{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("file_id IN ?", fileIDs).Delete(&model.FileThumbnail{}).Error; err != nil {
+				return err
+			}
+		}
+
+		// メッセージ・チャンネルを削除 (スタンプ・ピン・未読・購読などは外部キー制約により削除される)
+		if err := tx.Unscoped().Where("channel_id IN ?", ids).Delete(&model.Message{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id IN ?", ids).Delete(&model.Channel{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range webhooks {
+		repo.hub.Publish(hub.Message{
+			Name: event.WebhookDeleted,
+			Fields: hub.Fields{
+				"webhook_id": w.ID,
+			},
+		})
+	}
+	for _, ch := range chs {
+		deletedUnreads := make([]*model.Unread, 0)
+		for _, u := range unreads {
+			if u.ChannelID == ch.ID {
+				deletedUnreads = append(deletedUnreads, u)
+			}
+		}
+		repo.hub.Publish(hub.Message{
+			Name: event.ChannelDeleted,
+			Fields: hub.Fields{
+				"channel_id":      ch.ID,
+				"private":         !ch.IsPublic,
+				"deleted_unreads": deletedUnreads,
+			},
+		})
+	}
+	return files, nil
+}
+
 // GetChannel implements ChannelRepository interface.
 func (repo *Repository) GetChannel(channelID uuid.UUID) (*model.Channel, error) {
 	if channelID == uuid.Nil {
@@ -253,6 +368,89 @@ func (repo *Repository) GetPrivateChannelMemberIDs(channelID uuid.UUID) (users [
 		Error
 }
 
+// ChangePrivateChannelMembers implements ChannelRepository interface.
+func (repo *Repository) ChangePrivateChannelMembers(channelID uuid.UUID, add, remove []uuid.UUID) (added []uuid.UUID, removed []uuid.UUID, err error) {
+	if channelID == uuid.Nil {
+		return nil, nil, repository.ErrNilID
+	}
+
+	added = make([]uuid.UUID, 0)
+	removed = make([]uuid.UUID, 0)
+
+	err = repo.db.Transaction(func(tx *gorm.DB) error {
+		// 同時に変更されてメンバーがいなくなることを防ぐため、チャンネルとメンバーをロックする
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&model.Channel{}, &model.Channel{ID: channelID}).Error; err != nil {
+			return convertError(err)
+		}
+		var _current []uuid.UUID
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Model(&model.UsersPrivateChannel{}).
+			Where(&model.UsersPrivateChannel{ChannelID: channelID}).
+			Pluck("user_id", &_current).
+			Error; err != nil {
+			return err
+		}
+		current := set.UUIDSetFromArray(_current)
+
+		for _, uid := range add {
+			if current.Contains(uid) {
+				continue // 既にメンバー
+			}
+			if err := tx.Create(&model.UsersPrivateChannel{UserID: uid, ChannelID: channelID}).Error; err != nil {
+				if gormutil.IsMySQLForeignKeyConstraintFailsError(err) {
+					continue // 存在しないユーザーは無視
+				}
+				return err
+			}
+			current.Add(uid)
+			added = append(added, uid)
+		}
+		for _, uid := range remove {
+			if !current.Contains(uid) {
+				continue // メンバーでない
+			}
+			if err := tx.Delete(&model.UsersPrivateChannel{}, &model.UsersPrivateChannel{UserID: uid, ChannelID: channelID}).Error; err != nil {
+				return err
+			}
+			current.Remove(uid)
+			removed = append(removed, uid)
+		}
+		if len(current) == 0 {
+			return repository.ErrForbidden // 全員を削除することはできない
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(added) > 0 || len(removed) > 0 {
+		repo.hub.Publish(hub.Message{
+			Name: event.ChannelMembersChanged,
+			Fields: hub.Fields{
+				"channel_id":  channelID,
+				"added_ids":   added,
+				"removed_ids": removed,
+			},
+		})
+	}
+	return added, removed, nil
+}
+
+// GetPrivateChannelsByUser implements ChannelRepository interface.
+func (repo *Repository) GetPrivateChannelsByUser(userID uuid.UUID) (channels []*model.Channel, err error) {
+	channels = make([]*model.Channel, 0)
+	if userID == uuid.Nil {
+		return channels, nil
+	}
+	return channels, repo.db.
+		Joins("INNER JOIN users_private_channels ON users_private_channels.channel_id = channels.id").
+		Where("users_private_channels.user_id = ? AND channels.parent_id = ?", userID, model.PrivateChannelRootID).
+		Find(&channels).
+		Error
+}
+
 // ChangeChannelSubscription implements ChannelRepository interface.
 func (repo *Repository) ChangeChannelSubscription(channelID uuid.UUID, args repository.ChangeChannelSubscriptionArgs) (on []uuid.UUID, off []uuid.UUID, err error) {
 	if channelID == uuid.Nil {
@@ -0,0 +1,93 @@
+package gorm
+
+import (
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+// GetChannelRoles implements ChannelRoleRepository interface.
+func (repo *Repository) GetChannelRoles(channelID uuid.UUID) ([]*model.ChannelRole, error) {
+	if channelID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+	roles := make([]*model.ChannelRole, 0)
+	return roles, repo.db.Where(&model.ChannelRole{ChannelID: channelID}).Find(&roles).Error
+}
+
+// GetAllChannelRoles implements ChannelRoleRepository interface.
+func (repo *Repository) GetAllChannelRoles() ([]*model.ChannelRole, error) {
+	roles := make([]*model.ChannelRole, 0)
+	return roles, repo.db.Find(&roles).Error
+}
+
+// SetChannelRoles implements ChannelRoleRepository interface.
+func (repo *Repository) SetChannelRoles(channelID uuid.UUID, roles []*model.ChannelRole) error {
+	if channelID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(&model.ChannelRole{ChannelID: channelID}).Delete(&model.ChannelRole{}).Error; err != nil {
+			return err
+		}
+
+		records := make([]*model.ChannelRole, 0, len(roles))
+		added := map[uuid.UUID]struct{}{}
+		for _, r := range roles {
+			if _, ok := added[r.UserID]; ok {
+				continue
+			}
+			added[r.UserID] = struct{}{}
+			records = append(records, &model.ChannelRole{ChannelID: channelID, UserID: r.UserID, Role: r.Role})
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		return tx.Create(records).Error
+	})
+}
+
+// GetChannelPermissionOverrides implements ChannelRoleRepository interface.
+func (repo *Repository) GetChannelPermissionOverrides(channelID uuid.UUID) ([]*model.ChannelPermissionOverride, error) {
+	if channelID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+	overrides := make([]*model.ChannelPermissionOverride, 0)
+	return overrides, repo.db.Where(&model.ChannelPermissionOverride{ChannelID: channelID}).Find(&overrides).Error
+}
+
+// GetAllChannelPermissionOverrides implements ChannelRoleRepository interface.
+func (repo *Repository) GetAllChannelPermissionOverrides() ([]*model.ChannelPermissionOverride, error) {
+	overrides := make([]*model.ChannelPermissionOverride, 0)
+	return overrides, repo.db.Find(&overrides).Error
+}
+
+// SetChannelPermissionOverrides implements ChannelRoleRepository interface.
+func (repo *Repository) SetChannelPermissionOverrides(channelID uuid.UUID, overrides []*model.ChannelPermissionOverride) error {
+	if channelID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(&model.ChannelPermissionOverride{ChannelID: channelID}).Delete(&model.ChannelPermissionOverride{}).Error; err != nil {
+			return err
+		}
+
+		type key struct{ role, perm string }
+		records := make([]*model.ChannelPermissionOverride, 0, len(overrides))
+		added := map[key]struct{}{}
+		for _, o := range overrides {
+			k := key{o.Role, o.Permission}
+			if _, ok := added[k]; ok {
+				continue
+			}
+			added[k] = struct{}{}
+			records = append(records, &model.ChannelPermissionOverride{ChannelID: channelID, Role: o.Role, Permission: o.Permission, Allow: o.Allow})
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		return tx.Create(records).Error
+	})
+}
@@ -0,0 +1,138 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/random"
+)
+
+func mustMakeChannelRoleTestRole(t *testing.T, repo repository.Repository) string {
+	t.Helper()
+	name := "cr_" + random.AlphaNumeric(16)
+	if err := repo.CreateUserRoles(&model.UserRole{Name: name}); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestRepositoryImpl_SetChannelRoles(t *testing.T) {
+	t.Parallel()
+	repo, _, _ := setup(t, common)
+
+	ch := mustMakeChannel(t, repo, rand)
+	user1 := mustMakeUser(t, repo, rand)
+	user2 := mustMakeUser(t, repo, rand)
+	role1 := mustMakeChannelRoleTestRole(t, repo)
+	role2 := mustMakeChannelRoleTestRole(t, repo)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.SetChannelRoles(uuid.Nil, nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		require.NoError(repo.SetChannelRoles(ch.ID, []*model.ChannelRole{
+			{UserID: user1.GetID(), Role: role1},
+			{UserID: user2.GetID(), Role: role1},
+		}))
+		roles, err := repo.GetChannelRoles(ch.ID)
+		require.NoError(err)
+		assert.Len(roles, 2)
+
+		// 置き換え
+		require.NoError(repo.SetChannelRoles(ch.ID, []*model.ChannelRole{
+			{UserID: user1.GetID(), Role: role2},
+		}))
+		roles, err = repo.GetChannelRoles(ch.ID)
+		require.NoError(err)
+		if assert.Len(roles, 1) {
+			assert.Equal(ch.ID, roles[0].ChannelID)
+			assert.Equal(user1.GetID(), roles[0].UserID)
+			assert.Equal(role2, roles[0].Role)
+		}
+
+		all, err := repo.GetAllChannelRoles()
+		require.NoError(err)
+		assert.NotEmpty(all)
+
+		// 全削除
+		require.NoError(repo.SetChannelRoles(ch.ID, nil))
+		roles, err = repo.GetChannelRoles(ch.ID)
+		require.NoError(err)
+		assert.Len(roles, 0)
+	})
+}
+
+func TestRepositoryImpl_GetChannelRoles(t *testing.T) {
+	t.Parallel()
+	repo, _, _ := setup(t, common)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.GetChannelRoles(uuid.Nil)
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		roles, err := repo.GetChannelRoles(uuid.Must(uuid.NewV4()))
+		if assert.NoError(t, err) {
+			assert.Len(t, roles, 0)
+		}
+	})
+}
+
+func TestRepositoryImpl_SetChannelPermissionOverrides(t *testing.T) {
+	t.Parallel()
+	repo, _, _ := setup(t, common)
+
+	ch := mustMakeChannel(t, repo, rand)
+	role := mustMakeChannelRoleTestRole(t, repo)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.SetChannelPermissionOverrides(uuid.Nil, nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		require.NoError(repo.SetChannelPermissionOverrides(ch.ID, []*model.ChannelPermissionOverride{
+			{Role: role, Permission: "post_message", Allow: false},
+			{Role: role, Permission: "create_message_pin", Allow: true},
+		}))
+		overrides, err := repo.GetChannelPermissionOverrides(ch.ID)
+		require.NoError(err)
+		assert.Len(overrides, 2)
+
+		// 置き換え
+		require.NoError(repo.SetChannelPermissionOverrides(ch.ID, []*model.ChannelPermissionOverride{
+			{Role: role, Permission: "post_message", Allow: true},
+		}))
+		overrides, err = repo.GetChannelPermissionOverrides(ch.ID)
+		require.NoError(err)
+		if assert.Len(overrides, 1) {
+			assert.Equal(ch.ID, overrides[0].ChannelID)
+			assert.Equal(role, overrides[0].Role)
+			assert.Equal("post_message", overrides[0].Permission)
+			assert.True(overrides[0].Allow)
+		}
+
+		all, err := repo.GetAllChannelPermissionOverrides()
+		require.NoError(err)
+		assert.NotEmpty(all)
+	})
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/random"
+	"github.com/traPtitech/traQ/utils/set"
 )
 
 func TestGormRepository_UpdateChannel(t *testing.T) {
@@ -130,6 +132,123 @@ func TestGormRepository_ChangeChannelSubscription(t *testing.T) {
 	})
 }
 
+func TestGormRepository_ChangePrivateChannelMembers(t *testing.T) {
+	t.Parallel()
+	repo, _, _ := setup(t, common)
+
+	t.Run("Nil ID", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+
+		_, _, err := repo.ChangePrivateChannelMembers(uuid.Nil, nil, nil)
+		assert.EqualError(err, repository.ErrNilID.Error())
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		user1 := mustMakeUser(t, repo, rand)
+		user2 := mustMakeUser(t, repo, rand)
+		user3 := mustMakeUser(t, repo, rand)
+		ch, err := repo.CreateChannel(model.Channel{
+			Name:      random.AlphaNumeric(20),
+			ParentID:  uuid.Must(uuid.FromString(model.PrivateChannelRootID)),
+			IsVisible: true,
+		}, set.UUIDSetFromArray([]uuid.UUID{user1.GetID()}), false)
+		require.NoError(err)
+		assert.False(ch.IsPublic)
+
+		added, removed, err := repo.ChangePrivateChannelMembers(ch.ID, []uuid.UUID{user1.GetID(), user2.GetID(), uuid.Must(uuid.NewV4())}, nil)
+		if assert.NoError(err) {
+			assert.ElementsMatch([]uuid.UUID{user2.GetID()}, added)
+			assert.Empty(removed)
+		}
+
+		added, removed, err = repo.ChangePrivateChannelMembers(ch.ID, []uuid.UUID{user3.GetID()}, []uuid.UUID{user1.GetID(), uuid.Must(uuid.NewV4())})
+		if assert.NoError(err) {
+			assert.ElementsMatch([]uuid.UUID{user3.GetID()}, added)
+			assert.ElementsMatch([]uuid.UUID{user1.GetID()}, removed)
+		}
+
+		members, err := repo.GetPrivateChannelMemberIDs(ch.ID)
+		if assert.NoError(err) {
+			assert.ElementsMatch([]uuid.UUID{user2.GetID(), user3.GetID()}, members)
+		}
+
+		chs, err := repo.GetPrivateChannelsByUser(user2.GetID())
+		if assert.NoError(err) && assert.Len(chs, 1) {
+			assert.Equal(ch.ID, chs[0].ID)
+		}
+		chs, err = repo.GetPrivateChannelsByUser(user1.GetID())
+		if assert.NoError(err) {
+			assert.Len(chs, 0)
+		}
+
+		// 全員を削除することはできない
+		_, _, err = repo.ChangePrivateChannelMembers(ch.ID, nil, []uuid.UUID{user2.GetID(), user3.GetID()})
+		assert.EqualError(err, repository.ErrForbidden.Error())
+		members, err = repo.GetPrivateChannelMemberIDs(ch.ID)
+		if assert.NoError(err) {
+			assert.ElementsMatch([]uuid.UUID{user2.GetID(), user3.GetID()}, members)
+		}
+	})
+}
+
+func TestGormRepository_DeleteChannels(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+		_, err := repo.DeleteChannels([]uuid.UUID{uuid.Nil})
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		parent := mustMakeChannel(t, repo, rand)
+		child, err := repo.CreateChannel(model.Channel{Name: random.AlphaNumeric(20), ParentID: parent.ID, IsVisible: true}, nil, false)
+		require.NoError(err)
+		other := mustMakeChannel(t, repo, rand)
+		m := mustMakeMessage(t, repo, user.GetID(), child.ID)
+		mustMakeMessageUnread(t, repo, user.GetID(), m.ID)
+		w := mustMakeWebhook(t, repo, rand, parent.ID, user.GetID(), "")
+		mustMakeMessage(t, repo, user.GetID(), other.ID)
+		f := &model.FileMeta{
+			ID:        uuid.Must(uuid.NewV4()),
+			Name:      "dummy",
+			Mime:      "application/octet-stream",
+			Size:      10,
+			Hash:      "d41d8cd98f00b204e9800998ecf8427e",
+			Type:      model.FileTypeUserFile,
+			ChannelID: optional.From(child.ID),
+		}
+		require.NoError(repo.SaveFileMeta(f, []*model.FileACLEntry{{UserID: uuid.Nil, Allow: true}}))
+
+		files, err := repo.DeleteChannels([]uuid.UUID{parent.ID, child.ID})
+		if assert.NoError(err) {
+			if assert.Len(files, 1) {
+				assert.Equal(f.ID, files[0].ID)
+			}
+			_, err = repo.GetFileMeta(f.ID)
+			assert.EqualError(err, repository.ErrNotFound.Error())
+			_, err = repo.GetChannel(parent.ID)
+			assert.EqualError(err, repository.ErrNotFound.Error())
+			_, err = repo.GetChannel(child.ID)
+			assert.EqualError(err, repository.ErrNotFound.Error())
+			_, err = repo.GetMessageByID(m.ID)
+			assert.EqualError(err, repository.ErrNotFound.Error())
+			_, err = repo.GetWebhook(w.GetID())
+			assert.EqualError(err, repository.ErrNotFound.Error())
+			assert.Equal(0, count(t, getDB(repo).Model(model.Unread{}).Where(&model.Unread{ChannelID: child.ID})))
+			assert.Equal(0, count(t, getDB(repo).Model(model.ChannelLatestMessage{}).Where(&model.ChannelLatestMessage{ChannelID: child.ID})))
+			assert.Equal(1, count(t, getDB(repo).Model(model.ChannelLatestMessage{}).Where(&model.ChannelLatestMessage{ChannelID: other.ID})))
+		}
+	})
+}
+
 func TestGormRepository_GetChannelStats(t *testing.T) {
 	t.Parallel()
 	repo, _, _ := setup(t, common)
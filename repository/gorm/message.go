@@ -13,6 +13,7 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/utils/message"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // CreateMessage implements MessageRepository interface.
@@ -29,26 +30,78 @@ func (repo *Repository) CreateMessage(userID, channelID uuid.UUID, text string)
 		Stamps:    []model.MessageStamp{},
 	}
 	err := repo.db.Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(m).Error; err != nil {
-			return err
-		}
+		return createMessage(tx, m)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		clm := &model.ChannelLatestMessage{
-			ChannelID: m.ChannelID,
-			MessageID: m.ID,
-			DateTime:  m.CreatedAt,
+	repo.publishMessageCreated(m)
+	return m, nil
+}
+
+// CreateReplyMessage implements MessageRepository interface.
+func (repo *Repository) CreateReplyMessage(userID, parentID uuid.UUID, text string) (*model.Message, error) {
+	if userID == uuid.Nil || parentID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	m := &model.Message{
+		ID:     uuid.Must(uuid.NewV4()),
+		UserID: userID,
+		Text:   text,
+		Stamps: []model.MessageStamp{},
+	}
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		var parent model.Message
+		if err := tx.Where(&model.Message{ID: parentID}).First(&parent).Error; err != nil {
+			return convertError(err)
 		}
 
-		return tx.
-			Clauses(clause.OnConflict{UpdateAll: true}).
-			Create(clm).
-			Error
+		// スレッドは1階層のみ。返信への返信は大本の親メッセージのスレッドに入れる
+		m.ChannelID = parent.ChannelID
+		if parent.ParentMessageID.Valid {
+			m.ParentMessageID = parent.ParentMessageID
+		} else {
+			m.ParentMessageID = optional.From(parent.ID)
+		}
+		return createMessage(tx, m)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	parseResult := message.Parse(text)
+	repo.publishMessageCreated(m)
+	repo.hub.Publish(hub.Message{
+		Name: event.MessageReplied,
+		Fields: hub.Fields{
+			"message_id":        m.ID,
+			"message":           m,
+			"parent_message_id": m.ParentMessageID.V,
+		},
+	})
+	return m, nil
+}
+
+func createMessage(tx *gorm.DB, m *model.Message) error {
+	if err := tx.Create(m).Error; err != nil {
+		return err
+	}
+
+	clm := &model.ChannelLatestMessage{
+		ChannelID: m.ChannelID,
+		MessageID: m.ID,
+		DateTime:  m.CreatedAt,
+	}
+
+	return tx.
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(clm).
+		Error
+}
+
+func (repo *Repository) publishMessageCreated(m *model.Message) {
+	parseResult := message.Parse(m.Text)
 	repo.hub.Publish(hub.Message{
 		Name: event.MessageCreated,
 		Fields: hub.Fields{
@@ -67,7 +120,6 @@ func (repo *Repository) CreateMessage(userID, channelID uuid.UUID, text string)
 			},
 		})
 	}
-	return m, nil
 }
 
 // UpdateMessage implements MessageRepository interface.
@@ -85,8 +137,8 @@ func (repo *Repository) UpdateMessage(messageID uuid.UUID, text string) error {
 			return convertError(err)
 		}
 
-		// archiving
-		if err := tx.Create(&model.ArchivedMessage{
+		// 編集前の版を保存
+		if err := tx.Create(&model.MessageRevision{
 			ID:        uuid.Must(uuid.NewV4()),
 			MessageID: oldMes.ID,
 			UserID:    oldMes.UserID,
@@ -117,6 +169,16 @@ func (repo *Repository) UpdateMessage(messageID uuid.UUID, text string) error {
 	return nil
 }
 
+// GetMessageRevisions implements MessageRepository interface.
+func (repo *Repository) GetMessageRevisions(messageID uuid.UUID) (arr []*model.MessageRevision, err error) {
+	arr = make([]*model.MessageRevision, 0)
+	if messageID == uuid.Nil {
+		return arr, nil
+	}
+	err = repo.db.Where(&model.MessageRevision{MessageID: messageID}).Order("date_time").Find(&arr).Error
+	return arr, err
+}
+
 // DeleteMessage implements MessageRepository interface.
 func (repo *Repository) DeleteMessage(messageID uuid.UUID) error {
 	if messageID == uuid.Nil {
@@ -180,6 +242,119 @@ func (repo *Repository) DeleteMessage(messageID uuid.UUID) error {
 	return nil
 }
 
+// MoveMessages implements MessageRepository interface.
+func (repo *Repository) MoveMessages(fromChannelID, toChannelID uuid.UUID, messageIDs optional.Of[[]uuid.UUID]) ([]uuid.UUID, error) {
+	if fromChannelID == uuid.Nil || toChannelID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	moved := make([]uuid.UUID, 0)
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		messages := tx.Unscoped().Model(&model.Message{})
+		unreads := tx.Model(&model.Unread{})
+		if messageIDs.Valid {
+			if len(messageIDs.V) == 0 {
+				return nil
+			}
+			var ms []*model.Message
+			if err := tx.
+				Select("id", "text").
+				Where("channel_id = ? AND (id IN ? OR parent_message_id IN ?)", fromChannelID, messageIDs.V, messageIDs.V).
+				Find(&ms).
+				Error; err != nil {
+				return err
+			}
+			if len(ms) == 0 {
+				return nil
+			}
+			var attachments []uuid.UUID
+			for _, m := range ms {
+				moved = append(moved, m.ID)
+				attachments = append(attachments, message.Parse(m.Text).Attachments...)
+			}
+			messages = messages.Where("id IN ?", moved)
+			unreads = unreads.Where("message_id IN ?", moved)
+
+			// 移動するメッセージに添付された、移動元チャンネルのファイルも移動
+			if len(attachments) > 0 {
+				if err := tx.
+					Model(&model.FileMeta{}).
+					Where("id IN ? AND channel_id = ?", attachments, fromChannelID).
+					UpdateColumn("channel_id", toChannelID).
+					Error; err != nil {
+					return err
+				}
+			}
+		} else {
+			if err := tx.
+				Unscoped().
+				Model(&model.Message{}).
+				Where(&model.Message{ChannelID: fromChannelID}).
+				Pluck("id", &moved).
+				Error; err != nil {
+				return err
+			}
+			messages = messages.Where("channel_id = ?", fromChannelID)
+			unreads = unreads.Where("channel_id = ?", fromChannelID)
+
+			if err := tx.
+				Model(&model.FileMeta{}).
+				Where("channel_id = ?", fromChannelID).
+				UpdateColumn("channel_id", toChannelID).
+				Error; err != nil {
+				return err
+			}
+		}
+
+		// 移動はメッセージの編集ではないため、updated_atを更新しない
+		if err := messages.UpdateColumn("channel_id", toChannelID).Error; err != nil {
+			return err
+		}
+		if err := unreads.UpdateColumn("channel_id", toChannelID).Error; err != nil {
+			return err
+		}
+
+		if err := updateChannelLatestMessage(tx, fromChannelID); err != nil {
+			return err
+		}
+		return updateChannelLatestMessage(tx, toChannelID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(moved) > 0 {
+		repo.hub.Publish(hub.Message{
+			Name: event.MessagesMoved,
+			Fields: hub.Fields{
+				"message_ids":     moved,
+				"from_channel_id": fromChannelID,
+				"to_channel_id":   toChannelID,
+			},
+		})
+	}
+	return moved, nil
+}
+
+// updateChannelLatestMessage 指定したチャンネルの最新メッセージを再計算します
+func updateChannelLatestMessage(tx *gorm.DB, channelID uuid.UUID) error {
+	var mes []model.Message
+	if err := tx.
+		Where(&model.Message{ChannelID: channelID}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "created_at"}, Desc: true}).
+		Limit(1).
+		Find(&mes).Error; err != nil {
+		return err
+	}
+	if len(mes) == 0 {
+		return tx.Delete(&model.ChannelLatestMessage{}, &model.ChannelLatestMessage{ChannelID: channelID}).Error
+	}
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&model.ChannelLatestMessage{
+		ChannelID: mes[0].ChannelID,
+		MessageID: mes[0].ID,
+		DateTime:  mes[0].CreatedAt,
+	}).Error
+}
+
 // GetMessageByID implements MessageRepository interface.
 func (repo *Repository) GetMessageByID(messageID uuid.UUID) (*model.Message, error) {
 	if messageID == uuid.Nil {
@@ -222,10 +397,16 @@ func (repo *Repository) GetMessages(query repository.MessagesQuery) (messages []
 	}
 	if query.Channel != uuid.Nil {
 		tx = tx.Where("messages.channel_id = ?", query.Channel)
+		if !query.IncludeReplies {
+			tx = tx.Where("messages.parent_message_id IS NULL")
+		}
 	}
 	if query.User != uuid.Nil {
 		tx = tx.Where("messages.user_id = ?", query.User)
 	}
+	if query.Parent != uuid.Nil {
+		tx = tx.Where("messages.parent_message_id = ?", query.Parent)
+	}
 	if query.ChannelsSubscribedByUser != uuid.Nil {
 		tx = tx.Where("channels.is_forced = TRUE OR channels.id IN (SELECT s.channel_id FROM users_subscribe_channels s WHERE s.user_id = ?)", query.ChannelsSubscribedByUser)
 	}
@@ -253,12 +434,70 @@ func (repo *Repository) GetMessages(query repository.MessagesQuery) (messages []
 	if query.Limit > 0 {
 		err = tx.Limit(query.Limit + 1).Find(&messages).Error
 		if len(messages) > query.Limit {
-			return messages[:len(messages)-1], true, err
+			messages, more = messages[:len(messages)-1], true
 		}
 	} else {
 		err = tx.Find(&messages).Error
 	}
-	return messages, false, err
+	if err != nil {
+		return nil, false, err
+	}
+	if !query.DisablePreload {
+		if err := repo.loadReplyCounts(messages); err != nil {
+			return nil, false, err
+		}
+	}
+	return messages, more, nil
+}
+
+// loadReplyCounts 各メッセージのスレッドの返信数を設定します
+func (repo *Repository) loadReplyCounts(messages []*model.Message) error {
+	ids := make([]uuid.UUID, 0, len(messages))
+	for _, m := range messages {
+		if !m.IsReply() {
+			ids = append(ids, m.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var counts []struct {
+		ParentMessageID uuid.UUID
+		Count           int
+	}
+	if err := repo.db.
+		Model(&model.Message{}).
+		Select("parent_message_id, COUNT(*) AS count").
+		Where("parent_message_id IN ?", ids).
+		Group("parent_message_id").
+		Scan(&counts).
+		Error; err != nil {
+		return err
+	}
+	countMap := make(map[uuid.UUID]int, len(counts))
+	for _, c := range counts {
+		countMap[c.ParentMessageID] = c.Count
+	}
+	for _, m := range messages {
+		m.ReplyCount = countMap[m.ID]
+	}
+	return nil
+}
+
+// GetThreadParticipantIDs implements MessageRepository interface.
+func (repo *Repository) GetThreadParticipantIDs(parentID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0)
+	if parentID == uuid.Nil {
+		return ids, nil
+	}
+	return ids, repo.db.
+		Model(&model.Message{}).
+		Distinct("user_id").
+		Where("parent_message_id = ?", parentID).
+		Limit(limit).
+		Pluck("user_id", &ids).
+		Error
 }
 
 // GetUpdatedMessagesAfter implements MessageRepository interface.
@@ -410,7 +649,7 @@ func (repo *Repository) GetChannelLatestMessages(query repository.ChannelLatestM
 
 	tx := repo.db.
 		Unscoped().
-		Select("m.id, m.user_id, m.channel_id, m.text, m.created_at, m.updated_at, m.deleted_at").
+		Select("m.id, m.user_id, m.channel_id, m.text, m.parent_message_id, m.created_at, m.updated_at, m.deleted_at").
 		Table("channel_latest_messages clm").
 		Joins("INNER JOIN messages m ON clm.message_id = m.id").
 		Joins("INNER JOIN channels c ON clm.channel_id = c.id").
@@ -491,8 +730,44 @@ func (repo *Repository) RemoveStampFromMessage(messageID, stampID, userID uuid.U
 	return nil
 }
 
+// SetMessageComponents implements MessageRepository interface.
+func (repo *Repository) SetMessageComponents(messageID uuid.UUID, components model.MessageComponentList) error {
+	if messageID == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	var m model.Message
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&m, &model.Message{ID: messageID}).Error; err != nil {
+			return convertError(err)
+		}
+
+		if len(components) == 0 {
+			return tx.Delete(&model.MessageComponents{}, &model.MessageComponents{MessageID: messageID}).Error
+		}
+		return tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.AssignmentColumns([]string{"components", "updated_at"}),
+		}).Create(&model.MessageComponents{
+			MessageID:  messageID,
+			Components: components,
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	repo.hub.Publish(hub.Message{
+		Name: event.MessageComponentsUpdated,
+		Fields: hub.Fields{
+			"message_id": messageID,
+			"message":    &m,
+		},
+	})
+	return nil
+}
+
 func messagePreloads(db *gorm.DB) *gorm.DB {
 	return db.
 		Preload("Stamps").
-		Preload("Pin")
+		Preload("Pin").
+		Preload("Components")
 }
@@ -1,18 +1,22 @@
 package gorm
 
 import (
+	"time"
+
 	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
 
+	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/utils/gormutil"
 )
 
 // CreateMessageReport implements MessageReportRepository interface.
-func (repo *Repository) CreateMessageReport(messageID, reporterID uuid.UUID, reason string) error {
+func (repo *Repository) CreateMessageReport(messageID, reporterID uuid.UUID, reason string) (*model.MessageReport, error) {
 	// nil check
 	if messageID == uuid.Nil || reporterID == uuid.Nil {
-		return repository.ErrNilID
+		return nil, repository.ErrNilID
 	}
 
 	// make report
@@ -21,20 +25,45 @@ func (repo *Repository) CreateMessageReport(messageID, reporterID uuid.UUID, rea
 		MessageID: messageID,
 		Reporter:  reporterID,
 		Reason:    reason,
+		Status:    model.MessageReportStatusPending,
 	}
 	if err := repo.db.Create(r).Error; err != nil {
 		if gormutil.IsMySQLDuplicatedRecordErr(err) {
-			return repository.ErrAlreadyExists
+			return nil, repository.ErrAlreadyExists
 		}
-		return err
+		return nil, err
 	}
-	return nil
+	repo.hub.Publish(hub.Message{
+		Name: event.MessageReported,
+		Fields: hub.Fields{
+			"report_id":  r.ID,
+			"report":     r,
+			"message_id": r.MessageID,
+		},
+	})
+	return r, nil
+}
+
+// GetMessageReport implements MessageReportRepository interface.
+func (repo *Repository) GetMessageReport(reportID uuid.UUID) (*model.MessageReport, error) {
+	if reportID == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var r model.MessageReport
+	if err := repo.db.First(&r, &model.MessageReport{ID: reportID}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &r, nil
 }
 
 // GetMessageReports implements MessageReportRepository interface.
-func (repo *Repository) GetMessageReports(offset, limit int) (arr []*model.MessageReport, err error) {
+func (repo *Repository) GetMessageReports(query repository.MessageReportsQuery) (arr []*model.MessageReport, err error) {
 	arr = make([]*model.MessageReport, 0)
-	err = repo.db.Scopes(gormutil.LimitAndOffset(limit, offset)).Order("created_at").Find(&arr).Error
+	tx := repo.db.Scopes(gormutil.LimitAndOffset(query.Limit, query.Offset)).Order("created_at")
+	if query.Status.Valid {
+		tx = tx.Where("status = ?", query.Status.V)
+	}
+	err = tx.Find(&arr).Error
 	return arr, err
 }
 
@@ -57,3 +86,32 @@ func (repo *Repository) GetMessageReportsByReporterID(reporterID uuid.UUID) (arr
 	err = repo.db.Where(&model.MessageReport{Reporter: reporterID}).Order("created_at").Find(&arr).Error
 	return arr, err
 }
+
+// UpdateMessageReportStatus implements MessageReportRepository interface.
+func (repo *Repository) UpdateMessageReportStatus(reportID, handlerID uuid.UUID, status model.MessageReportStatus) error {
+	if reportID == uuid.Nil || handlerID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.
+		Model(&model.MessageReport{ID: reportID}).
+		Where("status = ?", model.MessageReportStatusPending).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"handled_by": handlerID,
+			"handled_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		var count int64
+		if err := repo.db.Model(&model.MessageReport{}).Where(&model.MessageReport{ID: reportID}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return repository.ErrNotFound
+		}
+		return repository.ErrAlreadyExists
+	}
+	return nil
+}
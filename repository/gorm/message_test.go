@@ -74,6 +74,84 @@ func TestRepositoryImpl_CreateMessage(t *testing.T) {
 	})
 }
 
+func TestRepositoryImpl_CreateReplyMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.CreateReplyMessage(user.GetID(), uuid.Nil, "a")
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("parent not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.CreateReplyMessage(user.GetID(), uuid.Must(uuid.NewV4()), "a")
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+
+		parent := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+		r1, err := repo.CreateReplyMessage(user.GetID(), parent.ID, "reply")
+		if assert.NoError(err) {
+			assert.NotZero(r1.ID)
+			assert.Equal(channel.ID, r1.ChannelID)
+			assert.Equal("reply", r1.Text)
+			assert.Equal(optional.From(parent.ID), r1.ParentMessageID)
+		}
+
+		// 返信への返信は大本のスレッドに入る
+		r2, err := repo.CreateReplyMessage(user.GetID(), r1.ID, "reply2")
+		if assert.NoError(err) {
+			assert.Equal(optional.From(parent.ID), r2.ParentMessageID)
+		}
+
+		replies, more, err := repo.GetMessages(repository.MessagesQuery{Parent: parent.ID})
+		if assert.NoError(err) {
+			assert.False(more)
+			if assert.Len(replies, 2) {
+				assert.Equal(r2.ID, replies[0].ID)
+				assert.Equal(r1.ID, replies[1].ID)
+			}
+		}
+
+		ids, err := repo.GetThreadParticipantIDs(parent.ID, 10)
+		if assert.NoError(err) {
+			assert.ElementsMatch([]uuid.UUID{user.GetID()}, ids)
+		}
+	})
+
+	t.Run("channel timeline", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+
+		ch := mustMakeChannel(t, repo, rand)
+		parent := mustMakeMessage(t, repo, user.GetID(), ch.ID)
+		r, err := repo.CreateReplyMessage(user.GetID(), parent.ID, "reply")
+		if !assert.NoError(err) {
+			return
+		}
+
+		messages, _, err := repo.GetMessages(repository.MessagesQuery{Channel: ch.ID})
+		if assert.NoError(err) && assert.Len(messages, 1) {
+			assert.Equal(parent.ID, messages[0].ID)
+			assert.Equal(1, messages[0].ReplyCount)
+		}
+
+		messages, _, err = repo.GetMessages(repository.MessagesQuery{Channel: ch.ID, IncludeReplies: true})
+		if assert.NoError(err) && assert.Len(messages, 2) {
+			assert.Equal(r.ID, messages[0].ID)
+			assert.Equal(parent.ID, messages[1].ID)
+			assert.Equal(1, messages[1].ReplyCount)
+		}
+	})
+}
+
 func TestRepositoryImpl_UpdateMessage(t *testing.T) {
 	t.Parallel()
 	repo, assert, _, user, channel := setupWithUserAndChannel(t, common3)
@@ -88,7 +166,29 @@ func TestRepositoryImpl_UpdateMessage(t *testing.T) {
 	m, err := repo.GetMessageByID(m.ID)
 	if assert.NoError(err) {
 		assert.Equal("new message", m.Text)
-		assert.Equal(1, count(t, getDB(repo).Model(&model.ArchivedMessage{}).Where(&model.ArchivedMessage{MessageID: m.ID, Text: originalText})))
+		assert.Equal(1, count(t, getDB(repo).Model(&model.MessageRevision{}).Where(&model.MessageRevision{MessageID: m.ID, Text: originalText})))
+	}
+}
+
+func TestRepositoryImpl_GetMessageRevisions(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	originalText := m.Text
+	require.NoError(repo.UpdateMessage(m.ID, "second"))
+	require.NoError(repo.UpdateMessage(m.ID, "third"))
+
+	revisions, err := repo.GetMessageRevisions(m.ID)
+	if assert.NoError(err) && assert.Len(revisions, 2) {
+		assert.Equal(originalText, revisions[0].Text)
+		assert.Equal("second", revisions[1].Text)
+		assert.Equal(m.ID, revisions[0].MessageID)
+	}
+
+	revisions, err = repo.GetMessageRevisions(uuid.Nil)
+	if assert.NoError(err) {
+		assert.Empty(revisions)
 	}
 }
 
@@ -107,6 +207,77 @@ func TestRepositoryImpl_DeleteMessage(t *testing.T) {
 	assert.EqualError(repo.DeleteMessage(m.ID), repository.ErrNotFound.Error())
 }
 
+func TestRepositoryImpl_MoveMessages(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common3)
+
+	t.Run("selected messages", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		from := mustMakeChannel(t, repo, rand)
+		to := mustMakeChannel(t, repo, rand)
+		m1 := mustMakeMessage(t, repo, user.GetID(), from.ID)
+		r1, err := repo.CreateReplyMessage(user.GetID(), m1.ID, "reply")
+		require.NoError(err)
+		m2 := mustMakeMessage(t, repo, user.GetID(), from.ID)
+		mustMakeMessageUnread(t, repo, user.GetID(), m1.ID)
+		f := &model.FileMeta{
+			ID:        uuid.Must(uuid.NewV4()),
+			Name:      "attached",
+			Mime:      "application/octet-stream",
+			Size:      10,
+			Hash:      "d41d8cd98f00b204e9800998ecf8427e",
+			Type:      model.FileTypeUserFile,
+			ChannelID: optional.From(from.ID),
+		}
+		require.NoError(repo.SaveFileMeta(f, nil))
+		r2, err := repo.CreateReplyMessage(user.GetID(), m1.ID, "http://localhost:3000/files/"+f.ID.String())
+		require.NoError(err)
+
+		moved, err := repo.MoveMessages(from.ID, to.ID, optional.From([]uuid.UUID{m1.ID}))
+		if assert.NoError(err) {
+			assert.ElementsMatch([]uuid.UUID{m1.ID, r1.ID, r2.ID}, moved)
+			fm, err := repo.GetFileMeta(f.ID)
+			require.NoError(err)
+			assert.Equal(optional.From(to.ID), fm.ChannelID)
+
+			m, err := repo.GetMessageByID(m1.ID)
+			require.NoError(err)
+			assert.Equal(to.ID, m.ChannelID)
+			assert.False(m.IsEdited())
+			m, err = repo.GetMessageByID(m2.ID)
+			require.NoError(err)
+			assert.Equal(from.ID, m.ChannelID)
+			assert.Equal(1, count(t, getDB(repo).Model(model.Unread{}).Where(&model.Unread{ChannelID: to.ID})))
+
+			var clm model.ChannelLatestMessage
+			require.NoError(getDB(repo).Where(&model.ChannelLatestMessage{ChannelID: to.ID}).First(&clm).Error)
+			assert.Equal(r2.ID, clm.MessageID)
+			require.NoError(getDB(repo).Where(&model.ChannelLatestMessage{ChannelID: from.ID}).First(&clm).Error)
+			assert.Equal(m2.ID, clm.MessageID)
+		}
+	})
+
+	t.Run("all messages", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		from := mustMakeChannel(t, repo, rand)
+		to := mustMakeChannel(t, repo, rand)
+		m1 := mustMakeMessage(t, repo, user.GetID(), from.ID)
+		m2 := mustMakeMessage(t, repo, user.GetID(), from.ID)
+		require.NoError(repo.DeleteMessage(m2.ID))
+
+		moved, err := repo.MoveMessages(from.ID, to.ID, optional.Of[[]uuid.UUID]{})
+		if assert.NoError(err) {
+			assert.ElementsMatch([]uuid.UUID{m1.ID, m2.ID}, moved)
+			assert.Equal(0, count(t, getDB(repo).Unscoped().Model(model.Message{}).Where(&model.Message{ChannelID: from.ID})))
+			assert.Equal(0, count(t, getDB(repo).Model(model.ChannelLatestMessage{}).Where(&model.ChannelLatestMessage{ChannelID: from.ID})))
+		}
+	})
+}
+
 func TestRepositoryImpl_GetMessageByID(t *testing.T) {
 	t.Parallel()
 	repo, assert, _, user, channel := setupWithUserAndChannel(t, common3)
@@ -456,3 +627,38 @@ func TestRepositoryImpl_RemoveStampFromMessage(t *testing.T) {
 		}
 	})
 }
+
+func TestRepositoryImpl_SetMessageComponents(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	components := model.MessageComponentList{
+		{Type: model.MessageComponentTypeButton, CustomID: "ok", Label: "OK", Style: "primary"},
+	}
+
+	assert.EqualError(repo.SetMessageComponents(uuid.Nil, components), repository.ErrNilID.Error())
+	assert.EqualError(repo.SetMessageComponents(uuid.Must(uuid.NewV4()), components), repository.ErrNotFound.Error())
+
+	require.NoError(repo.SetMessageComponents(m.ID, components))
+	got, err := repo.GetMessageByID(m.ID)
+	require.NoError(err)
+	if assert.NotNil(got.Components) {
+		assert.Equal(components, got.Components.Components)
+	}
+
+	// 上書き
+	components = model.MessageComponentList{
+		{Type: model.MessageComponentTypeButton, CustomID: "ok", Label: "OK", Disabled: true},
+	}
+	require.NoError(repo.SetMessageComponents(m.ID, components))
+	got, err = repo.GetMessageByID(m.ID)
+	require.NoError(err)
+	if assert.NotNil(got.Components) {
+		assert.Equal(components, got.Components.Components)
+	}
+
+	// 削除
+	require.NoError(repo.SetMessageComponents(m.ID, nil))
+	assert.Equal(0, count(t, getDB(repo).Model(&model.MessageComponents{}).Where(&model.MessageComponents{MessageID: m.ID})))
+}
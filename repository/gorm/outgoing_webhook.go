@@ -0,0 +1,276 @@
+package gorm
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormutil"
+)
+
+// CreateOutgoingWebhook implements OutgoingWebhookRepository interface.
+func (repo *Repository) CreateOutgoingWebhook(args repository.CreateOutgoingWebhookArgs) (*model.OutgoingWebhook, error) {
+	if len(args.Name) == 0 || utf8.RuneCountInString(args.Name) > 32 {
+		return nil, repository.ArgError("args.Name", "Name must be non-empty and shorter than 33 characters")
+	}
+	if len(args.URL) == 0 {
+		return nil, repository.ArgError("args.URL", "URL must be non-empty")
+	}
+	if err := validateOutgoingWebhookEvents(args.Events); err != nil {
+		return nil, err
+	}
+
+	w := &model.OutgoingWebhook{
+		ID:          uuid.Must(uuid.NewV4()),
+		Name:        args.Name,
+		Description: args.Description,
+		ChannelID:   args.ChannelID,
+		URL:         args.URL,
+		Secret:      args.Secret,
+		Events:      args.Events,
+		CreatorID:   args.CreatorID,
+	}
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := validateOutgoingWebhookChannel(tx, "args.ChannelID", args.ChannelID); err != nil {
+			return err
+		}
+		return tx.Create(w).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// UpdateOutgoingWebhook implements OutgoingWebhookRepository interface.
+func (repo *Repository) UpdateOutgoingWebhook(id uuid.UUID, args repository.UpdateOutgoingWebhookArgs) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var w model.OutgoingWebhook
+		if err := tx.Where(&model.OutgoingWebhook{ID: id}).First(&w).Error; err != nil {
+			return convertError(err)
+		}
+
+		changes := map[string]interface{}{}
+		if args.Name.Valid {
+			if len(args.Name.V) == 0 || utf8.RuneCountInString(args.Name.V) > 32 {
+				return repository.ArgError("args.Name", "Name must be non-empty and shorter than 33 characters")
+			}
+			changes["name"] = args.Name.V
+		}
+		if args.Description.Valid {
+			changes["description"] = args.Description.V
+		}
+		if args.ChannelID.Valid {
+			if err := validateOutgoingWebhookChannel(tx, "args.ChannelID", args.ChannelID.V); err != nil {
+				return err
+			}
+			changes["channel_id"] = args.ChannelID.V
+		}
+		if args.URL.Valid {
+			if len(args.URL.V) == 0 {
+				return repository.ArgError("args.URL", "URL must be non-empty")
+			}
+			changes["url"] = args.URL.V
+		}
+		if args.Secret.Valid {
+			changes["secret"] = args.Secret.V
+		}
+		if args.Events != nil {
+			if err := validateOutgoingWebhookEvents(args.Events); err != nil {
+				return err
+			}
+			changes["events"] = args.Events
+		}
+		if args.CreatorID.Valid {
+			// 作成者検証
+			var u model.User
+			if err := tx.First(&u, &model.User{ID: args.CreatorID.V}).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return repository.ArgError("args.CreatorID", "the Creator is not found")
+				}
+				return err
+			}
+			if !u.IsActive() || u.IsBot() {
+				return repository.ArgError("args.CreatorID", "invalid User")
+			}
+			changes["creator_id"] = args.CreatorID.V
+		}
+
+		if len(changes) > 0 {
+			return tx.Model(&model.OutgoingWebhook{ID: id}).Updates(changes).Error
+		}
+		return nil
+	})
+}
+
+// DeleteOutgoingWebhook implements OutgoingWebhookRepository interface.
+func (repo *Repository) DeleteOutgoingWebhook(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&model.OutgoingWebhook{ID: id})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrNotFound
+		}
+		// 論理削除のため外部キー制約で削除されない再試行待ちの配送を削除
+		return tx.Where(&model.OutgoingWebhookDelivery{WebhookID: id}).Delete(&model.OutgoingWebhookDelivery{}).Error
+	})
+}
+
+// GetOutgoingWebhook implements OutgoingWebhookRepository interface.
+func (repo *Repository) GetOutgoingWebhook(id uuid.UUID) (*model.OutgoingWebhook, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var w model.OutgoingWebhook
+	if err := repo.db.Where(&model.OutgoingWebhook{ID: id}).Take(&w).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &w, nil
+}
+
+// GetOutgoingWebhooks implements OutgoingWebhookRepository interface.
+func (repo *Repository) GetOutgoingWebhooks(query repository.OutgoingWebhooksQuery) ([]*model.OutgoingWebhook, error) {
+	webhooks := make([]*model.OutgoingWebhook, 0)
+	tx := repo.db.Order("created_at")
+	if query.CreatorID.Valid {
+		tx = tx.Where("creator_id = ?", query.CreatorID.V)
+	}
+	if query.ChannelID.Valid {
+		tx = tx.Where("channel_id = ?", query.ChannelID.V)
+	}
+	if err := tx.Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	if !query.Event.Valid {
+		return webhooks, nil
+	}
+
+	result := make([]*model.OutgoingWebhook, 0, len(webhooks))
+	for _, w := range webhooks {
+		if w.Events.Contains(query.Event.V) {
+			result = append(result, w)
+		}
+	}
+	return result, nil
+}
+
+// WriteOutgoingWebhookDeliveryLog implements OutgoingWebhookRepository interface.
+func (repo *Repository) WriteOutgoingWebhookDeliveryLog(log *model.OutgoingWebhookDeliveryLog) error {
+	if log == nil || log.ID == uuid.Nil {
+		return nil
+	}
+	return repo.db.Create(log).Error
+}
+
+// GetOutgoingWebhookDeliveryLog implements OutgoingWebhookRepository interface.
+func (repo *Repository) GetOutgoingWebhookDeliveryLog(id uuid.UUID) (*model.OutgoingWebhookDeliveryLog, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var log model.OutgoingWebhookDeliveryLog
+	if err := repo.db.Where(&model.OutgoingWebhookDeliveryLog{ID: id}).Take(&log).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &log, nil
+}
+
+// GetOutgoingWebhookDeliveryLogs implements OutgoingWebhookRepository interface.
+func (repo *Repository) GetOutgoingWebhookDeliveryLogs(webhookID uuid.UUID, limit, offset int) ([]*model.OutgoingWebhookDeliveryLog, error) {
+	logs := make([]*model.OutgoingWebhookDeliveryLog, 0)
+	if webhookID == uuid.Nil {
+		return logs, nil
+	}
+	return logs, repo.db.Where(&model.OutgoingWebhookDeliveryLog{WebhookID: webhookID}).
+		Order("date_time DESC").
+		Scopes(gormutil.LimitAndOffset(limit, offset)).
+		Find(&logs).
+		Error
+}
+
+// PurgeOutgoingWebhookDeliveryLogs implements OutgoingWebhookRepository interface.
+func (repo *Repository) PurgeOutgoingWebhookDeliveryLogs(before time.Time) error {
+	return repo.db.Delete(&model.OutgoingWebhookDeliveryLog{}, "date_time < ?", before).Error
+}
+
+// SaveOutgoingWebhookDelivery implements OutgoingWebhookRepository interface.
+func (repo *Repository) SaveOutgoingWebhookDelivery(d *model.OutgoingWebhookDelivery) error {
+	if d == nil || d.ID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.Save(d).Error
+}
+
+// DeleteOutgoingWebhookDelivery implements OutgoingWebhookRepository interface.
+func (repo *Repository) DeleteOutgoingWebhookDelivery(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return nil
+	}
+	return repo.db.Delete(&model.OutgoingWebhookDelivery{ID: id}).Error
+}
+
+// GetRetryableOutgoingWebhookDeliveries implements OutgoingWebhookRepository interface.
+func (repo *Repository) GetRetryableOutgoingWebhookDeliveries(now time.Time, limit int) ([]*model.OutgoingWebhookDelivery, error) {
+	deliveries := make([]*model.OutgoingWebhookDelivery, 0)
+	return deliveries, repo.db.
+		Joins("INNER JOIN outgoing_webhooks ON outgoing_webhooks.id = outgoing_webhook_deliveries.webhook_id AND outgoing_webhooks.deleted_at IS NULL").
+		Where("outgoing_webhook_deliveries.next_attempt_at <= ? AND (outgoing_webhook_deliveries.lease_until IS NULL OR outgoing_webhook_deliveries.lease_until <= ?)", now, now).
+		Order("outgoing_webhook_deliveries.next_attempt_at").
+		Scopes(gormutil.LimitAndOffset(limit, 0)).
+		Find(&deliveries).
+		Error
+}
+
+// ClaimOutgoingWebhookDelivery implements OutgoingWebhookRepository interface.
+func (repo *Repository) ClaimOutgoingWebhookDelivery(id uuid.UUID, leaseUntil time.Time) (bool, error) {
+	if id == uuid.Nil {
+		return false, repository.ErrNilID
+	}
+	result := repo.db.
+		Model(&model.OutgoingWebhookDelivery{ID: id}).
+		Where("lease_until IS NULL OR lease_until <= ?", time.Now()).
+		Update("lease_until", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// validateOutgoingWebhookChannel 外部送信Webhookのチャンネルとして有効かどうかを検証します
+func validateOutgoingWebhookChannel(tx *gorm.DB, field string, channelID uuid.UUID) error {
+	var ch model.Channel
+	if err := tx.First(&ch, &model.Channel{ID: channelID}).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return repository.ArgError(field, "the Channel is not found")
+		}
+		return err
+	}
+	if !ch.IsPublic {
+		return repository.ArgError(field, "private channels are not allowed")
+	}
+	return nil
+}
+
+// validateOutgoingWebhookEvents 外部送信Webhookのイベントとして有効かどうかを検証します
+func validateOutgoingWebhookEvents(events model.OutgoingWebhookEvents) error {
+	if len(events) == 0 {
+		return repository.ArgError("args.Events", "Events must be non-empty")
+	}
+	for ev := range events {
+		if !ev.Valid() {
+			return repository.ArgError("args.Events", "unknown event: "+ev.String())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package gorm
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_CreateOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	args := func() repository.CreateOutgoingWebhookArgs {
+		return repository.CreateOutgoingWebhookArgs{
+			Name:      "test",
+			ChannelID: channel.ID,
+			URL:       "https://example.com/hook",
+			Secret:    "secret",
+			Events:    model.OutgoingWebhookEvents{model.OutgoingWebhookEventMessageCreated: {}},
+			CreatorID: user.GetID(),
+		}
+	}
+
+	t.Run("invalid name", func(t *testing.T) {
+		t.Parallel()
+		a := args()
+		a.Name = strings.Repeat("a", 33)
+		_, err := repo.CreateOutgoingWebhook(a)
+		assert.True(t, repository.IsArgError(err))
+	})
+
+	t.Run("invalid events", func(t *testing.T) {
+		t.Parallel()
+		a := args()
+		a.Events = model.OutgoingWebhookEvents{"UNKNOWN": {}}
+		_, err := repo.CreateOutgoingWebhook(a)
+		assert.True(t, repository.IsArgError(err))
+	})
+
+	t.Run("channel not found", func(t *testing.T) {
+		t.Parallel()
+		a := args()
+		a.ChannelID = uuid.Must(uuid.NewV4())
+		_, err := repo.CreateOutgoingWebhook(a)
+		assert.True(t, repository.IsArgError(err))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		w, err := repo.CreateOutgoingWebhook(args())
+		require.NoError(err)
+
+		got, err := repo.GetOutgoingWebhook(w.ID)
+		require.NoError(err)
+		assert.Equal("test", got.Name)
+		assert.Equal(channel.ID, got.ChannelID)
+		assert.Equal("secret", got.Secret)
+		assert.True(got.Events.Contains(model.OutgoingWebhookEventMessageCreated))
+		assert.Equal(user.GetID(), got.CreatorID)
+	})
+}
+
+func TestRepositoryImpl_UpdateOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+		assert.EqualError(t, repo.UpdateOutgoingWebhook(uuid.Nil, repository.UpdateOutgoingWebhookArgs{}), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		assert.EqualError(t, repo.UpdateOutgoingWebhook(uuid.Must(uuid.NewV4()), repository.UpdateOutgoingWebhookArgs{}), repository.ErrNotFound.Error())
+	})
+
+	t.Run("empty events", func(t *testing.T) {
+		t.Parallel()
+		w := mustMakeOutgoingWebhook(t, repo, channel.ID, user.GetID(), model.OutgoingWebhookEventMessageCreated)
+		err := repo.UpdateOutgoingWebhook(w.ID, repository.UpdateOutgoingWebhookArgs{Events: model.OutgoingWebhookEvents{}})
+		assert.True(t, repository.IsArgError(err))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		w := mustMakeOutgoingWebhook(t, repo, channel.ID, user.GetID(), model.OutgoingWebhookEventMessageCreated)
+
+		err := repo.UpdateOutgoingWebhook(w.ID, repository.UpdateOutgoingWebhookArgs{
+			Name:   optional.From("updated"),
+			URL:    optional.From("https://example.com/updated"),
+			Events: model.OutgoingWebhookEvents{model.OutgoingWebhookEventMessagePinned: {}},
+		})
+		require.NoError(err)
+
+		got, err := repo.GetOutgoingWebhook(w.ID)
+		require.NoError(err)
+		assert.Equal("updated", got.Name)
+		assert.Equal("https://example.com/updated", got.URL)
+		assert.False(got.Events.Contains(model.OutgoingWebhookEventMessageCreated))
+		assert.True(got.Events.Contains(model.OutgoingWebhookEventMessagePinned))
+	})
+}
+
+func TestRepositoryImpl_DeleteOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+		assert.EqualError(t, repo.DeleteOutgoingWebhook(uuid.Nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		assert.EqualError(t, repo.DeleteOutgoingWebhook(uuid.Must(uuid.NewV4())), repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		w := mustMakeOutgoingWebhook(t, repo, channel.ID, user.GetID(), model.OutgoingWebhookEventMessageCreated)
+		if assert.NoError(t, repo.DeleteOutgoingWebhook(w.ID)) {
+			_, err := repo.GetOutgoingWebhook(w.ID)
+			assert.EqualError(t, err, repository.ErrNotFound.Error())
+		}
+	})
+}
+
+func TestRepositoryImpl_GetOutgoingWebhooks(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	w1 := mustMakeOutgoingWebhook(t, repo, channel.ID, user.GetID(), model.OutgoingWebhookEventMessageCreated)
+	w2 := mustMakeOutgoingWebhook(t, repo, channel.ID, user.GetID(), model.OutgoingWebhookEventMessagePinned)
+
+	t.Run("by channel and event", func(t *testing.T) {
+		t.Parallel()
+		ws, err := repo.GetOutgoingWebhooks(repository.OutgoingWebhooksQuery{
+			ChannelID: optional.From(channel.ID),
+			Event:     optional.From(model.OutgoingWebhookEventMessageCreated),
+		})
+		if assert.NoError(t, err) && assert.Len(t, ws, 1) {
+			assert.Equal(t, w1.ID, ws[0].ID)
+		}
+	})
+
+	t.Run("by creator", func(t *testing.T) {
+		t.Parallel()
+		ws, err := repo.GetOutgoingWebhooks(repository.OutgoingWebhooksQuery{CreatorID: optional.From(user.GetID())})
+		if assert.NoError(t, err) && assert.Len(t, ws, 2) {
+			assert.Equal(t, w1.ID, ws[0].ID)
+			assert.Equal(t, w2.ID, ws[1].ID)
+		}
+	})
+}
+
+func TestRepositoryImpl_OutgoingWebhookDeliveryLogs(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+	w := mustMakeOutgoingWebhook(t, repo, channel.ID, user.GetID(), model.OutgoingWebhookEventMessageCreated)
+
+	deliveryID := uuid.Must(uuid.NewV4())
+	now := time.Now()
+	logs := make([]*model.OutgoingWebhookDeliveryLog, 3)
+	for i := range logs {
+		logs[i] = &model.OutgoingWebhookDeliveryLog{
+			ID:         uuid.Must(uuid.NewV4()),
+			DeliveryID: deliveryID,
+			WebhookID:  w.ID,
+			Event:      model.OutgoingWebhookEventMessageCreated,
+			Body:       "{}",
+			Attempt:    i + 1,
+			Result:     "ng",
+			Code:       500,
+			DateTime:   now.Add(time.Duration(i-3) * time.Hour),
+		}
+		require.NoError(t, repo.WriteOutgoingWebhookDeliveryLog(logs[i]))
+	}
+
+	t.Run("GetOutgoingWebhookDeliveryLog", func(t *testing.T) {
+		t.Parallel()
+		log, err := repo.GetOutgoingWebhookDeliveryLog(logs[0].ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, deliveryID, log.DeliveryID)
+			assert.Equal(t, 1, log.Attempt)
+		}
+		_, err = repo.GetOutgoingWebhookDeliveryLog(uuid.Must(uuid.NewV4()))
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("GetOutgoingWebhookDeliveryLogs", func(t *testing.T) {
+		t.Parallel()
+		res, err := repo.GetOutgoingWebhookDeliveryLogs(w.ID, 2, 0)
+		if assert.NoError(t, err) && assert.Len(t, res, 2) {
+			assert.Equal(t, logs[2].ID, res[0].ID)
+			assert.Equal(t, logs[1].ID, res[1].ID)
+		}
+	})
+}
+
+func TestRepositoryImpl_OutgoingWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3)
+	w := mustMakeOutgoingWebhook(t, repo, channel.ID, user.GetID(), model.OutgoingWebhookEventMessageCreated)
+	deleted := mustMakeOutgoingWebhook(t, repo, channel.ID, user.GetID(), model.OutgoingWebhookEventMessageCreated)
+
+	now := time.Now()
+	newDelivery := func(webhookID uuid.UUID, nextAttemptAt time.Time) *model.OutgoingWebhookDelivery {
+		d := &model.OutgoingWebhookDelivery{
+			ID:            uuid.Must(uuid.NewV4()),
+			WebhookID:     webhookID,
+			Event:         model.OutgoingWebhookEventMessageCreated,
+			Body:          "{}",
+			Attempts:      1,
+			NextAttemptAt: nextAttemptAt,
+		}
+		require.NoError(repo.SaveOutgoingWebhookDelivery(d))
+		return d
+	}
+	d1 := newDelivery(w.ID, now.Add(-time.Minute))
+	d2 := newDelivery(w.ID, now.Add(-2*time.Minute))
+	newDelivery(w.ID, now.Add(time.Minute))
+	leased := newDelivery(w.ID, now.Add(-3*time.Minute))
+	newDelivery(deleted.ID, now.Add(-time.Minute))
+	require.NoError(repo.DeleteOutgoingWebhook(deleted.ID))
+
+	assert.EqualError(repo.SaveOutgoingWebhookDelivery(&model.OutgoingWebhookDelivery{}), repository.ErrNilID.Error())
+	_, err := repo.ClaimOutgoingWebhookDelivery(uuid.Nil, now)
+	assert.EqualError(err, repository.ErrNilID.Error())
+
+	ok, err := repo.ClaimOutgoingWebhookDelivery(leased.ID, now.Add(time.Minute))
+	require.NoError(err)
+	assert.True(ok)
+	// 処理権の期限内は他のインスタンスが処理権を得られない
+	ok, err = repo.ClaimOutgoingWebhookDelivery(leased.ID, now.Add(time.Minute))
+	require.NoError(err)
+	assert.False(ok)
+
+	ds, err := repo.GetRetryableOutgoingWebhookDeliveries(now, 100)
+	require.NoError(err)
+	ids := make([]uuid.UUID, 0)
+	for _, d := range ds {
+		if d.WebhookID == w.ID {
+			ids = append(ids, d.ID)
+		}
+		assert.NotEqual(deleted.ID, d.WebhookID)
+	}
+	assert.Equal([]uuid.UUID{d2.ID, d1.ID}, ids)
+
+	require.NoError(repo.DeleteOutgoingWebhookDelivery(d1.ID))
+	ok, err = repo.ClaimOutgoingWebhookDelivery(d1.ID, now.Add(time.Minute))
+	require.NoError(err)
+	assert.False(ok)
+	assert.Equal(0, count(t, getDB(repo).Model(&model.OutgoingWebhookDelivery{}).Where(&model.OutgoingWebhookDelivery{WebhookID: deleted.ID})))
+}
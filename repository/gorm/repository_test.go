@@ -20,6 +20,7 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/rbac/role"
+	"github.com/traPtitech/traQ/utils/optional"
 	"github.com/traPtitech/traQ/utils/random"
 )
 
@@ -299,6 +300,60 @@ func mustMakeClipFolderMessage(t *testing.T, repo repository.Repository, folderI
 	return cfm
 }
 
+func mustMakeBot(t *testing.T, repo repository.Repository, creatorID uuid.UUID) *model.Bot {
+	t.Helper()
+	icon := mustMakeDummyFile(t, repo)
+	b, err := repo.CreateBot(random.AlphaNumeric(16), "bot", "", icon.ID, creatorID, model.BotModeHTTP, model.BotActive, "https://example.com")
+	require.NoError(t, err)
+	return b
+}
+
+func mustMakeBotEventDelivery(t *testing.T, repo repository.Repository, botID uuid.UUID, status model.BotEventDeliveryStatus, nextAttemptAt time.Time) *model.BotEventDelivery {
+	t.Helper()
+	d := &model.BotEventDelivery{
+		ID:            uuid.Must(uuid.NewV4()),
+		BotID:         botID,
+		Event:         "PING",
+		Body:          "{}",
+		Status:        status,
+		Attempts:      1,
+		NextAttemptAt: nextAttemptAt,
+	}
+	require.NoError(t, repo.SaveBotEventDelivery(d))
+	return d
+}
+
+func mustMakeOutgoingWebhook(t *testing.T, repo repository.Repository, channelID, creatorID uuid.UUID, events ...model.OutgoingWebhookEvent) *model.OutgoingWebhook {
+	t.Helper()
+	set := model.OutgoingWebhookEvents{}
+	for _, ev := range events {
+		set[ev] = struct{}{}
+	}
+	w, err := repo.CreateOutgoingWebhook(repository.CreateOutgoingWebhookArgs{
+		Name:        "outgoing",
+		Description: "desc",
+		ChannelID:   channelID,
+		URL:         "https://example.com/hook",
+		Secret:      "secret",
+		Events:      set,
+		CreatorID:   creatorID,
+	})
+	require.NoError(t, err)
+	return w
+}
+
+func mustMakeScheduledMessage(t *testing.T, repo repository.Repository, userID, channelID uuid.UUID, scheduledAt time.Time) *model.ScheduledMessage {
+	t.Helper()
+	sm, err := repo.CreateScheduledMessage(repository.CreateScheduledMessageArgs{
+		UserID:      userID,
+		ChannelID:   optional.From(channelID),
+		Content:     "scheduled",
+		ScheduledAt: scheduledAt,
+	})
+	require.NoError(t, err)
+	return sm
+}
+
 func count(t *testing.T, where *gorm.DB) int {
 	t.Helper()
 	var c int64
@@ -0,0 +1,198 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormutil"
+)
+
+// CreateScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) CreateScheduledMessage(args repository.CreateScheduledMessageArgs) (*model.ScheduledMessage, error) {
+	if args.UserID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	m := &model.ScheduledMessage{
+		ID:          uuid.Must(uuid.NewV4()),
+		UserID:      args.UserID,
+		ChannelID:   args.ChannelID,
+		DMUserID:    args.DMUserID,
+		Content:     args.Content,
+		ScheduledAt: args.ScheduledAt,
+		Status:      model.ScheduledMessageStatusPending,
+	}
+	if err := repo.db.Create(m).Error; err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GetScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) GetScheduledMessage(id uuid.UUID) (*model.ScheduledMessage, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var m model.ScheduledMessage
+	if err := repo.db.First(&m, &model.ScheduledMessage{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &m, nil
+}
+
+// GetScheduledMessages implements ScheduledMessageRepository interface.
+func (repo *Repository) GetScheduledMessages(query repository.ScheduledMessagesQuery) (arr []*model.ScheduledMessage, err error) {
+	arr = make([]*model.ScheduledMessage, 0)
+	tx := repo.db.Scopes(gormutil.LimitAndOffset(query.Limit, query.Offset)).Order("scheduled_at")
+	if query.UserID != uuid.Nil {
+		tx = tx.Where("user_id = ?", query.UserID)
+	}
+	if query.Status.Valid {
+		tx = tx.Where("status = ?", query.Status.V)
+	}
+	err = tx.Find(&arr).Error
+	return arr, err
+}
+
+// GetDueScheduledMessages implements ScheduledMessageRepository interface.
+func (repo *Repository) GetDueScheduledMessages(until time.Time, limit int) (arr []*model.ScheduledMessage, err error) {
+	arr = make([]*model.ScheduledMessage, 0)
+	err = repo.db.
+		Scopes(gormutil.LimitAndOffset(limit, 0)).
+		Where("status = ? AND scheduled_at <= ?", model.ScheduledMessageStatusPending, until).
+		Order("scheduled_at").
+		Find(&arr).
+		Error
+	return arr, err
+}
+
+// GetStaleScheduledMessages implements ScheduledMessageRepository interface.
+func (repo *Repository) GetStaleScheduledMessages(acquiredBefore time.Time, limit int) (arr []*model.ScheduledMessage, err error) {
+	arr = make([]*model.ScheduledMessage, 0)
+	err = repo.db.
+		Scopes(gormutil.LimitAndOffset(limit, 0)).
+		Where("status = ? AND acquired_at < ?", model.ScheduledMessageStatusSending, acquiredBefore).
+		Order("acquired_at").
+		Find(&arr).
+		Error
+	return arr, err
+}
+
+// UpdateScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) UpdateScheduledMessage(id uuid.UUID, args repository.UpdateScheduledMessageArgs) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	changes := map[string]interface{}{}
+	if args.Content.Valid {
+		changes["content"] = args.Content.V
+	}
+	if args.ScheduledAt.Valid {
+		changes["scheduled_at"] = args.ScheduledAt.V
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return repo.updatePendingScheduledMessage(id, changes)
+}
+
+// CancelScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) CancelScheduledMessage(id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.updatePendingScheduledMessage(id, map[string]interface{}{"status": model.ScheduledMessageStatusCanceled})
+}
+
+// updatePendingScheduledMessage 投稿待ちの予約投稿メッセージのみを更新します
+func (repo *Repository) updatePendingScheduledMessage(id uuid.UUID, changes map[string]interface{}) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var m model.ScheduledMessage
+		if err := tx.First(&m, &model.ScheduledMessage{ID: id}).Error; err != nil {
+			return convertError(err)
+		}
+		result := tx.Model(&m).Where("status = ?", model.ScheduledMessageStatusPending).Updates(changes)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			// 既に投稿処理が始まっている
+			return repository.ErrForbidden
+		}
+		return nil
+	})
+}
+
+// AcquireScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) AcquireScheduledMessage(id uuid.UUID) (bool, error) {
+	if id == uuid.Nil {
+		return false, repository.ErrNilID
+	}
+	result := repo.db.
+		Model(&model.ScheduledMessage{ID: id}).
+		Where("status = ?", model.ScheduledMessageStatusPending).
+		Updates(map[string]interface{}{
+			"status":      model.ScheduledMessageStatusSending,
+			"acquired_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CompleteScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) CompleteScheduledMessage(id, messageID uuid.UUID) error {
+	if id == uuid.Nil || messageID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.
+		Model(&model.ScheduledMessage{ID: id}).
+		Updates(map[string]interface{}{
+			"status":     model.ScheduledMessageStatusSent,
+			"message_id": messageID,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// FailScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) FailScheduledMessage(id uuid.UUID, reason string) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	var m model.ScheduledMessage
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&m, &model.ScheduledMessage{ID: id}).Error; err != nil {
+			return convertError(err)
+		}
+		return tx.Model(&m).Updates(map[string]interface{}{
+			"status": model.ScheduledMessageStatusFailed,
+			"error":  reason,
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	repo.hub.Publish(hub.Message{
+		Name: event.ScheduledMessageFailed,
+		Fields: hub.Fields{
+			"scheduled_message_id": m.ID,
+			"scheduled_message":    &m,
+			"user_id":              m.UserID,
+		},
+	})
+	return nil
+}
@@ -0,0 +1,122 @@
+package gorm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_GetDueScheduledMessages(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	now := time.Now()
+	due := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, now.Add(-time.Minute))
+	mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, now.Add(time.Hour))
+	canceled := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, now.Add(-time.Minute))
+	require.NoError(t, repo.CancelScheduledMessage(canceled.ID))
+
+	sms, err := repo.GetDueScheduledMessages(now, 100)
+	require.NoError(t, err)
+	ids := make([]uuid.UUID, 0, len(sms))
+	for _, sm := range sms {
+		ids = append(ids, sm.ID)
+	}
+	assert.Contains(t, ids, due.ID)
+	assert.NotContains(t, ids, canceled.ID)
+	for _, sm := range sms {
+		assert.False(t, sm.ScheduledAt.After(now))
+	}
+}
+
+func TestRepositoryImpl_AcquireScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.AcquireScheduledMessage(uuid.Nil)
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		sm := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now())
+
+		ok, err := repo.AcquireScheduledMessage(sm.ID)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		// 2回目は処理権を得られない
+		ok, err = repo.AcquireScheduledMessage(sm.ID)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		// 投稿処理中のものは編集・取り消しできない
+		assert.EqualError(t, repo.CancelScheduledMessage(sm.ID), repository.ErrForbidden.Error())
+		assert.EqualError(t, repo.UpdateScheduledMessage(sm.ID, repository.UpdateScheduledMessageArgs{Content: optional.From("a")}), repository.ErrForbidden.Error())
+
+		messageID := uuid.Must(uuid.NewV4())
+		require.NoError(t, repo.CompleteScheduledMessage(sm.ID, messageID))
+		sent, err := repo.GetScheduledMessage(sm.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.ScheduledMessageStatusSent, sent.Status)
+		assert.Equal(t, optional.From(messageID), sent.MessageID)
+	})
+}
+
+func TestRepositoryImpl_GetStaleScheduledMessages(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	pending := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now())
+	sending := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now())
+	ok, err := repo.AcquireScheduledMessage(sending.ID)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// 投稿処理を開始した直後のものは含まれない
+	sms, err := repo.GetStaleScheduledMessages(time.Now().Add(-time.Minute), 100)
+	require.NoError(t, err)
+	for _, sm := range sms {
+		assert.NotEqual(t, sending.ID, sm.ID)
+	}
+
+	sms, err = repo.GetStaleScheduledMessages(time.Now().Add(time.Minute), 100)
+	require.NoError(t, err)
+	ids := make([]uuid.UUID, len(sms))
+	for i, sm := range sms {
+		ids[i] = sm.ID
+	}
+	assert.Contains(t, ids, sending.ID)
+	assert.NotContains(t, ids, pending.ID)
+}
+
+func TestRepositoryImpl_FailScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		assert.EqualError(t, repo.FailScheduledMessage(uuid.Must(uuid.NewV4()), "failed"), repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		sm := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now())
+
+		require.NoError(t, repo.FailScheduledMessage(sm.ID, "failed"))
+		failed, err := repo.GetScheduledMessage(sm.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.ScheduledMessageStatusFailed, failed.Status)
+		assert.Equal(t, "failed", failed.Error)
+	})
+}
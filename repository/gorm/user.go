@@ -172,6 +172,9 @@ func (r *userRepository) GetUserIDs(query repository.UsersQuery) (ids []uuid.UUI
 func (r *userRepository) makeGetUsersTx(query repository.UsersQuery) *gorm.DB {
 	tx := r.db.Table("users")
 
+	if query.IDs.Valid {
+		tx = tx.Where("users.id IN ?", query.IDs.V)
+	}
 	if query.Name.Valid {
 		tx = tx.Where("users.name = ?", query.Name.V)
 	}
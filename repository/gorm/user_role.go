@@ -1,6 +1,11 @@
 package gorm
 
-import "github.com/traPtitech/traQ/model"
+import (
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
 
 // CreateUserRoles implements UserRoleRepository interface.
 func (repo *Repository) CreateUserRoles(roles ...*model.UserRole) error {
@@ -13,3 +18,110 @@ func (repo *Repository) GetAllUserRoles() ([]*model.UserRole, error) {
 	err := repo.db.Preload("Inheritances").Preload("Permissions").Find(&roles).Error
 	return roles, err
 }
+
+// GetUserRole implements UserRoleRepository interface.
+func (repo *Repository) GetUserRole(name string) (*model.UserRole, error) {
+	if len(name) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	var role model.UserRole
+	if err := repo.db.Preload("Inheritances").Preload("Permissions").First(&role, &model.UserRole{Name: name}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &role, nil
+}
+
+// UpdateUserRole implements UserRoleRepository interface.
+func (repo *Repository) UpdateUserRole(name string, args repository.UpdateUserRoleArgs) error {
+	if len(name) == 0 {
+		return repository.ErrNotFound
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var role model.UserRole
+		if err := tx.First(&role, &model.UserRole{Name: name}).Error; err != nil {
+			return convertError(err)
+		}
+		if role.System {
+			return repository.ErrForbidden
+		}
+
+		if args.Permissions.Valid {
+			if err := tx.Where(&model.RolePermission{Role: name}).Delete(&model.RolePermission{}).Error; err != nil {
+				return err
+			}
+			perms := make([]*model.RolePermission, 0, len(args.Permissions.V))
+			added := map[string]struct{}{}
+			for _, p := range args.Permissions.V {
+				if _, ok := added[p]; ok {
+					continue
+				}
+				added[p] = struct{}{}
+				perms = append(perms, &model.RolePermission{Role: name, Permission: p})
+			}
+			if len(perms) > 0 {
+				if err := tx.Create(perms).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if args.Inheritances.Valid {
+			var subs []*model.UserRole
+			if len(args.Inheritances.V) > 0 {
+				if err := tx.Where("name IN ?", args.Inheritances.V).Find(&subs).Error; err != nil {
+					return err
+				}
+			}
+			for _, s := range args.Inheritances.V {
+				found := false
+				for _, sub := range subs {
+					if sub.Name == s {
+						found = true
+						break
+					}
+				}
+				if !found || s == name {
+					return repository.ArgError("args.Inheritances", "invalid role: "+s)
+				}
+			}
+			if err := tx.Model(&role).Association("Inheritances").Replace(subs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteUserRole implements UserRoleRepository interface.
+func (repo *Repository) DeleteUserRole(name string) error {
+	if len(name) == 0 {
+		return repository.ErrNotFound
+	}
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var role model.UserRole
+		if err := tx.First(&role, &model.UserRole{Name: name}).Error; err != nil {
+			return convertError(err)
+		}
+		if role.System {
+			return repository.ErrForbidden
+		}
+
+		// ユーザー・他ロールの継承・チャンネルロール・チャンネル権限上書きから参照されている場合は削除できない
+		refs := []*gorm.DB{
+			tx.Model(&model.User{}).Where(&model.User{Role: name}),
+			tx.Table("user_role_inheritances").Where("sub_role = ?", name),
+			tx.Model(&model.ChannelRole{}).Where(&model.ChannelRole{Role: name}),
+			tx.Model(&model.ChannelPermissionOverride{}).Where(&model.ChannelPermissionOverride{Role: name}),
+		}
+		for _, ref := range refs {
+			var n int64
+			if err := ref.Count(&n).Error; err != nil {
+				return err
+			}
+			if n > 0 {
+				return repository.ErrForbidden
+			}
+		}
+		return tx.Delete(&role).Error
+	})
+}
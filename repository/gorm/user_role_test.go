@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 func TestGormRepository_CreateUserRoles(t *testing.T) {
@@ -64,3 +66,154 @@ func TestGormRepository_GetAllUserRoles(t *testing.T) {
 		}
 	}
 }
+
+func TestGormRepository_GetUserRole(t *testing.T) {
+	t.Parallel()
+
+	repo, assert, require := setup(t, common)
+
+	sub := &model.UserRole{Name: "get_ur_sub"}
+	r := &model.UserRole{Name: "get_ur", Permissions: []model.RolePermission{{Permission: "p1"}}, Inheritances: []*model.UserRole{sub}}
+	require.NoError(repo.CreateUserRoles(sub, r))
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.GetUserRole("get_ur_not_found")
+		assert.EqualError(err, repository.ErrNotFound.Error())
+		_, err = repo.GetUserRole("")
+		assert.EqualError(err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		role, err := repo.GetUserRole(r.Name)
+		if assert.NoError(err) {
+			assert.Equal(r.Name, role.Name)
+			if assert.Len(role.Permissions, 1) {
+				assert.Equal("p1", role.Permissions[0].Permission)
+			}
+			if assert.Len(role.Inheritances, 1) {
+				assert.Equal(sub.Name, role.Inheritances[0].Name)
+			}
+		}
+	})
+}
+
+func TestGormRepository_UpdateUserRole(t *testing.T) {
+	t.Parallel()
+
+	repo, assert, require := setup(t, common)
+
+	sys := &model.UserRole{Name: "update_ur_sys", System: true}
+	sub := &model.UserRole{Name: "update_ur_sub"}
+	r := &model.UserRole{Name: "update_ur", Permissions: []model.RolePermission{{Permission: "p1"}}}
+	require.NoError(repo.CreateUserRoles(sys, sub, r))
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(repo.UpdateUserRole("update_ur_not_found", repository.UpdateUserRoleArgs{}), repository.ErrNotFound.Error())
+	})
+
+	t.Run("system role", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(repo.UpdateUserRole(sys.Name, repository.UpdateUserRoleArgs{}), repository.ErrForbidden.Error())
+	})
+
+	t.Run("unknown inheritance", func(t *testing.T) {
+		t.Parallel()
+
+		err := repo.UpdateUserRole(sub.Name, repository.UpdateUserRoleArgs{Inheritances: optional.From([]string{"update_ur_not_found"})})
+		assert.True(repository.IsArgError(err))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		err := repo.UpdateUserRole(r.Name, repository.UpdateUserRoleArgs{
+			Permissions:  optional.From([]string{"p2", "p3", "p2"}),
+			Inheritances: optional.From([]string{sub.Name}),
+		})
+		if assert.NoError(err) {
+			role, err := repo.GetUserRole(r.Name)
+			require.NoError(err)
+			perms := make([]string, len(role.Permissions))
+			for i, p := range role.Permissions {
+				perms[i] = p.Permission
+			}
+			assert.ElementsMatch([]string{"p2", "p3"}, perms)
+			if assert.Len(role.Inheritances, 1) {
+				assert.Equal(sub.Name, role.Inheritances[0].Name)
+			}
+		}
+	})
+}
+
+func TestGormRepository_DeleteUserRole(t *testing.T) {
+	t.Parallel()
+
+	repo, assert, require := setup(t, common)
+
+	sys := &model.UserRole{Name: "delete_ur_sys", System: true}
+	assigned := &model.UserRole{Name: "delete_ur_assigned"}
+	inherited := &model.UserRole{Name: "delete_ur_inherited"}
+	inheriting := &model.UserRole{Name: "delete_ur_inheriting", Inheritances: []*model.UserRole{inherited}}
+	channelRole := &model.UserRole{Name: "delete_ur_ch_role"}
+	overridden := &model.UserRole{Name: "delete_ur_overridden"}
+	r := &model.UserRole{Name: "delete_ur", Permissions: []model.RolePermission{{Permission: "p1"}}}
+	require.NoError(repo.CreateUserRoles(sys, assigned, inherited, inheriting, channelRole, overridden, r))
+	user := mustMakeUser(t, repo, rand)
+	require.NoError(repo.UpdateUser(user.GetID(), repository.UpdateUserArgs{Role: optional.From(assigned.Name)}))
+	ch := mustMakeChannel(t, repo, rand)
+	require.NoError(repo.SetChannelRoles(ch.ID, []*model.ChannelRole{{UserID: user.GetID(), Role: channelRole.Name}}))
+	require.NoError(repo.SetChannelPermissionOverrides(ch.ID, []*model.ChannelPermissionOverride{{Role: overridden.Name, Permission: "p1", Allow: true}}))
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(repo.DeleteUserRole("delete_ur_not_found"), repository.ErrNotFound.Error())
+	})
+
+	t.Run("system role", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(repo.DeleteUserRole(sys.Name), repository.ErrForbidden.Error())
+	})
+
+	t.Run("assigned role", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(repo.DeleteUserRole(assigned.Name), repository.ErrForbidden.Error())
+	})
+
+	t.Run("inherited role", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(repo.DeleteUserRole(inherited.Name), repository.ErrForbidden.Error())
+	})
+
+	t.Run("channel role", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(repo.DeleteUserRole(channelRole.Name), repository.ErrForbidden.Error())
+	})
+
+	t.Run("overridden role", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(repo.DeleteUserRole(overridden.Name), repository.ErrForbidden.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		if assert.NoError(repo.DeleteUserRole(r.Name)) {
+			_, err := repo.GetUserRole(r.Name)
+			assert.EqualError(err, repository.ErrNotFound.Error())
+			assert.EqualValues(0, count(t, getDB(repo).Model(&model.RolePermission{}).Where(&model.RolePermission{Role: r.Name})))
+		}
+	})
+}
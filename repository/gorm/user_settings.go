@@ -2,9 +2,11 @@ package gorm
 
 import (
 	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/set"
 )
 
 const defaultNotifyCitation = false
@@ -77,3 +79,188 @@ func (repo *Repository) GetUserSettings(userID uuid.UUID) (*model.UserSettings,
 
 	return &settings, nil
 }
+
+// UpdateDoNotDisturb implements UserSettingsRepository interface
+func (repo *Repository) UpdateDoNotDisturb(userID uuid.UUID, args repository.UpdateDoNotDisturbArgs) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	settings := model.UserSettings{}
+
+	if err := repo.db.First(&settings, "user_id=?", userID).Error; err != nil {
+		err = convertError(err)
+		if err == repository.ErrNotFound {
+			return repo.db.Create(&model.UserSettings{
+				UserID:              userID,
+				NotifyCitation:      defaultNotifyCitation,
+				DoNotDisturbEnabled: args.Enabled,
+				DoNotDisturbStart:   args.Start,
+				DoNotDisturbEnd:     args.End,
+				TimeZone:            args.TimeZone,
+			}).Error
+		}
+		return err
+	}
+	if err := repo.db.Model(&settings).Updates(map[string]interface{}{
+		"do_not_disturb_enabled": args.Enabled,
+		"do_not_disturb_start":   args.Start,
+		"do_not_disturb_end":     args.End,
+		"time_zone":              args.TimeZone,
+	}).Error; err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
+// GetDoNotDisturbEnabledSettings implements UserSettingsRepository interface
+func (repo *Repository) GetDoNotDisturbEnabledSettings(userIDs []uuid.UUID) ([]*model.UserSettings, error) {
+	settings := make([]*model.UserSettings, 0)
+	if len(userIDs) == 0 {
+		return settings, nil
+	}
+	return settings, repo.db.Where("user_id IN ? AND do_not_disturb_enabled = ?", userIDs, true).Find(&settings).Error
+}
+
+// GetNotificationKeywords implements UserSettingsRepository interface
+func (repo *Repository) GetNotificationKeywords(userID uuid.UUID) ([]string, error) {
+	keywords := make([]string, 0)
+	if userID == uuid.Nil {
+		return keywords, nil
+	}
+	return keywords, repo.db.
+		Model(&model.UserNotificationKeyword{}).
+		Where("user_id = ?", userID).
+		Order("keyword").
+		Pluck("keyword", &keywords).
+		Error
+}
+
+// SetNotificationKeywords implements UserSettingsRepository interface
+func (repo *Repository) SetNotificationKeywords(userID uuid.UUID, keywords []string) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.UserNotificationKeyword{}, "user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		added := make(map[string]bool, len(keywords))
+		for _, k := range keywords {
+			if added[k] {
+				continue
+			}
+			added[k] = true
+			if err := tx.Create(&model.UserNotificationKeyword{UserID: userID, Keyword: k}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetUserIDsByNotificationKeyword implements UserSettingsRepository interface
+func (repo *Repository) GetUserIDsByNotificationKeyword(text string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0)
+	if len(text) == 0 {
+		return ids, nil
+	}
+	return ids, repo.db.
+		Model(&model.UserNotificationKeyword{}).
+		Distinct("user_id").
+		Where("LOCATE(keyword, ?) > 0", text).
+		Pluck("user_id", &ids).
+		Error
+}
+
+// GetMutedGroupMentions implements UserSettingsRepository interface
+func (repo *Repository) GetMutedGroupMentions(userID uuid.UUID) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0)
+	if userID == uuid.Nil {
+		return ids, nil
+	}
+	return ids, repo.db.
+		Model(&model.UserGroupMentionMute{}).
+		Where("user_id = ?", userID).
+		Pluck("group_id", &ids).
+		Error
+}
+
+// SetMutedGroupMentions implements UserSettingsRepository interface
+func (repo *Repository) SetMutedGroupMentions(userID uuid.UUID, groupIDs []uuid.UUID) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.UserGroupMentionMute{}, "user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		for id := range set.UUIDSetFromArray(groupIDs) {
+			if err := tx.Create(&model.UserGroupMentionMute{UserID: userID, GroupID: id}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetGroupMentionMutedUserIDs implements UserSettingsRepository interface
+func (repo *Repository) GetGroupMentionMutedUserIDs(groupID uuid.UUID) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0)
+	if groupID == uuid.Nil {
+		return ids, nil
+	}
+	return ids, repo.db.
+		Model(&model.UserGroupMentionMute{}).
+		Where("group_id = ?", groupID).
+		Pluck("user_id", &ids).
+		Error
+}
+
+// GetChannelPushMutes implements UserSettingsRepository interface
+func (repo *Repository) GetChannelPushMutes(userID uuid.UUID) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0)
+	if userID == uuid.Nil {
+		return ids, nil
+	}
+	return ids, repo.db.
+		Model(&model.UserChannelPushMute{}).
+		Where("user_id = ?", userID).
+		Pluck("channel_id", &ids).
+		Error
+}
+
+// SetChannelPushMutes implements UserSettingsRepository interface
+func (repo *Repository) SetChannelPushMutes(userID uuid.UUID, channelIDs []uuid.UUID) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.UserChannelPushMute{}, "user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		for id := range set.UUIDSetFromArray(channelIDs) {
+			if err := tx.Create(&model.UserChannelPushMute{UserID: userID, ChannelID: id}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetChannelPushMutedUserIDs implements UserSettingsRepository interface
+func (repo *Repository) GetChannelPushMutedUserIDs(channelID uuid.UUID) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0)
+	if channelID == uuid.Nil {
+		return ids, nil
+	}
+	return ids, repo.db.
+		Model(&model.UserChannelPushMute{}).
+		Where("channel_id = ?", channelID).
+		Pluck("user_id", &ids).
+		Error
+}
@@ -0,0 +1,142 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/repository"
+	random2 "github.com/traPtitech/traQ/utils/random"
+)
+
+func TestRepositoryImpl_UpdateDoNotDisturb(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.UpdateDoNotDisturb(uuid.Nil, repository.UpdateDoNotDisturbArgs{}), repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		require.NoError(repo.UpdateDoNotDisturb(user.GetID(), repository.UpdateDoNotDisturbArgs{
+			Enabled:  true,
+			Start:    "22:00",
+			End:      "07:00",
+			TimeZone: "Asia/Tokyo",
+		}))
+		us, err := repo.GetUserSettings(user.GetID())
+		require.NoError(err)
+		assert.True(us.DoNotDisturbEnabled)
+		assert.Equal("22:00", us.DoNotDisturbStart)
+		assert.Equal("07:00", us.DoNotDisturbEnd)
+		assert.Equal("Asia/Tokyo", us.TimeZone)
+
+		settings, err := repo.GetDoNotDisturbEnabledSettings([]uuid.UUID{user.GetID(), uuid.Must(uuid.NewV4())})
+		require.NoError(err)
+		if assert.Len(settings, 1) {
+			assert.Equal(user.GetID(), settings[0].UserID)
+		}
+		settings, err = repo.GetDoNotDisturbEnabledSettings(nil)
+		require.NoError(err)
+		assert.Empty(settings)
+
+		require.NoError(repo.UpdateDoNotDisturb(user.GetID(), repository.UpdateDoNotDisturbArgs{}))
+		us, err = repo.GetUserSettings(user.GetID())
+		require.NoError(err)
+		assert.False(us.DoNotDisturbEnabled)
+	})
+}
+
+func TestRepositoryImpl_SetNotificationKeywords(t *testing.T) {
+	t.Parallel()
+	repo, _, _ := setup(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.SetNotificationKeywords(uuid.Nil, nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		user := mustMakeUser(t, repo, rand)
+		keyword := random2.AlphaNumeric(20)
+
+		require.NoError(repo.SetNotificationKeywords(user.GetID(), []string{keyword, keyword, "b"}))
+		keywords, err := repo.GetNotificationKeywords(user.GetID())
+		require.NoError(err)
+		assert.ElementsMatch([]string{keyword, "b"}, keywords)
+
+		ids, err := repo.GetUserIDsByNotificationKeyword("hello " + keyword + "!")
+		require.NoError(err)
+		assert.Contains(ids, user.GetID())
+
+		require.NoError(repo.SetNotificationKeywords(user.GetID(), []string{}))
+		keywords, err = repo.GetNotificationKeywords(user.GetID())
+		require.NoError(err)
+		assert.Empty(keywords)
+	})
+}
+
+func TestRepositoryImpl_SetMutedGroupMentions(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.SetMutedGroupMentions(uuid.Nil, nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		g := mustMakeUserGroup(t, repo, rand, user.GetID())
+
+		require.NoError(repo.SetMutedGroupMentions(user.GetID(), []uuid.UUID{g.ID, g.ID}))
+		groups, err := repo.GetMutedGroupMentions(user.GetID())
+		require.NoError(err)
+		assert.ElementsMatch([]uuid.UUID{g.ID}, groups)
+
+		users, err := repo.GetGroupMentionMutedUserIDs(g.ID)
+		require.NoError(err)
+		assert.ElementsMatch([]uuid.UUID{user.GetID()}, users)
+	})
+}
+
+func TestRepositoryImpl_SetChannelPushMutes(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.SetChannelPushMutes(uuid.Nil, nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+
+		require.NoError(repo.SetChannelPushMutes(user.GetID(), []uuid.UUID{channel.ID}))
+		channels, err := repo.GetChannelPushMutes(user.GetID())
+		require.NoError(err)
+		assert.ElementsMatch([]uuid.UUID{channel.ID}, channels)
+
+		users, err := repo.GetChannelPushMutedUserIDs(channel.ID)
+		require.NoError(err)
+		assert.ElementsMatch([]uuid.UUID{user.GetID()}, users)
+
+		require.NoError(repo.SetChannelPushMutes(user.GetID(), nil))
+		channels, err = repo.GetChannelPushMutes(user.GetID())
+		require.NoError(err)
+		assert.Empty(channels)
+	})
+}
@@ -111,6 +111,19 @@ func TestRepositoryImpl_GetUsers(t *testing.T) {
 		})
 	}
 
+	t.Run("IDIn", func(t *testing.T) {
+		t.Parallel()
+
+		uids, err := repo.GetUserIDs(repository.UsersQuery{}.IDIn(us[:2]))
+		if assert.NoError(err) {
+			assert.ElementsMatch(uids, us[:2])
+		}
+		uids, err = repo.GetUserIDs(repository.UsersQuery{}.IDIn([]uuid.UUID{}))
+		if assert.NoError(err) {
+			assert.Empty(uids)
+		}
+	})
+
 	t.Run("GetUsers", func(t *testing.T) {
 		t.Parallel()
 
@@ -119,6 +119,9 @@ func (repo *Repository) UpdateWebhook(id uuid.UUID, args repository.UpdateWebhoo
 		if args.Secret.Valid {
 			changes["secret"] = args.Secret.V
 		}
+		if args.Template.Valid {
+			changes["template"] = args.Template.V
+		}
 		if args.CreatorID.Valid {
 			// 作成者検証
 			user, err := repo.GetUser(args.CreatorID.V, false)
@@ -123,6 +123,7 @@ func TestRepositoryImpl_UpdateWebhook(t *testing.T) {
 			Description: optional.From("new description"),
 			Name:        optional.From("new name"),
 			Secret:      optional.From("new secret"),
+			Template:    optional.From("{{.text}}"),
 			ChannelID:   optional.From(ch.ID),
 			CreatorID:   optional.From(user.GetID()),
 		})
@@ -132,6 +133,7 @@ func TestRepositoryImpl_UpdateWebhook(t *testing.T) {
 			assert.Equal("new name", wb.GetName())
 			assert.Equal("new description", wb.GetDescription())
 			assert.Equal("new secret", wb.GetSecret())
+			assert.Equal("{{.text}}", wb.GetTemplate())
 			assert.Equal(user.GetID(), wb.GetCreatorID())
 			assert.Equal(ch.ID, wb.GetChannelID())
 		}
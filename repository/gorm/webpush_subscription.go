@@ -0,0 +1,64 @@
+package gorm
+
+import (
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+// RegisterWebPushSubscription implements WebPushSubscriptionRepository interface.
+func (repo *Repository) RegisterWebPushSubscription(userID uuid.UUID, args repository.RegisterWebPushSubscriptionArgs) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	if len(args.Endpoint) == 0 {
+		return repository.ArgError("Endpoint", "endpoint is empty")
+	}
+
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var s model.WebPushSubscription
+		if err := tx.First(&s, &model.WebPushSubscription{Endpoint: args.Endpoint}).Error; err == nil {
+			if s.UserID != userID {
+				return repository.ArgError("Endpoint", "the Endpoint has already been associated with other user")
+			}
+			return tx.Model(&s).Updates(map[string]interface{}{
+				"p256dh": args.P256dh,
+				"auth":   args.Auth,
+			}).Error
+		} else if err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		return tx.Create(&model.WebPushSubscription{
+			Endpoint: args.Endpoint,
+			UserID:   userID,
+			P256dh:   args.P256dh,
+			Auth:     args.Auth,
+		}).Error
+	})
+}
+
+// GetWebPushSubscriptions implements WebPushSubscriptionRepository interface.
+func (repo *Repository) GetWebPushSubscriptions(userIDs set.UUID) (map[uuid.UUID][]*model.WebPushSubscription, error) {
+	var tmp []*model.WebPushSubscription
+	if err := repo.db.Where("user_id IN (?)", userIDs.StringArray()).Find(&tmp).Error; err != nil {
+		return nil, err
+	}
+
+	subs := make(map[uuid.UUID][]*model.WebPushSubscription, len(userIDs))
+	for _, s := range tmp {
+		subs[s.UserID] = append(subs[s.UserID], s)
+	}
+	return subs, nil
+}
+
+// DeleteWebPushSubscriptions implements WebPushSubscriptionRepository interface.
+func (repo *Repository) DeleteWebPushSubscriptions(endpoints []string) error {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return repo.db.Where("endpoint IN (?)", endpoints).Delete(&model.WebPushSubscription{}).Error
+}
@@ -0,0 +1,70 @@
+package gorm
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	random2 "github.com/traPtitech/traQ/utils/random"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+func TestRepositoryImpl_RegisterWebPushSubscription(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, common)
+
+	id1 := mustMakeUser(t, repo, rand).GetID()
+	id2 := mustMakeUser(t, repo, rand).GetID()
+	endpoint1 := "https://push.example.com/" + random2.AlphaNumeric(20)
+	endpoint2 := "https://push.example.com/" + random2.AlphaNumeric(20)
+
+	cases := []struct {
+		user     uuid.UUID
+		endpoint string
+		error    bool
+	}{
+		{id1, endpoint1, false},
+		{id2, endpoint2, false},
+		{id2, endpoint2, false},
+		{id1, endpoint2, true},
+		{uuid.Nil, endpoint2, true},
+		{id1, "", true},
+	}
+
+	for _, v := range cases {
+		err := repo.RegisterWebPushSubscription(v.user, repository.RegisterWebPushSubscriptionArgs{
+			Endpoint: v.endpoint,
+			P256dh:   "p256dh",
+			Auth:     random2.AlphaNumeric(10),
+		})
+		if v.error {
+			assert.Error(err)
+		} else {
+			assert.NoError(err)
+		}
+	}
+
+	assert.EqualValues(2, count(t, getDB(repo).Model(model.WebPushSubscription{}).Where("user_id IN (?, ?)", id1, id2)))
+
+	subs, err := repo.GetWebPushSubscriptions(set.UUIDSetFromArray([]uuid.UUID{id1, id2}))
+	require.NoError(err)
+	if assert.Len(subs[id1], 1) {
+		assert.Equal(endpoint1, subs[id1][0].Endpoint)
+	}
+	assert.Len(subs[id2], 1)
+}
+
+func TestRepositoryImpl_DeleteWebPushSubscriptions(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, common)
+
+	id := mustMakeUser(t, repo, rand).GetID()
+	endpoint := "https://push.example.com/" + random2.AlphaNumeric(20)
+	require.NoError(repo.RegisterWebPushSubscription(id, repository.RegisterWebPushSubscriptionArgs{Endpoint: endpoint, P256dh: "p256dh", Auth: "auth"}))
+
+	assert.NoError(repo.DeleteWebPushSubscriptions([]string{endpoint}))
+	assert.EqualValues(0, count(t, getDB(repo).Model(model.WebPushSubscription{}).Where("user_id = ?", id)))
+	assert.NoError(repo.DeleteWebPushSubscriptions(nil))
+}
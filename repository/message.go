@@ -13,19 +13,26 @@ import (
 
 // MessagesQuery GetMessages用クエリ
 type MessagesQuery struct {
-	IDIn    optional.Of[[]uuid.UUID]
-	User    uuid.UUID
+	IDIn optional.Of[[]uuid.UUID]
+	User uuid.UUID
+	// Channel 指定したチャンネルのメッセージを指定
+	//
+	// IncludeRepliesがfalseの場合、スレッドの返信メッセージは含まれません。
 	Channel uuid.UUID
+	// IncludeReplies Channel指定時にスレッドの返信メッセージも含めるかどうか
+	IncludeReplies bool
 	// ChannelsSubscribedByUser 指定したユーザーが購読しているチャンネルのメッセージを指定
 	ChannelsSubscribedByUser uuid.UUID
-	Since                    optional.Of[time.Time]
-	Until                    optional.Of[time.Time]
-	Inclusive                bool
-	Limit                    int
-	Offset                   int
-	Asc                      bool
-	ExcludeDMs               bool
-	DisablePreload           bool
+	// Parent 指定したメッセージを親とするスレッドの返信メッセージを指定
+	Parent         uuid.UUID
+	Since          optional.Of[time.Time]
+	Until          optional.Of[time.Time]
+	Inclusive      bool
+	Limit          int
+	Offset         int
+	Asc            bool
+	ExcludeDMs     bool
+	DisablePreload bool
 }
 
 // ChannelLatestMessagesQuery GetChannelLatestMessages用クエリ
@@ -44,6 +51,14 @@ type MessageRepository interface {
 	// 引数にuuid.Nilを指定するとErrNilIDを返します。
 	// DBによるエラーを返すことがあります。
 	CreateMessage(userID, channelID uuid.UUID, text string) (*model.Message, error)
+	// CreateReplyMessage 指定したメッセージのスレッドに返信メッセージを作成します
+	//
+	// 成功した場合、メッセージとnilを返します。
+	// 返信メッセージは親メッセージと同じチャンネルに作成されます。
+	// 存在しない親メッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateReplyMessage(userID, parentID uuid.UUID, text string) (*model.Message, error)
 	// UpdateMessage 指定したメッセージを更新します
 	//
 	// 成功した場合、nilを返します。
@@ -51,6 +66,12 @@ type MessageRepository interface {
 	// 引数にuuid.Nilを指定するとErrNilIDを返します。
 	// DBによるエラーを返すことがあります。
 	UpdateMessage(messageID uuid.UUID, text string) error
+	// GetMessageRevisions 指定したメッセージの編集前の版を全て日時の昇順で取得します
+	//
+	// 成功した場合、版の配列とnilを返します。
+	// 存在しないメッセージ・編集されていないメッセージを指定した場合は空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetMessageRevisions(messageID uuid.UUID) ([]*model.MessageRevision, error)
 	// DeleteMessage 指定したメッセージを削除します
 	//
 	// 成功した場合、nilを返します。
@@ -58,6 +79,15 @@ type MessageRepository interface {
 	// 引数にuuid.Nilを指定するとErrNilIDを返します。
 	// DBによるエラーを返すことがあります。
 	DeleteMessage(messageID uuid.UUID) error
+	// MoveMessages 指定したチャンネルのメッセージを別のチャンネルに移動します
+	//
+	// 成功した場合、移動したメッセージのIDの配列とnilを返します。
+	// messageIDsを指定した場合、指定したメッセージとそのスレッドの返信メッセージ、およびそれらに添付された移動元チャンネルのファイルを移動します。
+	// messageIDsを指定しなかった場合、削除されたメッセージを含む全てのメッセージとチャンネルのファイルを移動します。
+	// メッセージの更新日時は変更されません。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	MoveMessages(fromChannelID, toChannelID uuid.UUID, messageIDs optional.Of[[]uuid.UUID]) ([]uuid.UUID, error)
 	// GetMessageByID 指定したメッセージを取得します
 	//
 	// 成功した場合、メッセージとnilを返します。
@@ -70,6 +100,11 @@ type MessageRepository interface {
 	// 指定した範囲内にlimitを超えてメッセージが存在していた場合、trueを返します。
 	// DBによるエラーを返すことがあります。
 	GetMessages(query MessagesQuery) (messages []*model.Message, more bool, err error)
+	// GetThreadParticipantIDs 指定したメッセージを親とするスレッドに返信したユーザーのIDを取得します
+	//
+	// 成功した場合、重複のないユーザーIDの配列を最大limit件返します。
+	// DBによるエラーを返すことがあります。
+	GetThreadParticipantIDs(parentID uuid.UUID, limit int) ([]uuid.UUID, error)
 	// GetUpdatedMessagesAfter 指定した時間より後に更新されたメッセージを取得します
 	//
 	// 成功した場合、updatedAtで昇順ソートされたメッセージの配列を返します。
@@ -123,6 +158,14 @@ type MessageRepository interface {
 	// 引数にuuid.Nilを指定するとErrNilIDを返します。
 	// DBによるエラーを返すことがあります。
 	RemoveStampFromMessage(messageID, stampID, userID uuid.UUID) (err error)
+	// SetMessageComponents 指定したメッセージのコンポーネントを置き換えます
+	//
+	// 成功した場合、nilを返します。
+	// 空配列を指定した場合、コンポーネントを全て削除します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	SetMessageComponents(messageID uuid.UUID, components model.MessageComponentList) error
 }
 
 // UserUnreadChannel ユーザーの未読チャンネル構造体
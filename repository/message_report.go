@@ -4,22 +4,36 @@ import (
 	"github.com/gofrs/uuid"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
+// MessageReportsQuery GetMessageReports用クエリ
+type MessageReportsQuery struct {
+	Status optional.Of[model.MessageReportStatus]
+	Offset int
+	Limit  int
+}
+
 // MessageReportRepository メッセージ通報リポジトリ
 type MessageReportRepository interface {
 	// CreateMessageReport 指定したユーザーによる指定したメッセージの通報を登録します
 	//
-	// 成功した場合、nilを返します。
+	// 成功した場合、メッセージ通報とnilを返します。
 	// 既に通報がされていた場合、ErrAlreadyExistsを返します。
 	// 引数にuuid.Nilを指定するとErrNilIDを返します。
 	// DBによるエラーを返すことがあります。
-	CreateMessageReport(messageID, reporterID uuid.UUID, reason string) error
-	// GetMessageReports メッセージ通報を通報日時の昇順で取得します
+	CreateMessageReport(messageID, reporterID uuid.UUID, reason string) (*model.MessageReport, error)
+	// GetMessageReport 指定したメッセージ通報を取得します
+	//
+	// 成功した場合、メッセージ通報とnilを返します。
+	// 存在しない通報を指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetMessageReport(reportID uuid.UUID) (*model.MessageReport, error)
+	// GetMessageReports 指定したクエリでメッセージ通報を通報日時の昇順で取得します
 	//
 	// 成功した場合、メッセージ通報の配列とnilを返します。負のoffset, limitは無視されます。
 	// DBによるエラーを返すことがあります。
-	GetMessageReports(offset, limit int) ([]*model.MessageReport, error)
+	GetMessageReports(query MessageReportsQuery) ([]*model.MessageReport, error)
 	// GetMessageReportsByMessageID 指定したメッセージのメッセージ通報を全て取得します
 	//
 	// 成功した場合、メッセージ通報の配列とnilを返します。
@@ -32,4 +46,12 @@ type MessageReportRepository interface {
 	// 存在しないユーザーを指定した場合は空配列とnilを返します。
 	// DBによるエラーを返すことがあります。
 	GetMessageReportsByReporterID(reporterID uuid.UUID) ([]*model.MessageReport, error)
+	// UpdateMessageReportStatus 指定した未対応のメッセージ通報の対応状態を更新します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない通報を指定した場合、ErrNotFoundを返します。
+	// 既に対応済みの通報を指定した場合、ErrAlreadyExistsを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateMessageReportStatus(reportID, handlerID uuid.UUID, status model.MessageReportStatus) error
 }
@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: bot_event_delivery.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/traPtitech/traQ/model"
+)
+
+// MockBotEventDeliveryRepository is a mock of BotEventDeliveryRepository interface.
+type MockBotEventDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBotEventDeliveryRepositoryMockRecorder
+}
+
+// MockBotEventDeliveryRepositoryMockRecorder is the mock recorder for MockBotEventDeliveryRepository.
+type MockBotEventDeliveryRepositoryMockRecorder struct {
+	mock *MockBotEventDeliveryRepository
+}
+
+// NewMockBotEventDeliveryRepository creates a new mock instance.
+func NewMockBotEventDeliveryRepository(ctrl *gomock.Controller) *MockBotEventDeliveryRepository {
+	mock := &MockBotEventDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockBotEventDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBotEventDeliveryRepository) EXPECT() *MockBotEventDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimBotEventDelivery mocks base method.
+func (m *MockBotEventDeliveryRepository) ClaimBotEventDelivery(id uuid.UUID, leaseUntil time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimBotEventDelivery", id, leaseUntil)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimBotEventDelivery indicates an expected call of ClaimBotEventDelivery.
+func (mr *MockBotEventDeliveryRepositoryMockRecorder) ClaimBotEventDelivery(id, leaseUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimBotEventDelivery", reflect.TypeOf((*MockBotEventDeliveryRepository)(nil).ClaimBotEventDelivery), id, leaseUntil)
+}
+
+// CountBotEventDeliveries mocks base method.
+func (m *MockBotEventDeliveryRepository) CountBotEventDeliveries(botID uuid.UUID, status model.BotEventDeliveryStatus) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBotEventDeliveries", botID, status)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBotEventDeliveries indicates an expected call of CountBotEventDeliveries.
+func (mr *MockBotEventDeliveryRepositoryMockRecorder) CountBotEventDeliveries(botID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBotEventDeliveries", reflect.TypeOf((*MockBotEventDeliveryRepository)(nil).CountBotEventDeliveries), botID, status)
+}
+
+// DeleteBotEventDelivery mocks base method.
+func (m *MockBotEventDeliveryRepository) DeleteBotEventDelivery(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBotEventDelivery", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBotEventDelivery indicates an expected call of DeleteBotEventDelivery.
+func (mr *MockBotEventDeliveryRepositoryMockRecorder) DeleteBotEventDelivery(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBotEventDelivery", reflect.TypeOf((*MockBotEventDeliveryRepository)(nil).DeleteBotEventDelivery), id)
+}
+
+// GetBotEventDeliveries mocks base method.
+func (m *MockBotEventDeliveryRepository) GetBotEventDeliveries(botID uuid.UUID, status model.BotEventDeliveryStatus, limit, offset int) ([]*model.BotEventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBotEventDeliveries", botID, status, limit, offset)
+	ret0, _ := ret[0].([]*model.BotEventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBotEventDeliveries indicates an expected call of GetBotEventDeliveries.
+func (mr *MockBotEventDeliveryRepositoryMockRecorder) GetBotEventDeliveries(botID, status, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBotEventDeliveries", reflect.TypeOf((*MockBotEventDeliveryRepository)(nil).GetBotEventDeliveries), botID, status, limit, offset)
+}
+
+// GetRetryableBotEventDeliveries mocks base method.
+func (m *MockBotEventDeliveryRepository) GetRetryableBotEventDeliveries(now time.Time, limit int) ([]*model.BotEventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRetryableBotEventDeliveries", now, limit)
+	ret0, _ := ret[0].([]*model.BotEventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRetryableBotEventDeliveries indicates an expected call of GetRetryableBotEventDeliveries.
+func (mr *MockBotEventDeliveryRepositoryMockRecorder) GetRetryableBotEventDeliveries(now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetryableBotEventDeliveries", reflect.TypeOf((*MockBotEventDeliveryRepository)(nil).GetRetryableBotEventDeliveries), now, limit)
+}
+
+// PurgeBotEventDeliveries mocks base method.
+func (m *MockBotEventDeliveryRepository) PurgeBotEventDeliveries(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeBotEventDeliveries", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeBotEventDeliveries indicates an expected call of PurgeBotEventDeliveries.
+func (mr *MockBotEventDeliveryRepositoryMockRecorder) PurgeBotEventDeliveries(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeBotEventDeliveries", reflect.TypeOf((*MockBotEventDeliveryRepository)(nil).PurgeBotEventDeliveries), before)
+}
+
+// RequeueBotEventDeliveries mocks base method.
+func (m *MockBotEventDeliveryRepository) RequeueBotEventDeliveries(botID uuid.UUID, ids []uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueBotEventDeliveries", botID, ids)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequeueBotEventDeliveries indicates an expected call of RequeueBotEventDeliveries.
+func (mr *MockBotEventDeliveryRepositoryMockRecorder) RequeueBotEventDeliveries(botID, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueBotEventDeliveries", reflect.TypeOf((*MockBotEventDeliveryRepository)(nil).RequeueBotEventDeliveries), botID, ids)
+}
+
+// SaveBotEventDelivery mocks base method.
+func (m *MockBotEventDeliveryRepository) SaveBotEventDelivery(d *model.BotEventDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBotEventDelivery", d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBotEventDelivery indicates an expected call of SaveBotEventDelivery.
+func (mr *MockBotEventDeliveryRepositoryMockRecorder) SaveBotEventDelivery(d interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBotEventDelivery", reflect.TypeOf((*MockBotEventDeliveryRepository)(nil).SaveBotEventDelivery), d)
+}
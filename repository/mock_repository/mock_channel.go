@@ -69,6 +69,22 @@ func (mr *MockChannelRepositoryMockRecorder) ChangeChannelSubscription(channelID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeChannelSubscription", reflect.TypeOf((*MockChannelRepository)(nil).ChangeChannelSubscription), channelID, args)
 }
 
+// ChangePrivateChannelMembers mocks base method.
+func (m *MockChannelRepository) ChangePrivateChannelMembers(channelID uuid.UUID, add, remove []uuid.UUID) ([]uuid.UUID, []uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePrivateChannelMembers", channelID, add, remove)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].([]uuid.UUID)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ChangePrivateChannelMembers indicates an expected call of ChangePrivateChannelMembers.
+func (mr *MockChannelRepositoryMockRecorder) ChangePrivateChannelMembers(channelID, add, remove interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePrivateChannelMembers", reflect.TypeOf((*MockChannelRepository)(nil).ChangePrivateChannelMembers), channelID, add, remove)
+}
+
 // CreateChannel mocks base method.
 func (m *MockChannelRepository) CreateChannel(ch model.Channel, privateMembers set.UUID, dm bool) (*model.Channel, error) {
 	m.ctrl.T.Helper()
@@ -84,6 +100,21 @@ func (mr *MockChannelRepositoryMockRecorder) CreateChannel(ch, privateMembers, d
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannel", reflect.TypeOf((*MockChannelRepository)(nil).CreateChannel), ch, privateMembers, dm)
 }
 
+// DeleteChannels mocks base method.
+func (m *MockChannelRepository) DeleteChannels(ids []uuid.UUID) ([]*model.FileMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannels", ids)
+	ret0, _ := ret[0].([]*model.FileMeta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteChannels indicates an expected call of DeleteChannels.
+func (mr *MockChannelRepositoryMockRecorder) DeleteChannels(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannels", reflect.TypeOf((*MockChannelRepository)(nil).DeleteChannels), ids)
+}
+
 // GetChannel mocks base method.
 func (m *MockChannelRepository) GetChannel(channelID uuid.UUID) (*model.Channel, error) {
 	m.ctrl.T.Helper()
@@ -190,6 +221,21 @@ func (mr *MockChannelRepositoryMockRecorder) GetPrivateChannelMemberIDs(channelI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateChannelMemberIDs", reflect.TypeOf((*MockChannelRepository)(nil).GetPrivateChannelMemberIDs), channelID)
 }
 
+// GetPrivateChannelsByUser mocks base method.
+func (m *MockChannelRepository) GetPrivateChannelsByUser(userID uuid.UUID) ([]*model.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateChannelsByUser", userID)
+	ret0, _ := ret[0].([]*model.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateChannelsByUser indicates an expected call of GetPrivateChannelsByUser.
+func (mr *MockChannelRepositoryMockRecorder) GetPrivateChannelsByUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateChannelsByUser", reflect.TypeOf((*MockChannelRepository)(nil).GetPrivateChannelsByUser), userID)
+}
+
 // GetPublicChannels mocks base method.
 func (m *MockChannelRepository) GetPublicChannels() ([]*model.Channel, error) {
 	m.ctrl.T.Helper()
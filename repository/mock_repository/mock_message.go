@@ -12,6 +12,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/traPtitech/traQ/model"
 	repository "github.com/traPtitech/traQ/repository"
+	optional "github.com/traPtitech/traQ/utils/optional"
 )
 
 // MockMessageRepository is a mock of MessageRepository interface.
@@ -67,6 +68,21 @@ func (mr *MockMessageRepositoryMockRecorder) CreateMessage(userID, channelID, te
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockMessageRepository)(nil).CreateMessage), userID, channelID, text)
 }
 
+// CreateReplyMessage mocks base method.
+func (m *MockMessageRepository) CreateReplyMessage(userID, parentID uuid.UUID, text string) (*model.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReplyMessage", userID, parentID, text)
+	ret0, _ := ret[0].(*model.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReplyMessage indicates an expected call of CreateReplyMessage.
+func (mr *MockMessageRepositoryMockRecorder) CreateReplyMessage(userID, parentID, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReplyMessage", reflect.TypeOf((*MockMessageRepository)(nil).CreateReplyMessage), userID, parentID, text)
+}
+
 // DeleteMessage mocks base method.
 func (m *MockMessageRepository) DeleteMessage(messageID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -141,6 +157,21 @@ func (mr *MockMessageRepositoryMockRecorder) GetMessageByID(messageID interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageByID", reflect.TypeOf((*MockMessageRepository)(nil).GetMessageByID), messageID)
 }
 
+// GetMessageRevisions mocks base method.
+func (m *MockMessageRepository) GetMessageRevisions(messageID uuid.UUID) ([]*model.MessageRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageRevisions", messageID)
+	ret0, _ := ret[0].([]*model.MessageRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageRevisions indicates an expected call of GetMessageRevisions.
+func (mr *MockMessageRepositoryMockRecorder) GetMessageRevisions(messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageRevisions", reflect.TypeOf((*MockMessageRepository)(nil).GetMessageRevisions), messageID)
+}
+
 // GetMessages mocks base method.
 func (m *MockMessageRepository) GetMessages(query repository.MessagesQuery) ([]*model.Message, bool, error) {
 	m.ctrl.T.Helper()
@@ -157,6 +188,21 @@ func (mr *MockMessageRepositoryMockRecorder) GetMessages(query interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockMessageRepository)(nil).GetMessages), query)
 }
 
+// GetThreadParticipantIDs mocks base method.
+func (m *MockMessageRepository) GetThreadParticipantIDs(parentID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThreadParticipantIDs", parentID, limit)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetThreadParticipantIDs indicates an expected call of GetThreadParticipantIDs.
+func (mr *MockMessageRepositoryMockRecorder) GetThreadParticipantIDs(parentID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThreadParticipantIDs", reflect.TypeOf((*MockMessageRepository)(nil).GetThreadParticipantIDs), parentID, limit)
+}
+
 // GetUnreadMessagesByUserID mocks base method.
 func (m *MockMessageRepository) GetUnreadMessagesByUserID(userID uuid.UUID) ([]*model.Message, error) {
 	m.ctrl.T.Helper()
@@ -203,6 +249,21 @@ func (mr *MockMessageRepositoryMockRecorder) GetUserUnreadChannels(userID interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserUnreadChannels", reflect.TypeOf((*MockMessageRepository)(nil).GetUserUnreadChannels), userID)
 }
 
+// MoveMessages mocks base method.
+func (m *MockMessageRepository) MoveMessages(fromChannelID, toChannelID uuid.UUID, messageIDs optional.Of[[]uuid.UUID]) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveMessages", fromChannelID, toChannelID, messageIDs)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveMessages indicates an expected call of MoveMessages.
+func (mr *MockMessageRepositoryMockRecorder) MoveMessages(fromChannelID, toChannelID, messageIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveMessages", reflect.TypeOf((*MockMessageRepository)(nil).MoveMessages), fromChannelID, toChannelID, messageIDs)
+}
+
 // RemoveStampFromMessage mocks base method.
 func (m *MockMessageRepository) RemoveStampFromMessage(messageID, stampID, userID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -231,6 +292,20 @@ func (mr *MockMessageRepositoryMockRecorder) SetMessageUnread(userID, messageID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMessageUnread", reflect.TypeOf((*MockMessageRepository)(nil).SetMessageUnread), userID, messageID, noticeable)
 }
 
+// SetMessageComponents mocks base method.
+func (m *MockMessageRepository) SetMessageComponents(messageID uuid.UUID, components model.MessageComponentList) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMessageComponents", messageID, components)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMessageComponents indicates an expected call of SetMessageComponents.
+func (mr *MockMessageRepositoryMockRecorder) SetMessageComponents(messageID, components interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMessageComponents", reflect.TypeOf((*MockMessageRepository)(nil).SetMessageComponents), messageID, components)
+}
+
 // UpdateMessage mocks base method.
 func (m *MockMessageRepository) UpdateMessage(messageID uuid.UUID, text string) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,184 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stamp.go
+
+// Package mock_repository is a generated GoMock package.
+package mock_repository
+
+import (
+	reflect "reflect"
+
+	uuid "github.com/gofrs/uuid"
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/traPtitech/traQ/model"
+	repository "github.com/traPtitech/traQ/repository"
+)
+
+// MockStampRepository is a mock of StampRepository interface.
+type MockStampRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStampRepositoryMockRecorder
+}
+
+// MockStampRepositoryMockRecorder is the mock recorder for MockStampRepository.
+type MockStampRepositoryMockRecorder struct {
+	mock *MockStampRepository
+}
+
+// NewMockStampRepository creates a new mock instance.
+func NewMockStampRepository(ctrl *gomock.Controller) *MockStampRepository {
+	mock := &MockStampRepository{ctrl: ctrl}
+	mock.recorder = &MockStampRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStampRepository) EXPECT() *MockStampRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateStamp mocks base method.
+func (m *MockStampRepository) CreateStamp(args repository.CreateStampArgs) (*model.Stamp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStamp", args)
+	ret0, _ := ret[0].(*model.Stamp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStamp indicates an expected call of CreateStamp.
+func (mr *MockStampRepositoryMockRecorder) CreateStamp(args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStamp", reflect.TypeOf((*MockStampRepository)(nil).CreateStamp), args)
+}
+
+// DeleteStamp mocks base method.
+func (m *MockStampRepository) DeleteStamp(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStamp", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStamp indicates an expected call of DeleteStamp.
+func (mr *MockStampRepositoryMockRecorder) DeleteStamp(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStamp", reflect.TypeOf((*MockStampRepository)(nil).DeleteStamp), id)
+}
+
+// ExistStamps mocks base method.
+func (m *MockStampRepository) ExistStamps(stampIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistStamps", stampIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExistStamps indicates an expected call of ExistStamps.
+func (mr *MockStampRepositoryMockRecorder) ExistStamps(stampIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistStamps", reflect.TypeOf((*MockStampRepository)(nil).ExistStamps), stampIDs)
+}
+
+// GetAllStampsWithThumbnail mocks base method.
+func (m *MockStampRepository) GetAllStampsWithThumbnail(stampType repository.StampType) ([]*model.StampWithThumbnail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllStampsWithThumbnail", stampType)
+	ret0, _ := ret[0].([]*model.StampWithThumbnail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllStampsWithThumbnail indicates an expected call of GetAllStampsWithThumbnail.
+func (mr *MockStampRepositoryMockRecorder) GetAllStampsWithThumbnail(stampType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllStampsWithThumbnail", reflect.TypeOf((*MockStampRepository)(nil).GetAllStampsWithThumbnail), stampType)
+}
+
+// GetStamp mocks base method.
+func (m *MockStampRepository) GetStamp(id uuid.UUID) (*model.Stamp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStamp", id)
+	ret0, _ := ret[0].(*model.Stamp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStamp indicates an expected call of GetStamp.
+func (mr *MockStampRepositoryMockRecorder) GetStamp(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStamp", reflect.TypeOf((*MockStampRepository)(nil).GetStamp), id)
+}
+
+// GetStampByName mocks base method.
+func (m *MockStampRepository) GetStampByName(name string) (*model.Stamp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStampByName", name)
+	ret0, _ := ret[0].(*model.Stamp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStampByName indicates an expected call of GetStampByName.
+func (mr *MockStampRepositoryMockRecorder) GetStampByName(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStampByName", reflect.TypeOf((*MockStampRepository)(nil).GetStampByName), name)
+}
+
+// GetStampStats mocks base method.
+func (m *MockStampRepository) GetStampStats(stampID uuid.UUID) (*repository.StampStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStampStats", stampID)
+	ret0, _ := ret[0].(*repository.StampStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStampStats indicates an expected call of GetStampStats.
+func (mr *MockStampRepositoryMockRecorder) GetStampStats(stampID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStampStats", reflect.TypeOf((*MockStampRepository)(nil).GetStampStats), stampID)
+}
+
+// GetUserStampHistory mocks base method.
+func (m *MockStampRepository) GetUserStampHistory(userID uuid.UUID, limit int) ([]*repository.UserStampHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserStampHistory", userID, limit)
+	ret0, _ := ret[0].([]*repository.UserStampHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserStampHistory indicates an expected call of GetUserStampHistory.
+func (mr *MockStampRepositoryMockRecorder) GetUserStampHistory(userID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserStampHistory", reflect.TypeOf((*MockStampRepository)(nil).GetUserStampHistory), userID, limit)
+}
+
+// StampExists mocks base method.
+func (m *MockStampRepository) StampExists(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StampExists", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StampExists indicates an expected call of StampExists.
+func (mr *MockStampRepositoryMockRecorder) StampExists(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StampExists", reflect.TypeOf((*MockStampRepository)(nil).StampExists), id)
+}
+
+// UpdateStamp mocks base method.
+func (m *MockStampRepository) UpdateStamp(id uuid.UUID, args repository.UpdateStampArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStamp", id, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStamp indicates an expected call of UpdateStamp.
+func (mr *MockStampRepositoryMockRecorder) UpdateStamp(id, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStamp", reflect.TypeOf((*MockStampRepository)(nil).UpdateStamp), id, args)
+}
@@ -9,6 +9,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/traPtitech/traQ/model"
+	repository "github.com/traPtitech/traQ/repository"
 )
 
 // MockUserRoleRepository is a mock of UserRoleRepository interface.
@@ -52,6 +53,20 @@ func (mr *MockUserRoleRepositoryMockRecorder) CreateUserRoles(roles ...interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserRoles", reflect.TypeOf((*MockUserRoleRepository)(nil).CreateUserRoles), roles...)
 }
 
+// DeleteUserRole mocks base method.
+func (m *MockUserRoleRepository) DeleteUserRole(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserRole", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserRole indicates an expected call of DeleteUserRole.
+func (mr *MockUserRoleRepositoryMockRecorder) DeleteUserRole(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserRole", reflect.TypeOf((*MockUserRoleRepository)(nil).DeleteUserRole), name)
+}
+
 // GetAllUserRoles mocks base method.
 func (m *MockUserRoleRepository) GetAllUserRoles() ([]*model.UserRole, error) {
 	m.ctrl.T.Helper()
@@ -66,3 +81,32 @@ func (mr *MockUserRoleRepositoryMockRecorder) GetAllUserRoles() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllUserRoles", reflect.TypeOf((*MockUserRoleRepository)(nil).GetAllUserRoles))
 }
+
+// GetUserRole mocks base method.
+func (m *MockUserRoleRepository) GetUserRole(name string) (*model.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRole", name)
+	ret0, _ := ret[0].(*model.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRole indicates an expected call of GetUserRole.
+func (mr *MockUserRoleRepositoryMockRecorder) GetUserRole(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRole", reflect.TypeOf((*MockUserRoleRepository)(nil).GetUserRole), name)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockUserRoleRepository) UpdateUserRole(name string, args repository.UpdateUserRoleArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", name, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockUserRoleRepositoryMockRecorder) UpdateUserRole(name, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockUserRoleRepository)(nil).UpdateUserRole), name, args)
+}
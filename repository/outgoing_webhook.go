@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// CreateOutgoingWebhookArgs 外部送信Webhook作成引数
+type CreateOutgoingWebhookArgs struct {
+	Name        string
+	Description string
+	ChannelID   uuid.UUID
+	URL         string
+	Secret      string
+	Events      model.OutgoingWebhookEvents
+	CreatorID   uuid.UUID
+}
+
+// UpdateOutgoingWebhookArgs 外部送信Webhook更新引数
+type UpdateOutgoingWebhookArgs struct {
+	Name        optional.Of[string]
+	Description optional.Of[string]
+	ChannelID   optional.Of[uuid.UUID]
+	URL         optional.Of[string]
+	Secret      optional.Of[string]
+	Events      model.OutgoingWebhookEvents
+	CreatorID   optional.Of[uuid.UUID]
+}
+
+// OutgoingWebhooksQuery GetOutgoingWebhooks用クエリ
+type OutgoingWebhooksQuery struct {
+	CreatorID optional.Of[uuid.UUID]
+	ChannelID optional.Of[uuid.UUID]
+	Event     optional.Of[model.OutgoingWebhookEvent]
+}
+
+// OutgoingWebhookRepository 外部送信Webhookリポジトリ
+type OutgoingWebhookRepository interface {
+	// CreateOutgoingWebhook 外部送信Webhookを作成します
+	//
+	// 成功した場合、外部送信Webhookとnilを返します。
+	// 引数に問題がある場合、ArgumentErrorを返します。
+	// DBによるエラーを返すことがあります。
+	CreateOutgoingWebhook(args CreateOutgoingWebhookArgs) (*model.OutgoingWebhook, error)
+	// UpdateOutgoingWebhook 外部送信Webhookを更新します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない外部送信Webhookの場合、ErrNotFoundを返します。
+	// 更新内容に問題がある場合、ArgumentErrorを返します。
+	// idにuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateOutgoingWebhook(id uuid.UUID, args UpdateOutgoingWebhookArgs) error
+	// DeleteOutgoingWebhook 外部送信Webhookを削除します
+	//
+	// 成功した場合、nilを返します。
+	// 既に存在しなかった場合、ErrNotFoundを返します。
+	// idにuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteOutgoingWebhook(id uuid.UUID) error
+	// GetOutgoingWebhook 指定した外部送信Webhookを取得します
+	//
+	// 成功した場合、外部送信Webhookとnilを返します。
+	// 存在しなかった場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetOutgoingWebhook(id uuid.UUID) (*model.OutgoingWebhook, error)
+	// GetOutgoingWebhooks 指定したクエリで外部送信Webhookを取得します
+	//
+	// 成功した場合、外部送信Webhookの配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetOutgoingWebhooks(query OutgoingWebhooksQuery) ([]*model.OutgoingWebhook, error)
+	// WriteOutgoingWebhookDeliveryLog 外部送信Webhookの配送ログを書き込みます
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	WriteOutgoingWebhookDeliveryLog(log *model.OutgoingWebhookDeliveryLog) error
+	// GetOutgoingWebhookDeliveryLog 指定した外部送信Webhookの配送ログを取得します
+	//
+	// 成功した場合、配送ログとnilを返します。
+	// 存在しなかった場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetOutgoingWebhookDeliveryLog(id uuid.UUID) (*model.OutgoingWebhookDeliveryLog, error)
+	// GetOutgoingWebhookDeliveryLogs 指定した外部送信Webhookの配送ログを新しい順に取得します
+	//
+	// 成功した場合、配送ログの配列とnilを返します。負のoffset, limitは無視されます。
+	// DBによるエラーを返すことがあります。
+	GetOutgoingWebhookDeliveryLogs(webhookID uuid.UUID, limit, offset int) ([]*model.OutgoingWebhookDeliveryLog, error)
+	// PurgeOutgoingWebhookDeliveryLogs 指定した時間以前の外部送信Webhookの配送ログを全て消去します
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	PurgeOutgoingWebhookDeliveryLogs(before time.Time) error
+	// SaveOutgoingWebhookDelivery 再試行待ちの外部送信Webhookの配送を作成、或いは更新します
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	SaveOutgoingWebhookDelivery(d *model.OutgoingWebhookDelivery) error
+	// DeleteOutgoingWebhookDelivery 指定した再試行待ちの外部送信Webhookの配送を削除します
+	//
+	// 成功した、或いは既に存在しない場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteOutgoingWebhookDelivery(id uuid.UUID) error
+	// GetRetryableOutgoingWebhookDeliveries 再試行時刻を過ぎた、処理権を得ているインスタンスがない配送を再試行時刻の昇順で取得します
+	//
+	// 削除された外部送信Webhookの配送は含みません。
+	// 成功した場合、配送の配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetRetryableOutgoingWebhookDeliveries(now time.Time, limit int) ([]*model.OutgoingWebhookDelivery, error)
+	// ClaimOutgoingWebhookDelivery 指定した配送について、leaseUntilまでの再試行の処理権を得ます
+	//
+	// 処理権を得ているインスタンスがない、或いはその期限が切れている場合のみ処理権を得られます。
+	// 処理権を得た場合、trueとnilを返します。他のインスタンスが処理中、或いは存在しない場合、falseとnilを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	ClaimOutgoingWebhookDelivery(id uuid.UUID, leaseUntil time.Time) (bool, error)
+}
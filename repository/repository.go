@@ -10,15 +10,22 @@ type Repository interface {
 	ChannelRepository
 	MessageRepository
 	MessageReportRepository
+	ScheduledMessageRepository
 	StampRepository
 	StampPaletteRepository
 	StarRepository
 	PinRepository
 	DeviceRepository
+	WebPushSubscriptionRepository
+	ChannelRoleRepository
 	FileRepository
 	WebhookRepository
+	OutgoingWebhookRepository
 	OAuth2Repository
 	BotRepository
+	BotCommandRepository
+	BotEventDeliveryRepository
 	ClipRepository
 	OgpCacheRepository
+	AuditLogRepository
 }
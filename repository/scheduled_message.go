@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// CreateScheduledMessageArgs 予約投稿メッセージ作成引数
+type CreateScheduledMessageArgs struct {
+	UserID      uuid.UUID
+	ChannelID   optional.Of[uuid.UUID]
+	DMUserID    optional.Of[uuid.UUID]
+	Content     string
+	ScheduledAt time.Time
+}
+
+// UpdateScheduledMessageArgs 予約投稿メッセージ更新引数
+type UpdateScheduledMessageArgs struct {
+	Content     optional.Of[string]
+	ScheduledAt optional.Of[time.Time]
+}
+
+// ScheduledMessagesQuery GetScheduledMessages用クエリ
+type ScheduledMessagesQuery struct {
+	UserID uuid.UUID
+	Status optional.Of[model.ScheduledMessageStatus]
+	Offset int
+	Limit  int
+}
+
+// ScheduledMessageRepository 予約投稿メッセージリポジトリ
+type ScheduledMessageRepository interface {
+	// CreateScheduledMessage 予約投稿メッセージを作成します
+	//
+	// 成功した場合、予約投稿メッセージとnilを返します。
+	// DBによるエラーを返すことがあります。
+	CreateScheduledMessage(args CreateScheduledMessageArgs) (*model.ScheduledMessage, error)
+	// GetScheduledMessage 指定した予約投稿メッセージを取得します
+	//
+	// 成功した場合、予約投稿メッセージとnilを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetScheduledMessage(id uuid.UUID) (*model.ScheduledMessage, error)
+	// GetScheduledMessages 指定したクエリで予約投稿メッセージを投稿予定日時の昇順で取得します
+	//
+	// 成功した場合、予約投稿メッセージの配列とnilを返します。負のoffset, limitは無視されます。
+	// DBによるエラーを返すことがあります。
+	GetScheduledMessages(query ScheduledMessagesQuery) ([]*model.ScheduledMessage, error)
+	// GetDueScheduledMessages 指定した日時までに投稿予定の投稿待ちの予約投稿メッセージを投稿予定日時の昇順で取得します
+	//
+	// 成功した場合、予約投稿メッセージの配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetDueScheduledMessages(until time.Time, limit int) ([]*model.ScheduledMessage, error)
+	// GetStaleScheduledMessages 指定した日時より前に投稿処理中にされたまま完了していない予約投稿メッセージを取得します
+	//
+	// 成功した場合、予約投稿メッセージの配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetStaleScheduledMessages(acquiredBefore time.Time, limit int) ([]*model.ScheduledMessage, error)
+	// UpdateScheduledMessage 指定した投稿待ちの予約投稿メッセージを更新します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// 投稿待ちでない予約投稿メッセージを指定した場合、ErrForbiddenを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateScheduledMessage(id uuid.UUID, args UpdateScheduledMessageArgs) error
+	// CancelScheduledMessage 指定した投稿待ちの予約投稿メッセージを取り消します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// 投稿待ちでない予約投稿メッセージを指定した場合、ErrForbiddenを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CancelScheduledMessage(id uuid.UUID) error
+	// AcquireScheduledMessage 指定した投稿待ちの予約投稿メッセージを投稿処理中にします
+	//
+	// 投稿処理を開始した日時が記録されます。
+	// 投稿処理の権利を得られた場合、trueとnilを返します。
+	// 既に他の処理によって投稿処理中にされていたり、取り消されていた場合はfalseとnilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	AcquireScheduledMessage(id uuid.UUID) (bool, error)
+	// CompleteScheduledMessage 指定した予約投稿メッセージを投稿済みにします
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CompleteScheduledMessage(id, messageID uuid.UUID) error
+	// FailScheduledMessage 指定した予約投稿メッセージを投稿失敗にします
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	FailScheduledMessage(id uuid.UUID, reason string) error
+}
@@ -42,6 +42,7 @@ type LinkExternalUserAccountArgs struct {
 
 // UsersQuery GetUsers用クエリ
 type UsersQuery struct {
+	IDs                         optional.Of[[]uuid.UUID]
 	Name                        optional.Of[string]
 	IsBot                       optional.Of[bool]
 	IsActive                    optional.Of[bool]
@@ -58,6 +59,12 @@ func (q UsersQuery) NotBot() UsersQuery {
 	return q
 }
 
+// IDIn idsのいずれかのユーザーである
+func (q UsersQuery) IDIn(ids []uuid.UUID) UsersQuery {
+	q.IDs = optional.From(ids)
+	return q
+}
+
 // NameOf nameの名前のユーザーである
 func (q UsersQuery) NameOf(name string) UsersQuery {
 	q.Name = optional.From(name)
@@ -1,7 +1,18 @@
 //go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
 package repository
 
-import "github.com/traPtitech/traQ/model"
+import (
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// UpdateUserRoleArgs ユーザーロール更新引数
+type UpdateUserRoleArgs struct {
+	// Permissions ロールに直接与えられる権限の集合 (置き換え)
+	Permissions optional.Of[[]string]
+	// Inheritances 継承するロールの名前の集合 (置き換え)
+	Inheritances optional.Of[[]string]
+}
 
 type UserRoleRepository interface {
 	// CreateUserRoles ユーザーロールを作成します
@@ -14,4 +25,26 @@ type UserRoleRepository interface {
 	// 成功した場合、ユーザーロールの配列とnilを返します。
 	// DBによるエラーを返すことがあります。
 	GetAllUserRoles() ([]*model.UserRole, error)
+	// GetUserRole 指定した名前のユーザーロールを返します
+	//
+	// 成功した場合、ユーザーロールとnilを返します。
+	// 存在しなかった場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetUserRole(name string) (*model.UserRole, error)
+	// UpdateUserRole 指定したユーザーロールを更新します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しなかった場合、ErrNotFoundを返します。
+	// システムロールを指定した場合、ErrForbiddenを返します。
+	// 存在しないロールを継承しようとした場合、ArgumentErrorを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateUserRole(name string, args UpdateUserRoleArgs) error
+	// DeleteUserRole 指定したユーザーロールを削除します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しなかった場合、ErrNotFoundを返します。
+	// システムロールを指定した場合、ErrForbiddenを返します。
+	// ロールがユーザー・他ロールの継承・チャンネルロール・チャンネル権限上書きから参照されている場合、ErrForbiddenを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteUserRole(name string) error
 }
@@ -23,4 +23,59 @@ type UserSettingsRepository interface {
 	// GetUserSettings ユーザー設定を返します
 	// DBによるエラーを返すことがあります
 	GetUserSettings(userID uuid.UUID) (*model.UserSettings, error)
+	// UpdateDoNotDisturb おやすみモードを設定します
+	//
+	// DBによるエラーを返すことがあります
+	UpdateDoNotDisturb(userID uuid.UUID, args UpdateDoNotDisturbArgs) error
+	// GetDoNotDisturbEnabledSettings 指定したユーザーのうち、おやすみモードが有効なユーザーの設定を取得します
+	//
+	// DBによるエラーを返すことがあります
+	GetDoNotDisturbEnabledSettings(userIDs []uuid.UUID) ([]*model.UserSettings, error)
+	// GetNotificationKeywords ユーザーの通知キーワードを取得します
+	//
+	// DBによるエラーを返すことがあります
+	GetNotificationKeywords(userID uuid.UUID) ([]string, error)
+	// SetNotificationKeywords ユーザーの通知キーワードを置き換えます
+	//
+	// DBによるエラーを返すことがあります
+	SetNotificationKeywords(userID uuid.UUID, keywords []string) error
+	// GetUserIDsByNotificationKeyword 文字列中に通知キーワードが含まれているユーザーのUUIDを取得します
+	//
+	// DBによるエラーを返すことがあります
+	GetUserIDsByNotificationKeyword(text string) ([]uuid.UUID, error)
+	// GetMutedGroupMentions ユーザーがメンションをミュートしているユーザーグループのUUIDを取得します
+	//
+	// DBによるエラーを返すことがあります
+	GetMutedGroupMentions(userID uuid.UUID) ([]uuid.UUID, error)
+	// SetMutedGroupMentions ユーザーがメンションをミュートするユーザーグループを置き換えます
+	//
+	// DBによるエラーを返すことがあります
+	SetMutedGroupMentions(userID uuid.UUID, groupIDs []uuid.UUID) error
+	// GetGroupMentionMutedUserIDs 指定したユーザーグループへのメンションをミュートしているユーザーのUUIDを取得します
+	//
+	// DBによるエラーを返すことがあります
+	GetGroupMentionMutedUserIDs(groupID uuid.UUID) ([]uuid.UUID, error)
+	// GetChannelPushMutes ユーザーがプッシュ通知を受け取らないチャンネルのUUIDを取得します
+	//
+	// DBによるエラーを返すことがあります
+	GetChannelPushMutes(userID uuid.UUID) ([]uuid.UUID, error)
+	// SetChannelPushMutes ユーザーがプッシュ通知を受け取らないチャンネルを置き換えます
+	//
+	// DBによるエラーを返すことがあります
+	SetChannelPushMutes(userID uuid.UUID, channelIDs []uuid.UUID) error
+	// GetChannelPushMutedUserIDs 指定したチャンネルのプッシュ通知を受け取らないユーザーのUUIDを取得します
+	//
+	// DBによるエラーを返すことがあります
+	GetChannelPushMutedUserIDs(channelID uuid.UUID) ([]uuid.UUID, error)
+}
+
+// UpdateDoNotDisturbArgs おやすみモード設定引数
+type UpdateDoNotDisturbArgs struct {
+	Enabled bool
+	// Start 開始時刻 (HH:MM)
+	Start string
+	// End 終了時刻 (HH:MM)
+	End string
+	// TimeZone IANA Time Zone名
+	TimeZone string
 }
@@ -13,6 +13,7 @@ type UpdateWebhookArgs struct {
 	Description optional.Of[string]
 	ChannelID   optional.Of[uuid.UUID]
 	Secret      optional.Of[string]
+	Template    optional.Of[string]
 	CreatorID   optional.Of[uuid.UUID]
 }
 
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+// RegisterWebPushSubscriptionArgs Web Push購読登録引数
+type RegisterWebPushSubscriptionArgs struct {
+	Endpoint string
+	P256dh   string
+	Auth     string
+}
+
+// WebPushSubscriptionRepository Web Push購読リポジトリ
+type WebPushSubscriptionRepository interface {
+	// RegisterWebPushSubscription Web Push購読を登録します
+	//
+	// 成功した、或いは既に登録されていた場合にnilを返します。既に登録されていた場合は鍵を更新します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// エンドポイントが既に他のユーザーと関連づけられていた場合はArgumentErrorを返します。
+	// DBによるエラーを返すことがあります。
+	RegisterWebPushSubscription(userID uuid.UUID, args RegisterWebPushSubscriptionArgs) error
+	// GetWebPushSubscriptions 指定したユーザーの全Web Push購読を取得します
+	//
+	// 成功した場合、ユーザーUUIDをキーとするマップとnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetWebPushSubscriptions(userIDs set.UUID) (map[uuid.UUID][]*model.WebPushSubscription, error)
+	// DeleteWebPushSubscriptions Web Push購読の登録を解除します
+	//
+	// 成功した、或いは既に登録解除されていた場合にnilを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteWebPushSubscriptions(endpoints []string) error
+}
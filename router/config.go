@@ -28,6 +28,8 @@ type Config struct {
 	SkyWaySecretKey string
 	// ExternalAuth 外部認証設定
 	ExternalAuth ExternalAuthConfig
+	// VAPIDPublicKey Web PushのVAPID公開鍵 (Web Pushが無効の場合は空)
+	VAPIDPublicKey string
 }
 
 // ExternalAuthConfig 外部認証設定
@@ -78,5 +80,6 @@ func provideV3Config(c *Config) v3.Config {
 		SkyWaySecretKey:                 c.SkyWaySecretKey,
 		AllowSignUp:                     c.AllowSignUp,
 		EnabledExternalAccountProviders: c.ExternalAuth.ValidProviders(),
+		VAPIDPublicKey:                  c.VAPIDPublicKey,
 	}
 }
@@ -13,4 +13,8 @@ const (
 	HeaderChannelID         = "X-TRAQ-Channel-Id"
 	HeaderMore              = "X-TRAQ-More"
 	HeaderVersion           = "X-TRAQ-VERSION"
+	HeaderGitHubEvent       = "X-GitHub-Event"
+	HeaderGitHubSignature   = "X-Hub-Signature-256"
+	HeaderGitLabEvent       = "X-Gitlab-Event"
+	HeaderGitLabToken       = "X-Gitlab-Token"
 )
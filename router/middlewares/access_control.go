@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v4"
 
 	"github.com/traPtitech/traQ/model"
@@ -33,8 +34,16 @@ func AccessControlMiddlewareGenerator(r rbac.RBAC) func(p ...permission.Permissi
 
 				// ユーザー権限検証
 				user := c.Get(consts.KeyUser).(model.UserInfo)
+				channelID, inChannel := requestChannelID(c)
 				for _, v := range p {
-					if !r.IsGranted(user.GetRole(), v) {
+					var granted bool
+					if inChannel {
+						// チャンネルロール・チャンネル権限上書き設定を考慮
+						granted = r.IsGrantedInChannel(user.GetRole(), user.GetID(), channelID, v)
+					} else {
+						granted = r.IsGranted(user.GetRole(), v)
+					}
+					if !granted {
 						// NG
 						return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("you are not permitted to request to '%s'", c.Request().URL.Path))
 					}
@@ -46,6 +55,17 @@ func AccessControlMiddlewareGenerator(r rbac.RBAC) func(p ...permission.Permissi
 	}
 }
 
+// requestChannelID リクエストURLの`channelID`パラメータのチャンネル、或いは`messageID`パラメータのメッセージのチャンネルのUUIDを返します
+func requestChannelID(c echo.Context) (uuid.UUID, bool) {
+	if ch, ok := c.Get(consts.KeyParamChannel).(*model.Channel); ok {
+		return ch.ID, true
+	}
+	if m, ok := c.Get(consts.KeyParamMessage).(message.Message); ok {
+		return m.GetChannelID(), true
+	}
+	return uuid.Nil, false
+}
+
 // BlockBot Botのリクエストを制限するミドルウェア
 func BlockBot() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -112,6 +132,23 @@ func CheckWebhookAccessPerm(rbac rbac.RBAC) echo.MiddlewareFunc {
 	}
 }
 
+// CheckOutgoingWebhookAccessPerm 外部送信Webhookアクセス権限を確認するミドルウェア
+func CheckOutgoingWebhookAccessPerm(rbac rbac.RBAC) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user := c.Get(consts.KeyUser).(model.UserInfo)
+			w := c.Get(consts.KeyParamOutgoingWebhook).(*model.OutgoingWebhook)
+
+			// アクセス権確認
+			if !rbac.IsGranted(user.GetRole(), permission.AccessOthersWebhook) && w.CreatorID != user.GetID() {
+				return herror.Forbidden()
+			}
+
+			return next(c)
+		}
+	}
+}
+
 // CheckFileAccessPerm Fileアクセス権限を確認するミドルウェア
 func CheckFileAccessPerm(fm file.Manager) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -221,3 +258,18 @@ func CheckClipFolderAccessPerm() echo.MiddlewareFunc {
 		}
 	}
 }
+
+// CheckScheduledMessageAccessPerm 予約投稿メッセージアクセス権限を確認するミドルウェア
+func CheckScheduledMessageAccessPerm() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user := c.Get(consts.KeyUser).(model.UserInfo)
+			sm := c.Get(consts.KeyParamScheduledMessage).(*model.ScheduledMessage)
+			if user.GetID() == sm.UserID {
+				return next(c) // 予約したユーザーのアクセス
+			}
+
+			return herror.Forbidden()
+		}
+	}
+}
@@ -176,3 +176,31 @@ func (pr *ParamRetriever) ClipFolderID() echo.MiddlewareFunc {
 		return pr.repo.GetClipFolder(v)
 	})
 }
+
+// MessageReportID リクエストURLの`reportID`パラメータからMessageReportを取り出す
+func (pr *ParamRetriever) MessageReportID() echo.MiddlewareFunc {
+	return pr.byUUID(consts.ParamReportID, consts.KeyParamMessageReport, func(c echo.Context, v uuid.UUID) (interface{}, error) {
+		return pr.repo.GetMessageReport(v)
+	})
+}
+
+// ScheduledMessageID リクエストURLの`scheduledMessageID`パラメータからScheduledMessageを取り出す
+func (pr *ParamRetriever) ScheduledMessageID() echo.MiddlewareFunc {
+	return pr.byUUID(consts.ParamScheduledMessageID, consts.KeyParamScheduledMessage, func(c echo.Context, v uuid.UUID) (interface{}, error) {
+		return pr.repo.GetScheduledMessage(v)
+	})
+}
+
+// OutgoingWebhookID リクエストURLの`outgoingWebhookID`パラメータからOutgoingWebhookを取り出す
+func (pr *ParamRetriever) OutgoingWebhookID() echo.MiddlewareFunc {
+	return pr.byUUID(consts.ParamOutgoingWebhookID, consts.KeyParamOutgoingWebhook, func(c echo.Context, v uuid.UUID) (interface{}, error) {
+		return pr.repo.GetOutgoingWebhook(v)
+	})
+}
+
+// RoleName リクエストURLの`roleName`パラメータからUserRoleを取り出す
+func (pr *ParamRetriever) RoleName() echo.MiddlewareFunc {
+	return pr.byString(consts.ParamRoleName, consts.KeyParamRole, func(c echo.Context, v string) (interface{}, error) {
+		return pr.repo.GetUserRole(v)
+	})
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"context"
 	"errors"
+	"regexp"
 
 	vd "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/gofrs/uuid"
@@ -14,6 +15,7 @@ import (
 	"github.com/traPtitech/traQ/service/bot/event"
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/validator"
 )
 
 type ctxKey int
@@ -200,3 +202,33 @@ var IsValidBotEvents = vd.By(func(value interface{}) error {
 	}
 	return nil
 })
+
+// IsValidBotEventFilters 有効なBOTイベント購読フィルターである
+var IsValidBotEventFilters = vd.By(func(value interface{}) error {
+	filters, ok := value.(model.BotEventFilters)
+	if !ok || filters == nil {
+		return nil
+	}
+	for ev, f := range filters {
+		if !event.Types.Contains(ev) {
+			return errors.New("must be valid bot event type")
+		}
+		if f == nil {
+			return errors.New("filter must not be null")
+		}
+		err := vd.ValidateStruct(f,
+			vd.Field(&f.Channels, vd.Length(0, 100), vd.Each(validator.NotNilUUID)),
+			vd.Field(&f.Prefix, vd.RuneLength(0, 100)),
+			vd.Field(&f.Pattern, vd.RuneLength(0, 200), vd.By(func(value interface{}) error {
+				if _, err := regexp.Compile(value.(string)); err != nil {
+					return errors.New("must be valid regular expression")
+				}
+				return nil
+			})),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+})
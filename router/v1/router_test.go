@@ -22,6 +22,7 @@ import (
 	"github.com/traPtitech/traQ/service/imaging"
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/rbac"
+	"github.com/traPtitech/traQ/service/relay"
 	"github.com/traPtitech/traQ/testutils"
 	"github.com/traPtitech/traQ/utils/random"
 	"github.com/traPtitech/traQ/utils/storage"
@@ -55,8 +56,8 @@ func TestMain(m *testing.M) {
 		env.Hub = hub.New()
 		env.SessStore = session.NewMemorySessionStore()
 		env.RBAC = testutils.NewTestRBAC()
-		env.ChannelManager, _ = channel.InitChannelManager(env.Repository, zap.NewNop())
-		env.MessageManager, _ = message.NewMessageManager(env.Repository, env.ChannelManager, zap.NewNop())
+		env.ChannelManager, _ = channel.InitChannelManager(env.Repository, relay.NewNullRelay(), zap.NewNop())
+		env.MessageManager, _ = message.NewMessageManager(env.Repository, env.ChannelManager, relay.NewNullRelay(), zap.NewNop())
 		env.ImageProcessor = imaging.NewProcessor(imaging.Config{
 			MaxPixels:        1000 * 1000,
 			Concurrency:      1,
@@ -0,0 +1,98 @@
+package v3
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// recordAuditLog 監査ログの記録対象の操作が行われたことを通知します
+//
+// 操作者はリクエストしたユーザーになります
+func (h *Handlers) recordAuditLog(c echo.Context, action model.AuditAction, target string, detail model.AuditLogDetail) {
+	actorID := uuid.Nil
+	if user, ok := c.Get(consts.KeyUser).(model.UserInfo); ok {
+		actorID = user.GetID()
+	}
+	if detail == nil {
+		detail = model.AuditLogDetail{}
+	}
+	h.Hub.Publish(hub.Message{
+		Name: event.AuditActionPerformed,
+		Fields: hub.Fields{
+			"action":     action,
+			"actor_id":   actorID,
+			"target":     target,
+			"detail":     detail,
+			"ip_address": c.RealIP(),
+			"datetime":   time.Now(),
+		},
+	})
+}
+
+type auditLogsQuery struct {
+	Actor     optional.Of[uuid.UUID] `query:"actor"`
+	Target    optional.Of[string]    `query:"target"`
+	Action    optional.Of[string]    `query:"action"`
+	Limit     int                    `query:"limit"`
+	Offset    int                    `query:"offset"`
+	Since     optional.Of[time.Time] `query:"since"`
+	Until     optional.Of[time.Time] `query:"until"`
+	Inclusive bool                   `query:"inclusive"`
+	Order     string                 `query:"order"`
+}
+
+func (q *auditLogsQuery) Validate() error {
+	if q.Limit == 0 {
+		q.Limit = 50
+	}
+	return vd.ValidateStruct(q,
+		vd.Field(&q.Limit, vd.Min(1), vd.Max(200)),
+		vd.Field(&q.Offset, vd.Min(0)),
+	)
+}
+
+func (q *auditLogsQuery) convert() repository.AuditLogsQuery {
+	query := repository.AuditLogsQuery{
+		ActorID:   q.Actor,
+		Target:    q.Target,
+		Since:     q.Since,
+		Until:     q.Until,
+		Inclusive: q.Inclusive,
+		Limit:     q.Limit,
+		Offset:    q.Offset,
+		Asc:       strings.ToLower(q.Order) == "asc",
+	}
+	if q.Action.Valid {
+		query.Action = optional.From(model.AuditAction(q.Action.V))
+	}
+	return query
+}
+
+// GetAuditLogs GET /audit-logs
+func (h *Handlers) GetAuditLogs(c echo.Context) error {
+	var req auditLogsQuery
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	logs, more, err := h.Repo.GetAuditLogs(req.convert())
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	c.Response().Header().Set(consts.HeaderMore, strconv.FormatBool(more))
+	return c.JSON(http.StatusOK, logs)
+}
@@ -0,0 +1,115 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestHandlers_GetAuditLogs(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/audit-logs"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	userSession := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	target := uuid.Must(uuid.NewV4()).String()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, env.Repository.CreateAuditLog(&model.AuditLog{
+			Action:    model.AuditActionStampUpdated,
+			ActorID:   optional.From(admin.GetID()),
+			Target:    target,
+			IPAddress: "127.0.0.1",
+			CreatedAt: time.Now().Add(time.Duration(i) * time.Second),
+		}))
+	}
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, userSession).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, adminSession).
+			WithQuery("limit", 1000).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		res := e.GET(path).
+			WithCookie(session.CookieName, adminSession).
+			WithQuery("target", target).
+			WithQuery("limit", 2).
+			Expect().
+			Status(http.StatusOK)
+		res.Header(consts.HeaderMore).IsEqual("true")
+
+		obj := res.JSON().Array()
+		obj.Length().IsEqual(2)
+		first := obj.Value(0).Object()
+		first.Value("action").String().IsEqual(string(model.AuditActionStampUpdated))
+		first.Value("actorId").String().IsEqual(admin.GetID().String())
+		first.Value("target").String().IsEqual(target)
+	})
+
+	t.Run("success (recorded by handler)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH("/api/v3/users/{userId}", user.GetID()).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PatchUserRequest{DisplayName: optional.From("audit")}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		assert.Eventually(t, func() bool {
+			logs, _, err := env.Repository.GetAuditLogs(repository.AuditLogsQuery{
+				ActorID: optional.From(admin.GetID()),
+				Target:  optional.From(user.GetID().String()),
+				Action:  optional.From(model.AuditActionUserUpdated),
+			})
+			return err == nil && len(logs) == 1
+		}, 3*time.Second, 50*time.Millisecond)
+
+		obj := e.GET(path).
+			WithCookie(session.CookieName, adminSession).
+			WithQuery("actor", admin.GetID()).
+			WithQuery("target", user.GetID()).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+		obj.Length().IsEqual(1)
+		obj.Value(0).Object().Value("action").String().IsEqual(string(model.AuditActionUserUpdated))
+	})
+}
@@ -0,0 +1,453 @@
+package v3
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/set"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// botCommandResponseTimeout コマンドの実行からBotが応答できるまでの時間
+const botCommandResponseTimeout = 15 * time.Minute
+
+// botCommandRegex /コマンド名 または /コマンド名@Botユーザー名
+var botCommandRegex = regexp.MustCompile(`^/([a-zA-Z0-9_-]{1,32})(?:@([a-zA-Z0-9_-]{1,32}))?(?:\s+|$)`)
+
+var errInvalidBotCommandArgs = errors.New("invalid arguments")
+
+var botCommandArgTypeRule = vd.In(
+	string(model.BotCommandArgTypeString),
+	string(model.BotCommandArgTypeNumber),
+	string(model.BotCommandArgTypeBoolean),
+	string(model.BotCommandArgTypeUser),
+)
+
+// GetBotCommands GET /bots/:botID/commands
+func (h *Handlers) GetBotCommands(c echo.Context) error {
+	b := getParamBot(c)
+
+	commands, err := h.Repo.GetBotCommands([]uuid.UUID{b.ID})
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, commands)
+}
+
+// BotCommandArgRequest Botコマンドの引数定義
+type BotCommandArgRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required"`
+}
+
+func (r BotCommandArgRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Name, validator.BotCommandArgNameRuleRequired...),
+		vd.Field(&r.Description, validator.BotCommandDescriptionRule...),
+		vd.Field(&r.Type, vd.Required, botCommandArgTypeRule),
+	)
+}
+
+// BotCommandRequest Botコマンドの定義
+type BotCommandRequest struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Args        []BotCommandArgRequest `json:"args"`
+}
+
+func (r BotCommandRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Name, validator.BotCommandNameRuleRequired...),
+		vd.Field(&r.Description, validator.BotCommandDescriptionRule...),
+		vd.Field(&r.Args, vd.Length(0, 10)),
+	)
+}
+
+// PutBotCommandsRequest PUT /bots/:botID/commands リクエストボディ
+type PutBotCommandsRequest struct {
+	Commands []BotCommandRequest `json:"commands"`
+}
+
+func (r PutBotCommandsRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Commands, vd.NotNil, vd.Length(0, 100)),
+	)
+}
+
+// SetBotCommands PUT /bots/:botID/commands
+func (h *Handlers) SetBotCommands(c echo.Context) error {
+	b := getParamBot(c)
+
+	var req PutBotCommandsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	args := make([]repository.SetBotCommandArgs, len(req.Commands))
+	names := make([]string, len(req.Commands))
+	for i, cmd := range req.Commands {
+		cmdArgs := make(model.BotCommandArgs, len(cmd.Args))
+		for j, a := range cmd.Args {
+			cmdArgs[j] = model.BotCommandArg{
+				Name:        a.Name,
+				Description: a.Description,
+				Type:        model.BotCommandArgType(a.Type),
+				Required:    a.Required,
+			}
+		}
+		args[i] = repository.SetBotCommandArgs{
+			Name:        cmd.Name,
+			Description: cmd.Description,
+			Args:        cmdArgs,
+		}
+		names[i] = cmd.Name
+	}
+
+	commands, err := h.Repo.SetBotCommands(b.ID, args)
+	if err != nil {
+		switch {
+		case repository.IsArgError(err):
+			return herror.BadRequest(err)
+		case err == repository.ErrAlreadyExists:
+			return herror.Conflict("the commands have been updated concurrently")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	h.recordAuditLog(c, model.AuditActionBotUpdated, b.ID.String(), model.AuditLogDetail{"commands": names})
+	return c.JSON(http.StatusOK, commands)
+}
+
+// GetChannelBotCommands GET /channels/:channelID/commands
+func (h *Handlers) GetChannelBotCommands(c echo.Context) error {
+	ch := getParamChannel(c)
+
+	bots, err := h.getChannelCommandBots(ch, getRequestUserID(c))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	botIDs := make([]uuid.UUID, len(bots))
+	for i, b := range bots {
+		botIDs[i] = b.ID
+	}
+
+	commands, err := h.Repo.GetBotCommands(botIDs)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, commands)
+}
+
+// PostBotCommandResponseRequest POST /command-invocations/:invocationID/response リクエストボディ
+type PostBotCommandResponseRequest struct {
+	Content   string `json:"content"`
+	Embed     bool   `json:"embed"`
+	Ephemeral bool   `json:"ephemeral"`
+}
+
+func (r PostBotCommandResponseRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Content, vd.Required, vd.RuneLength(1, 10000)),
+	)
+}
+
+// PostBotCommandResponse POST /command-invocations/:invocationID/response
+func (h *Handlers) PostBotCommandResponse(c echo.Context) error {
+	userID := getRequestUserID(c)
+
+	var req PostBotCommandResponseRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	inv, err := h.Repo.GetBotCommandInvocation(getParamAsUUID(c, consts.ParamInvocationID))
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	b, err := h.Repo.GetBotByID(inv.BotID)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	if b.BotUserID != userID {
+		return herror.Forbidden("you are not allowed to respond to this invocation")
+	}
+	if time.Since(inv.CreatedAt) > botCommandResponseTimeout {
+		return herror.BadRequest("this invocation has expired")
+	}
+
+	if req.Embed {
+		req.Content = h.Replacer.Replace(req.Content)
+	}
+
+	if req.Ephemeral {
+		return c.JSON(http.StatusCreated, h.sendEphemeralMessage(inv.ChannelID, userID, inv.UserID, req.Content))
+	}
+
+	m, err := h.MessageManager.Create(inv.ChannelID, userID, req.Content)
+	if err != nil {
+		switch err {
+		case message.ErrChannelArchived:
+			return herror.BadRequest("this channel has been archived")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusCreated, m)
+}
+
+// invokeBotCommand contentがチャンネルで利用可能なBotコマンドであれば実行します
+//
+// コマンドはチャンネルで利用可能なBotの中から探します。複数のBotが同じ名前のコマンドを
+// 登録している場合は、/コマンド名@Botユーザー名 の形式でBotを指定する必要があります。
+// コマンドとして処理した場合、trueを返します。falseの場合は通常のメッセージとして投稿してください。
+func (h *Handlers) invokeBotCommand(c echo.Context, ch *model.Channel, content string) (bool, error) {
+	user := getRequestUser(c)
+	if user.IsBot() {
+		return false, nil
+	}
+
+	matches := botCommandRegex.FindStringSubmatch(content)
+	if matches == nil {
+		return false, nil
+	}
+
+	bots, err := h.getChannelCommandBots(ch, user.GetID())
+	if err != nil {
+		return true, herror.InternalServerError(err)
+	}
+	if len(matches[2]) > 0 {
+		bots, err = h.filterBotsByUserName(bots, matches[2])
+		if err != nil {
+			return true, herror.InternalServerError(err)
+		}
+	}
+	botIDs := make([]uuid.UUID, len(bots))
+	for i, b := range bots {
+		botIDs[i] = b.ID
+	}
+
+	// 利用可能なBotのコマンドでなければ通常のメッセージとして扱う
+	cmds, err := h.Repo.GetBotCommandsByName(matches[1], botIDs)
+	if err != nil {
+		return true, herror.InternalServerError(err)
+	}
+	switch len(cmds) {
+	case 0:
+		return false, nil
+	case 1:
+	default:
+		return true, herror.BadRequest(fmt.Sprintf("the command is ambiguous: specify the bot like /%s@BOT_name", matches[1]))
+	}
+	cmd := cmds[0]
+
+	if ch.IsArchived() {
+		return true, herror.BadRequest("this channel has been archived")
+	}
+	args, err := h.parseBotCommandArgs(cmd.Args, content[len(matches[0]):])
+	if err != nil {
+		if errors.Is(err, errInvalidBotCommandArgs) {
+			return true, herror.BadRequest(err)
+		}
+		return true, herror.InternalServerError(err)
+	}
+
+	inv, err := h.Repo.CreateBotCommandInvocation(repository.CreateBotCommandInvocationArgs{
+		CommandID: cmd.ID,
+		BotID:     cmd.BotID,
+		UserID:    user.GetID(),
+		ChannelID: ch.ID,
+		Text:      content,
+	})
+	if err != nil {
+		return true, herror.InternalServerError(err)
+	}
+	h.Hub.Publish(hub.Message{
+		Name: event.BotCommandInvoked,
+		Fields: hub.Fields{
+			"invocation": inv,
+			"command":    cmd,
+			"args":       args,
+		},
+	})
+	return true, c.JSON(http.StatusAccepted, inv)
+}
+
+// getChannelCommandBots チャンネルでコマンドを利用可能なBotを取得します
+//
+// 公開チャンネルでは参加しているBot、プライベートチャンネルではメンバーかつ参加しているBot、
+// DMでは相手のBotが対象になります
+func (h *Handlers) getChannelCommandBots(ch *model.Channel, userID uuid.UUID) ([]*model.Bot, error) {
+	if ch.IsDMChannel() {
+		members, err := h.ChannelManager.GetDMChannelMembers(ch.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range members {
+			if id != userID {
+				return h.Repo.GetBots(repository.BotsQuery{}.Active().BotUserID(id))
+			}
+		}
+		return []*model.Bot{}, nil
+	}
+
+	bots, err := h.Repo.GetBots(repository.BotsQuery{}.Active().CMemberOf(ch.ID))
+	if err != nil {
+		return nil, err
+	}
+	if ch.IsPrivateChannel() {
+		members, err := h.ChannelManager.GetPrivateChannelMembers(ch.ID)
+		if err != nil {
+			return nil, err
+		}
+		memberSet := set.UUIDSetFromArray(members)
+		result := make([]*model.Bot, 0, len(bots))
+		for _, b := range bots {
+			if memberSet.Contains(b.BotUserID) {
+				result = append(result, b)
+			}
+		}
+		bots = result
+	}
+	return bots, nil
+}
+
+// filterBotsByUserName botsの中からユーザー名がnameのBotを返します
+func (h *Handlers) filterBotsByUserName(bots []*model.Bot, name string) ([]*model.Bot, error) {
+	u, err := h.Repo.GetUserByName(name, false)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return []*model.Bot{}, nil
+		default:
+			return nil, err
+		}
+	}
+	for _, b := range bots {
+		if b.BotUserID == u.GetID() {
+			return []*model.Bot{b}, nil
+		}
+	}
+	return []*model.Bot{}, nil
+}
+
+// parseBotCommandArgs コマンドの引数を定義に従って解析します
+//
+// 引数は空白区切りで、ダブルクォートで囲むと空白を含められます。
+// 最後の引数が文字列型の場合、残りの全ての引数が結合されます。
+// 引数が定義に合わない場合、errInvalidBotCommandArgsをラップしたエラーを返します。
+func (h *Handlers) parseBotCommandArgs(defs model.BotCommandArgs, text string) (map[string]interface{}, error) {
+	tokens, err := splitBotCommandText(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidBotCommandArgs, err)
+	}
+	if len(tokens) > len(defs) {
+		if len(defs) == 0 || defs[len(defs)-1].Type != model.BotCommandArgTypeString {
+			return nil, fmt.Errorf("%w: too many arguments", errInvalidBotCommandArgs)
+		}
+		last := len(defs) - 1
+		tokens = append(tokens[:last], strings.Join(tokens[last:], " "))
+	}
+
+	args := make(map[string]interface{}, len(defs))
+	for i, def := range defs {
+		if i >= len(tokens) {
+			if def.Required {
+				return nil, fmt.Errorf("%w: missing required argument: %s", errInvalidBotCommandArgs, def.Name)
+			}
+			continue
+		}
+
+		token := tokens[i]
+		switch def.Type {
+		case model.BotCommandArgTypeString:
+			args[def.Name] = token
+		case model.BotCommandArgTypeNumber:
+			v, err := strconv.ParseFloat(token, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: argument %s must be a number", errInvalidBotCommandArgs, def.Name)
+			}
+			args[def.Name] = v
+		case model.BotCommandArgTypeBoolean:
+			v, err := strconv.ParseBool(token)
+			if err != nil {
+				return nil, fmt.Errorf("%w: argument %s must be a boolean", errInvalidBotCommandArgs, def.Name)
+			}
+			args[def.Name] = v
+		case model.BotCommandArgTypeUser:
+			u, err := h.Repo.GetUserByName(strings.TrimPrefix(token, "@"), false)
+			if err != nil {
+				switch err {
+				case repository.ErrNotFound:
+					return nil, fmt.Errorf("%w: argument %s: unknown user: %s", errInvalidBotCommandArgs, def.Name, token)
+				default:
+					return nil, err
+				}
+			}
+			args[def.Name] = u.GetID()
+		}
+	}
+	return args, nil
+}
+
+// splitBotCommandText コマンドの引数部分を空白で分割します
+func splitBotCommandText(text string) ([]string, error) {
+	var (
+		tokens  []string
+		current strings.Builder
+		quoted  bool
+		inToken bool
+	)
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inToken = true
+		case unicode.IsSpace(r) && !quoted:
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quoted {
+		return nil, errors.New("unclosed quotation")
+	}
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
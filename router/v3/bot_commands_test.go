@@ -0,0 +1,456 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/random"
+)
+
+func TestHandlers_GetBotCommands(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/bots/{botId}/commands"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	commonSession := env.S(t, user.GetID())
+	bot := env.CreateBot(t, rand, user.GetID())
+	cmds := env.SetBotCommands(t, bot.ID, repository.SetBotCommandArgs{
+		Name:        random.AlphaNumeric(20),
+		Description: "desc",
+		Args:        model.BotCommandArgs{{Name: "text", Type: model.BotCommandArgTypeString, Required: true}},
+	})
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, bot.ID.String()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, bot.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(1)
+		first := obj.Value(0).Object()
+		first.Value("id").String().IsEqual(cmds[0].ID.String())
+		first.Value("botId").String().IsEqual(bot.ID.String())
+		first.Value("name").String().IsEqual(cmds[0].Name)
+		first.Value("description").String().IsEqual("desc")
+		arg := first.Value("args").Array().Value(0).Object()
+		arg.Value("name").String().IsEqual("text")
+		arg.Value("type").String().IsEqual("string")
+		arg.Value("required").Boolean().IsTrue()
+	})
+}
+
+func TestHandlers_SetBotCommands(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/bots/{botId}/commands"
+	env := Setup(t, common1)
+	user1 := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	commonSession := env.S(t, user1.GetID())
+	bot1 := env.CreateBot(t, rand, user1.GetID())
+	bot2 := env.CreateBot(t, rand, user2.GetID())
+	taken := env.SetBotCommands(t, bot2.ID, repository.SetBotCommandArgs{Name: random.AlphaNumeric(20)})
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot1.ID.String()).
+			WithJSON(&PutBotCommandsRequest{Commands: []BotCommandRequest{}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		reqs := []*PutBotCommandsRequest{
+			{},
+			{Commands: []BotCommandRequest{{Name: "in valid"}}},
+			{Commands: []BotCommandRequest{{Name: "po", Args: []BotCommandArgRequest{{Name: "a", Type: "unknown"}}}}},
+			{Commands: []BotCommandRequest{{Name: "po"}, {Name: "po"}}},
+			{Commands: []BotCommandRequest{{Name: "po", Args: []BotCommandArgRequest{{Name: "a", Type: "string"}, {Name: "b", Type: "string", Required: true}}}}},
+		}
+		for _, req := range reqs {
+			e.PUT(path, bot1.ID.String()).
+				WithCookie(session.CookieName, commonSession).
+				WithJSON(req).
+				Expect().
+				Status(http.StatusBadRequest)
+		}
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot2.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PutBotCommandsRequest{Commands: []BotCommandRequest{}}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("same name as other bot", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PutBotCommandsRequest{Commands: []BotCommandRequest{{Name: taken[0].Name}}}).
+			Expect().
+			Status(http.StatusOK)
+	})
+
+	t.Run("success (by bot)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		bot := env.CreateBot(t, rand, user1.GetID())
+		name := random.AlphaNumeric(20)
+		obj := e.PUT(path, bot.ID.String()).
+			WithCookie(session.CookieName, env.S(t, bot.BotUserID)).
+			WithJSON(&PutBotCommandsRequest{Commands: []BotCommandRequest{{
+				Name:        name,
+				Description: "desc",
+				Args:        []BotCommandArgRequest{{Name: "n", Type: "number", Required: true}},
+			}}}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(1)
+		obj.Value(0).Object().Value("name").String().IsEqual(name)
+
+		cmds, err := env.Repository.GetBotCommands([]uuid.UUID{bot.ID})
+		require.NoError(t, err)
+		if assert.Len(t, cmds, 1) {
+			assert.Equal(t, name, cmds[0].Name)
+			assert.Equal(t, model.BotCommandArgs{{Name: "n", Type: model.BotCommandArgTypeNumber, Required: true}}, cmds[0].Args)
+		}
+	})
+}
+
+func TestHandlers_GetChannelBotCommands(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/channels/{channelId}/commands"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	commonSession := env.S(t, user.GetID())
+	channel := env.CreateChannel(t, rand)
+
+	joined := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(joined.ID, model.BotActive))
+	require.NoError(t, env.Repository.AddBotToChannel(joined.ID, channel.ID))
+	cmds := env.SetBotCommands(t, joined.ID, repository.SetBotCommandArgs{Name: random.AlphaNumeric(20)})
+
+	notJoined := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(notJoined.ID, model.BotActive))
+	env.SetBotCommands(t, notJoined.ID, repository.SetBotCommandArgs{Name: random.AlphaNumeric(20)})
+
+	inactive := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.AddBotToChannel(inactive.ID, channel.ID))
+	env.SetBotCommands(t, inactive.ID, repository.SetBotCommandArgs{Name: random.AlphaNumeric(20)})
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, channel.ID.String()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, channel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(1)
+		obj.Value(0).Object().Value("id").String().IsEqual(cmds[0].ID.String())
+	})
+
+	t.Run("success (dm)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		dm := env.CreateDMChannel(t, user.GetID(), notJoined.BotUserID)
+		obj := e.GET(path, dm.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(1)
+		obj.Value(0).Object().Value("botId").String().IsEqual(notJoined.ID.String())
+	})
+}
+
+func TestHandlers_PostMessage_BotCommand(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/channels/{channelId}/messages"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	target := env.CreateUser(t, rand)
+	commonSession := env.S(t, user.GetID())
+	channel := env.CreateChannel(t, rand)
+
+	bot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(bot.ID, model.BotActive))
+	require.NoError(t, env.Repository.AddBotToChannel(bot.ID, channel.ID))
+	sharedName := random.AlphaNumeric(20)
+	cmds := env.SetBotCommands(t, bot.ID, repository.SetBotCommandArgs{
+		Name: random.AlphaNumeric(20),
+		Args: model.BotCommandArgs{
+			{Name: "user", Type: model.BotCommandArgTypeUser, Required: true},
+			{Name: "count", Type: model.BotCommandArgTypeNumber, Required: true},
+			{Name: "message", Type: model.BotCommandArgTypeString},
+		},
+	}, repository.SetBotCommandArgs{Name: sharedName})
+	cmdName := cmds[0].Name
+
+	// 同じ名前のコマンドを登録している別のBot
+	sharedBot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(sharedBot.ID, model.BotActive))
+	require.NoError(t, env.Repository.AddBotToChannel(sharedBot.ID, channel.ID))
+	sharedCmds := env.SetBotCommands(t, sharedBot.ID, repository.SetBotCommandArgs{Name: sharedName})
+	sharedBotUser, err := env.Repository.GetUser(sharedBot.BotUserID, false)
+	require.NoError(t, err)
+
+	otherBot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(otherBot.ID, model.BotActive))
+	otherCmds := env.SetBotCommands(t, otherBot.ID, repository.SetBotCommandArgs{Name: random.AlphaNumeric(20)})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		contents := []string{
+			"/" + cmdName,
+			"/" + cmdName + " @" + target.GetName(),
+			"/" + cmdName + " @" + target.GetName() + " po",
+			"/" + cmdName + " @" + random.AlphaNumeric(32) + " 1",
+			"/" + cmdName + ` @` + target.GetName() + ` 1 "po`,
+			"/" + sharedName,
+		}
+		for _, content := range contents {
+			e.POST(path, channel.ID.String()).
+				WithCookie(session.CookieName, commonSession).
+				WithJSON(&PostMessageRequest{Content: content}).
+				Expect().
+				Status(http.StatusBadRequest)
+		}
+	})
+
+	t.Run("not available command is posted as message", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		content := "/" + otherCmds[0].Name
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostMessageRequest{Content: content}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object().
+			Value("content").String().IsEqual(content)
+	})
+
+	t.Run("command of other bot is posted as message", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		content := "/" + cmdName + "@" + sharedBotUser.GetName()
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostMessageRequest{Content: content}).
+			Expect().
+			Status(http.StatusCreated)
+	})
+
+	t.Run("success (bot specified)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostMessageRequest{Content: "/" + sharedName + "@" + sharedBotUser.GetName()}).
+			Expect().
+			Status(http.StatusAccepted).
+			JSON().
+			Object().
+			Value("commandId").String().IsEqual(sharedCmds[0].ID.String())
+	})
+
+	t.Run("bot message is not treated as command", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		content := "/" + cmdName
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, env.S(t, otherBot.BotUserID)).
+			WithJSON(&PostMessageRequest{Content: content}).
+			Expect().
+			Status(http.StatusCreated)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		sub := env.Hub.Subscribe(10, event.BotCommandInvoked)
+		defer env.Hub.Unsubscribe(sub)
+
+		e := env.R(t)
+		content := "/" + cmdName + " @" + target.GetName() + ` 3 "hello world" !`
+		obj := e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostMessageRequest{Content: content}).
+			Expect().
+			Status(http.StatusAccepted).
+			JSON().
+			Object()
+
+		obj.Value("commandId").String().IsEqual(cmds[0].ID.String())
+		obj.Value("botId").String().IsEqual(bot.ID.String())
+		obj.Value("userId").String().IsEqual(user.GetID().String())
+		obj.Value("channelId").String().IsEqual(channel.ID.String())
+		obj.Value("text").String().IsEqual(content)
+		invID := obj.Value("id").String().Raw()
+
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case ev := <-sub.Receiver:
+				inv := ev.Fields["invocation"].(*model.BotCommandInvocation)
+				if inv.ID.String() != invID {
+					continue
+				}
+				assert.Equal(t, map[string]interface{}{
+					"user":    target.GetID(),
+					"count":   float64(3),
+					"message": "hello world !",
+				}, ev.Fields["args"])
+				return
+			case <-timeout:
+				t.Fatal("BotCommandInvoked event was not published")
+			}
+		}
+	})
+}
+
+func TestHandlers_PostBotCommandResponse(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/command-invocations/{invocationId}/response"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	channel := env.CreateChannel(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	otherBot := env.CreateBot(t, rand, user.GetID())
+	botSession := env.S(t, bot.BotUserID)
+	cmds := env.SetBotCommands(t, bot.ID, repository.SetBotCommandArgs{Name: random.AlphaNumeric(20)})
+	inv, err := env.Repository.CreateBotCommandInvocation(repository.CreateBotCommandInvocationArgs{
+		CommandID: cmds[0].ID,
+		BotID:     bot.ID,
+		UserID:    user.GetID(),
+		ChannelID: channel.ID,
+		Text:      "/" + cmds[0].Name,
+	})
+	require.NoError(t, err)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, inv.ID.String()).
+			WithJSON(&PostBotCommandResponseRequest{Content: "po"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("non bot", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, inv.ID.String()).
+			WithCookie(session.CookieName, env.S(t, user.GetID())).
+			WithJSON(&PostBotCommandResponseRequest{Content: "po"}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("other bot", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, inv.ID.String()).
+			WithCookie(session.CookieName, env.S(t, otherBot.BotUserID)).
+			WithJSON(&PostBotCommandResponseRequest{Content: "po"}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, inv.ID.String()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PostBotCommandResponseRequest{Content: ""}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4()).String()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PostBotCommandResponseRequest{Content: "po"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success (ephemeral)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path, inv.ID.String()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PostBotCommandResponseRequest{Content: "secret", Ephemeral: true}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("userId").String().IsEqual(bot.BotUserID.String())
+		obj.Value("channelId").String().IsEqual(channel.ID.String())
+		obj.Value("content").String().IsEqual("secret")
+	})
+
+	t.Run("success (public)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path, inv.ID.String()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PostBotCommandResponseRequest{Content: "public"}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("userId").String().IsEqual(bot.BotUserID.String())
+		obj.Value("channelId").String().IsEqual(channel.ID.String())
+		obj.Value("content").String().IsEqual("public")
+	})
+}
@@ -93,6 +93,7 @@ func (h *Handlers) CreateBot(c echo.Context) error {
 	if err != nil {
 		return herror.InternalServerError(err)
 	}
+	h.recordAuditLog(c, model.AuditActionBotCreated, b.ID.String(), model.AuditLogDetail{"name": req.Name, "mode": req.Mode})
 
 	t, err := h.Repo.GetTokenByID(b.AccessTokenID)
 	if err != nil {
@@ -144,6 +145,7 @@ type PatchBotRequest struct {
 	Privileged      optional.Of[bool]      `json:"privileged"`
 	DeveloperID     optional.Of[uuid.UUID] `json:"developerId"`
 	SubscribeEvents model.BotEventTypes    `json:"subscribeEvents"`
+	EventFilters    model.BotEventFilters  `json:"eventFilters"`
 }
 
 func (r PatchBotRequest) ValidateWithContext(ctx context.Context) error {
@@ -154,6 +156,7 @@ func (r PatchBotRequest) ValidateWithContext(ctx context.Context) error {
 		vd.Field(&r.Endpoint, is.URL, validator.NotInternalURL),
 		vd.Field(&r.DeveloperID, validator.NotNilUUID, utils.IsActiveHumanUserID),
 		vd.Field(&r.SubscribeEvents, utils.IsValidBotEvents),
+		vd.Field(&r.EventFilters, utils.IsValidBotEventFilters),
 	)
 }
 
@@ -184,11 +187,18 @@ func (h *Handlers) EditBot(c echo.Context) error {
 		Privileged:      req.Privileged,
 		CreatorID:       req.DeveloperID,
 		SubscribeEvents: req.SubscribeEvents,
+		EventFilters:    req.EventFilters,
 	}
 
 	if err := h.Repo.UpdateBot(b.ID, args); err != nil {
 		return herror.InternalServerError(err)
 	}
+	h.recordAuditLog(c, model.AuditActionBotUpdated, b.ID.String(), model.AuditLogDetail{
+		"mode":        req.Mode,
+		"endpoint":    req.Endpoint,
+		"privileged":  req.Privileged,
+		"developerId": req.DeveloperID,
+	})
 
 	return c.NoContent(http.StatusNoContent)
 }
@@ -200,6 +210,7 @@ func (h *Handlers) DeleteBot(c echo.Context) error {
 	if err := h.Repo.DeleteBot(b.ID); err != nil {
 		return herror.InternalServerError(err)
 	}
+	h.recordAuditLog(c, model.AuditActionBotDeleted, b.ID.String(), nil)
 
 	return c.NoContent(http.StatusNoContent)
 }
@@ -219,7 +230,12 @@ func (h *Handlers) GetBotIcon(c echo.Context) error {
 
 // ChangeBotIcon PUT /bots/:botID/icon
 func (h *Handlers) ChangeBotIcon(c echo.Context) error {
-	return utils.ChangeUserIcon(h.Imaging, c, h.Repo, h.FileManager, getParamBot(c).BotUserID)
+	b := getParamBot(c)
+	if err := utils.ChangeUserIcon(h.Imaging, c, h.Repo, h.FileManager, b.BotUserID); err != nil {
+		return err
+	}
+	h.recordAuditLog(c, model.AuditActionBotUpdated, b.ID.String(), model.AuditLogDetail{"icon": true})
+	return nil
 }
 
 // GetBotLogsRequest GET /bots/:botID/logs リクエストクエリ
@@ -255,6 +271,23 @@ func (h *Handlers) GetBotLogs(c echo.Context) error {
 	return c.JSON(http.StatusOK, formatBotEventLogs(logs))
 }
 
+// GetBotDeadLetters GET /bots/:botID/dead-letters
+func (h *Handlers) GetBotDeadLetters(c echo.Context) error {
+	b := getParamBot(c)
+
+	var req GetBotLogsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	deliveries, err := h.Repo.GetBotEventDeliveries(b.ID, model.BotEventDeliveryDead, req.Limit, req.Offset)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, formatBotDeadLetters(deliveries))
+}
+
 // GetChannelBots GET /channels/:channelID/bots
 func (h *Handlers) GetChannelBots(c echo.Context) error {
 	channelID := getParamAsUUID(c, consts.ParamChannelID)
@@ -285,6 +318,7 @@ func (h *Handlers) ActivateBot(c echo.Context) error {
 			"bot":    b,
 		},
 	})
+	h.recordAuditLog(c, model.AuditActionBotStateChanged, b.ID.String(), model.AuditLogDetail{"operation": "activate"})
 	return c.NoContent(http.StatusAccepted)
 }
 
@@ -295,6 +329,7 @@ func (h *Handlers) InactivateBot(c echo.Context) error {
 	if err := h.Repo.ChangeBotState(b.ID, model.BotInactive); err != nil {
 		return herror.InternalServerError(err)
 	}
+	h.recordAuditLog(c, model.AuditActionBotStateChanged, b.ID.String(), model.AuditLogDetail{"operation": "inactivate"})
 	return c.NoContent(http.StatusNoContent)
 }
 
@@ -306,6 +341,7 @@ func (h *Handlers) ReissueBot(c echo.Context) error {
 	if err != nil {
 		return herror.InternalServerError(err)
 	}
+	h.recordAuditLog(c, model.AuditActionBotTokenReissued, b.ID.String(), nil)
 
 	t, err := h.Repo.GetTokenByID(b.AccessTokenID)
 	if err != nil {
@@ -314,10 +350,49 @@ func (h *Handlers) ReissueBot(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, echo.Map{
 		"verificationToken": b.VerificationToken,
+		"signingSecret":     b.SigningSecret,
 		"accessToken":       t.AccessToken,
 	})
 }
 
+// PostBotActionReplayRequest POST /bots/:botID/actions/replay リクエストボディ
+type PostBotActionReplayRequest struct {
+	// IDs 再送するデッドレターのID。空の場合は全てのデッドレターを再送します
+	IDs []uuid.UUID `json:"ids"`
+}
+
+func (r PostBotActionReplayRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.IDs, vd.Length(0, 200), vd.Each(validator.NotNilUUID)),
+	)
+}
+
+// ReplayBotEvents POST /bots/:botID/actions/replay
+func (h *Handlers) ReplayBotEvents(c echo.Context) error {
+	b := getParamBot(c)
+
+	var req PostBotActionReplayRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	n, err := h.Repo.RequeueBotEventDeliveries(b.ID, req.IDs)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if n > 0 {
+		h.Hub.Publish(hub.Message{
+			Name: event.BotEventDeliveriesRequeued,
+			Fields: hub.Fields{
+				"bot_id": b.ID,
+				"count":  n,
+			},
+		})
+	}
+	h.recordAuditLog(c, model.AuditActionBotEventsReplayed, b.ID.String(), model.AuditLogDetail{"count": n})
+	return c.JSON(http.StatusAccepted, echo.Map{"count": n})
+}
+
 // PostBotActionJoinRequest POST /bots/:botID/actions/join リクエストボディ
 type PostBotActionJoinRequest struct {
 	ChannelID uuid.UUID `json:"channelId"`
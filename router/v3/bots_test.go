@@ -8,6 +8,7 @@ import (
 
 	"github.com/gavv/httpexpect/v2"
 	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/traPtitech/traQ/model"
@@ -233,6 +234,7 @@ func TestHandlers_CreateBot(t *testing.T) {
 		obj.Value("createdAt").String().NotEmpty()
 		obj.Value("updatedAt").String().NotEmpty()
 		obj.Value("tokens").Object().Value("verificationToken").String().NotEmpty()
+		obj.Value("tokens").Object().Value("signingSecret").String().NotEmpty()
 		obj.Value("tokens").Object().Value("accessToken").String().NotEmpty()
 		obj.Value("endpoint").String().IsEqual("https://example.com")
 		obj.Value("privileged").Boolean().IsFalse()
@@ -260,6 +262,7 @@ func TestHandlers_CreateBot(t *testing.T) {
 		obj.Value("createdAt").String().NotEmpty()
 		obj.Value("updatedAt").String().NotEmpty()
 		obj.Value("tokens").Object().Value("verificationToken").String().NotEmpty()
+		obj.Value("tokens").Object().Value("signingSecret").String().NotEmpty()
 		obj.Value("tokens").Object().Value("accessToken").String().NotEmpty()
 		obj.Value("endpoint").String().IsEqual("")
 		obj.Value("privileged").Boolean().IsFalse()
@@ -343,6 +346,7 @@ func TestHandlers_GetBot(t *testing.T) {
 
 		botEquals(t, bot1, obj)
 		obj.Value("tokens").Object().Value("verificationToken").String().NotEmpty()
+		obj.Value("tokens").Object().Value("signingSecret").String().NotEmpty()
 		obj.Value("tokens").Object().Value("accessToken").String().NotEmpty()
 		obj.Value("endpoint").String().IsEqual("https://example.com")
 		obj.Value("privileged").Boolean().IsFalse()
@@ -465,6 +469,50 @@ func TestHandlers_EditBot(t *testing.T) {
 			Status(http.StatusBadRequest)
 	})
 
+	t.Run("bad request (event filters)", func(t *testing.T) {
+		t.Parallel()
+		cases := []model.BotEventFilters{
+			{"NON_EXISTENT_EVENT": {}},
+			{event.MessageCreated: nil},
+			{event.MessageCreated: {Pattern: "("}},
+			{event.MessageCreated: {Channels: []uuid.UUID{uuid.Nil}}},
+			{event.MessageCreated: {Prefix: strings.Repeat("a", 101)}},
+		}
+		for _, filters := range cases {
+			e := env.R(t)
+			e.PATCH(path, bot1.ID.String()).
+				WithCookie(session.CookieName, commonSession).
+				WithJSON(&PatchBotRequest{EventFilters: filters}).
+				Expect().
+				Status(http.StatusBadRequest)
+		}
+	})
+
+	t.Run("success (event filters)", func(t *testing.T) {
+		t.Parallel()
+		bot := env.CreateBot(t, rand, user1.GetID())
+		channelID := uuid.Must(uuid.NewV4())
+		e := env.R(t)
+		e.PATCH(path, bot.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PatchBotRequest{
+				EventFilters: model.BotEventFilters{
+					event.MessageCreated: {Channels: []uuid.UUID{channelID}, Prefix: "!", Pattern: "^!(help|deploy)", ExcludeBots: true},
+				},
+			}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		b, err := env.Repository.GetBotByID(bot.ID)
+		require.NoError(t, err)
+		if f := b.EventFilters.Get(event.MessageCreated); assert.NotNil(t, f) {
+			assert.Equal(t, []uuid.UUID{channelID}, f.Channels)
+			assert.Equal(t, "!", f.Prefix)
+			assert.Equal(t, "^!(help|deploy)", f.Pattern)
+			assert.True(t, f.ExcludeBots)
+		}
+	})
+
 	t.Run("bad request (change mode to HTTP and endpoint not set)", func(t *testing.T) {
 		t.Parallel()
 		e := env.R(t)
@@ -750,6 +798,95 @@ func TestHandlers_GetBotLogs(t *testing.T) {
 	})
 }
 
+func TestHandlers_GetBotDeadLetters(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/bots/{botId}/dead-letters"
+	env := Setup(t, common1)
+	user1 := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	commonSession := env.S(t, user1.GetID())
+	bot1 := env.CreateBot(t, rand, user1.GetID())
+	bot2 := env.CreateBot(t, rand, user2.GetID())
+
+	dead := &model.BotEventDelivery{
+		ID:        uuid.Must(uuid.NewV4()),
+		BotID:     bot1.ID,
+		Event:     event.Ping,
+		Body:      "{}",
+		Status:    model.BotEventDeliveryDead,
+		Attempts:  8,
+		LastError: "unexpected status code: 500",
+	}
+	require.NoError(t, env.Repository.SaveBotEventDelivery(dead))
+	require.NoError(t, env.Repository.SaveBotEventDelivery(&model.BotEventDelivery{
+		ID:       uuid.Must(uuid.NewV4()),
+		BotID:    bot1.ID,
+		Event:    event.Ping,
+		Body:     "{}",
+		Status:   model.BotEventDeliveryPending,
+		Attempts: 1,
+	}))
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, bot1.ID.String()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (negative limit)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithQuery("limit", -1).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, bot2.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4()).String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(1)
+
+		first := obj.Value(0).Object()
+		first.Keys().ContainsOnly(
+			"id", "botId", "event", "attempts", "lastError", "createdAt", "updatedAt",
+		)
+		first.Value("id").String().IsEqual(dead.ID.String())
+		first.Value("botId").String().IsEqual(bot1.ID.String())
+		first.Value("event").String().IsEqual(event.Ping.String())
+		first.Value("attempts").Number().IsEqual(8)
+		first.Value("lastError").String().IsEqual(dead.LastError)
+	})
+}
+
 func TestHandlers_GetChannelBots(t *testing.T) {
 	t.Parallel()
 	path := "/api/v3/channels/{channelId}/bots"
@@ -934,10 +1071,115 @@ func TestHandlers_ReissueBot(t *testing.T) {
 			Object()
 
 		obj.Value("verificationToken").String().NotEmpty()
+		obj.Value("signingSecret").String().NotEmpty().NotEqual(bot1.SigningSecret)
 		obj.Value("accessToken").String().NotEmpty()
 	})
 }
 
+func TestHandlers_ReplayBotEvents(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/bots/{botId}/actions/replay"
+	env := Setup(t, common1)
+	user1 := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	commonSession := env.S(t, user1.GetID())
+	bot2 := env.CreateBot(t, rand, user2.GetID())
+
+	createDeadLetter := func(t *testing.T, botID uuid.UUID) *model.BotEventDelivery {
+		t.Helper()
+		d := &model.BotEventDelivery{
+			ID:       uuid.Must(uuid.NewV4()),
+			BotID:    botID,
+			Event:    event.Ping,
+			Body:     "{}",
+			Status:   model.BotEventDeliveryDead,
+			Attempts: 8,
+		}
+		require.NoError(t, env.Repository.SaveBotEventDelivery(d))
+		return d
+	}
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bot2.ID.String()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, bot2.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4()).String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		bot := env.CreateBot(t, rand, user1.GetID())
+		e := env.R(t)
+		e.POST(path, bot.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostBotActionReplayRequest{IDs: []uuid.UUID{uuid.Nil}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success (specified ids)", func(t *testing.T) {
+		t.Parallel()
+		bot := env.CreateBot(t, rand, user1.GetID())
+		d1 := createDeadLetter(t, bot.ID)
+		d2 := createDeadLetter(t, bot.ID)
+
+		e := env.R(t)
+		e.POST(path, bot.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostBotActionReplayRequest{IDs: []uuid.UUID{d1.ID}}).
+			Expect().
+			Status(http.StatusAccepted).
+			JSON().
+			Object().
+			Value("count").Number().IsEqual(1)
+
+		ds, err := env.Repository.GetBotEventDeliveries(bot.ID, model.BotEventDeliveryDead, 0, 0)
+		require.NoError(t, err)
+		if assert.Len(t, ds, 1) {
+			assert.Equal(t, d2.ID, ds[0].ID)
+		}
+	})
+
+	t.Run("success (all)", func(t *testing.T) {
+		t.Parallel()
+		bot := env.CreateBot(t, rand, user1.GetID())
+		createDeadLetter(t, bot.ID)
+		createDeadLetter(t, bot.ID)
+
+		e := env.R(t)
+		e.POST(path, bot.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusAccepted).
+			JSON().
+			Object().
+			Value("count").Number().IsEqual(2)
+
+		ds, err := env.Repository.GetBotEventDeliveries(bot.ID, model.BotEventDeliveryPending, 0, 0)
+		require.NoError(t, err)
+		assert.Len(t, ds, 2)
+	})
+}
+
 func TestHandlers_LetBotJoinChannel(t *testing.T) {
 	t.Parallel()
 	path := "/api/v3/bots/{botId}/actions/join"
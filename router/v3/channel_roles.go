@@ -0,0 +1,153 @@
+package v3
+
+import (
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+// ChannelRolesRequest GET/PUT /channels/:channelID/roles リクエスト・レスポンスボディ
+type ChannelRolesRequest struct {
+	Roles []*model.ChannelRole `json:"roles"`
+}
+
+func (r ChannelRolesRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Roles, vd.NotNil, vd.Each(vd.NotNil)),
+	)
+}
+
+// GetChannelRoles GET /channels/:channelID/roles
+func (h *Handlers) GetChannelRoles(c echo.Context) error {
+	ch := getParamChannel(c)
+
+	roles, err := h.Repo.GetChannelRoles(ch.ID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, &ChannelRolesRequest{Roles: roles})
+}
+
+// SetChannelRoles PUT /channels/:channelID/roles
+func (h *Handlers) SetChannelRoles(c echo.Context) error {
+	ch := getParamChannel(c)
+
+	var req ChannelRolesRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if ch.IsDMChannel() {
+		return herror.BadRequest("channel roles cannot be set to DM channels")
+	}
+
+	roleNames, err := h.userRoleNames()
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	users := set.UUID{}
+	for _, r := range req.Roles {
+		if users.Contains(r.UserID) {
+			return herror.BadRequest("duplicated user: " + r.UserID.String())
+		}
+		users.Add(r.UserID)
+		if !roleNames[r.Role] {
+			return herror.BadRequest("invalid role: " + r.Role)
+		}
+		if ok, err := h.Repo.UserExists(r.UserID); err != nil {
+			return herror.InternalServerError(err)
+		} else if !ok {
+			return herror.BadRequest("invalid user: " + r.UserID.String())
+		}
+		r.ChannelID = ch.ID
+	}
+
+	if err := h.Repo.SetChannelRoles(ch.ID, req.Roles); err != nil {
+		return herror.InternalServerError(err)
+	}
+	if err := h.RBAC.Reload(); err != nil {
+		return herror.InternalServerError(err)
+	}
+	h.recordAuditLog(c, model.AuditActionChannelRolesChanged, ch.ID.String(), model.AuditLogDetail{"roles": req.Roles})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ChannelPermissionOverridesRequest GET/PUT /channels/:channelID/permission-overrides リクエスト・レスポンスボディ
+type ChannelPermissionOverridesRequest struct {
+	Overrides []*model.ChannelPermissionOverride `json:"overrides"`
+}
+
+func (r ChannelPermissionOverridesRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Overrides, vd.NotNil, vd.Each(vd.NotNil)),
+	)
+}
+
+// GetChannelPermissionOverrides GET /channels/:channelID/permission-overrides
+func (h *Handlers) GetChannelPermissionOverrides(c echo.Context) error {
+	ch := getParamChannel(c)
+
+	overrides, err := h.Repo.GetChannelPermissionOverrides(ch.ID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, &ChannelPermissionOverridesRequest{Overrides: overrides})
+}
+
+// SetChannelPermissionOverrides PUT /channels/:channelID/permission-overrides
+func (h *Handlers) SetChannelPermissionOverrides(c echo.Context) error {
+	ch := getParamChannel(c)
+
+	var req ChannelPermissionOverridesRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if ch.IsDMChannel() {
+		return herror.BadRequest("permission overrides cannot be set to DM channels")
+	}
+
+	roleNames, err := h.userRoleNames()
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	for _, o := range req.Overrides {
+		if !roleNames[o.Role] {
+			return herror.BadRequest("invalid role: " + o.Role)
+		}
+		if !permission.ChannelScoped.Contains(permission.Permission(o.Permission)) {
+			return herror.BadRequest("the permission cannot be overridden per channel: " + o.Permission)
+		}
+		o.ChannelID = ch.ID
+	}
+
+	if err := h.Repo.SetChannelPermissionOverrides(ch.ID, req.Overrides); err != nil {
+		return herror.InternalServerError(err)
+	}
+	if err := h.RBAC.Reload(); err != nil {
+		return herror.InternalServerError(err)
+	}
+	h.recordAuditLog(c, model.AuditActionChannelPermissionOverridesChanged, ch.ID.String(), model.AuditLogDetail{"overrides": req.Overrides})
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// userRoleNames 存在するユーザーロール名のセットを返します
+func (h *Handlers) userRoleNames() (map[string]bool, error) {
+	roles, err := h.Repo.GetAllUserRoles()
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]bool, len(roles))
+	for _, r := range roles {
+		res[r.Name] = true
+	}
+	return res, nil
+}
@@ -0,0 +1,311 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+	"github.com/traPtitech/traQ/service/rbac/role"
+)
+
+func TestHandlers_GetChannelRoles(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/channels/{channelId}/roles"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	s := env.S(t, user.GetID())
+	require.NoError(t, env.Repository.SetChannelRoles(ch.ID, []*model.ChannelRole{{UserID: user.GetID(), Role: role.User}}))
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, ch.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		roles := obj.Value("roles").Array()
+		roles.Length().IsEqual(1)
+		roles.Value(0).Object().Value("userId").String().IsEqual(user.GetID().String())
+		roles.Value(0).Object().Value("role").String().IsEqual(role.User)
+	})
+}
+
+func TestHandlers_SetChannelRoles(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/channels/{channelId}/roles"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	ch := env.CreateChannel(t, rand)
+	dm := env.CreateDMChannel(t, user.GetID(), admin.GetID())
+	userSession := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, ch.ID).
+			WithJSON(&ChannelRolesRequest{Roles: []*model.ChannelRole{}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, ch.ID).
+			WithCookie(session.CookieName, userSession).
+			WithJSON(&ChannelRolesRequest{Roles: []*model.ChannelRole{}}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request (nil roles)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, ch.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&ChannelRolesRequest{}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (unknown role)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, ch.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&ChannelRolesRequest{Roles: []*model.ChannelRole{{UserID: user.GetID(), Role: "unknown_role"}}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (unknown user)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, ch.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&ChannelRolesRequest{Roles: []*model.ChannelRole{{UserID: uuid.Must(uuid.NewV4()), Role: role.User}}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (dm channel)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, dm.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&ChannelRolesRequest{Roles: []*model.ChannelRole{}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, ch.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&ChannelRolesRequest{Roles: []*model.ChannelRole{{UserID: user.GetID(), Role: role.Admin}}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		roles, err := env.Repository.GetChannelRoles(ch.ID)
+		require.NoError(t, err)
+		if assert.Len(t, roles, 1) {
+			assert.Equal(t, user.GetID(), roles[0].UserID)
+			assert.Equal(t, role.Admin, roles[0].Role)
+		}
+	})
+}
+
+func TestHandlers_GetChannelPermissionOverrides(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/channels/{channelId}/permission-overrides"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	s := env.S(t, user.GetID())
+	require.NoError(t, env.Repository.SetChannelPermissionOverrides(ch.ID, []*model.ChannelPermissionOverride{
+		{Role: role.User, Permission: permission.CreateMessagePin.Name(), Allow: false},
+	}))
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, ch.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		overrides := obj.Value("overrides").Array()
+		overrides.Length().IsEqual(1)
+		o := overrides.Value(0).Object()
+		o.Value("role").String().IsEqual(role.User)
+		o.Value("permission").String().IsEqual(permission.CreateMessagePin.Name())
+		o.Value("allow").Boolean().IsFalse()
+	})
+}
+
+func TestHandlers_SetChannelPermissionOverrides(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/channels/{channelId}/permission-overrides"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	ch := env.CreateChannel(t, rand)
+	userSession := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, ch.ID).
+			WithCookie(session.CookieName, userSession).
+			WithJSON(&ChannelPermissionOverridesRequest{Overrides: []*model.ChannelPermissionOverride{}}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request (not channel scoped permission)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, ch.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&ChannelPermissionOverridesRequest{Overrides: []*model.ChannelPermissionOverride{
+				{Role: role.User, Permission: permission.CreateChannel.Name(), Allow: false},
+			}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (unknown role)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, ch.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&ChannelPermissionOverridesRequest{Overrides: []*model.ChannelPermissionOverride{
+				{Role: "unknown_role", Permission: permission.PostMessage.Name(), Allow: false},
+			}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, ch.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&ChannelPermissionOverridesRequest{Overrides: []*model.ChannelPermissionOverride{
+				{Role: role.User, Permission: permission.CreateMessagePin.Name(), Allow: false},
+			}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		overrides, err := env.Repository.GetChannelPermissionOverrides(ch.ID)
+		require.NoError(t, err)
+		assert.Len(t, overrides, 1)
+	})
+}
+
+func TestHandlers_ChannelPermissionOverride(t *testing.T) {
+	t.Parallel()
+
+	env := Setup(t, common1)
+	admin := env.CreateAdmin(t, rand)
+	moderator := env.CreateUser(t, rand)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	other := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, admin.GetID(), ch.ID, rand)
+	adminSession := env.S(t, admin.GetID())
+	moderatorSession := env.S(t, moderator.GetID())
+	userSession := env.S(t, user.GetID())
+
+	require.NoError(t, env.Repository.CreateUserRoles(&model.UserRole{
+		Name:        "channel_moderator",
+		Permissions: []model.RolePermission{{Role: "channel_moderator", Permission: permission.DeleteOthersMessage.Name()}},
+	}))
+	require.NoError(t, env.RBAC.Reload())
+
+	// アナウンスチャンネル: 一般ユーザーは投稿不可、チャンネルモデレーターは投稿・削除可
+	e := env.R(t)
+	e.PUT("/api/v3/channels/{channelId}/roles", ch.ID).
+		WithCookie(session.CookieName, adminSession).
+		WithJSON(&ChannelRolesRequest{Roles: []*model.ChannelRole{{UserID: moderator.GetID(), Role: "channel_moderator"}}}).
+		Expect().
+		Status(http.StatusNoContent)
+	e.PUT("/api/v3/channels/{channelId}/permission-overrides", ch.ID).
+		WithCookie(session.CookieName, adminSession).
+		WithJSON(&ChannelPermissionOverridesRequest{Overrides: []*model.ChannelPermissionOverride{
+			{Role: role.User, Permission: permission.PostMessage.Name(), Allow: false},
+			{Role: "channel_moderator", Permission: permission.PostMessage.Name(), Allow: true},
+		}}).
+		Expect().
+		Status(http.StatusNoContent)
+
+	e.POST("/api/v3/channels/{channelId}/messages", ch.ID).
+		WithCookie(session.CookieName, userSession).
+		WithJSON(&PostMessageRequest{Content: "test"}).
+		Expect().
+		Status(http.StatusForbidden)
+	e.POST("/api/v3/channels/{channelId}/messages", other.ID).
+		WithCookie(session.CookieName, userSession).
+		WithJSON(&PostMessageRequest{Content: "test"}).
+		Expect().
+		Status(http.StatusCreated)
+	e.POST("/api/v3/channels/{channelId}/messages", ch.ID).
+		WithCookie(session.CookieName, moderatorSession).
+		WithJSON(&PostMessageRequest{Content: "test"}).
+		Expect().
+		Status(http.StatusCreated)
+
+	e.DELETE("/api/v3/messages/{messageId}", m.GetID()).
+		WithCookie(session.CookieName, userSession).
+		Expect().
+		Status(http.StatusForbidden)
+	e.DELETE("/api/v3/messages/{messageId}", m.GetID()).
+		WithCookie(session.CookieName, moderatorSession).
+		Expect().
+		Status(http.StatusNoContent)
+}
@@ -1,6 +1,8 @@
 package v3
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,13 +11,16 @@ import (
 	vd "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router/consts"
 	"github.com/traPtitech/traQ/router/extension"
 	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/router/utils"
 	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/utils/optional"
 	"github.com/traPtitech/traQ/utils/set"
@@ -28,6 +33,16 @@ func (h *Handlers) GetChannels(c echo.Context) error {
 		"public": h.ChannelManager.PublicChannelTree(),
 	}
 
+	private, err := h.ChannelManager.GetPrivateChannels(getRequestUserID(c))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	privateRes := make([]*Channel, len(private))
+	for i, ch := range private {
+		privateRes[i] = formatChannel(ch, ch.ChildrenID)
+	}
+	res["private"] = privateRes
+
 	if isTrue(c.QueryParam("include-dm")) {
 		mapping, err := h.ChannelManager.GetDMChannelMapping(getRequestUserID(c))
 		if err != nil {
@@ -41,13 +56,17 @@ func (h *Handlers) GetChannels(c echo.Context) error {
 
 // PostChannelRequest POST /channels リクエストボディ
 type PostChannelRequest struct {
-	Name   string                 `json:"name"`
-	Parent optional.Of[uuid.UUID] `json:"parent"`
+	Name    string                 `json:"name"`
+	Parent  optional.Of[uuid.UUID] `json:"parent"`
+	Private bool                   `json:"private"`
+	Members set.UUID               `json:"members"`
 }
 
 func (r PostChannelRequest) Validate() error {
 	return vd.ValidateStruct(&r,
 		vd.Field(&r.Name, validator.ChannelNameRuleRequired...),
+		vd.Field(&r.Parent, vd.When(r.Private, vd.Empty.Error("must be empty for private channel"))),
+		vd.Field(&r.Members, vd.When(!r.Private, vd.Empty.Error("must be empty for public channel"))),
 	)
 }
 
@@ -60,6 +79,27 @@ func (h *Handlers) CreateChannels(c echo.Context) error {
 		return err
 	}
 
+	if req.Private {
+		for uid := range req.Members {
+			if ok, err := h.Repo.UserExists(uid); err != nil {
+				return herror.InternalServerError(err)
+			} else if !ok {
+				return herror.BadRequest("invalid member: " + uid.String())
+			}
+		}
+
+		ch, err := h.ChannelManager.CreatePrivateChannel(req.Name, userID, req.Members)
+		if err != nil {
+			switch err {
+			case channel.ErrInvalidChannelName:
+				return herror.BadRequest("invalid channel name")
+			default:
+				return herror.InternalServerError(err)
+			}
+		}
+		return c.JSON(http.StatusCreated, formatChannel(ch, make([]uuid.UUID, 0)))
+	}
+
 	ch, err := h.ChannelManager.CreatePublicChannel(req.Name, req.Parent.V, userID)
 	if err != nil {
 		switch err {
@@ -140,6 +180,8 @@ func (h *Handlers) EditChannel(c echo.Context) error {
 	}
 	if err := h.ChannelManager.UpdateChannel(channelID, args); err != nil {
 		switch err {
+		case channel.ErrInvalidChannel:
+			return herror.BadRequest("only the topic of non-public channels can be changed")
 		case channel.ErrInvalidChannelName:
 			return herror.BadRequest("invalid channel name")
 		case channel.ErrInvalidParentChannel:
@@ -152,6 +194,195 @@ func (h *Handlers) EditChannel(c echo.Context) error {
 			return herror.InternalServerError(err)
 		}
 	}
+	h.recordAuditLog(c, model.AuditActionChannelUpdated, channelID.String(), model.AuditLogDetail{
+		"name":     req.Name,
+		"archived": req.Archived,
+		"force":    req.Force,
+		"parent":   req.Parent,
+	})
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteChannel DELETE /channels/:channelID
+func (h *Handlers) DeleteChannel(c echo.Context) error {
+	ch := getParamChannel(c)
+	if !ch.IsPublic {
+		return herror.BadRequest("only public channels can be deleted")
+	}
+
+	files, err := h.ChannelManager.DeleteChannel(ch.ID, getRequestUserID(c))
+	if err != nil {
+		switch err {
+		case channel.ErrInvalidChannel:
+			return herror.BadRequest("only public channels can be deleted")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	h.recordAuditLog(c, model.AuditActionChannelDeleted, ch.ID.String(), model.AuditLogDetail{"name": ch.Name})
+	// ファイルのメタデータはチャンネルと同じトランザクションで削除済み
+	if err := h.FileManager.DeleteStorage(files); err != nil {
+		return herror.InternalServerError(fmt.Errorf("the channel was deleted, but failed to delete its files from storage: %w", err))
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// MergeChannelRequest POST /channels/:channelID/actions/merge リクエストボディ
+type MergeChannelRequest struct {
+	Target uuid.UUID `json:"target"`
+}
+
+func (r MergeChannelRequest) ValidateWithContext(ctx context.Context) error {
+	return vd.ValidateStructWithContext(ctx, &r,
+		vd.Field(&r.Target, vd.Required, validator.NotNilUUID, utils.IsPublicChannelID),
+	)
+}
+
+// MergeChannel POST /channels/:channelID/actions/merge
+func (h *Handlers) MergeChannel(c echo.Context) error {
+	ch := getParamChannel(c)
+
+	var req MergeChannelRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if !ch.IsPublic {
+		return herror.BadRequest("only public channels can be merged")
+	}
+	if len(h.ChannelManager.PublicChannelTree().GetChildrenIDs(ch.ID)) > 0 {
+		return herror.BadRequest("channels which have child channels cannot be merged")
+	}
+	if req.Target == ch.ID {
+		return herror.BadRequest("invalid target channel")
+	}
+	if h.ChannelManager.PublicChannelTree().IsArchivedChannel(req.Target) {
+		return herror.BadRequest("the target channel has been archived")
+	}
+
+	// 移動してから削除するまでの間に投稿されたメッセージが削除されないよう、先にアーカイブして投稿できなくする
+	archived := ch.IsArchived()
+	if !archived {
+		if err := h.ChannelManager.ArchiveChannel(ch.ID, getRequestUserID(c)); err != nil {
+			return herror.InternalServerError(err)
+		}
+	}
+	if err := h.MessageManager.MoveAll(ch.ID, req.Target, getRequestUserID(c)); err != nil {
+		if !archived {
+			if err := h.ChannelManager.UnarchiveChannel(ch.ID, getRequestUserID(c)); err != nil {
+				h.L(c).Warn("failed to unarchive the channel", zap.Error(err), zap.Stringer("cid", ch.ID))
+			}
+		}
+		switch err {
+		case message.ErrInvalidChannel:
+			return herror.BadRequest("invalid target channel")
+		case message.ErrChannelArchived:
+			return herror.BadRequest("the target channel has been archived")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	files, err := h.ChannelManager.DeleteChannel(ch.ID, getRequestUserID(c))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	h.recordAuditLog(c, model.AuditActionChannelMerged, ch.ID.String(), model.AuditLogDetail{"name": ch.Name, "target": req.Target})
+	// 移動後に残っていたファイルのメタデータはチャンネルと同じトランザクションで削除済み
+	if err := h.FileManager.DeleteStorage(files); err != nil {
+		return herror.InternalServerError(fmt.Errorf("the channel was deleted, but failed to delete its files from storage: %w", err))
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// MoveMessagesRequest POST /channels/:channelID/actions/move-messages リクエストボディ
+type MoveMessagesRequest struct {
+	MessageIDs []uuid.UUID `json:"messageIds"`
+	Target     uuid.UUID   `json:"target"`
+}
+
+func (r MoveMessagesRequest) ValidateWithContext(ctx context.Context) error {
+	return vd.ValidateStructWithContext(ctx, &r,
+		vd.Field(&r.MessageIDs, vd.Required, vd.Length(1, 200), vd.Each(validator.NotNilUUID)),
+		vd.Field(&r.Target, vd.Required, validator.NotNilUUID, utils.IsPublicChannelID),
+	)
+}
+
+// MoveMessages POST /channels/:channelID/actions/move-messages
+func (h *Handlers) MoveMessages(c echo.Context) error {
+	ch := getParamChannel(c)
+
+	var req MoveMessagesRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.MessageManager.Move(req.MessageIDs, ch.ID, req.Target, getRequestUserID(c)); err != nil {
+		switch err {
+		case message.ErrNotFound:
+			return herror.BadRequest("unknown message id")
+		case message.ErrInvalidChannel:
+			return herror.BadRequest("invalid channel or messages")
+		case message.ErrReplyMessage:
+			return herror.BadRequest("reply messages cannot be moved without the parent message")
+		case message.ErrChannelArchived:
+			return herror.BadRequest("the target channel has been archived")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	h.recordAuditLog(c, model.AuditActionChannelMessagesMoved, ch.ID.String(), model.AuditLogDetail{"messageIds": req.MessageIDs, "target": req.Target})
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetChannelMembers GET /channels/:channelID/members
+func (h *Handlers) GetChannelMembers(c echo.Context) error {
+	ch := getParamChannel(c)
+
+	if !ch.IsPrivateChannel() {
+		return herror.BadRequest("this channel is not a private channel")
+	}
+
+	members, err := h.ChannelManager.GetPrivateChannelMembers(ch.ID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, members)
+}
+
+// PatchChannelMembersRequest PATCH /channels/:channelID/members リクエストボディ
+type PatchChannelMembersRequest struct {
+	Add    set.UUID `json:"add"`
+	Remove set.UUID `json:"remove"`
+}
+
+// EditChannelMembers PATCH /channels/:channelID/members
+func (h *Handlers) EditChannelMembers(c echo.Context) error {
+	ch := getParamChannel(c)
+
+	var req PatchChannelMembersRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	for uid := range req.Add {
+		if ok, err := h.Repo.UserExists(uid); err != nil {
+			return herror.InternalServerError(err)
+		} else if !ok {
+			return herror.BadRequest("invalid user: " + uid.String())
+		}
+	}
+
+	if err := h.ChannelManager.ChangePrivateChannelMembers(ch.ID, req.Add.Array(), req.Remove.Array(), getRequestUserID(c)); err != nil {
+		switch err {
+		case channel.ErrInvalidChannel:
+			return herror.BadRequest("this channel is not a private channel")
+		case channel.ErrNoPrivateChannelMembers:
+			return herror.BadRequest("private channel must have at least one member")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	h.recordAuditLog(c, model.AuditActionChannelMembersChanged, ch.ID.String(), model.AuditLogDetail{"add": req.Add, "remove": req.Remove})
 	return c.NoContent(http.StatusNoContent)
 }
 
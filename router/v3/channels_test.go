@@ -38,6 +38,12 @@ func messageEquals(t *testing.T, expect message.Message, actual *httpexpect.Obje
 	actual.Value("createdAt").String().NotEmpty()
 	actual.Value("updatedAt").String().NotEmpty()
 	actual.Value("pinned").Boolean().IsEqual(expect.GetPin() != nil)
+	actual.Value("edited").Boolean().IsEqual(expect.GetUpdatedAt().After(expect.GetCreatedAt()))
+	if p := expect.GetParentMessageID(); p.Valid {
+		actual.Value("threadId").String().IsEqual(p.V.String())
+	} else {
+		actual.Value("threadId").IsNull()
+	}
 
 	stamps := actual.Value("stamps").Array()
 	stamps.Length().IsEqual(len(expect.GetStamps()))
@@ -169,8 +175,10 @@ func TestHandlers_GetChannels(t *testing.T) {
 func TestPostChannelRequest_Validate(t *testing.T) {
 	t.Parallel()
 	type fields struct {
-		Name   string
-		Parent optional.Of[uuid.UUID]
+		Name    string
+		Parent  optional.Of[uuid.UUID]
+		Private bool
+		Members set.UUID
 	}
 	tests := []struct {
 		name    string
@@ -192,17 +200,34 @@ func TestPostChannelRequest_Validate(t *testing.T) {
 			fields{Name: strings.Repeat("a", 50)},
 			true,
 		},
+		{
+			"private with parent",
+			fields{Name: "po", Parent: optional.From(uuid.Must(uuid.NewV4())), Private: true},
+			true,
+		},
+		{
+			"public with members",
+			fields{Name: "po", Members: set.UUIDSetFromArray([]uuid.UUID{uuid.Must(uuid.NewV4())})},
+			true,
+		},
 		{
 			"success",
 			fields{Name: "po"},
 			false,
 		},
+		{
+			"success (private)",
+			fields{Name: "po", Private: true, Members: set.UUIDSetFromArray([]uuid.UUID{uuid.Must(uuid.NewV4())})},
+			false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := PostChannelRequest{
-				Name:   tt.fields.Name,
-				Parent: tt.fields.Parent,
+				Name:    tt.fields.Name,
+				Parent:  tt.fields.Parent,
+				Private: tt.fields.Private,
+				Members: tt.fields.Members,
 			}
 			if err := r.Validate(); (err != nil) != tt.wantErr {
 				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
@@ -285,6 +310,102 @@ func TestHandlers_CreateChannels(t *testing.T) {
 	})
 }
 
+func TestHandlers_PrivateChannel(t *testing.T) {
+	t.Parallel()
+	env := Setup(t, common1)
+	user1 := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	user3 := env.CreateUser(t, rand)
+	s1 := env.S(t, user1.GetID())
+	s2 := env.S(t, user2.GetID())
+	s3 := env.S(t, user3.GetID())
+	e := env.R(t)
+
+	// 作成
+	name := random.AlphaNumeric(20)
+	obj := e.POST("/api/v3/channels").
+		WithCookie(session.CookieName, s1).
+		WithJSON(&PostChannelRequest{Name: name, Private: true, Members: set.UUIDSetFromArray([]uuid.UUID{user2.GetID()})}).
+		Expect().
+		Status(http.StatusCreated).
+		JSON().
+		Object()
+	obj.Value("name").String().IsEqual(name)
+	obj.Value("parentId").IsNull()
+	chID, err := uuid.FromString(obj.Value("id").String().Raw())
+	require.NoError(t, err)
+	assert.False(t, env.CM.IsPublicChannel(chID))
+
+	e.POST("/api/v3/channels").
+		WithCookie(session.CookieName, s1).
+		WithJSON(&PostChannelRequest{Name: random.AlphaNumeric(20), Private: true, Members: set.UUIDSetFromArray([]uuid.UUID{uuid.Must(uuid.NewV4())})}).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	// メンバーのみアクセス可能
+	e.GET("/api/v3/channels/{channelId}", chID).
+		WithCookie(session.CookieName, s2).
+		Expect().
+		Status(http.StatusOK)
+	e.GET("/api/v3/channels/{channelId}", chID).
+		WithCookie(session.CookieName, s3).
+		Expect().
+		Status(http.StatusNotFound)
+	e.GET("/api/v3/channels").
+		WithCookie(session.CookieName, s2).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		Value("private").Array().Length().IsEqual(1)
+	e.GET("/api/v3/channels").
+		WithCookie(session.CookieName, s3).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Object().
+		Value("private").Array().Length().IsEqual(0)
+
+	e.GET("/api/v3/channels/{channelId}/members", chID).
+		WithCookie(session.CookieName, s1).
+		Expect().
+		Status(http.StatusOK).
+		JSON().
+		Array().
+		ContainsOnly(user1.GetID(), user2.GetID())
+
+	// メンバー変更
+	e.PATCH("/api/v3/channels/{channelId}/members", chID).
+		WithCookie(session.CookieName, s1).
+		WithJSON(&PatchChannelMembersRequest{Add: set.UUIDSetFromArray([]uuid.UUID{user3.GetID()}), Remove: set.UUIDSetFromArray([]uuid.UUID{user2.GetID()})}).
+		Expect().
+		Status(http.StatusNoContent)
+	e.GET("/api/v3/channels/{channelId}", chID).
+		WithCookie(session.CookieName, s2).
+		Expect().
+		Status(http.StatusNotFound)
+	e.POST("/api/v3/channels/{channelId}/messages", chID).
+		WithCookie(session.CookieName, s3).
+		WithJSON(&PostMessageRequest{Content: "test"}).
+		Expect().
+		Status(http.StatusCreated)
+
+	// 全員は削除できない
+	e.PATCH("/api/v3/channels/{channelId}/members", chID).
+		WithCookie(session.CookieName, s1).
+		WithJSON(&PatchChannelMembersRequest{Remove: set.UUIDSetFromArray([]uuid.UUID{user1.GetID(), user3.GetID()})}).
+		Expect().
+		Status(http.StatusBadRequest)
+
+	// 公開チャンネルのメンバーは変更できない
+	pub := env.CreateChannel(t, rand)
+	e.PATCH("/api/v3/channels/{channelId}/members", pub.ID).
+		WithCookie(session.CookieName, s1).
+		WithJSON(&PatchChannelMembersRequest{Add: set.UUIDSetFromArray([]uuid.UUID{user3.GetID()})}).
+		Expect().
+		Status(http.StatusBadRequest)
+}
+
 func TestHandlers_GetChannel(t *testing.T) {
 	t.Parallel()
 	path := "/api/v3/channels/{channelId}"
@@ -483,6 +604,229 @@ func TestHandlers_EditChannel(t *testing.T) {
 	})
 }
 
+func TestHandlers_DeleteChannel(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/channels/{channelId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	userSession := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	ch := env.CreateChannel(t, rand)
+	dm := env.CreateDMChannel(t, user.GetID(), admin.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, ch.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, ch.ID).
+			WithCookie(session.CookieName, userSession).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request (dm channel)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, dm.ID).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		parent := env.CreateChannel(t, rand)
+		child, err := env.CM.CreatePublicChannel(random.AlphaNumeric(20), parent.ID, admin.GetID())
+		require.NoError(t, err)
+		m := env.CreateMessage(t, admin.GetID(), child.ID, rand)
+		f := env.CreateFile(t, admin.GetID(), child.ID)
+
+		e := env.R(t)
+		e.DELETE(path, parent.ID).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusNoContent)
+
+		assert.False(t, env.CM.PublicChannelTree().IsChannelPresent(parent.ID))
+		assert.False(t, env.CM.PublicChannelTree().IsChannelPresent(child.ID))
+		_, err = env.Repository.GetMessageByID(m.GetID())
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+		_, err = env.Repository.GetFileMeta(f.GetID())
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
+
+func TestHandlers_MergeChannel(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/channels/{channelId}/actions/merge"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	userSession := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	target := env.CreateChannel(t, rand)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		ch := env.CreateChannel(t, rand)
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithJSON(&MergeChannelRequest{Target: target.ID}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		ch := env.CreateChannel(t, rand)
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, userSession).
+			WithJSON(&MergeChannelRequest{Target: target.ID}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request (same channel)", func(t *testing.T) {
+		t.Parallel()
+		ch := env.CreateChannel(t, rand)
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&MergeChannelRequest{Target: ch.ID}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (has child channels)", func(t *testing.T) {
+		t.Parallel()
+		ch := env.CreateChannel(t, rand)
+		_, err := env.CM.CreatePublicChannel(random.AlphaNumeric(20), ch.ID, admin.GetID())
+		require.NoError(t, err)
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&MergeChannelRequest{Target: target.ID}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ch := env.CreateChannel(t, rand)
+		m := env.CreateMessage(t, admin.GetID(), ch.ID, rand)
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&MergeChannelRequest{Target: target.ID}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		assert.False(t, env.CM.PublicChannelTree().IsChannelPresent(ch.ID))
+		moved, err := env.Repository.GetMessageByID(m.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, target.ID, moved.ChannelID)
+		// 編集扱いにならない
+		assert.False(t, moved.IsEdited())
+	})
+}
+
+func TestHandlers_MoveMessages(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/channels/{channelId}/actions/move-messages"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	userSession := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	from := env.CreateChannel(t, rand)
+	to := env.CreateChannel(t, rand)
+	other := env.CreateChannel(t, rand)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, admin.GetID(), from.ID, rand)
+		e := env.R(t)
+		e.POST(path, from.ID).
+			WithJSON(&MoveMessagesRequest{MessageIDs: []uuid.UUID{m.GetID()}, Target: to.ID}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, admin.GetID(), from.ID, rand)
+		e := env.R(t)
+		e.POST(path, from.ID).
+			WithCookie(session.CookieName, userSession).
+			WithJSON(&MoveMessagesRequest{MessageIDs: []uuid.UUID{m.GetID()}, Target: to.ID}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request (empty)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, from.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&MoveMessagesRequest{MessageIDs: []uuid.UUID{}, Target: to.ID}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (message in another channel)", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, admin.GetID(), other.ID, rand)
+		e := env.R(t)
+		e.POST(path, from.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&MoveMessagesRequest{MessageIDs: []uuid.UUID{m.GetID()}, Target: to.ID}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (reply without parent)", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, admin.GetID(), from.ID, rand)
+		r := env.CreateReplyMessage(t, admin.GetID(), m.GetID(), rand)
+		e := env.R(t)
+		e.POST(path, from.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&MoveMessagesRequest{MessageIDs: []uuid.UUID{r.GetID()}, Target: to.ID}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, admin.GetID(), from.ID, rand)
+		r := env.CreateReplyMessage(t, admin.GetID(), m.GetID(), rand)
+		e := env.R(t)
+		e.POST(path, from.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&MoveMessagesRequest{MessageIDs: []uuid.UUID{m.GetID()}, Target: to.ID}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		for _, id := range []uuid.UUID{m.GetID(), r.GetID()} {
+			moved, err := env.Repository.GetMessageByID(id)
+			require.NoError(t, err)
+			assert.Equal(t, to.ID, moved.ChannelID)
+		}
+	})
+}
+
 func TestHandlers_GetChannelStats(t *testing.T) {
 	t.Parallel()
 	path := "/api/v3/channels/{channelId}/stats"
@@ -71,6 +71,11 @@ func (h *Handlers) CreateClient(c echo.Context) error {
 	if err := h.Repo.SaveClient(client); err != nil {
 		return herror.InternalServerError(err)
 	}
+	h.recordAuditLog(c, model.AuditActionOAuth2ClientCreated, client.ID, model.AuditLogDetail{
+		"name":        req.Name,
+		"callbackUrl": req.CallbackURL,
+		"scopes":      req.Scopes,
+	})
 
 	return c.JSON(http.StatusCreated, formatOAuth2ClientDetail(client))
 }
@@ -125,6 +130,11 @@ func (h *Handlers) EditClient(c echo.Context) error {
 	if err := h.Repo.UpdateClient(oc.ID, args); err != nil {
 		return herror.InternalServerError(err)
 	}
+	h.recordAuditLog(c, model.AuditActionOAuth2ClientUpdated, oc.ID, model.AuditLogDetail{
+		"name":        req.Name,
+		"developerId": req.DeveloperID,
+		"callbackUrl": req.CallbackURL,
+	})
 
 	return c.NoContent(http.StatusNoContent)
 }
@@ -137,6 +147,7 @@ func (h *Handlers) DeleteClient(c echo.Context) error {
 	if err := h.Repo.DeleteClient(oc.ID); err != nil {
 		return herror.InternalServerError(err)
 	}
+	h.recordAuditLog(c, model.AuditActionOAuth2ClientDeleted, oc.ID, nil)
 
 	return c.NoContent(http.StatusNoContent)
 }
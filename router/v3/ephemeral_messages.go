@@ -0,0 +1,81 @@
+package v3
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/router/utils"
+	"github.com/traPtitech/traQ/service/ws"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// ephemeralMessage 一時メッセージ
+//
+// 永続化されず、対象ユーザーのWebSocketにのみ送信されます
+type ephemeralMessage struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"userId"`
+	ChannelID uuid.UUID `json:"channelId"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// sendEphemeralMessage 一時メッセージをtargetIDのユーザーに送信します
+func (h *Handlers) sendEphemeralMessage(channelID, userID, targetID uuid.UUID, content string) *ephemeralMessage {
+	m := &ephemeralMessage{
+		ID:        uuid.Must(uuid.NewV4()),
+		UserID:    userID,
+		ChannelID: channelID,
+		Content:   content,
+		CreatedAt: time.Now(),
+	}
+	h.WS.WriteMessage("EPHEMERAL_MESSAGE", m, ws.TargetUsers(targetID))
+	return m
+}
+
+// PostEphemeralMessageRequest POST /channels/:channelID/ephemeral-messages リクエストボディ
+type PostEphemeralMessageRequest struct {
+	UserID  uuid.UUID `json:"userId"`
+	Content string    `json:"content"`
+	Embed   bool      `json:"embed"`
+}
+
+func (r PostEphemeralMessageRequest) ValidateWithContext(ctx context.Context) error {
+	return vd.ValidateStructWithContext(ctx, &r,
+		vd.Field(&r.UserID, vd.Required, validator.NotNilUUID, utils.IsActiveHumanUserID),
+		vd.Field(&r.Content, vd.Required, vd.RuneLength(1, 10000)),
+	)
+}
+
+// PostEphemeralMessage POST /channels/:channelID/ephemeral-messages
+func (h *Handlers) PostEphemeralMessage(c echo.Context) error {
+	userID := getRequestUserID(c)
+	ch := getParamChannel(c)
+
+	var req PostEphemeralMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if h.ChannelManager.IsPublicChannel(ch.ID) && h.ChannelManager.PublicChannelTree().IsArchivedChannel(ch.ID) {
+		return herror.BadRequest("this channel has been archived")
+	}
+	// 対象ユーザーがチャンネルにアクセスできるか
+	if ok, err := h.ChannelManager.IsChannelAccessibleToUser(req.UserID, ch.ID); err != nil {
+		return herror.InternalServerError(err)
+	} else if !ok {
+		return herror.BadRequest("the user cannot access this channel")
+	}
+
+	if req.Embed {
+		req.Content = h.Replacer.Replace(req.Content)
+	}
+
+	return c.JSON(http.StatusCreated, h.sendEphemeralMessage(ch.ID, userID, req.UserID, req.Content))
+}
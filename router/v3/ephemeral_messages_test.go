@@ -0,0 +1,109 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/random"
+)
+
+func TestHandlers_PostEphemeralMessage(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/channels/{channelId}/ephemeral-messages"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	channel := env.CreateChannel(t, rand)
+	dm := env.CreateDMChannel(t, user.GetID(), user2.GetID())
+	bot := env.CreateBot(t, rand, user.GetID())
+	botSession := env.S(t, bot.BotUserID)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, channel.ID.String()).
+			WithJSON(&PostEphemeralMessageRequest{UserID: user.GetID(), Content: "po"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("non bot", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, env.S(t, user.GetID())).
+			WithJSON(&PostEphemeralMessageRequest{UserID: user2.GetID(), Content: "po"}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request (empty content)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PostEphemeralMessageRequest{UserID: user.GetID(), Content: ""}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (unknown user)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PostEphemeralMessageRequest{UserID: uuid.Must(uuid.NewV4()), Content: "po"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("channel not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4()).String()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PostEphemeralMessageRequest{UserID: user.GetID(), Content: "po"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("inaccessible channel", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, dm.ID.String()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PostEphemeralMessageRequest{UserID: user.GetID(), Content: "po"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		content := random.AlphaNumeric(20)
+		obj := e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PostEphemeralMessageRequest{UserID: user.GetID(), Content: content}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("id").String().NotEmpty()
+		obj.Value("userId").String().IsEqual(bot.BotUserID.String())
+		obj.Value("channelId").String().IsEqual(channel.ID.String())
+		obj.Value("content").String().IsEqual(content)
+
+		// 永続化されない
+		e.GET("/api/v3/channels/{channelId}/messages", channel.ID.String()).
+			WithCookie(session.CookieName, env.S(t, user.GetID())).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array().
+			IsEmpty()
+	})
+}
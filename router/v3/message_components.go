@@ -0,0 +1,234 @@
+package v3
+
+import (
+	"errors"
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/message"
+)
+
+// maxMessageComponents 1メッセージに添付できるコンポーネントの最大数
+const maxMessageComponents = 25
+
+var messageButtonStyleRule = vd.In("primary", "secondary", "danger")
+
+// MessageComponentOptionRequest セレクトメニューの選択肢
+type MessageComponentOptionRequest struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+func (r MessageComponentOptionRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Label, vd.Required, vd.RuneLength(1, 80)),
+		vd.Field(&r.Value, vd.Required, vd.RuneLength(1, 100)),
+	)
+}
+
+// MessageComponentRequest メッセージコンポーネントの定義
+type MessageComponentRequest struct {
+	Type     string                          `json:"type"`
+	CustomID string                          `json:"customId"`
+	Label    string                          `json:"label"`
+	Style    string                          `json:"style"`
+	Options  []MessageComponentOptionRequest `json:"options"`
+	Disabled bool                            `json:"disabled"`
+}
+
+func (r MessageComponentRequest) Validate() error {
+	isButton := r.Type == string(model.MessageComponentTypeButton)
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Type, vd.Required, vd.In(string(model.MessageComponentTypeButton), string(model.MessageComponentTypeSelect))),
+		vd.Field(&r.CustomID, vd.Required, vd.RuneLength(1, 100)),
+		vd.Field(&r.Label, vd.When(isButton, vd.Required), vd.RuneLength(0, 80)),
+		vd.Field(&r.Style, vd.When(isButton, messageButtonStyleRule).Else(vd.Empty)),
+		vd.Field(&r.Options, vd.When(isButton, vd.Empty).Else(vd.Required, vd.Length(1, 25))),
+	)
+}
+
+// MessageComponentsRequest メッセージコンポーネントの配列
+type MessageComponentsRequest []MessageComponentRequest
+
+func (l MessageComponentsRequest) Validate() error {
+	if len(l) > maxMessageComponents {
+		return vd.NewError("validation_length_too_long", "the number of components must be 25 or less")
+	}
+	if err := vd.Validate([]MessageComponentRequest(l)); err != nil {
+		return err
+	}
+	ids := make(map[string]struct{}, len(l))
+	for _, c := range l {
+		if _, ok := ids[c.CustomID]; ok {
+			return vd.NewError("validation_duplicate_custom_id", "duplicate customId: "+c.CustomID)
+		}
+		ids[c.CustomID] = struct{}{}
+	}
+	return nil
+}
+
+func (l MessageComponentsRequest) toModel() model.MessageComponentList {
+	components := make(model.MessageComponentList, len(l))
+	for i, c := range l {
+		var options []model.MessageComponentOption
+		if len(c.Options) > 0 {
+			options = make([]model.MessageComponentOption, len(c.Options))
+			for j, o := range c.Options {
+				options[j] = model.MessageComponentOption{Label: o.Label, Value: o.Value}
+			}
+		}
+		components[i] = model.MessageComponent{
+			Type:     model.MessageComponentType(c.Type),
+			CustomID: c.CustomID,
+			Label:    c.Label,
+			Style:    c.Style,
+			Options:  options,
+			Disabled: c.Disabled,
+		}
+	}
+	return components
+}
+
+// PostMessageWithComponentsRequest POST /channels/:channelID/messages, POST /users/:userId/messages リクエストボディ
+type PostMessageWithComponentsRequest struct {
+	PostMessageRequest
+	// Components BOTのみ指定可能
+	Components MessageComponentsRequest `json:"components"`
+}
+
+func (r PostMessageWithComponentsRequest) Validate() error {
+	if err := r.PostMessageRequest.Validate(); err != nil {
+		return err
+	}
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Components),
+	)
+}
+
+// attachMessageComponents 作成したメッセージにコンポーネントを添付します
+func (h *Handlers) attachMessageComponents(m message.Message, components MessageComponentsRequest) (message.Message, error) {
+	if len(components) == 0 {
+		return m, nil
+	}
+	if err := h.MessageManager.SetComponents(m.GetID(), components.toModel()); err != nil {
+		return nil, err
+	}
+	return h.MessageManager.Get(m.GetID())
+}
+
+// PutMessageComponentsRequest PUT /messages/:messageID/components リクエストボディ
+type PutMessageComponentsRequest struct {
+	Components MessageComponentsRequest `json:"components"`
+}
+
+func (r PutMessageComponentsRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Components, vd.NotNil),
+	)
+}
+
+// EditMessageComponents PUT /messages/:messageID/components
+func (h *Handlers) EditMessageComponents(c echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	var req PutMessageComponentsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	// 他人のメッセージは編集できない
+	if userID != m.GetUserID() {
+		return herror.Forbidden("This is not your message")
+	}
+
+	if err := h.MessageManager.SetComponents(m.GetID(), req.Components.toModel()); err != nil {
+		switch err {
+		case message.ErrNotFound:
+			return herror.NotFound()
+		case message.ErrChannelArchived:
+			return herror.BadRequest("the channel of this message has been archived")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PostMessageInteractionRequest POST /messages/:messageID/interactions リクエストボディ
+type PostMessageInteractionRequest struct {
+	CustomID string   `json:"customId"`
+	Values   []string `json:"values"`
+}
+
+func (r PostMessageInteractionRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.CustomID, vd.Required),
+		vd.Field(&r.Values, vd.Length(0, 1)),
+	)
+}
+
+var errInvalidInteraction = errors.New("invalid interaction")
+
+// PostMessageInteraction POST /messages/:messageID/interactions
+func (h *Handlers) PostMessageInteraction(c echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	var req PostMessageInteractionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	component := m.GetComponents().Find(req.CustomID)
+	if component == nil {
+		return herror.NotFound("the component was not found")
+	}
+	if component.Disabled {
+		return herror.BadRequest("this component is disabled")
+	}
+	if h.ChannelManager.IsPublicChannel(m.GetChannelID()) && h.ChannelManager.PublicChannelTree().IsArchivedChannel(m.GetChannelID()) {
+		return herror.BadRequest("the channel of this message has been archived")
+	}
+	values, err := validateInteractionValues(component, req.Values)
+	if err != nil {
+		return herror.BadRequest(err)
+	}
+
+	h.Hub.Publish(hub.Message{
+		Name: event.MessageComponentInteracted,
+		Fields: hub.Fields{
+			"message_id":  m.GetID(),
+			"channel_id":  m.GetChannelID(),
+			"bot_user_id": m.GetUserID(),
+			"user_id":     userID,
+			"component":   *component,
+			"values":      values,
+		},
+	})
+	return c.NoContent(http.StatusNoContent)
+}
+
+// validateInteractionValues コンポーネントに対して送信された値を検証します
+func validateInteractionValues(component *model.MessageComponent, values []string) ([]string, error) {
+	switch component.Type {
+	case model.MessageComponentTypeButton:
+		if len(values) > 0 {
+			return nil, errInvalidInteraction
+		}
+		return []string{}, nil
+	case model.MessageComponentTypeSelect:
+		if len(values) != 1 || !component.HasOption(values[0]) {
+			return nil, errInvalidInteraction
+		}
+		return values, nil
+	default:
+		return nil, errInvalidInteraction
+	}
+}
@@ -0,0 +1,279 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/service/message"
+)
+
+func TestHandlers_PostMessage_Components(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/channels/{channelId}/messages"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	channel := env.CreateChannel(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	botSession := env.S(t, bot.BotUserID)
+
+	button := MessageComponentRequest{Type: "button", CustomID: "approve", Label: "承認", Style: "primary"}
+	sel := MessageComponentRequest{
+		Type:     "select",
+		CustomID: "vote",
+		Label:    "選んでください",
+		Options:  []MessageComponentOptionRequest{{Label: "A", Value: "a"}, {Label: "B", Value: "b"}},
+	}
+
+	t.Run("non bot", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, env.S(t, user.GetID())).
+			WithJSON(&PostMessageWithComponentsRequest{
+				PostMessageRequest: PostMessageRequest{Content: "po"},
+				Components:         MessageComponentsRequest{button},
+			}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		cases := []MessageComponentsRequest{
+			{button, button},
+			{{Type: "text", CustomID: "a", Label: "a"}},
+			{{Type: "button", CustomID: "a"}},
+			{{Type: "button", CustomID: "a", Label: "a", Style: "unknown"}},
+			{{Type: "select", CustomID: "a"}},
+		}
+		for _, components := range cases {
+			e := env.R(t)
+			e.POST(path, channel.ID.String()).
+				WithCookie(session.CookieName, botSession).
+				WithJSON(&PostMessageWithComponentsRequest{
+					PostMessageRequest: PostMessageRequest{Content: "po"},
+					Components:         components,
+				}).
+				Expect().
+				Status(http.StatusBadRequest)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path, channel.ID.String()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PostMessageWithComponentsRequest{
+				PostMessageRequest: PostMessageRequest{Content: "vote"},
+				Components:         MessageComponentsRequest{button, sel},
+			}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("content").String().IsEqual("vote")
+		components := obj.Value("components").Array()
+		components.Length().IsEqual(2)
+		components.Value(0).Object().Value("customId").String().IsEqual("approve")
+		components.Value(0).Object().Value("style").String().IsEqual("primary")
+		components.Value(1).Object().Value("options").Array().Length().IsEqual(2)
+	})
+}
+
+func TestHandlers_EditMessageComponents(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/messages/{messageId}/components"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	channel := env.CreateChannel(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	otherBot := env.CreateBot(t, rand, user.GetID())
+	botSession := env.S(t, bot.BotUserID)
+	m := env.CreateMessage(t, bot.BotUserID, channel.ID, rand)
+
+	req := &PutMessageComponentsRequest{
+		Components: MessageComponentsRequest{{Type: "button", CustomID: "approve", Label: "承認済み", Disabled: true}},
+	}
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, m.GetID()).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("non bot", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, m.GetID()).
+			WithCookie(session.CookieName, env.S(t, user.GetID())).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("other bot", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, m.GetID()).
+			WithCookie(session.CookieName, env.S(t, otherBot.BotUserID)).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, m.GetID()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PutMessageComponentsRequest{}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, m.GetID()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusNoContent)
+
+		updated, err := env.MM.Get(m.GetID())
+		require.NoError(t, err)
+		if components := updated.GetComponents(); assert.Len(t, components, 1) {
+			assert.Equal(t, "承認済み", components[0].Label)
+			assert.True(t, components[0].Disabled)
+		}
+	})
+}
+
+func TestHandlers_PostMessageInteraction(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/messages/{messageId}/interactions"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	commonSession := env.S(t, user.GetID())
+	channel := env.CreateChannel(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+
+	createMessage := func(t *testing.T) message.Message {
+		t.Helper()
+		m := env.CreateMessage(t, bot.BotUserID, channel.ID, rand)
+		require.NoError(t, env.MM.SetComponents(m.GetID(), model.MessageComponentList{
+			{Type: model.MessageComponentTypeButton, CustomID: "approve", Label: "承認"},
+			{Type: model.MessageComponentTypeButton, CustomID: "disabled", Label: "無効", Disabled: true},
+			{
+				Type:     model.MessageComponentTypeSelect,
+				CustomID: "vote",
+				Options:  []model.MessageComponentOption{{Label: "A", Value: "a"}, {Label: "B", Value: "b"}},
+			},
+		}))
+		return m
+	}
+	m := createMessage(t)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "approve"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bot", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, env.S(t, bot.BotUserID)).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "approve"}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("component not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "unknown"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		cases := []PostMessageInteractionRequest{
+			{CustomID: ""},
+			{CustomID: "disabled"},
+			{CustomID: "approve", Values: []string{"a"}},
+			{CustomID: "vote"},
+			{CustomID: "vote", Values: []string{"c"}},
+			{CustomID: "vote", Values: []string{"a", "b"}},
+		}
+		for _, req := range cases {
+			e := env.R(t)
+			e.POST(path, m.GetID()).
+				WithCookie(session.CookieName, commonSession).
+				WithJSON(&req).
+				Expect().
+				Status(http.StatusBadRequest)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		m := createMessage(t)
+		sub := env.Hub.Subscribe(10, event.MessageComponentInteracted)
+		defer env.Hub.Unsubscribe(sub)
+
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, commonSession).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "vote", Values: []string{"b"}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case ev := <-sub.Receiver:
+				if ev.Fields["message_id"].(uuid.UUID) != m.GetID() {
+					continue
+				}
+				assert.Equal(t, bot.BotUserID, ev.Fields["bot_user_id"])
+				assert.Equal(t, user.GetID(), ev.Fields["user_id"])
+				assert.Equal(t, "vote", ev.Fields["component"].(model.MessageComponent).CustomID)
+				assert.Equal(t, []string{"b"}, ev.Fields["values"])
+				return
+			case <-timeout:
+				t.Fatal("MessageComponentInteracted event was not published")
+			}
+		}
+	})
+}
@@ -0,0 +1,208 @@
+package v3
+
+import (
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// PostMessageReportRequest POST /messages/:messageID/reports リクエストボディ
+type PostMessageReportRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (r PostMessageReportRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Reason, vd.Required, vd.RuneLength(1, 1000)),
+	)
+}
+
+// PostMessageReport POST /messages/:messageID/reports
+func (h *Handlers) PostMessageReport(c echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	var req PostMessageReportRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	// 自分のメッセージは通報できない
+	if m.GetUserID() == userID {
+		return herror.BadRequest("you cannot report your own message")
+	}
+
+	report, err := h.Repo.CreateMessageReport(m.GetID(), userID, req.Reason)
+	if err != nil {
+		switch err {
+		case repository.ErrAlreadyExists:
+			return herror.Conflict("you have already reported this message")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	return c.JSON(http.StatusCreated, report)
+}
+
+// GetMessageReportsRequest GET /message-reports リクエストクエリ
+type GetMessageReportsRequest struct {
+	Status string `query:"status"`
+	Limit  int    `query:"limit"`
+	Offset int    `query:"offset"`
+}
+
+func (r *GetMessageReportsRequest) Validate() error {
+	if r.Limit == 0 {
+		r.Limit = 50
+	}
+	return vd.ValidateStruct(r,
+		vd.Field(&r.Status, vd.In(
+			string(model.MessageReportStatusPending),
+			string(model.MessageReportStatusResolved),
+			string(model.MessageReportStatusDismissed),
+		)),
+		vd.Field(&r.Limit, vd.Min(1), vd.Max(200)),
+		vd.Field(&r.Offset, vd.Min(0)),
+	)
+}
+
+// GetMessageReports GET /message-reports
+func (h *Handlers) GetMessageReports(c echo.Context) error {
+	var req GetMessageReportsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	q := repository.MessageReportsQuery{
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	if len(req.Status) > 0 {
+		q.Status = optional.From(model.MessageReportStatus(req.Status))
+	}
+	reports, err := h.Repo.GetMessageReports(q)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, reports)
+}
+
+// GetMessageReport GET /message-reports/:reportID
+func (h *Handlers) GetMessageReport(c echo.Context) error {
+	return c.JSON(http.StatusOK, getParamMessageReport(c))
+}
+
+// PostResolveMessageReportRequest POST /message-reports/:reportID/resolve リクエストボディ
+type PostResolveMessageReportRequest struct {
+	DeleteMessage bool `json:"deleteMessage"`
+	SuspendUser   bool `json:"suspendUser"`
+}
+
+// ResolveMessageReport POST /message-reports/:reportID/resolve
+func (h *Handlers) ResolveMessageReport(c echo.Context) error {
+	userID := getRequestUserID(c)
+	report := getParamMessageReport(c)
+
+	var req PostResolveMessageReportRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if report.Status != model.MessageReportStatusPending {
+		return herror.Conflict("this report has already been handled")
+	}
+	// ユーザーの一時停止にはユーザー編集権限が必要
+	if req.SuspendUser && !h.RBAC.IsGranted(getRequestUser(c).GetRole(), permission.EditOtherUsers) {
+		return herror.Forbidden("you are not permitted to suspend users")
+	}
+
+	var m message.Message
+	if req.DeleteMessage || req.SuspendUser {
+		var err error
+		m, err = h.MessageManager.Get(report.MessageID)
+		if err != nil {
+			switch err {
+			case message.ErrNotFound:
+				return herror.BadRequest("the reported message has already been deleted")
+			default:
+				return herror.InternalServerError(err)
+			}
+		}
+
+		if req.SuspendUser && m.GetUserID() == userID {
+			return herror.BadRequest("you cannot suspend yourself")
+		}
+		if req.DeleteMessage {
+			ch, err := h.ChannelManager.GetChannel(m.GetChannelID())
+			if err != nil {
+				return herror.InternalServerError(err)
+			}
+			if ch.IsArchived() {
+				return herror.BadRequest("the channel of the reported message has been archived")
+			}
+		}
+	}
+
+	// 複数人が同時に対応しても処理が一度だけ行われるよう、先に対応状態を更新する
+	if err := h.Repo.UpdateMessageReportStatus(report.ID, userID, model.MessageReportStatusResolved); err != nil {
+		switch err {
+		case repository.ErrAlreadyExists:
+			return herror.Conflict("this report has already been handled")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	if req.DeleteMessage {
+		if err := h.MessageManager.Delete(m.GetID()); err != nil {
+			switch err {
+			case message.ErrNotFound:
+				// 既に削除されている
+			default:
+				return herror.InternalServerError(err)
+			}
+		}
+	}
+	if req.SuspendUser {
+		if err := h.Repo.UpdateUser(m.GetUserID(), repository.UpdateUserArgs{UserState: optional.From(model.UserAccountStatusSuspended)}); err != nil {
+			return herror.InternalServerError(err)
+		}
+		h.recordAuditLog(c, model.AuditActionUserUpdated, m.GetUserID().String(), model.AuditLogDetail{
+			"state":           model.UserAccountStatusSuspended,
+			"messageReportId": report.ID,
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DismissMessageReport POST /message-reports/:reportID/dismiss
+func (h *Handlers) DismissMessageReport(c echo.Context) error {
+	userID := getRequestUserID(c)
+	report := getParamMessageReport(c)
+
+	if report.Status != model.MessageReportStatusPending {
+		return herror.Conflict("this report has already been handled")
+	}
+
+	if err := h.Repo.UpdateMessageReportStatus(report.ID, userID, model.MessageReportStatusDismissed); err != nil {
+		switch err {
+		case repository.ErrAlreadyExists:
+			return herror.Conflict("this report has already been handled")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,313 @@
+package v3
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/router/session"
+)
+
+func TestPostMessageReportRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		reason  string
+		wantErr bool
+	}{
+		{"empty", "", true},
+		{"too long", strings.Repeat("a", 1001), true},
+		{"success", "spam", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := PostMessageReportRequest{Reason: tt.reason}
+			if err := r.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandlers_PostMessageReport(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/reports"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user2.GetID(), ch.ID, rand)
+	m2 := env.CreateMessage(t, user2.GetID(), ch.ID, rand)
+	own := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	s := env.S(t, user.GetID())
+
+	req := &PostMessageReportRequest{Reason: "spam"}
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request (empty reason)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageReportRequest{}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (own message)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, own.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("id").String().NotEmpty()
+		obj.Value("messageId").String().IsEqual(m.GetID().String())
+		obj.Value("reporter").String().IsEqual(user.GetID().String())
+		obj.Value("reason").String().IsEqual("spam")
+		obj.Value("status").String().IsEqual(string(model.MessageReportStatusPending))
+		obj.Value("handledBy").IsNull()
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		t.Parallel()
+		_, err := env.Repository.CreateMessageReport(m2.GetID(), user.GetID(), "spam")
+		require.NoError(t, err)
+
+		e := env.R(t)
+		e.POST(path, m2.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusConflict)
+	})
+}
+
+func TestHandlers_GetMessageReports(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/message-reports"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, admin.GetID(), ch.ID, rand)
+	report, err := env.Repository.CreateMessageReport(m.GetID(), user.GetID(), "spam")
+	require.NoError(t, err)
+	s := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, adminSession).
+			WithQuery("status", "unknown").
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, adminSession).
+			WithQuery("status", "pending").
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(1)
+		obj.Value(0).Object().Value("id").String().IsEqual(report.ID.String())
+	})
+}
+
+func TestHandlers_ResolveMessageReport(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/message-reports/{reportId}/resolve"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	ch := env.CreateChannel(t, rand)
+	s := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	createReport := func(t *testing.T) *model.MessageReport {
+		t.Helper()
+		m := env.CreateMessage(t, user2.GetID(), ch.ID, rand)
+		r, err := env.Repository.CreateMessageReport(m.GetID(), user.GetID(), "spam")
+		require.NoError(t, err)
+		return r
+	}
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		r := createReport(t)
+		e := env.R(t)
+		e.POST(path, r.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostResolveMessageReportRequest{}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PostResolveMessageReportRequest{}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success (delete message)", func(t *testing.T) {
+		t.Parallel()
+		r := createReport(t)
+		e := env.R(t)
+		e.POST(path, r.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PostResolveMessageReportRequest{DeleteMessage: true}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		_, err := env.MM.Get(r.MessageID)
+		assert.Error(t, err)
+
+		r, err = env.Repository.GetMessageReport(r.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.MessageReportStatusResolved, r.Status)
+		assert.Equal(t, admin.GetID(), r.HandledBy.V)
+
+		// 対応済みの通報
+		e.POST(path, r.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PostResolveMessageReportRequest{}).
+			Expect().
+			Status(http.StatusConflict)
+	})
+
+	t.Run("success (suspend user)", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, env.CreateUser(t, rand).GetID(), ch.ID, rand)
+		r, err := env.Repository.CreateMessageReport(m.GetID(), user.GetID(), "spam")
+		require.NoError(t, err)
+
+		e := env.R(t)
+		e.POST(path, r.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PostResolveMessageReportRequest{SuspendUser: true}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		u, err := env.Repository.GetUser(m.GetUserID(), false)
+		require.NoError(t, err)
+		assert.Equal(t, model.UserAccountStatusSuspended, u.GetState())
+	})
+}
+
+func TestHandlers_DismissMessageReport(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/message-reports/{reportId}/dismiss"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, admin.GetID(), ch.ID, rand)
+	report, err := env.Repository.CreateMessageReport(m.GetID(), user.GetID(), "spam")
+	require.NoError(t, err)
+	s := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, report.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, report.ID).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusNoContent)
+
+		r, err := env.Repository.GetMessageReport(report.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.MessageReportStatusDismissed, r.Status)
+
+		// メッセージは削除されない
+		_, err = env.MM.Get(m.GetID())
+		assert.NoError(t, err)
+
+		// 対応済みの通報
+		e.POST(path, report.ID).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusConflict)
+	})
+}
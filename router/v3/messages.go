@@ -3,6 +3,7 @@ package v3
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	vd "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/labstack/echo/v4"
@@ -12,6 +13,7 @@ import (
 	"github.com/traPtitech/traQ/router/consts"
 	"github.com/traPtitech/traQ/router/extension/herror"
 	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/rbac/permission"
 	"github.com/traPtitech/traQ/service/search"
 )
 
@@ -130,7 +132,8 @@ func (h *Handlers) DeleteMessage(c echo.Context) error {
 	userID := getRequestUserID(c)
 	m := getParamMessage(c)
 
-	if muid := m.GetUserID(); muid != userID {
+	// 他ユーザーのメッセージ削除権限がある場合は所有者の確認をしない
+	if muid := m.GetUserID(); muid != userID && !h.RBAC.IsGrantedInChannel(getRequestUser(c).GetRole(), userID, m.GetChannelID(), permission.DeleteOthersMessage) {
 		mUser, err := h.Repo.GetUser(muid, false)
 		if err != nil {
 			return herror.InternalServerError(err)
@@ -319,10 +322,17 @@ func (h *Handlers) PostMessage(c echo.Context) error {
 	userID := getRequestUserID(c)
 	ch := getParamChannel(c)
 
-	var req PostMessageRequest
+	var req PostMessageWithComponentsRequest
 	if err := bindAndValidate(c, &req); err != nil {
 		return err
 	}
+	if len(req.Components) > 0 && !getRequestUser(c).IsBot() {
+		return herror.BadRequest("only bots can attach components")
+	}
+
+	if handled, err := h.invokeBotCommand(c, ch, req.Content); handled {
+		return err
+	}
 
 	if req.Embed {
 		req.Content = h.Replacer.Replace(req.Content)
@@ -337,6 +347,10 @@ func (h *Handlers) PostMessage(c echo.Context) error {
 			return herror.InternalServerError(err)
 		}
 	}
+	m, err = h.attachMessageComponents(m, req.Components)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
 	return c.JSON(http.StatusCreated, m)
 }
 
@@ -359,15 +373,85 @@ func (h *Handlers) GetDirectMessages(c echo.Context) error {
 	return serveMessages(c, h.MessageManager, req.convertC(ch.ID))
 }
 
+// GetMessageRevisions GET /messages/:messageID/revisions
+func (h *Handlers) GetMessageRevisions(c echo.Context) error {
+	m := getParamMessage(c)
+
+	revisions, err := h.Repo.GetMessageRevisions(m.GetID())
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatMessageRevisions(revisions))
+}
+
+// GetMessageReplies GET /messages/:messageID/replies
+func (h *Handlers) GetMessageReplies(c echo.Context) error {
+	m := getParamMessage(c)
+
+	var req MessagesQuery
+	if err := req.bind(c); err != nil {
+		return err
+	}
+
+	// 返信メッセージが指定された場合はそのスレッドを返す
+	parentID := m.GetID()
+	if p := m.GetParentMessageID(); p.Valid {
+		parentID = p.V
+	}
+
+	return serveMessages(c, h.MessageManager, req.convertP(parentID))
+}
+
+// PostMessageReply POST /messages/:messageID/replies
+func (h *Handlers) PostMessageReply(c echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	var req PostMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if req.Embed {
+		req.Content = h.Replacer.Replace(req.Content)
+	}
+
+	reply, err := h.MessageManager.CreateReply(m.GetID(), userID, req.Content)
+	if err != nil {
+		switch err {
+		case message.ErrNotFound:
+			return herror.NotFound()
+		case message.ErrChannelArchived:
+			return herror.BadRequest("the channel of this message has been archived")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusCreated, reply)
+}
+
 // PostDirectMessage POST /users/:userId/messages
 func (h *Handlers) PostDirectMessage(c echo.Context) error {
 	myID := getRequestUserID(c)
 	targetID := getParamAsUUID(c, consts.ParamUserID)
 
-	var req PostMessageRequest
+	var req PostMessageWithComponentsRequest
 	if err := bindAndValidate(c, &req); err != nil {
 		return err
 	}
+	if len(req.Components) > 0 && !getRequestUser(c).IsBot() {
+		return herror.BadRequest("only bots can attach components")
+	}
+
+	if strings.HasPrefix(req.Content, "/") {
+		ch, err := h.ChannelManager.GetDMChannel(myID, targetID)
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+		if handled, err := h.invokeBotCommand(c, ch, req.Content); handled {
+			return err
+		}
+	}
 
 	if req.Embed {
 		req.Content = h.Replacer.Replace(req.Content)
@@ -377,5 +461,9 @@ func (h *Handlers) PostDirectMessage(c echo.Context) error {
 	if err != nil {
 		return herror.InternalServerError(err)
 	}
+	m, err = h.attachMessageComponents(m, req.Components)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
 	return c.JSON(http.StatusCreated, m)
 }
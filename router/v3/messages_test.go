@@ -467,6 +467,17 @@ func TestHandlers_DeleteMessage(t *testing.T) {
 				Status(tt.expect)
 		})
 	}
+
+	t.Run("other's message (admin)", func(t *testing.T) {
+		t.Parallel()
+		admin := env.CreateAdmin(t, rand)
+		m := env.CreateMessage(t, user3.GetID(), pub.ID, rand)
+		e := env.R(t)
+		e.DELETE(path, m.GetID()).
+			WithCookie(session.CookieName, env.S(t, admin.GetID())).
+			Expect().
+			Status(http.StatusNoContent)
+	})
 }
 
 func TestHandlers_GetPin(t *testing.T) {
@@ -1003,6 +1014,44 @@ func TestHandlers_GetMessages(t *testing.T) {
 		messageEquals(t, m2, obj.Value(0).Object())
 		messageEquals(t, m, obj.Value(1).Object())
 	})
+
+	t.Run("success (replies excluded)", func(t *testing.T) {
+		t.Parallel()
+		ch := env.CreateChannel(t, rand)
+		parent := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+		env.CreateReplyMessage(t, user.GetID(), parent.GetID(), rand)
+		e := env.R(t)
+		obj := e.GET(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(1)
+		messageEquals(t, parent, obj.Value(0).Object())
+		obj.Value(0).Object().Value("replyCount").Number().IsEqual(1)
+	})
+
+	t.Run("success (includeReplies)", func(t *testing.T) {
+		t.Parallel()
+		ch := env.CreateChannel(t, rand)
+		parent := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+		r := env.CreateReplyMessage(t, user.GetID(), parent.GetID(), rand)
+		e := env.R(t)
+		obj := e.GET(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithQuery("includeReplies", true).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(2)
+		messageEquals(t, r, obj.Value(0).Object())
+		messageEquals(t, parent, obj.Value(1).Object())
+		obj.Value(1).Object().Value("replyCount").Number().IsEqual(1)
+	})
 }
 
 func TestHandlers_PostMessage(t *testing.T) {
@@ -1089,6 +1138,228 @@ func TestHandlers_PostMessage(t *testing.T) {
 	})
 }
 
+func TestHandlers_GetMessageReplies(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/replies"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	parent := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	r1 := env.CreateReplyMessage(t, user.GetID(), parent.GetID(), rand)
+	r2 := env.CreateReplyMessage(t, user.GetID(), r1.GetID(), rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, parent.GetID()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, parent.GetID()).
+			WithCookie(session.CookieName, s).
+			WithQuery("limit", -1).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, parent.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(2)
+		messageEquals(t, r2, obj.Value(0).Object())
+		messageEquals(t, r1, obj.Value(1).Object())
+		obj.Value(0).Object().Value("threadId").String().IsEqual(parent.GetID().String())
+	})
+
+	t.Run("success (reply specified)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, r1.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(2)
+	})
+}
+
+func TestHandlers_GetMessageRevisions(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/revisions"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	user3 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	dm := env.CreateDMChannel(t, user.GetID(), user2.GetID())
+	m := env.CreateMessage(t, user.GetID(), ch.ID, "first")
+	require.NoError(t, env.MM.Edit(m.GetID(), "second"))
+	require.NoError(t, env.MM.Edit(m.GetID(), "third"))
+	dmMessage := env.CreateMessage(t, user.GetID(), dm.ID, rand)
+	s := env.S(t, user.GetID())
+	s3 := env.S(t, user3.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, m.GetID()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("not found (inaccessible channel)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, dmMessage.GetID()).
+			WithCookie(session.CookieName, s3).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(2)
+		obj.Value(0).Object().Value("messageId").String().IsEqual(m.GetID().String())
+		obj.Value(0).Object().Value("content").String().IsEqual("first")
+		obj.Value(1).Object().Value("content").String().IsEqual("second")
+	})
+
+	t.Run("success (not edited)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, dmMessage.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array().
+			IsEmpty()
+	})
+}
+
+func TestHandlers_PostMessageReply(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/replies"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	parent := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	archived := env.CreateChannel(t, rand)
+	archivedParent := env.CreateMessage(t, user.GetID(), archived.ID, rand)
+	require.NoError(t, env.CM.ArchiveChannel(archived.ID, user.GetID()))
+	s := env.S(t, user.GetID())
+
+	req := &PostMessageRequest{
+		Content: "Hello, traP",
+	}
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, parent.GetID()).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("archived", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, archivedParent.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, parent.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageRequest{Content: ""}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path, parent.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("userId").String().IsEqual(user.GetID().String())
+		obj.Value("channelId").String().IsEqual(ch.ID.String())
+		obj.Value("content").String().IsEqual("Hello, traP")
+		obj.Value("threadId").String().IsEqual(parent.GetID().String())
+
+		id, err := uuid.FromString(obj.Value("id").String().Raw())
+		if assert.NoError(t, err) {
+			m, err := env.MM.Get(id)
+			require.NoError(t, err)
+			messageEquals(t, m, obj)
+		}
+	})
+}
+
 func TestHandlers_GetDirectMessages(t *testing.T) {
 	t.Parallel()
 
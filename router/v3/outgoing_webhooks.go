@@ -0,0 +1,221 @@
+package v3
+
+import (
+	"context"
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/router/utils"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+var outgoingWebhookEventRule = vd.In(
+	model.OutgoingWebhookEventMessageCreated.String(),
+	model.OutgoingWebhookEventMessageStamped.String(),
+	model.OutgoingWebhookEventMessagePinned.String(),
+)
+
+// GetOutgoingWebhooks GET /outgoing-webhooks
+func (h *Handlers) GetOutgoingWebhooks(c echo.Context) error {
+	user := getRequestUser(c)
+
+	var q repository.OutgoingWebhooksQuery
+	if !isTrue(c.QueryParam("all")) || !h.RBAC.IsGranted(user.GetRole(), permission.AccessOthersWebhook) {
+		q.CreatorID = optional.From(user.GetID())
+	}
+	list, err := h.Repo.GetOutgoingWebhooks(q)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, list)
+}
+
+// PostOutgoingWebhookRequest POST /outgoing-webhooks リクエストボディ
+type PostOutgoingWebhookRequest struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	ChannelID   uuid.UUID `json:"channelId"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"secret"`
+	Events      []string  `json:"events"`
+}
+
+func (r PostOutgoingWebhookRequest) ValidateWithContext(ctx context.Context) error {
+	return vd.ValidateStructWithContext(ctx, &r,
+		vd.Field(&r.Name, vd.Required, vd.RuneLength(1, 32)),
+		vd.Field(&r.Description, vd.RuneLength(0, 1000)),
+		vd.Field(&r.ChannelID, vd.Required, validator.NotNilUUID, utils.IsPublicChannelID),
+		vd.Field(&r.URL, vd.Required, vd.RuneLength(1, 1000), is.URL, validator.NotInternalURL),
+		vd.Field(&r.Secret, vd.Required, vd.RuneLength(1, 50)),
+		vd.Field(&r.Events, vd.Required, vd.Each(outgoingWebhookEventRule)),
+	)
+}
+
+// CreateOutgoingWebhook POST /outgoing-webhooks
+func (h *Handlers) CreateOutgoingWebhook(c echo.Context) error {
+	var req PostOutgoingWebhookRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	w, err := h.Repo.CreateOutgoingWebhook(repository.CreateOutgoingWebhookArgs{
+		Name:        req.Name,
+		Description: req.Description,
+		ChannelID:   req.ChannelID,
+		URL:         req.URL,
+		Secret:      req.Secret,
+		Events:      model.OutgoingWebhookEventsFromArray(req.Events),
+		CreatorID:   getRequestUserID(c),
+	})
+	if err != nil {
+		switch {
+		case repository.IsArgError(err):
+			return herror.BadRequest(err)
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	h.recordAuditLog(c, model.AuditActionOutgoingWebhookCreated, w.ID.String(), model.AuditLogDetail{"name": req.Name, "channelId": req.ChannelID, "url": req.URL})
+	return c.JSON(http.StatusCreated, w)
+}
+
+// GetOutgoingWebhook GET /outgoing-webhooks/:outgoingWebhookID
+func (h *Handlers) GetOutgoingWebhook(c echo.Context) error {
+	return c.JSON(http.StatusOK, getParamOutgoingWebhook(c))
+}
+
+// PatchOutgoingWebhookRequest PATCH /outgoing-webhooks/:outgoingWebhookID リクエストボディ
+type PatchOutgoingWebhookRequest struct {
+	Name        optional.Of[string]    `json:"name"`
+	Description optional.Of[string]    `json:"description"`
+	ChannelID   optional.Of[uuid.UUID] `json:"channelId"`
+	URL         optional.Of[string]    `json:"url"`
+	Secret      optional.Of[string]    `json:"secret"`
+	Events      []string               `json:"events"`
+	OwnerID     optional.Of[uuid.UUID] `json:"ownerId"`
+}
+
+func (r PatchOutgoingWebhookRequest) ValidateWithContext(ctx context.Context) error {
+	return vd.ValidateStructWithContext(ctx, &r,
+		vd.Field(&r.Name, validator.RequiredIfValid, vd.RuneLength(1, 32)),
+		vd.Field(&r.Description, vd.RuneLength(0, 1000)),
+		vd.Field(&r.ChannelID, validator.NotNilUUID, utils.IsPublicChannelID),
+		vd.Field(&r.URL, validator.RequiredIfValid, vd.RuneLength(1, 1000), is.URL, validator.NotInternalURL),
+		vd.Field(&r.Secret, validator.RequiredIfValid, vd.RuneLength(1, 50)),
+		vd.Field(&r.Events, vd.NilOrNotEmpty, vd.Each(outgoingWebhookEventRule)),
+		vd.Field(&r.OwnerID, validator.NotNilUUID, utils.IsActiveHumanUserID),
+	)
+}
+
+// EditOutgoingWebhook PATCH /outgoing-webhooks/:outgoingWebhookID
+func (h *Handlers) EditOutgoingWebhook(c echo.Context) error {
+	w := getParamOutgoingWebhook(c)
+
+	var req PatchOutgoingWebhookRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	args := repository.UpdateOutgoingWebhookArgs{
+		Name:        req.Name,
+		Description: req.Description,
+		ChannelID:   req.ChannelID,
+		URL:         req.URL,
+		Secret:      req.Secret,
+		CreatorID:   req.OwnerID,
+	}
+	if req.Events != nil {
+		args.Events = model.OutgoingWebhookEventsFromArray(req.Events)
+	}
+	if err := h.Repo.UpdateOutgoingWebhook(w.ID, args); err != nil {
+		switch {
+		case repository.IsArgError(err):
+			return herror.BadRequest(err)
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	h.recordAuditLog(c, model.AuditActionOutgoingWebhookUpdated, w.ID.String(), model.AuditLogDetail{
+		"name":          req.Name,
+		"channelId":     req.ChannelID,
+		"url":           req.URL,
+		"secretChanged": req.Secret.Valid,
+		"events":        req.Events,
+		"ownerId":       req.OwnerID,
+	})
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteOutgoingWebhook DELETE /outgoing-webhooks/:outgoingWebhookID
+func (h *Handlers) DeleteOutgoingWebhook(c echo.Context) error {
+	w := getParamOutgoingWebhook(c)
+
+	if err := h.Repo.DeleteOutgoingWebhook(w.ID); err != nil {
+		return herror.InternalServerError(err)
+	}
+	h.recordAuditLog(c, model.AuditActionOutgoingWebhookDeleted, w.ID.String(), model.AuditLogDetail{"name": w.Name})
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetOutgoingWebhookLogs GET /outgoing-webhooks/:outgoingWebhookID/logs
+func (h *Handlers) GetOutgoingWebhookLogs(c echo.Context) error {
+	w := getParamOutgoingWebhook(c)
+
+	var req GetBotLogsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	logs, err := h.Repo.GetOutgoingWebhookDeliveryLogs(w.ID, req.Limit, req.Offset)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, logs)
+}
+
+// RedeliverOutgoingWebhookRequest POST /outgoing-webhooks/:outgoingWebhookID/actions/redeliver リクエストボディ
+type RedeliverOutgoingWebhookRequest struct {
+	LogID uuid.UUID `json:"logId"`
+}
+
+func (r RedeliverOutgoingWebhookRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.LogID, vd.Required, validator.NotNilUUID),
+	)
+}
+
+// RedeliverOutgoingWebhook POST /outgoing-webhooks/:outgoingWebhookID/actions/redeliver
+func (h *Handlers) RedeliverOutgoingWebhook(c echo.Context) error {
+	w := getParamOutgoingWebhook(c)
+
+	var req RedeliverOutgoingWebhookRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	log, err := h.Repo.GetOutgoingWebhookDeliveryLog(req.LogID)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.BadRequest("invalid logId")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	if log.WebhookID != w.ID {
+		return herror.BadRequest("invalid logId")
+	}
+
+	h.OutgoingWebhook.Redeliver(w, log)
+	return c.NoContent(http.StatusAccepted)
+}
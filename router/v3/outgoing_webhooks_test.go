@@ -0,0 +1,287 @@
+package v3
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestHandlers_GetOutgoingWebhooks(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/outgoing-webhooks"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	ch := env.CreateChannel(t, rand)
+	w := env.CreateOutgoingWebhook(t, rand, user.GetID(), ch.ID, "https://example.com/hook")
+	env.CreateOutgoingWebhook(t, rand, user2.GetID(), ch.ID, "https://example.com/hook")
+	userSession := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, userSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(1)
+		first := obj.Value(0).Object()
+		first.Value("id").String().IsEqual(w.ID.String())
+		first.Value("url").String().IsEqual(w.URL)
+		first.NotContainsKey("secret")
+	})
+
+	t.Run("success (all=true)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, adminSession).
+			WithQuery("all", true).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array().
+			Length().
+			Ge(2)
+	})
+}
+
+func TestHandlers_CreateOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/outgoing-webhooks"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithJSON(&PostOutgoingWebhookRequest{}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostOutgoingWebhookRequest{Name: "po", ChannelID: ch.ID, URL: "https://example.com", Secret: "secret", Events: []string{"UNKNOWN"}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (dm channel)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		dm := env.CreateDMChannel(t, user.GetID(), env.CreateUser(t, rand).GetID())
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostOutgoingWebhookRequest{Name: "po", ChannelID: dm.ID, URL: "https://example.com", Secret: "secret", Events: []string{"MESSAGE_CREATED"}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostOutgoingWebhookRequest{Name: "po", Description: "desc", ChannelID: ch.ID, URL: "https://example.com", Secret: "secret", Events: []string{"MESSAGE_CREATED", "MESSAGE_PINNED"}}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("name").String().IsEqual("po")
+		obj.Value("channelId").String().IsEqual(ch.ID.String())
+		obj.Value("ownerId").String().IsEqual(user.GetID().String())
+		obj.Value("events").Array().ContainsOnly("MESSAGE_CREATED", "MESSAGE_PINNED")
+		obj.NotContainsKey("secret")
+	})
+}
+
+func TestHandlers_EditOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/outgoing-webhooks/{outgoingWebhookId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	w := env.CreateOutgoingWebhook(t, rand, user.GetID(), ch.ID, "https://example.com/hook")
+	userSession := env.S(t, user.GetID())
+	user2Session := env.S(t, user2.GetID())
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, w.ID).
+			WithCookie(session.CookieName, user2Session).
+			WithJSON(&PatchOutgoingWebhookRequest{Name: optional.From("po")}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, userSession).
+			WithJSON(&PatchOutgoingWebhookRequest{Name: optional.From("po")}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request (empty events)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, w.ID).
+			WithCookie(session.CookieName, userSession).
+			WithJSON(map[string]interface{}{"events": []string{}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, w.ID).
+			WithCookie(session.CookieName, userSession).
+			WithJSON(&PatchOutgoingWebhookRequest{Name: optional.From("updated"), Events: []string{"MESSAGE_STAMPED"}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		updated, err := env.Repository.GetOutgoingWebhook(w.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "updated", updated.Name)
+		assert.Equal(t, model.OutgoingWebhookEvents{model.OutgoingWebhookEventMessageStamped: {}}, updated.Events)
+	})
+}
+
+func TestHandlers_DeleteOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/outgoing-webhooks/{outgoingWebhookId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	w := env.CreateOutgoingWebhook(t, rand, user.GetID(), ch.ID, "https://example.com/hook")
+	userSession := env.S(t, user.GetID())
+	user2Session := env.S(t, user2.GetID())
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, w.ID).
+			WithCookie(session.CookieName, user2Session).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		w := env.CreateOutgoingWebhook(t, rand, user.GetID(), ch.ID, "https://example.com/hook")
+		e := env.R(t)
+		e.DELETE(path, w.ID).
+			WithCookie(session.CookieName, userSession).
+			Expect().
+			Status(http.StatusNoContent)
+
+		e.GET(path, w.ID).
+			WithCookie(session.CookieName, userSession).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+}
+
+func TestHandlers_RedeliverOutgoingWebhook(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/outgoing-webhooks/{outgoingWebhookId}/actions/redeliver"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	userSession := env.S(t, user.GetID())
+
+	received := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Get("X-TRAQ-WEBHOOK-EVENT")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	w := env.CreateOutgoingWebhook(t, rand, user.GetID(), ch.ID, server.URL)
+	w2 := env.CreateOutgoingWebhook(t, rand, user.GetID(), ch.ID, server.URL)
+	log := &model.OutgoingWebhookDeliveryLog{
+		ID:         uuid.Must(uuid.NewV4()),
+		DeliveryID: uuid.Must(uuid.NewV4()),
+		WebhookID:  w.ID,
+		Event:      model.OutgoingWebhookEventMessageCreated,
+		Body:       "{}",
+		Attempt:    1,
+		Result:     "ne",
+		Code:       -1,
+		DateTime:   time.Now(),
+	}
+	require.NoError(t, env.Repository.WriteOutgoingWebhookDeliveryLog(log))
+
+	t.Run("bad request (log of another webhook)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, w2.ID).
+			WithCookie(session.CookieName, userSession).
+			WithJSON(&RedeliverOutgoingWebhookRequest{LogID: log.ID}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, w.ID).
+			WithCookie(session.CookieName, userSession).
+			WithJSON(&RedeliverOutgoingWebhookRequest{LogID: log.ID}).
+			Expect().
+			Status(http.StatusAccepted)
+
+		select {
+		case ev := <-received:
+			assert.Equal(t, "MESSAGE_CREATED", ev)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the event was not redelivered")
+		}
+
+		assert.Eventually(t, func() bool {
+			logs, err := env.Repository.GetOutgoingWebhookDeliveryLogs(w.ID, 10, 0)
+			return err == nil && len(logs) == 2
+		}, 3*time.Second, 50*time.Millisecond)
+	})
+}
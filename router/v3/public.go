@@ -22,8 +22,9 @@ func (h *Handlers) GetVersion(c echo.Context) error {
 		"version":  h.Version,
 		"revision": h.Revision,
 		"flags": echo.Map{
-			"externalLogin": extLogins,
-			"signUpAllowed": h.Config.AllowSignUp,
+			"externalLogin":  extLogins,
+			"signUpAllowed":  h.Config.AllowSignUp,
+			"vapidPublicKey": h.Config.VAPIDPublicKey,
 		},
 	})
 }
@@ -31,6 +31,7 @@ func TestHandlers_GetVersion(t *testing.T) {
 	flags := obj.Value("flags").Object()
 
 	flags.Value("signUpAllowed").Boolean().IsFalse()
+	flags.Value("vapidPublicKey").String().IsEmpty()
 
 	ext := flags.Value("externalLogin").Array()
 	ext.Length().IsEqual(1)
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/rbac/permission"
 	"github.com/traPtitech/traQ/utils/optional"
 
 	"github.com/gofrs/uuid"
@@ -24,7 +25,7 @@ func formatChannel(channel *model.Channel, childrenID []uuid.UUID) *Channel {
 	return &Channel{
 		ID:       channel.ID,
 		Name:     channel.Name,
-		ParentID: optional.New(channel.ParentID, channel.ParentID != uuid.Nil),
+		ParentID: optional.New(channel.ParentID, channel.ParentID != uuid.Nil && !channel.IsPrivateChannel()),
 		Topic:    channel.Topic,
 		Children: childrenID,
 		Archived: channel.IsArchived(),
@@ -147,6 +148,7 @@ type Webhook struct {
 	DisplayName string    `json:"displayName"`
 	Description string    `json:"description"`
 	Secure      bool      `json:"secure"`
+	Template    string    `json:"template"`
 	ChannelID   string    `json:"channelId"`
 	OwnerID     string    `json:"ownerId"`
 	CreatedAt   time.Time `json:"createdAt"`
@@ -160,6 +162,7 @@ func formatWebhook(w model.Webhook) *Webhook {
 		DisplayName: w.GetName(),
 		Description: w.GetDescription(),
 		Secure:      len(w.GetSecret()) > 0,
+		Template:    w.GetTemplate(),
 		ChannelID:   w.GetChannelID().String(),
 		OwnerID:     w.GetCreatorID().String(),
 		CreatedAt:   w.GetCreatedAt(),
@@ -211,23 +214,25 @@ func formatBots(bs []*model.Bot) []*Bot {
 
 type BotTokens struct {
 	VerificationToken string `json:"verificationToken"`
+	SigningSecret     string `json:"signingSecret"`
 	AccessToken       string `json:"accessToken"`
 }
 
 type BotDetail struct {
-	ID              uuid.UUID           `json:"id"`
-	BotUserID       uuid.UUID           `json:"botUserId"`
-	Description     string              `json:"description"`
-	DeveloperID     uuid.UUID           `json:"developerId"`
-	SubscribeEvents model.BotEventTypes `json:"subscribeEvents"`
-	Mode            model.BotMode       `json:"mode"`
-	State           model.BotState      `json:"state"`
-	CreatedAt       time.Time           `json:"createdAt"`
-	UpdatedAt       time.Time           `json:"updatedAt"`
-	Tokens          BotTokens           `json:"tokens"`
-	Endpoint        string              `json:"endpoint"`
-	Privileged      bool                `json:"privileged"`
-	Channels        []uuid.UUID         `json:"channels"`
+	ID              uuid.UUID             `json:"id"`
+	BotUserID       uuid.UUID             `json:"botUserId"`
+	Description     string                `json:"description"`
+	DeveloperID     uuid.UUID             `json:"developerId"`
+	SubscribeEvents model.BotEventTypes   `json:"subscribeEvents"`
+	EventFilters    model.BotEventFilters `json:"eventFilters"`
+	Mode            model.BotMode         `json:"mode"`
+	State           model.BotState        `json:"state"`
+	CreatedAt       time.Time             `json:"createdAt"`
+	UpdatedAt       time.Time             `json:"updatedAt"`
+	Tokens          BotTokens             `json:"tokens"`
+	Endpoint        string                `json:"endpoint"`
+	Privileged      bool                  `json:"privileged"`
+	Channels        []uuid.UUID           `json:"channels"`
 }
 
 func formatBotDetail(b *model.Bot, t *model.OAuth2Token, channels []uuid.UUID) *BotDetail {
@@ -236,6 +241,7 @@ func formatBotDetail(b *model.Bot, t *model.OAuth2Token, channels []uuid.UUID) *
 		BotUserID:       b.BotUserID,
 		Description:     b.Description,
 		SubscribeEvents: b.SubscribeEvents,
+		EventFilters:    b.EventFilters,
 		Mode:            b.Mode,
 		State:           b.State,
 		DeveloperID:     b.CreatorID,
@@ -243,6 +249,7 @@ func formatBotDetail(b *model.Bot, t *model.OAuth2Token, channels []uuid.UUID) *
 		UpdatedAt:       b.UpdatedAt,
 		Tokens: BotTokens{
 			VerificationToken: b.VerificationToken,
+			SigningSecret:     b.SigningSecret,
 			AccessToken:       t.AccessToken,
 		},
 		Endpoint:   b.PostURL,
@@ -279,6 +286,32 @@ func formatBotEventLogs(logs []*model.BotEventLog) []*botEventLogResponse {
 	return res
 }
 
+type botDeadLetterResponse struct {
+	ID        uuid.UUID          `json:"id"`
+	BotID     uuid.UUID          `json:"botId"`
+	Event     model.BotEventType `json:"event"`
+	Attempts  int                `json:"attempts"`
+	LastError string             `json:"lastError"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+func formatBotDeadLetters(deliveries []*model.BotEventDelivery) []*botDeadLetterResponse {
+	res := make([]*botDeadLetterResponse, len(deliveries))
+	for i, d := range deliveries {
+		res[i] = &botDeadLetterResponse{
+			ID:        d.ID,
+			BotID:     d.BotID,
+			Event:     d.Event,
+			Attempts:  d.Attempts,
+			LastError: d.LastError,
+			CreatedAt: d.CreatedAt,
+			UpdatedAt: d.UpdatedAt,
+		}
+	}
+	return res
+}
+
 type Message struct {
 	ID        uuid.UUID              `json:"id"`
 	UserID    uuid.UUID              `json:"userId"`
@@ -288,7 +321,8 @@ type Message struct {
 	UpdatedAt time.Time              `json:"updatedAt"`
 	Pinned    bool                   `json:"pinned"`
 	Stamps    []model.MessageStamp   `json:"stamps"`
-	ThreadID  optional.Of[uuid.UUID] `json:"threadId"` // TODO
+	ThreadID  optional.Of[uuid.UUID] `json:"threadId"`
+	Edited    bool                   `json:"edited"`
 }
 
 func formatMessage(m *model.Message) *Message {
@@ -301,9 +335,31 @@ func formatMessage(m *model.Message) *Message {
 		UpdatedAt: m.UpdatedAt,
 		Pinned:    m.Pin != nil,
 		Stamps:    m.Stamps,
+		ThreadID:  m.ParentMessageID,
+		Edited:    m.IsEdited(),
 	}
 }
 
+type MessageRevision struct {
+	ID        uuid.UUID `json:"id"`
+	MessageID uuid.UUID `json:"messageId"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func formatMessageRevisions(revisions []*model.MessageRevision) []*MessageRevision {
+	res := make([]*MessageRevision, len(revisions))
+	for i, r := range revisions {
+		res[i] = &MessageRevision{
+			ID:        r.ID,
+			MessageID: r.MessageID,
+			Content:   r.Text,
+			CreatedAt: r.DateTime,
+		}
+	}
+	return res
+}
+
 type Pin struct {
 	UserID   uuid.UUID `json:"userId"`
 	PinnedAt time.Time `json:"pinnedAt"`
@@ -623,3 +679,32 @@ func formatStampPalettes(cfs []*model.StampPalette) []*StampPalette {
 	sort.Slice(res, func(i, j int) bool { return res[i].ID.String() < res[j].ID.String() })
 	return res
 }
+
+type Role struct {
+	Name               string                  `json:"name"`
+	System             bool                    `json:"system"`
+	Permissions        []string                `json:"permissions"`
+	Inheritances       []string                `json:"inheritances"`
+	GrantedPermissions []permission.Permission `json:"grantedPermissions"`
+}
+
+func formatRole(r *model.UserRole, granted []permission.Permission) *Role {
+	res := &Role{
+		Name:               r.Name,
+		System:             r.System,
+		Permissions:        make([]string, len(r.Permissions)),
+		Inheritances:       make([]string, len(r.Inheritances)),
+		GrantedPermissions: make([]permission.Permission, len(granted)),
+	}
+	for i, p := range r.Permissions {
+		res.Permissions[i] = p.Permission
+	}
+	for i, sub := range r.Inheritances {
+		res.Inheritances[i] = sub.Name
+	}
+	copy(res.GrantedPermissions, granted)
+	sort.Strings(res.Permissions)
+	sort.Strings(res.Inheritances)
+	sort.Slice(res.GrantedPermissions, func(i, j int) bool { return res.GrantedPermissions[i] < res.GrantedPermissions[j] })
+	return res
+}
@@ -0,0 +1,219 @@
+package v3
+
+import (
+	"net/http"
+	"sort"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/rbac"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+var rolePermissionRules = []vd.Rule{
+	vd.Each(vd.Required, vd.In(func() []interface{} {
+		res := make([]interface{}, len(permission.List))
+		for i, p := range permission.List {
+			res[i] = p.Name()
+		}
+		return res
+	}()...).Error("must be a valid permission")),
+}
+
+var roleInheritancesRules = []vd.Rule{
+	vd.Each(vd.Required, vd.Match(validator.UserRoleNameRegex)),
+}
+
+// GetRoles GET /roles
+func (h *Handlers) GetRoles(c echo.Context) error {
+	roles, err := h.Repo.GetAllUserRoles()
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	res := make([]*Role, len(roles))
+	for i, r := range roles {
+		res[i] = formatRole(r, h.RBAC.GetGrantedPermissions(r.Name))
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return c.JSON(http.StatusOK, res)
+}
+
+// PostRoleRequest POST /roles リクエストボディ
+type PostRoleRequest struct {
+	Name         string   `json:"name"`
+	Permissions  []string `json:"permissions"`
+	Inheritances []string `json:"inheritances"`
+}
+
+func (r PostRoleRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Name, vd.Required, vd.Match(validator.UserRoleNameRegex)),
+		vd.Field(&r.Permissions, rolePermissionRules...),
+		vd.Field(&r.Inheritances, roleInheritancesRules...),
+	)
+}
+
+// CreateRole POST /roles
+func (h *Handlers) CreateRole(c echo.Context) error {
+	var req PostRoleRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	roles, err := h.Repo.GetAllUserRoles()
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	roleMap := make(map[string]*model.UserRole, len(roles))
+	for _, r := range roles {
+		roleMap[r.Name] = r
+	}
+	if _, ok := roleMap[req.Name]; ok {
+		return herror.Conflict("this name has already been used")
+	}
+
+	role := &model.UserRole{Name: req.Name}
+	added := map[string]bool{}
+	for _, p := range req.Permissions {
+		if !added[p] {
+			added[p] = true
+			role.Permissions = append(role.Permissions, model.RolePermission{Role: req.Name, Permission: p})
+		}
+	}
+	for _, name := range req.Inheritances {
+		sub, ok := roleMap[name]
+		if !ok {
+			return herror.BadRequest("invalid inheritance: " + name)
+		}
+		role.Inheritances = append(role.Inheritances, sub)
+	}
+
+	if err := h.Repo.CreateUserRoles(role); err != nil {
+		return herror.InternalServerError(err)
+	}
+	h.recordAuditLog(c, model.AuditActionUserRoleCreated, req.Name, model.AuditLogDetail{
+		"permissions":  req.Permissions,
+		"inheritances": req.Inheritances,
+	})
+	if err := h.RBAC.Reload(); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	role, err = h.Repo.GetUserRole(req.Name)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusCreated, formatRole(role, h.RBAC.GetGrantedPermissions(role.Name)))
+}
+
+// GetRole GET /roles/:roleName
+func (h *Handlers) GetRole(c echo.Context) error {
+	role := getParamRole(c)
+	return c.JSON(http.StatusOK, formatRole(role, h.RBAC.GetGrantedPermissions(role.Name)))
+}
+
+// PatchRoleRequest PATCH /roles/:roleName リクエストボディ
+type PatchRoleRequest struct {
+	Permissions  []string `json:"permissions"`
+	Inheritances []string `json:"inheritances"`
+}
+
+func (r PatchRoleRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Permissions, rolePermissionRules...),
+		vd.Field(&r.Inheritances, roleInheritancesRules...),
+	)
+}
+
+// EditRole PATCH /roles/:roleName
+func (h *Handlers) EditRole(c echo.Context) error {
+	role := getParamRole(c)
+
+	var req PatchRoleRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if role.System {
+		return herror.Forbidden("system roles cannot be modified")
+	}
+
+	// 継承関係の循環検知
+	if req.Inheritances != nil {
+		roles, err := h.Repo.GetAllUserRoles()
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+		roleMap := make(map[string]*model.UserRole, len(roles))
+		for _, r := range roles {
+			roleMap[r.Name] = r
+		}
+		subs := make([]*model.UserRole, 0, len(req.Inheritances))
+		for _, name := range req.Inheritances {
+			sub, ok := roleMap[name]
+			if !ok {
+				return herror.BadRequest("invalid inheritance: " + name)
+			}
+			subs = append(subs, sub)
+		}
+		roleMap[role.Name].Inheritances = subs
+		if err := rbac.CheckInheritanceCycle(roles); err != nil {
+			return herror.BadRequest(err)
+		}
+	}
+
+	args := repository.UpdateUserRoleArgs{
+		Permissions:  optional.New(req.Permissions, req.Permissions != nil),
+		Inheritances: optional.New(req.Inheritances, req.Inheritances != nil),
+	}
+	if err := h.Repo.UpdateUserRole(role.Name, args); err != nil {
+		switch {
+		case err == repository.ErrForbidden:
+			return herror.Forbidden("system roles cannot be modified")
+		case repository.IsArgError(err):
+			return herror.BadRequest(err)
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	h.recordAuditLog(c, model.AuditActionUserRoleUpdated, role.Name, model.AuditLogDetail{
+		"permissions":  req.Permissions,
+		"inheritances": req.Inheritances,
+	})
+	if err := h.RBAC.Reload(); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteRole DELETE /roles/:roleName
+func (h *Handlers) DeleteRole(c echo.Context) error {
+	role := getParamRole(c)
+
+	if role.System {
+		return herror.Forbidden("system roles cannot be deleted")
+	}
+
+	if err := h.Repo.DeleteUserRole(role.Name); err != nil {
+		switch {
+		case err == repository.ErrForbidden:
+			return herror.Conflict("the role is still in use")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	h.recordAuditLog(c, model.AuditActionUserRoleDeleted, role.Name, nil)
+	if err := h.RBAC.Reload(); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,429 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+	"github.com/traPtitech/traQ/service/rbac/role"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestPostRoleRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		req     PostRoleRequest
+		wantErr bool
+	}{
+		{
+			"empty",
+			PostRoleRequest{},
+			true,
+		},
+		{
+			"invalid name",
+			PostRoleRequest{Name: "モデレーター"},
+			true,
+		},
+		{
+			"invalid permission",
+			PostRoleRequest{Name: "moderator", Permissions: []string{"unknown_permission"}},
+			true,
+		},
+		{
+			"invalid inheritance",
+			PostRoleRequest{Name: "moderator", Inheritances: []string{""}},
+			true,
+		},
+		{
+			"success",
+			PostRoleRequest{Name: "moderator", Permissions: []string{permission.DeleteOthersMessage.Name()}, Inheritances: []string{role.User}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandlers_GetRoles(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/roles"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	userSession := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, userSession).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		arr := e.GET(path).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		names := make([]interface{}, 0)
+		for _, v := range arr.Iter() {
+			names = append(names, v.Object().Value("name").String().Raw())
+		}
+		assert.Subset(t, names, []interface{}{role.Admin, role.User, role.Read, role.Write, role.Bot, role.ManageBot})
+	})
+}
+
+func TestHandlers_CreateRole(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/roles"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	userSession := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithJSON(&PostRoleRequest{Name: "create_role_1"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, userSession).
+			WithJSON(&PostRoleRequest{Name: "create_role_1"}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PostRoleRequest{Name: "create_role_2", Permissions: []string{"unknown_permission"}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (unknown inheritance)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PostRoleRequest{Name: "create_role_3", Inheritances: []string{"create_role_unknown"}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PostRoleRequest{Name: role.User}).
+			Expect().
+			Status(http.StatusConflict)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PostRoleRequest{
+				Name:         "create_role_4",
+				Permissions:  []string{permission.DeleteOthersMessage.Name(), permission.DeleteStamp.Name()},
+				Inheritances: []string{role.User},
+			}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("name").String().IsEqual("create_role_4")
+		obj.Value("system").Boolean().IsFalse()
+		obj.Value("permissions").Array().ContainsOnly(permission.DeleteOthersMessage.Name(), permission.DeleteStamp.Name())
+		obj.Value("inheritances").Array().ContainsOnly(role.User)
+
+		assert.True(t, env.RBAC.IsGranted("create_role_4", permission.DeleteOthersMessage))
+		assert.True(t, env.RBAC.IsGranted("create_role_4", permission.PostMessage))
+	})
+}
+
+func TestHandlers_GetRole(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/roles/{roleName}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	userSession := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, role.User).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, role.User).
+			WithCookie(session.CookieName, userSession).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, "get_role_unknown").
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, role.User).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("name").String().IsEqual(role.User)
+		obj.Value("system").Boolean().IsTrue()
+		obj.Value("grantedPermissions").Array().ContainsAll(permission.PostMessage.Name())
+	})
+}
+
+func TestHandlers_EditRole(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/roles/{roleName}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	userSession := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	require.NoError(t, env.Repository.CreateUserRoles(
+		&model.UserRole{Name: "edit_role_1"},
+		&model.UserRole{Name: "edit_role_2"},
+		&model.UserRole{Name: "edit_role_3"},
+	))
+	require.NoError(t, env.Repository.UpdateUserRole("edit_role_2", repository.UpdateUserRoleArgs{Inheritances: optional.From([]string{"edit_role_3"})}))
+	require.NoError(t, env.RBAC.Reload())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, "edit_role_1").
+			WithJSON(&PatchRoleRequest{}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, "edit_role_1").
+			WithCookie(session.CookieName, userSession).
+			WithJSON(&PatchRoleRequest{}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("forbidden (system role)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, role.User).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PatchRoleRequest{Permissions: []string{}}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, "edit_role_unknown").
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PatchRoleRequest{}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request (cycle)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, "edit_role_3").
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PatchRoleRequest{Inheritances: []string{"edit_role_2"}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, "edit_role_1").
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PatchRoleRequest{
+				Permissions:  []string{permission.EditStampCreatedByOthers.Name()},
+				Inheritances: []string{role.User},
+			}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		assert.True(t, env.RBAC.IsGranted("edit_role_1", permission.EditStampCreatedByOthers))
+		assert.True(t, env.RBAC.IsGranted("edit_role_1", permission.PostMessage))
+	})
+}
+
+func TestHandlers_DeleteRole(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/roles/{roleName}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	admin := env.CreateAdmin(t, rand)
+	userSession := env.S(t, user.GetID())
+	adminSession := env.S(t, admin.GetID())
+
+	require.NoError(t, env.Repository.CreateUserRoles(
+		&model.UserRole{Name: "delete_role_1"},
+		&model.UserRole{Name: "delete_role_2"},
+	))
+	assigned := env.CreateUser(t, rand)
+	require.NoError(t, env.Repository.UpdateUser(assigned.GetID(), repository.UpdateUserArgs{Role: optional.From("delete_role_2")}))
+	require.NoError(t, env.RBAC.Reload())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, "delete_role_1").
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, "delete_role_1").
+			WithCookie(session.CookieName, userSession).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("forbidden (system role)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, role.User).
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("conflict (assigned)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, "delete_role_2").
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusConflict)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, "delete_role_1").
+			WithCookie(session.CookieName, adminSession).
+			Expect().
+			Status(http.StatusNoContent)
+
+		_, err := env.Repository.GetUserRole("delete_role_1")
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
+
+func TestHandlers_CustomRole(t *testing.T) {
+	t.Parallel()
+
+	env := Setup(t, common1)
+	admin := env.CreateAdmin(t, rand)
+	moderator := env.CreateUser(t, rand)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	adminSession := env.S(t, admin.GetID())
+	moderatorSession := env.S(t, moderator.GetID())
+
+	e := env.R(t)
+	e.POST("/api/v3/roles").
+		WithCookie(session.CookieName, adminSession).
+		WithJSON(&PostRoleRequest{
+			Name: "custom_role_moderator",
+			Permissions: []string{
+				permission.DeleteOthersMessage.Name(),
+				permission.EditStampCreatedByOthers.Name(),
+				permission.DeleteStamp.Name(),
+			},
+			Inheritances: []string{role.User},
+		}).
+		Expect().
+		Status(http.StatusCreated)
+
+	e.PATCH("/api/v3/users/{userId}", moderator.GetID()).
+		WithCookie(session.CookieName, adminSession).
+		WithJSON(&PatchUserRequest{Role: optional.From("custom_role_moderator")}).
+		Expect().
+		Status(http.StatusNoContent)
+
+	e.DELETE("/api/v3/messages/{messageId}", m.GetID()).
+		WithCookie(session.CookieName, moderatorSession).
+		Expect().
+		Status(http.StatusNoContent)
+}
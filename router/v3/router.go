@@ -16,6 +16,7 @@ import (
 	"github.com/traPtitech/traQ/service/imaging"
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/ogp"
+	"github.com/traPtitech/traQ/service/outgoingwebhook"
 	"github.com/traPtitech/traQ/service/rbac"
 	"github.com/traPtitech/traQ/service/rbac/permission"
 	"github.com/traPtitech/traQ/service/search"
@@ -26,23 +27,24 @@ import (
 )
 
 type Handlers struct {
-	RBAC           rbac.RBAC
-	Repo           repository.Repository
-	WS             *ws.Streamer
-	BotWS          *botWS.Streamer
-	Hub            *hub.Hub
-	Logger         *zap.Logger
-	OC             *counter.OnlineCounter
-	OGP            ogp.Service
-	VM             *viewer.Manager
-	WebRTC         *webrtcv3.Manager
-	Imaging        imaging.Processor
-	SessStore      session.Store
-	SearchEngine   search.Engine
-	ChannelManager channel.Manager
-	MessageManager message.Manager
-	FileManager    file.Manager
-	Replacer       *mutil.Replacer
+	RBAC            rbac.RBAC
+	Repo            repository.Repository
+	WS              *ws.Streamer
+	BotWS           *botWS.Streamer
+	Hub             *hub.Hub
+	Logger          *zap.Logger
+	OC              *counter.OnlineCounter
+	OGP             ogp.Service
+	VM              *viewer.Manager
+	WebRTC          *webrtcv3.Manager
+	Imaging         imaging.Processor
+	SessStore       session.Store
+	SearchEngine    search.Engine
+	ChannelManager  channel.Manager
+	MessageManager  message.Manager
+	FileManager     file.Manager
+	Replacer        *mutil.Replacer
+	OutgoingWebhook *outgoingwebhook.Service
 	Config
 }
 
@@ -58,6 +60,9 @@ type Config struct {
 
 	// EnabledExternalAccountLink リンク可能な外部認証アカウントのプロバイダ
 	EnabledExternalAccountProviders map[string]bool
+
+	// VAPIDPublicKey Web PushのVAPID公開鍵 (Web Pushが無効の場合は空)
+	VAPIDPublicKey string
 }
 
 // Setup APIルーティングを行います
@@ -72,12 +77,14 @@ func (h *Handlers) Setup(e *echo.Group) {
 
 	requiresBotAccessPerm := middlewares.CheckBotAccessPerm(h.RBAC)
 	requiresWebhookAccessPerm := middlewares.CheckWebhookAccessPerm(h.RBAC)
+	requiresOutgoingWebhookAccessPerm := middlewares.CheckOutgoingWebhookAccessPerm(h.RBAC)
 	requiresFileAccessPerm := middlewares.CheckFileAccessPerm(h.FileManager)
 	requiresClientAccessPerm := middlewares.CheckClientAccessPerm(h.RBAC)
 	requiresMessageAccessPerm := middlewares.CheckMessageAccessPerm(h.ChannelManager)
 	requiresChannelAccessPerm := middlewares.CheckChannelAccessPerm(h.ChannelManager)
 	requiresGroupAdminPerm := middlewares.CheckUserGroupAdminPerm(h.RBAC)
 	requiresClipFolderAccessPerm := middlewares.CheckClipFolderAccessPerm()
+	requiresScheduledMessageAccessPerm := middlewares.CheckScheduledMessageAccessPerm()
 
 	api := e.Group("/v3", middlewares.UserAuthenticate(h.Repo, h.SessStore))
 	{
@@ -119,6 +126,7 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiUsersMe.PUT("/icon", h.ChangeMyIcon, requires(permission.ChangeMyIcon))
 				apiUsersMe.PUT("/password", h.PutMyPassword, requires(permission.ChangeMyPassword), blockBot)
 				apiUsersMe.POST("/fcm-device", h.PostMyFCMDevice, requires(permission.RegisterFCMDevice), blockBot)
+				apiUsersMe.POST("/webpush-subscriptions", h.PostMyWebPushSubscription, requires(permission.RegisterWebPushSubscription), blockBot)
 				apiUsersMe.GET("/view-states", h.GetMyViewStates, requires(permission.ConnectNotificationStream), blockBot)
 				apiUsersMeTags := apiUsersMe.Group("/tags")
 				{
@@ -167,6 +175,14 @@ func (h *Handlers) Setup(e *echo.Group) {
 					apiUsersMeSettings.GET("", h.GetMySettings, requires(permission.GetMe))
 					apiUsersMeSettings.GET("/notify-citation", h.GetMyNotifyCitation, requires(permission.GetMe))
 					apiUsersMeSettings.PUT("/notify-citation", h.PutMyNotifyCitation, requires(permission.EditMe))
+					apiUsersMeSettings.GET("/do-not-disturb", h.GetMyDoNotDisturb, requires(permission.GetMe))
+					apiUsersMeSettings.PUT("/do-not-disturb", h.PutMyDoNotDisturb, requires(permission.EditMe))
+					apiUsersMeSettings.GET("/notification-keywords", h.GetMyNotificationKeywords, requires(permission.GetMe))
+					apiUsersMeSettings.PUT("/notification-keywords", h.PutMyNotificationKeywords, requires(permission.EditMe))
+					apiUsersMeSettings.GET("/group-mention-mutes", h.GetMyGroupMentionMutes, requires(permission.GetMe))
+					apiUsersMeSettings.PUT("/group-mention-mutes", h.PutMyGroupMentionMutes, requires(permission.EditMe))
+					apiUsersMeSettings.GET("/channel-push-mutes", h.GetMyChannelPushMutes, requires(permission.GetMe))
+					apiUsersMeSettings.PUT("/channel-push-mutes", h.PutMyChannelPushMutes, requires(permission.EditMe))
 				}
 			}
 		}
@@ -178,18 +194,32 @@ func (h *Handlers) Setup(e *echo.Group) {
 			{
 				apiChannelsCID.GET("", h.GetChannel, requires(permission.GetChannel))
 				apiChannelsCID.PATCH("", h.EditChannel, requires(permission.EditChannel))
+				apiChannelsCID.DELETE("", h.DeleteChannel, blockBot, requires(permission.DeleteChannel))
 				apiChannelsCID.GET("/messages", h.GetMessages, requires(permission.GetMessage))
 				apiChannelsCID.POST("/messages", h.PostMessage, bodyLimit(100), requires(permission.PostMessage))
+				apiChannelsCID.POST("/ephemeral-messages", h.PostEphemeralMessage, blockNonBot, requires(permission.PostMessage))
 				apiChannelsCID.GET("/stats", h.GetChannelStats, requires(permission.GetChannel))
 				apiChannelsCID.GET("/topic", h.GetChannelTopic, requires(permission.GetChannel))
 				apiChannelsCID.PUT("/topic", h.EditChannelTopic, requires(permission.EditChannelTopic))
 				apiChannelsCID.GET("/viewers", h.GetChannelViewers, requires(permission.GetChannel))
+				apiChannelsCID.GET("/members", h.GetChannelMembers, requires(permission.GetChannel))
+				apiChannelsCID.PATCH("/members", h.EditChannelMembers, requires(permission.EditPrivateChannelMembers))
 				apiChannelsCID.GET("/pins", h.GetChannelPins, requires(permission.GetMessage))
 				apiChannelsCID.GET("/subscribers", h.GetChannelSubscribers, requires(permission.GetChannelSubscription))
 				apiChannelsCID.PUT("/subscribers", h.SetChannelSubscribers, requires(permission.EditChannelSubscription))
 				apiChannelsCID.PATCH("/subscribers", h.EditChannelSubscribers, requires(permission.EditChannelSubscription))
 				apiChannelsCID.GET("/bots", h.GetChannelBots, requires(permission.GetChannel))
+				apiChannelsCID.GET("/commands", h.GetChannelBotCommands, requires(permission.GetChannel))
 				apiChannelsCID.GET("/events", h.GetChannelEvents, requires(permission.GetChannel))
+				apiChannelsCID.GET("/roles", h.GetChannelRoles, requires(permission.GetChannel))
+				apiChannelsCID.PUT("/roles", h.SetChannelRoles, blockBot, requires(permission.ManageChannelRole))
+				apiChannelsCID.GET("/permission-overrides", h.GetChannelPermissionOverrides, requires(permission.GetChannel))
+				apiChannelsCID.PUT("/permission-overrides", h.SetChannelPermissionOverrides, blockBot, requires(permission.ManageChannelRole))
+				apiChannelsCIDActions := apiChannelsCID.Group("/actions", blockBot)
+				{
+					apiChannelsCIDActions.POST("/merge", h.MergeChannel, requires(permission.DeleteChannel, permission.MoveMessages))
+					apiChannelsCIDActions.POST("/move-messages", h.MoveMessages, requires(permission.MoveMessages))
+				}
 			}
 		}
 		apiMessages := api.Group("/messages")
@@ -200,10 +230,16 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiMessagesMID.GET("", h.GetMessage, requires(permission.GetMessage))
 				apiMessagesMID.PUT("", h.EditMessage, bodyLimit(100), requires(permission.EditMessage))
 				apiMessagesMID.DELETE("", h.DeleteMessage, requires(permission.DeleteMessage))
+				apiMessagesMID.PUT("/components", h.EditMessageComponents, blockNonBot, requires(permission.EditMessage))
+				apiMessagesMID.POST("/interactions", h.PostMessageInteraction, blockBot, requires(permission.GetMessage))
 				apiMessagesMID.GET("/pin", h.GetPin, requires(permission.GetMessage))
 				apiMessagesMID.POST("/pin", h.CreatePin, requires(permission.CreateMessagePin))
 				apiMessagesMID.DELETE("/pin", h.RemovePin, requires(permission.DeleteMessagePin))
 				apiMessagesMID.GET("/clips", h.GetMessageClips, requires(permission.GetClipFolder))
+				apiMessagesMID.POST("/reports", h.PostMessageReport, blockBot, requires(permission.ReportMessage))
+				apiMessagesMID.GET("/revisions", h.GetMessageRevisions, requires(permission.GetMessage))
+				apiMessagesMID.GET("/replies", h.GetMessageReplies, requires(permission.GetMessage))
+				apiMessagesMID.POST("/replies", h.PostMessageReply, bodyLimit(100), requires(permission.PostMessage))
 				apiMessagesMIDStamps := apiMessagesMID.Group("/stamps")
 				{
 					apiMessagesMIDStamps.GET("", h.GetMessageStamps, requires(permission.GetMessage))
@@ -215,6 +251,27 @@ func (h *Handlers) Setup(e *echo.Group) {
 				}
 			}
 		}
+		apiMessageReports := api.Group("/message-reports", blockBot)
+		{
+			apiMessageReports.GET("", h.GetMessageReports, requires(permission.GetMessageReports))
+			apiMessageReportsRID := apiMessageReports.Group("/:reportID", retrieve.MessageReportID())
+			{
+				apiMessageReportsRID.GET("", h.GetMessageReport, requires(permission.GetMessageReports))
+				apiMessageReportsRID.POST("/resolve", h.ResolveMessageReport, requires(permission.HandleMessageReports))
+				apiMessageReportsRID.POST("/dismiss", h.DismissMessageReport, requires(permission.HandleMessageReports))
+			}
+		}
+		apiScheduledMessages := api.Group("/scheduled-messages", blockBot)
+		{
+			apiScheduledMessages.GET("", h.GetScheduledMessages, requires(permission.GetScheduledMessage))
+			apiScheduledMessages.POST("", h.CreateScheduledMessage, bodyLimit(100), requires(permission.CreateScheduledMessage))
+			apiScheduledMessagesSMID := apiScheduledMessages.Group("/:scheduledMessageID", retrieve.ScheduledMessageID(), requiresScheduledMessageAccessPerm)
+			{
+				apiScheduledMessagesSMID.GET("", h.GetScheduledMessage, requires(permission.GetScheduledMessage))
+				apiScheduledMessagesSMID.PATCH("", h.EditScheduledMessage, bodyLimit(100), requires(permission.EditScheduledMessage))
+				apiScheduledMessagesSMID.DELETE("", h.CancelScheduledMessage, requires(permission.DeleteScheduledMessage))
+			}
+		}
 		apiFiles := api.Group("/files")
 		{
 			apiFiles.GET("", h.GetFiles, requires(permission.DownloadFile))
@@ -267,12 +324,26 @@ func (h *Handlers) Setup(e *echo.Group) {
 			{
 				apiWebhooksWID.GET("", h.GetWebhook, requires(permission.GetWebhook))
 				apiWebhooksWID.PATCH("", h.EditWebhook, requires(permission.EditWebhook))
+				apiWebhooksWID.POST("/preview", h.PreviewWebhookTemplate, requires(permission.EditWebhook))
 				apiWebhooksWID.DELETE("", h.DeleteWebhook, requires(permission.DeleteWebhook))
 				apiWebhooksWID.GET("/icon", h.GetWebhookIcon, requires(permission.GetWebhook))
 				apiWebhooksWID.PUT("/icon", h.ChangeWebhookIcon, requires(permission.EditWebhook))
 				apiWebhooksWID.GET("/messages", h.GetWebhookMessages, requires(permission.GetWebhook))
 			}
 		}
+		apiOutgoingWebhooks := api.Group("/outgoing-webhooks", blockBot)
+		{
+			apiOutgoingWebhooks.GET("", h.GetOutgoingWebhooks, requires(permission.GetWebhook))
+			apiOutgoingWebhooks.POST("", h.CreateOutgoingWebhook, requires(permission.CreateWebhook))
+			apiOutgoingWebhooksOWID := apiOutgoingWebhooks.Group("/:outgoingWebhookID", retrieve.OutgoingWebhookID(), requiresOutgoingWebhookAccessPerm)
+			{
+				apiOutgoingWebhooksOWID.GET("", h.GetOutgoingWebhook, requires(permission.GetWebhook))
+				apiOutgoingWebhooksOWID.PATCH("", h.EditOutgoingWebhook, requires(permission.EditWebhook))
+				apiOutgoingWebhooksOWID.DELETE("", h.DeleteOutgoingWebhook, requires(permission.DeleteWebhook))
+				apiOutgoingWebhooksOWID.GET("/logs", h.GetOutgoingWebhookLogs, requires(permission.GetWebhook))
+				apiOutgoingWebhooksOWID.POST("/actions/redeliver", h.RedeliverOutgoingWebhook, requires(permission.EditWebhook))
+			}
+		}
 		apiGroups := api.Group("/groups")
 		{
 			apiGroups.GET("", h.GetUserGroups, requires(permission.GetUserGroup))
@@ -332,16 +403,21 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiBotsBID.GET("/icon", h.GetBotIcon, requires(permission.GetBot))
 				apiBotsBID.PUT("/icon", h.ChangeBotIcon, requiresBotAccessPerm, requires(permission.EditBot))
 				apiBotsBID.GET("/logs", h.GetBotLogs, requiresBotAccessPerm, requires(permission.GetBot))
+				apiBotsBID.GET("/dead-letters", h.GetBotDeadLetters, requiresBotAccessPerm, requires(permission.GetBot))
+				apiBotsBID.GET("/commands", h.GetBotCommands, requires(permission.GetBot))
+				apiBotsBID.PUT("/commands", h.SetBotCommands, requiresBotAccessPerm, requires(permission.ManageBotCommands))
 				apiBotsBIDActions := apiBotsBID.Group("/actions", requiresBotAccessPerm)
 				{
 					apiBotsBIDActions.POST("/activate", h.ActivateBot, requires(permission.EditBot))
 					apiBotsBIDActions.POST("/inactivate", h.InactivateBot, requires(permission.EditBot))
 					apiBotsBIDActions.POST("/reissue", h.ReissueBot, requires(permission.EditBot))
+					apiBotsBIDActions.POST("/replay", h.ReplayBotEvents, requires(permission.EditBot))
 					apiBotsBIDActions.POST("/join", h.LetBotJoinChannel, requires(permission.BotActionJoinChannel))
 					apiBotsBIDActions.POST("/leave", h.LetBotLeaveChannel, requires(permission.BotActionLeaveChannel))
 				}
 			}
 		}
+		api.POST("/command-invocations/:invocationID/response", h.PostBotCommandResponse, blockNonBot, requires(permission.PostMessage))
 		apiWebRTC := api.Group("/webrtc", requires(permission.WebRTC))
 		{
 			apiWebRTC.GET("/state", h.GetWebRTCState)
@@ -364,6 +440,18 @@ func (h *Handlers) Setup(e *echo.Group) {
 				}
 			}
 		}
+		apiRoles := api.Group("/roles", blockBot)
+		{
+			apiRoles.GET("", h.GetRoles, requires(permission.GetRole))
+			apiRoles.POST("", h.CreateRole, requires(permission.ManageRole))
+			apiRolesRN := apiRoles.Group("/:roleName", retrieve.RoleName())
+			{
+				apiRolesRN.GET("", h.GetRole, requires(permission.GetRole))
+				apiRolesRN.PATCH("", h.EditRole, requires(permission.ManageRole))
+				apiRolesRN.DELETE("", h.DeleteRole, requires(permission.ManageRole))
+			}
+		}
+		api.GET("/audit-logs", h.GetAuditLogs, blockBot, requires(permission.GetAuditLogs))
 		apiOgp := api.Group("/ogp", blockBot)
 		{
 			apiOgp.GET("", h.GetOgp)
@@ -381,6 +469,8 @@ func (h *Handlers) Setup(e *echo.Group) {
 		apiNoAuth.POST("/login", h.Login, noLogin)
 		apiNoAuth.POST("/logout", h.Logout)
 		apiNoAuth.POST("/webhooks/:webhookID", h.PostWebhook, retrieve.WebhookID())
+		apiNoAuth.POST("/webhooks/:webhookID/github", h.PostWebhookGitHub, retrieve.WebhookID())
+		apiNoAuth.POST("/webhooks/:webhookID/gitlab", h.PostWebhookGitLab, retrieve.WebhookID())
 		apiNoAuthPublic := apiNoAuth.Group("/public")
 		{
 			apiNoAuthPublic.GET("/icon/:username", h.GetPublicUserIcon)
@@ -25,13 +25,17 @@ import (
 	gorm2 "github.com/traPtitech/traQ/repository/gorm"
 	"github.com/traPtitech/traQ/router/extension"
 	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/service/audit"
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/imaging"
 	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/outgoingwebhook"
 	"github.com/traPtitech/traQ/service/rbac"
 	"github.com/traPtitech/traQ/service/rbac/role"
+	"github.com/traPtitech/traQ/service/relay"
 	"github.com/traPtitech/traQ/service/search"
+	"github.com/traPtitech/traQ/service/ws"
 	"github.com/traPtitech/traQ/utils/gormzap"
 	"github.com/traPtitech/traQ/utils/optional"
 	"github.com/traPtitech/traQ/utils/random"
@@ -103,8 +107,8 @@ func TestMain(m *testing.M) {
 		}
 		env.Repository = repo
 
-		env.CM, _ = channel.InitChannelManager(repo, l.Named("CM"))
-		env.MM, _ = message.NewMessageManager(repo, env.CM, l.Named("MM"))
+		env.CM, _ = channel.InitChannelManager(repo, relay.NewNullRelay(), l.Named("CM"))
+		env.MM, _ = message.NewMessageManager(repo, env.CM, relay.NewNullRelay(), l.Named("MM"))
 		env.IP = imaging.NewProcessor(imaging.Config{
 			MaxPixels:        1000 * 1000,
 			Concurrency:      1,
@@ -119,20 +123,23 @@ func TestMain(m *testing.M) {
 		e.HTTPErrorHandler = extension.ErrorHandler(l)
 		e.Use(extension.Wrap(repo, env.CM))
 
-		r, err := rbac.New(repo)
+		r, err := rbac.New(repo, relay.NewNullRelay(), l)
 		if err != nil {
 			panic(err)
 		}
+		env.RBAC = r
 		handlers := &Handlers{
-			RBAC:           r,
-			Repo:           env.Repository,
-			Hub:            env.Hub,
-			SessStore:      env.SessStore,
-			ChannelManager: env.CM,
-			MessageManager: env.MM,
-			FileManager:    env.FM,
-			Logger:         l,
-			Imaging:        env.IP,
+			RBAC:            r,
+			Repo:            env.Repository,
+			Hub:             env.Hub,
+			WS:              ws.NewStreamer(env.Hub, nil, nil, relay.NewNullRelay(), l),
+			SessStore:       env.SessStore,
+			ChannelManager:  env.CM,
+			MessageManager:  env.MM,
+			FileManager:     env.FM,
+			Logger:          l,
+			Imaging:         env.IP,
+			OutgoingWebhook: outgoingwebhook.NewService(env.Hub, repo, l),
 			Config: Config{
 				Version:         "version",
 				Revision:        "revision",
@@ -144,6 +151,7 @@ func TestMain(m *testing.M) {
 			},
 		}
 		handlers.Setup(e.Group("/api"))
+		audit.NewRecorder(env.Hub, repo, l).Start()
 		env.Server = httptest.NewServer(e)
 
 		envs[key] = env
@@ -171,6 +179,7 @@ type Env struct {
 	FM         file.Manager
 	IP         imaging.Processor
 	SE         search.Engine
+	RBAC       rbac.RBAC
 	Hub        *hub.Hub
 	SessStore  session.Store
 }
@@ -305,6 +314,17 @@ func (env *Env) CreateMessage(t *testing.T, userID, channelID uuid.UUID, text st
 	return m
 }
 
+// CreateReplyMessage スレッドに返信メッセージを必ず作成します
+func (env *Env) CreateReplyMessage(t *testing.T, userID, parentID uuid.UUID, text string) message.Message {
+	t.Helper()
+	if text == rand {
+		text = random.AlphaNumeric(20)
+	}
+	m, err := env.MM.CreateReply(parentID, userID, text)
+	require.NoError(t, err)
+	return m
+}
+
 // MakeMessageUnread 指定したメッセージを未読にします
 func (env *Env) MakeMessageUnread(t *testing.T, userID, messageID uuid.UUID) {
 	t.Helper()
@@ -395,6 +415,14 @@ func (env *Env) CreateBot(t *testing.T, name string, creatorID uuid.UUID) *model
 	return b
 }
 
+// SetBotCommands BOTのコマンドを必ず登録します
+func (env *Env) SetBotCommands(t *testing.T, botID uuid.UUID, commands ...repository.SetBotCommandArgs) []*model.BotCommand {
+	t.Helper()
+	cmds, err := env.Repository.SetBotCommands(botID, commands)
+	require.NoError(t, err)
+	return cmds
+}
+
 // CreateWebhook Webhookを必ず作成します
 func (env *Env) CreateWebhook(t *testing.T, name string, creatorID, channelID uuid.UUID) model.Webhook {
 	t.Helper()
@@ -407,6 +435,24 @@ func (env *Env) CreateWebhook(t *testing.T, name string, creatorID, channelID uu
 	return w
 }
 
+// CreateOutgoingWebhook 外部送信Webhookを必ず作成します
+func (env *Env) CreateOutgoingWebhook(t *testing.T, name string, creatorID, channelID uuid.UUID, url string) *model.OutgoingWebhook {
+	t.Helper()
+	if name == rand {
+		name = random.AlphaNumeric(20)
+	}
+	w, err := env.Repository.CreateOutgoingWebhook(repository.CreateOutgoingWebhookArgs{
+		Name:      name,
+		ChannelID: channelID,
+		URL:       url,
+		Secret:    random.SecureAlphaNumeric(20),
+		Events:    model.OutgoingWebhookEvents{model.OutgoingWebhookEventMessageCreated: {}},
+		CreatorID: creatorID,
+	})
+	require.NoError(t, err)
+	return w
+}
+
 // CreateOAuth2Client OAuth2クライアントを必ず作成します
 func (env *Env) CreateOAuth2Client(t *testing.T, name string, creatorID uuid.UUID) *model.OAuth2Client {
 	t.Helper()
@@ -449,6 +495,22 @@ func (env *Env) CreateClipFolder(t *testing.T, name, desc string, creatorID uuid
 	return cf
 }
 
+// CreateScheduledMessage 指定したチャンネルへの予約投稿メッセージを必ず作成します
+func (env *Env) CreateScheduledMessage(t *testing.T, userID, channelID uuid.UUID, content string) *model.ScheduledMessage {
+	t.Helper()
+	if content == rand {
+		content = random.AlphaNumeric(20)
+	}
+	sm, err := env.Repository.CreateScheduledMessage(repository.CreateScheduledMessageArgs{
+		UserID:      userID,
+		ChannelID:   optional.From(channelID),
+		Content:     content,
+		ScheduledAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	return sm
+}
+
 func getEnvOrDefault(env string, def string) string {
 	s := os.Getenv(env)
 	if len(s) == 0 {
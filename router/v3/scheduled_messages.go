@@ -0,0 +1,227 @@
+package v3
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// maxScheduleDuration 予約投稿の最大予約期間
+const maxScheduleDuration = 365 * 24 * time.Hour
+
+// scheduledAtRule 予約投稿日時のバリデーションルール
+var scheduledAtRule = vd.By(func(value interface{}) error {
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case optional.Of[time.Time]:
+		if !v.Valid {
+			return nil
+		}
+		t = v.V
+	default:
+		return nil
+	}
+	now := time.Now()
+	if !t.After(now) {
+		return errors.New("must be in the future")
+	}
+	if t.After(now.Add(maxScheduleDuration)) {
+		return errors.New("must be within a year")
+	}
+	return nil
+})
+
+// PostScheduledMessageRequest POST /scheduled-messages リクエストボディ
+type PostScheduledMessageRequest struct {
+	ChannelID   optional.Of[uuid.UUID] `json:"channelId"`
+	DMUserID    optional.Of[uuid.UUID] `json:"dmUserId"`
+	Content     string                 `json:"content"`
+	Embed       bool                   `json:"embed"`
+	ScheduledAt time.Time              `json:"scheduledAt"`
+}
+
+func (r PostScheduledMessageRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.ChannelID, validator.NotNilUUID, vd.When(!r.DMUserID.Valid, vd.Required).Else(vd.Empty)),
+		vd.Field(&r.DMUserID, validator.NotNilUUID),
+		vd.Field(&r.Content, vd.Required, vd.RuneLength(1, 10000)),
+		vd.Field(&r.ScheduledAt, vd.Required, scheduledAtRule),
+	)
+}
+
+// PatchScheduledMessageRequest PATCH /scheduled-messages/:scheduledMessageID リクエストボディ
+type PatchScheduledMessageRequest struct {
+	Content     optional.Of[string]    `json:"content"`
+	Embed       bool                   `json:"embed"`
+	ScheduledAt optional.Of[time.Time] `json:"scheduledAt"`
+}
+
+func (r PatchScheduledMessageRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Content, validator.RequiredIfValid, vd.RuneLength(1, 10000)),
+		vd.Field(&r.ScheduledAt, scheduledAtRule),
+	)
+}
+
+// GetScheduledMessagesRequest GET /scheduled-messages リクエストクエリ
+type GetScheduledMessagesRequest struct {
+	Status string `query:"status"`
+	Limit  int    `query:"limit"`
+	Offset int    `query:"offset"`
+}
+
+func (r *GetScheduledMessagesRequest) Validate() error {
+	if r.Limit == 0 {
+		r.Limit = 50
+	}
+	return vd.ValidateStruct(r,
+		vd.Field(&r.Status, vd.In(
+			string(model.ScheduledMessageStatusPending),
+			string(model.ScheduledMessageStatusSending),
+			string(model.ScheduledMessageStatusSent),
+			string(model.ScheduledMessageStatusFailed),
+			string(model.ScheduledMessageStatusCanceled),
+		)),
+		vd.Field(&r.Limit, vd.Min(1), vd.Max(200)),
+		vd.Field(&r.Offset, vd.Min(0)),
+	)
+}
+
+// GetScheduledMessages GET /scheduled-messages
+func (h *Handlers) GetScheduledMessages(c echo.Context) error {
+	userID := getRequestUserID(c)
+
+	var req GetScheduledMessagesRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	q := repository.ScheduledMessagesQuery{
+		UserID: userID,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+	if len(req.Status) > 0 {
+		q.Status = optional.From(model.ScheduledMessageStatus(req.Status))
+	}
+	sms, err := h.Repo.GetScheduledMessages(q)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, sms)
+}
+
+// CreateScheduledMessage POST /scheduled-messages
+func (h *Handlers) CreateScheduledMessage(c echo.Context) error {
+	userID := getRequestUserID(c)
+
+	var req PostScheduledMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if req.ChannelID.Valid {
+		// ユーザーがアクセスできるか
+		if ok, err := h.ChannelManager.IsChannelAccessibleToUser(userID, req.ChannelID.V); err != nil {
+			return herror.InternalServerError(err)
+		} else if !ok {
+			return herror.BadRequest("invalid channelId")
+		}
+		if h.ChannelManager.IsPublicChannel(req.ChannelID.V) && h.ChannelManager.PublicChannelTree().IsArchivedChannel(req.ChannelID.V) {
+			return herror.BadRequest("this channel has been archived")
+		}
+		// チャンネルでの投稿権限があるか
+		if !h.RBAC.IsGrantedInChannel(getRequestUser(c).GetRole(), userID, req.ChannelID.V, permission.PostMessage) {
+			return herror.Forbidden("you are not permitted to post messages to this channel")
+		}
+	} else {
+		if _, err := h.Repo.GetUser(req.DMUserID.V, false); err != nil {
+			switch err {
+			case repository.ErrNotFound:
+				return herror.BadRequest("invalid dmUserId")
+			default:
+				return herror.InternalServerError(err)
+			}
+		}
+	}
+
+	if req.Embed {
+		req.Content = h.Replacer.Replace(req.Content)
+	}
+
+	sm, err := h.Repo.CreateScheduledMessage(repository.CreateScheduledMessageArgs{
+		UserID:      userID,
+		ChannelID:   req.ChannelID,
+		DMUserID:    req.DMUserID,
+		Content:     req.Content,
+		ScheduledAt: req.ScheduledAt,
+	})
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusCreated, sm)
+}
+
+// GetScheduledMessage GET /scheduled-messages/:scheduledMessageID
+func (h *Handlers) GetScheduledMessage(c echo.Context) error {
+	return c.JSON(http.StatusOK, getParamScheduledMessage(c))
+}
+
+// EditScheduledMessage PATCH /scheduled-messages/:scheduledMessageID
+func (h *Handlers) EditScheduledMessage(c echo.Context) error {
+	sm := getParamScheduledMessage(c)
+
+	var req PatchScheduledMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if req.Content.Valid && req.Embed {
+		req.Content.V = h.Replacer.Replace(req.Content.V)
+	}
+
+	if err := h.Repo.UpdateScheduledMessage(sm.ID, repository.UpdateScheduledMessageArgs{
+		Content:     req.Content,
+		ScheduledAt: req.ScheduledAt,
+	}); err != nil {
+		switch err {
+		case repository.ErrForbidden:
+			return herror.BadRequest("this scheduled message is no longer pending")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CancelScheduledMessage DELETE /scheduled-messages/:scheduledMessageID
+func (h *Handlers) CancelScheduledMessage(c echo.Context) error {
+	sm := getParamScheduledMessage(c)
+
+	if err := h.Repo.CancelScheduledMessage(sm.ID); err != nil {
+		switch err {
+		case repository.ErrForbidden:
+			return herror.BadRequest("this scheduled message is no longer pending")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,353 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestPostScheduledMessageRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	id := optional.From(uuid.Must(uuid.NewV4()))
+	future := time.Now().Add(time.Hour)
+	tests := []struct {
+		name    string
+		req     PostScheduledMessageRequest
+		wantErr bool
+	}{
+		{"channel", PostScheduledMessageRequest{ChannelID: id, Content: "a", ScheduledAt: future}, false},
+		{"dm", PostScheduledMessageRequest{DMUserID: id, Content: "a", ScheduledAt: future}, false},
+		{"no destination", PostScheduledMessageRequest{Content: "a", ScheduledAt: future}, true},
+		{"both destinations", PostScheduledMessageRequest{ChannelID: id, DMUserID: id, Content: "a", ScheduledAt: future}, true},
+		{"empty content", PostScheduledMessageRequest{ChannelID: id, ScheduledAt: future}, true},
+		{"past", PostScheduledMessageRequest{ChannelID: id, Content: "a", ScheduledAt: time.Now().Add(-time.Hour)}, true},
+		{"too far", PostScheduledMessageRequest{ChannelID: id, Content: "a", ScheduledAt: time.Now().Add(2 * maxScheduleDuration)}, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHandlers_GetScheduledMessages(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/scheduled-messages"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm := env.CreateScheduledMessage(t, user.GetID(), ch.ID, rand)
+	sm2 := env.CreateScheduledMessage(t, user.GetID(), ch.ID, rand)
+	env.CreateScheduledMessage(t, user2.GetID(), ch.ID, rand)
+	require.NoError(t, env.Repository.CancelScheduledMessage(sm2.ID))
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, s).
+			WithQuery("status", "unknown").
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(2)
+	})
+
+	t.Run("success (status)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			WithQuery("status", string(model.ScheduledMessageStatusPending)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(1)
+		obj.Value(0).Object().Value("id").String().IsEqual(sm.ID.String())
+	})
+}
+
+func TestHandlers_CreateScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/scheduled-messages"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: optional.From(ch.ID), Content: "a", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (past)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: optional.From(ch.ID), Content: "a", ScheduledAt: time.Now().Add(-time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (unknown channel)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: optional.From(uuid.Must(uuid.NewV4())), Content: "a", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (unknown user)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{DMUserID: optional.From(uuid.Must(uuid.NewV4())), Content: "a", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success (channel)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: optional.From(ch.ID), Content: "reminder", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("id").String().NotEmpty()
+		obj.Value("userId").String().IsEqual(user.GetID().String())
+		obj.Value("channelId").String().IsEqual(ch.ID.String())
+		obj.Value("dmUserId").IsNull()
+		obj.Value("content").String().IsEqual("reminder")
+		obj.Value("status").String().IsEqual(string(model.ScheduledMessageStatusPending))
+		obj.Value("messageId").IsNull()
+	})
+
+	t.Run("success (dm)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{DMUserID: optional.From(user2.GetID()), Content: "dm", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("channelId").IsNull()
+		obj.Value("dmUserId").String().IsEqual(user2.GetID().String())
+	})
+}
+
+func TestHandlers_GetScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/scheduled-messages/{scheduledMessageId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm := env.CreateScheduledMessage(t, user.GetID(), ch.ID, rand)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, sm.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, sm.ID).
+			WithCookie(session.CookieName, s2).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, sm.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("id").String().IsEqual(sm.ID.String())
+		obj.Value("content").String().IsEqual(sm.Content)
+	})
+}
+
+func TestHandlers_EditScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/scheduled-messages/{scheduledMessageId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm := env.CreateScheduledMessage(t, user.GetID(), ch.ID, rand)
+	canceled := env.CreateScheduledMessage(t, user.GetID(), ch.ID, rand)
+	require.NoError(t, env.Repository.CancelScheduledMessage(canceled.ID))
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, sm.ID).
+			WithJSON(&PatchScheduledMessageRequest{Content: optional.From("edited")}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, sm.ID).
+			WithCookie(session.CookieName, s2).
+			WithJSON(&PatchScheduledMessageRequest{Content: optional.From("edited")}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request (canceled)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, canceled.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PatchScheduledMessageRequest{Content: optional.From("edited")}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		at := time.Now().Add(2 * time.Hour).Truncate(time.Microsecond)
+		e.PATCH(path, sm.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PatchScheduledMessageRequest{Content: optional.From("edited"), ScheduledAt: optional.From(at)}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		updated, err := env.Repository.GetScheduledMessage(sm.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "edited", updated.Content)
+		assert.True(t, at.Equal(updated.ScheduledAt))
+	})
+}
+
+func TestHandlers_CancelScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/scheduled-messages/{scheduledMessageId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm := env.CreateScheduledMessage(t, user.GetID(), ch.ID, rand)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, sm.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, sm.ID).
+			WithCookie(session.CookieName, s2).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		sm := env.CreateScheduledMessage(t, user.GetID(), ch.ID, rand)
+		e := env.R(t)
+		e.DELETE(path, sm.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNoContent)
+
+		canceled, err := env.Repository.GetScheduledMessage(sm.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.ScheduledMessageStatusCanceled, canceled.Status)
+
+		// 取り消し済みのものは取り消せない
+		e.DELETE(path, sm.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v4"
 
+	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router/consts"
 	"github.com/traPtitech/traQ/router/extension"
@@ -96,6 +97,7 @@ func (h *Handlers) CreateStamp(c echo.Context) error {
 			return herror.InternalServerError(err)
 		}
 	}
+	h.recordAuditLog(c, model.AuditActionStampCreated, s.ID.String(), model.AuditLogDetail{"name": s.Name})
 	return c.JSON(http.StatusCreated, s)
 }
 
@@ -148,6 +150,7 @@ func (h *Handlers) EditStamp(c echo.Context) error {
 			return herror.InternalServerError(err)
 		}
 	}
+	h.recordAuditLog(c, model.AuditActionStampUpdated, stamp.ID.String(), model.AuditLogDetail{"name": req.Name, "creatorId": req.CreatorID})
 	return c.NoContent(http.StatusNoContent)
 }
 
@@ -158,6 +161,7 @@ func (h *Handlers) DeleteStamp(c echo.Context) error {
 	if err := h.Repo.DeleteStamp(stampID); err != nil {
 		return herror.InternalServerError(err)
 	}
+	h.recordAuditLog(c, model.AuditActionStampDeleted, stampID.String(), nil)
 
 	return c.NoContent(http.StatusNoContent)
 }
@@ -211,6 +215,7 @@ func (h *Handlers) ChangeStampImage(c echo.Context) error {
 			return herror.InternalServerError(err)
 		}
 	}
+	h.recordAuditLog(c, model.AuditActionStampUpdated, stamp.ID.String(), model.AuditLogDetail{"fileId": fileID})
 	return c.NoContent(http.StatusNoContent)
 }
 
@@ -1,11 +1,20 @@
 package v3
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"time"
 
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v4"
 
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/router/utils"
+	"github.com/traPtitech/traQ/utils/validator"
 )
 
 // PutMyNotifyCitationRequest PUT /user/me/settings/notify-citation リクエストボディ
@@ -56,3 +65,197 @@ func (h *Handlers) GetMyNotifyCitation(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, &res{NotifyCitation: nc})
 }
+
+// clockRule "HH:MM"形式の時刻のバリデーションルール
+var clockRule = vd.By(func(value interface{}) error {
+	s, _ := value.(string)
+	if len(s) == 0 {
+		return nil
+	}
+	if _, err := model.ParseClock(s); err != nil {
+		return errors.New("must be in HH:MM format")
+	}
+	return nil
+})
+
+// timeZoneRule IANA Time Zone名のバリデーションルール
+var timeZoneRule = vd.By(func(value interface{}) error {
+	s, _ := value.(string)
+	if len(s) == 0 {
+		return nil
+	}
+	if _, err := time.LoadLocation(s); err != nil {
+		return errors.New("invalid time zone")
+	}
+	return nil
+})
+
+// PutMyDoNotDisturbRequest PUT /users/me/settings/do-not-disturb リクエストボディ
+type PutMyDoNotDisturbRequest struct {
+	Enabled  bool   `json:"enabled"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	TimeZone string `json:"timeZone"`
+}
+
+func (r PutMyDoNotDisturbRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Start, vd.When(r.Enabled, vd.Required), clockRule),
+		vd.Field(&r.End, vd.When(r.Enabled, vd.Required), clockRule),
+		vd.Field(&r.TimeZone, timeZoneRule),
+	)
+}
+
+// GetMyDoNotDisturb GET /users/me/settings/do-not-disturb
+func (h *Handlers) GetMyDoNotDisturb(c echo.Context) error {
+	id := getRequestUserID(c)
+
+	us, err := h.Repo.GetUserSettings(id)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, &PutMyDoNotDisturbRequest{
+		Enabled:  us.DoNotDisturbEnabled,
+		Start:    us.DoNotDisturbStart,
+		End:      us.DoNotDisturbEnd,
+		TimeZone: us.TimeZone,
+	})
+}
+
+// PutMyDoNotDisturb PUT /users/me/settings/do-not-disturb
+func (h *Handlers) PutMyDoNotDisturb(c echo.Context) error {
+	id := getRequestUserID(c)
+
+	var req PutMyDoNotDisturbRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.Repo.UpdateDoNotDisturb(id, repository.UpdateDoNotDisturbArgs{
+		Enabled:  req.Enabled,
+		Start:    req.Start,
+		End:      req.End,
+		TimeZone: req.TimeZone,
+	}); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PutMyNotificationKeywordsRequest PUT /users/me/settings/notification-keywords リクエストボディ
+type PutMyNotificationKeywordsRequest struct {
+	Keywords []string `json:"keywords"`
+}
+
+func (r PutMyNotificationKeywordsRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Keywords, vd.NotNil, vd.Length(0, 30), vd.Each(vd.Required, vd.RuneLength(1, 50))),
+	)
+}
+
+// GetMyNotificationKeywords GET /users/me/settings/notification-keywords
+func (h *Handlers) GetMyNotificationKeywords(c echo.Context) error {
+	keywords, err := h.Repo.GetNotificationKeywords(getRequestUserID(c))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, &PutMyNotificationKeywordsRequest{Keywords: keywords})
+}
+
+// PutMyNotificationKeywords PUT /users/me/settings/notification-keywords
+func (h *Handlers) PutMyNotificationKeywords(c echo.Context) error {
+	var req PutMyNotificationKeywordsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.Repo.SetNotificationKeywords(getRequestUserID(c), req.Keywords); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PutMyGroupMentionMutesRequest PUT /users/me/settings/group-mention-mutes リクエストボディ
+type PutMyGroupMentionMutesRequest struct {
+	GroupIDs []uuid.UUID `json:"groupIds"`
+}
+
+func (r PutMyGroupMentionMutesRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.GroupIDs, vd.NotNil, vd.Each(validator.NotNilUUID)),
+	)
+}
+
+// GetMyGroupMentionMutes GET /users/me/settings/group-mention-mutes
+func (h *Handlers) GetMyGroupMentionMutes(c echo.Context) error {
+	ids, err := h.Repo.GetMutedGroupMentions(getRequestUserID(c))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, &PutMyGroupMentionMutesRequest{GroupIDs: ids})
+}
+
+// PutMyGroupMentionMutes PUT /users/me/settings/group-mention-mutes
+func (h *Handlers) PutMyGroupMentionMutes(c echo.Context) error {
+	var req PutMyGroupMentionMutesRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	for _, gid := range req.GroupIDs {
+		if _, err := h.Repo.GetUserGroup(gid); err != nil {
+			switch err {
+			case repository.ErrNotFound:
+				return herror.BadRequest("invalid group id")
+			default:
+				return herror.InternalServerError(err)
+			}
+		}
+	}
+
+	if err := h.Repo.SetMutedGroupMentions(getRequestUserID(c), req.GroupIDs); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PutMyChannelPushMutesRequest PUT /users/me/settings/channel-push-mutes リクエストボディ
+type PutMyChannelPushMutesRequest struct {
+	ChannelIDs []uuid.UUID `json:"channelIds"`
+}
+
+func (r PutMyChannelPushMutesRequest) ValidateWithContext(ctx context.Context) error {
+	return vd.ValidateStructWithContext(ctx, &r,
+		vd.Field(&r.ChannelIDs, vd.NotNil, vd.Each(validator.NotNilUUID, utils.IsPublicChannelID)),
+	)
+}
+
+// GetMyChannelPushMutes GET /users/me/settings/channel-push-mutes
+func (h *Handlers) GetMyChannelPushMutes(c echo.Context) error {
+	ids, err := h.Repo.GetChannelPushMutes(getRequestUserID(c))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, &PutMyChannelPushMutesRequest{ChannelIDs: ids})
+}
+
+// PutMyChannelPushMutes PUT /users/me/settings/channel-push-mutes
+func (h *Handlers) PutMyChannelPushMutes(c echo.Context) error {
+	var req PutMyChannelPushMutesRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.Repo.SetChannelPushMutes(getRequestUserID(c), req.ChannelIDs); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
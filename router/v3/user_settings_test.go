@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -70,6 +71,7 @@ func TestHandlers_GetMySettings(t *testing.T) {
 
 		obj.Value("id").String().IsEqual(user.GetID().String())
 		obj.Value("notifyCitation").Boolean().IsFalse()
+		obj.Value("doNotDisturbEnabled").Boolean().IsFalse()
 	})
 }
 
@@ -102,3 +104,238 @@ func TestHandlers_GetMyNotifyCitation(t *testing.T) {
 		obj.Value("notifyCitation").Boolean().IsFalse()
 	})
 }
+
+func TestHandlers_PutMyDoNotDisturb(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/do-not-disturb"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithJSON(&PutMyDoNotDisturbRequest{}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (invalid clock)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyDoNotDisturbRequest{Enabled: true, Start: "25:00", End: "07:00"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (invalid time zone)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyDoNotDisturbRequest{Enabled: true, Start: "22:00", End: "07:00", TimeZone: "Invalid/Zone"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyDoNotDisturbRequest{Enabled: true, Start: "22:00", End: "07:00", TimeZone: "Asia/Tokyo"}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		us, err := env.Repository.GetUserSettings(user.GetID())
+		require.NoError(t, err)
+		assert.True(t, us.DoNotDisturbEnabled)
+		assert.Equal(t, "22:00", us.DoNotDisturbStart)
+		assert.Equal(t, "07:00", us.DoNotDisturbEnd)
+		assert.Equal(t, "Asia/Tokyo", us.TimeZone)
+	})
+}
+
+func TestHandlers_GetMyDoNotDisturb(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/do-not-disturb"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("enabled").Boolean().IsFalse()
+		obj.Value("start").String().IsEmpty()
+		obj.Value("end").String().IsEmpty()
+		obj.Value("timeZone").String().IsEmpty()
+	})
+}
+
+func TestHandlers_PutMyNotificationKeywords(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/notification-keywords"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithJSON(&PutMyNotificationKeywordsRequest{Keywords: []string{"traQ"}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyNotificationKeywordsRequest{Keywords: []string{""}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyNotificationKeywordsRequest{Keywords: []string{"traQ", "部内"}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			Value("keywords").
+			Array().
+			ContainsOnly("traQ", "部内")
+	})
+}
+
+func TestHandlers_PutMyGroupMentionMutes(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/group-mention-mutes"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	g := env.CreateUserGroup(t, rand, "", "", user.GetID())
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithJSON(&PutMyGroupMentionMutesRequest{GroupIDs: []uuid.UUID{g.ID}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (unknown group)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyGroupMentionMutesRequest{GroupIDs: []uuid.UUID{uuid.Must(uuid.NewV4())}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyGroupMentionMutesRequest{GroupIDs: []uuid.UUID{g.ID}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			Value("groupIds").
+			Array().
+			ContainsOnly(g.ID.String())
+	})
+}
+
+func TestHandlers_PutMyChannelPushMutes(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/channel-push-mutes"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	dm := env.CreateDMChannel(t, user.GetID(), env.CreateUser(t, rand).GetID())
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithJSON(&PutMyChannelPushMutesRequest{ChannelIDs: []uuid.UUID{ch.ID}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (dm channel)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyChannelPushMutesRequest{ChannelIDs: []uuid.UUID{dm.ID}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyChannelPushMutesRequest{ChannelIDs: []uuid.UUID{ch.ID}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			Value("channelIds").
+			Array().
+			ContainsOnly(ch.ID.String())
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/gofrs/uuid"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/labstack/echo/v4"
@@ -21,6 +22,7 @@ import (
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/rbac/role"
+	"github.com/traPtitech/traQ/service/webpush"
 	jwt2 "github.com/traPtitech/traQ/utils/jwt"
 	"github.com/traPtitech/traQ/utils/optional"
 	"github.com/traPtitech/traQ/utils/validator"
@@ -82,6 +84,7 @@ func (h *Handlers) CreateUser(c echo.Context) error {
 		}
 	}
 
+	h.recordAuditLog(c, model.AuditActionUserCreated, user.GetID().String(), model.AuditLogDetail{"name": req.Name})
 	return c.JSON(http.StatusCreated, formatUserDetail(user, []model.UserTag{}, []uuid.UUID{}))
 }
 
@@ -232,7 +235,12 @@ func (h *Handlers) GetUserIcon(c echo.Context) error {
 
 // ChangeUserIcon PUT /users/:userID/icon
 func (h *Handlers) ChangeUserIcon(c echo.Context) error {
-	return utils.ChangeUserIcon(h.Imaging, c, h.Repo, h.FileManager, getParamAsUUID(c, consts.ParamUserID))
+	userID := getParamAsUUID(c, consts.ParamUserID)
+	if err := utils.ChangeUserIcon(h.Imaging, c, h.Repo, h.FileManager, userID); err != nil {
+		return err
+	}
+	h.recordAuditLog(c, model.AuditActionUserIconUpdated, userID.String(), nil)
+	return nil
 }
 
 // GetMyIcon GET /users/me/icon
@@ -306,6 +314,52 @@ func (h *Handlers) PostMyFCMDevice(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// PostMyWebPushSubscriptionRequest POST /users/me/webpush-subscriptions リクエストボディ
+//
+// ブラウザのPushSubscription.toJSON()の形式
+type PostMyWebPushSubscriptionRequest struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+func (r PostMyWebPushSubscriptionRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Endpoint, vd.Required, vd.RuneLength(1, 500), is.URL, validator.HTTPSURL, validator.NotInternalURL),
+		vd.Field(&r.Keys.P256dh, vd.Required, vd.RuneLength(1, 100)),
+		vd.Field(&r.Keys.Auth, vd.Required, vd.RuneLength(1, 50)),
+		vd.Field(&r.Keys, vd.By(func(interface{}) error {
+			return webpush.ValidateSubscriptionKeys(r.Keys.P256dh, r.Keys.Auth)
+		})),
+	)
+}
+
+// PostMyWebPushSubscription POST /users/me/webpush-subscriptions
+func (h *Handlers) PostMyWebPushSubscription(c echo.Context) error {
+	var req PostMyWebPushSubscriptionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	userID := getRequestUserID(c)
+	if err := h.Repo.RegisterWebPushSubscription(userID, repository.RegisterWebPushSubscriptionArgs{
+		Endpoint: req.Endpoint,
+		P256dh:   req.Keys.P256dh,
+		Auth:     req.Keys.Auth,
+	}); err != nil {
+		switch {
+		case repository.IsArgError(err):
+			return herror.BadRequest(err)
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
 // PutUserPasswordRequest PUT /users/:userID/password リクエストボディ
 type PutUserPasswordRequest struct {
 	NewPassword string `json:"newPassword"`
@@ -323,7 +377,12 @@ func (h *Handlers) ChangeUserPassword(c echo.Context) error {
 	if err := bindAndValidate(c, &req); err != nil {
 		return err
 	}
-	return utils.ChangeUserPassword(c, h.Repo, h.SessStore, getParamAsUUID(c, consts.ParamUserID), req.NewPassword)
+	userID := getParamAsUUID(c, consts.ParamUserID)
+	if err := utils.ChangeUserPassword(c, h.Repo, h.SessStore, userID, req.NewPassword); err != nil {
+		return err
+	}
+	h.recordAuditLog(c, model.AuditActionUserPasswordChanged, userID.String(), nil)
+	return nil
 }
 
 // GetUser GET /users/:userID
@@ -369,6 +428,17 @@ func (h *Handlers) EditUser(c echo.Context) error {
 		return err
 	}
 
+	if req.Role.Valid {
+		if _, err := h.Repo.GetUserRole(req.Role.V); err != nil {
+			switch err {
+			case repository.ErrNotFound:
+				return herror.BadRequest("invalid role")
+			default:
+				return herror.InternalServerError(err)
+			}
+		}
+	}
+
 	args := repository.UpdateUserArgs{
 		DisplayName: req.DisplayName,
 		TwitterID:   req.TwitterID,
@@ -382,6 +452,12 @@ func (h *Handlers) EditUser(c echo.Context) error {
 		return herror.InternalServerError(err)
 	}
 
+	h.recordAuditLog(c, model.AuditActionUserUpdated, userID.String(), model.AuditLogDetail{
+		"displayName": req.DisplayName,
+		"twitterId":   req.TwitterID,
+		"role":        req.Role,
+		"state":       req.State,
+	})
 	return c.NoContent(http.StatusNoContent)
 }
 
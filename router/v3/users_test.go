@@ -689,6 +689,81 @@ func TestHandlers_PostMyFCMDevice(t *testing.T) {
 	})
 }
 
+func TestHandlers_PostMyWebPushSubscription(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/webpush-subscriptions"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	newRequest := func(endpoint string) *PostMyWebPushSubscriptionRequest {
+		req := &PostMyWebPushSubscriptionRequest{Endpoint: endpoint}
+		// RFC 8291 Appendix A
+		req.Keys.P256dh = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+		req.Keys.Auth = "BTBZMqHH6r4Tts7J_aSIgg"
+		return req
+	}
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithJSON(newRequest("https://push.example.com/a")).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (invalid endpoint)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(newRequest("not a url")).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (not https)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(newRequest("http://push.example.com/a")).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (invalid keys)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		req := newRequest("https://push.example.com/b")
+		req.Keys.P256dh = "AAAA"
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		endpoint := "https://push.example.com/" + random2.AlphaNumeric(20)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(newRequest(endpoint)).
+			Expect().
+			Status(http.StatusNoContent)
+
+		subs, err := env.Repository.GetWebPushSubscriptions(set.UUID{user.GetID(): {}})
+		require.NoError(t, err)
+		if assert.Len(t, subs[user.GetID()], 1) {
+			assert.Equal(t, endpoint, subs[user.GetID()][0].Endpoint)
+		}
+	})
+}
+
 func TestPutUserPasswordRequest_Validate(t *testing.T) {
 	t.Parallel()
 
@@ -909,6 +984,16 @@ func TestHandlers_EditUser(t *testing.T) {
 			Status(http.StatusBadRequest)
 	})
 
+	t.Run("bad request (unknown role)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, user.GetID()).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PatchUserRequest{Role: optional.From("unknown_role")}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
 	t.Run("forbidden", func(t *testing.T) {
 		t.Parallel()
 		e := env.R(t)
@@ -89,11 +89,31 @@ func getParamMessage(c echo.Context) message.Message {
 	return c.Get(consts.KeyParamMessage).(message.Message)
 }
 
+// getParamMessageReport URLの:reportIDに対応するMessageReportを取得
+func getParamMessageReport(c echo.Context) *model.MessageReport {
+	return c.Get(consts.KeyParamMessageReport).(*model.MessageReport)
+}
+
+// getParamScheduledMessage URLの:scheduledMessageIDに対応するScheduledMessageを取得
+func getParamScheduledMessage(c echo.Context) *model.ScheduledMessage {
+	return c.Get(consts.KeyParamScheduledMessage).(*model.ScheduledMessage)
+}
+
+// getParamOutgoingWebhook URLの:outgoingWebhookIDに対応するOutgoingWebhookを取得
+func getParamOutgoingWebhook(c echo.Context) *model.OutgoingWebhook {
+	return c.Get(consts.KeyParamOutgoingWebhook).(*model.OutgoingWebhook)
+}
+
 // getParamGroup URLの:groupIDに対応するUserGroupを取得
 func getParamGroup(c echo.Context) *model.UserGroup {
 	return c.Get(consts.KeyParamGroup).(*model.UserGroup)
 }
 
+// getParamRole URLの:roleNameに対応するUserRoleを取得
+func getParamRole(c echo.Context) *model.UserRole {
+	return c.Get(consts.KeyParamRole).(*model.UserRole)
+}
+
 // getParamAsUUID URLのnameパラメータの文字列をuuid.UUIDとして取得
 func getParamAsUUID(c echo.Context, name string) uuid.UUID {
 	return extension.GetRequestParamAsUUID(c, name)
@@ -105,12 +125,13 @@ func getParamClipFolder(c echo.Context) *model.ClipFolder {
 }
 
 type MessagesQuery struct {
-	Limit     int                    `query:"limit"`
-	Offset    int                    `query:"offset"`
-	Since     optional.Of[time.Time] `query:"since"`
-	Until     optional.Of[time.Time] `query:"until"`
-	Inclusive bool                   `query:"inclusive"`
-	Order     string                 `query:"order"`
+	Limit          int                    `query:"limit"`
+	Offset         int                    `query:"offset"`
+	Since          optional.Of[time.Time] `query:"since"`
+	Until          optional.Of[time.Time] `query:"until"`
+	Inclusive      bool                   `query:"inclusive"`
+	Order          string                 `query:"order"`
+	IncludeReplies bool                   `query:"includeReplies"`
 }
 
 func (q *MessagesQuery) bind(c echo.Context) error {
@@ -141,6 +162,7 @@ func (q *MessagesQuery) convert() message.TimelineQuery {
 func (q *MessagesQuery) convertC(cid uuid.UUID) message.TimelineQuery {
 	r := q.convert()
 	r.Channel = cid
+	r.IncludeReplies = q.IncludeReplies
 	return r
 }
 
@@ -150,6 +172,12 @@ func (q *MessagesQuery) convertU(uid uuid.UUID) message.TimelineQuery {
 	return r
 }
 
+func (q *MessagesQuery) convertP(pid uuid.UUID) message.TimelineQuery {
+	r := q.convert()
+	r.Parent = pid
+	return r
+}
+
 func serveMessages(c echo.Context, mm message.Manager, query message.TimelineQuery) error {
 	timeline, err := mm.GetTimeline(query)
 	if err != nil {
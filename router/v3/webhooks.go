@@ -4,10 +4,12 @@ import (
 	"context"
 	"crypto/subtle"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	vd "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/gofrs/uuid"
@@ -24,8 +26,13 @@ import (
 	"github.com/traPtitech/traQ/utils/hmac"
 	"github.com/traPtitech/traQ/utils/optional"
 	"github.com/traPtitech/traQ/utils/validator"
+	"github.com/traPtitech/traQ/utils/vcswebhook"
+	"github.com/traPtitech/traQ/utils/webhooktemplate"
 )
 
+// vcsWebhookBodyLimit GitHub・GitLab Webhookのリクエストボディの最大バイト数
+const vcsWebhookBodyLimit = 5 << 20
+
 // GetWebhooks GET /webhooks
 func (h *Handlers) GetWebhooks(c echo.Context) error {
 	user := getRequestUser(c)
@@ -61,7 +68,12 @@ func (h *Handlers) GetWebhookIcon(c echo.Context) error {
 
 // ChangeWebhookIcon PUT /webhooks/:webhookID/icon
 func (h *Handlers) ChangeWebhookIcon(c echo.Context) error {
-	return utils.ChangeUserIcon(h.Imaging, c, h.Repo, h.FileManager, getParamWebhook(c).GetBotUserID())
+	w := getParamWebhook(c)
+	if err := utils.ChangeUserIcon(h.Imaging, c, h.Repo, h.FileManager, w.GetBotUserID()); err != nil {
+		return err
+	}
+	h.recordAuditLog(c, model.AuditActionWebhookUpdated, w.GetID().String(), model.AuditLogDetail{"icon": true})
+	return nil
 }
 
 // PostWebhooksRequest POST /webhooks リクエストボディ
@@ -105,6 +117,7 @@ func (h *Handlers) CreateWebhook(c echo.Context) error {
 		}
 	}
 
+	h.recordAuditLog(c, model.AuditActionWebhookCreated, w.GetID().String(), model.AuditLogDetail{"name": req.Name, "channelId": req.ChannelID})
 	return c.JSON(http.StatusCreated, formatWebhook(w))
 }
 
@@ -120,6 +133,7 @@ type PatchWebhookRequest struct {
 	Description optional.Of[string]    `json:"description"`
 	ChannelID   optional.Of[uuid.UUID] `json:"channelId"`
 	Secret      optional.Of[string]    `json:"secret"`
+	Template    optional.Of[string]    `json:"template"`
 	OwnerID     optional.Of[uuid.UUID] `json:"ownerId"`
 }
 
@@ -129,6 +143,7 @@ func (r PatchWebhookRequest) ValidateWithContext(ctx context.Context) error {
 		vd.Field(&r.Description, validator.RequiredIfValid, vd.RuneLength(1, 1000)),
 		vd.Field(&r.ChannelID, validator.NotNilUUID, utils.IsPublicChannelID),
 		vd.Field(&r.Secret, vd.RuneLength(0, 50)),
+		vd.Field(&r.Template, vd.RuneLength(0, webhooktemplate.MaxTemplateLength), webhookTemplateRule),
 		vd.Field(&r.OwnerID, validator.NotNilUUID, utils.IsActiveHumanUserID),
 	)
 }
@@ -147,6 +162,7 @@ func (h *Handlers) EditWebhook(c echo.Context) error {
 		Description: req.Description,
 		ChannelID:   req.ChannelID,
 		Secret:      req.Secret,
+		Template:    req.Template,
 		CreatorID:   req.OwnerID,
 	}
 	if err := h.Repo.UpdateWebhook(w.GetID(), args); err != nil {
@@ -157,20 +173,95 @@ func (h *Handlers) EditWebhook(c echo.Context) error {
 			return herror.InternalServerError(err)
 		}
 	}
+	h.recordAuditLog(c, model.AuditActionWebhookUpdated, w.GetID().String(), model.AuditLogDetail{
+		"name":            req.Name,
+		"channelId":       req.ChannelID,
+		"secretChanged":   req.Secret.Valid,
+		"templateChanged": req.Template.Valid,
+		"ownerId":         req.OwnerID,
+	})
 	return c.NoContent(http.StatusNoContent)
 }
 
+// webhookTemplateLimiter Webhookごとのテンプレート適用のレートリミッター
+var webhookTemplateLimiter = webhooktemplate.NewLimiter(time.Second, 10)
+
+// webhookTemplateRule Webhookのメッセージテンプレートのバリデーションルール
+var webhookTemplateRule = vd.By(func(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case optional.Of[string]:
+		s = v.ValueOrZero()
+	}
+	if len(s) == 0 {
+		return nil
+	}
+	if err := webhooktemplate.Validate(s); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	return nil
+})
+
+// PostWebhookPreviewRequest POST /webhooks/:webhookID/preview リクエストボディ
+type PostWebhookPreviewRequest struct {
+	Template optional.Of[string] `json:"template"`
+	Payload  json.RawMessage     `json:"payload"`
+}
+
+func (r PostWebhookPreviewRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Template, validator.RequiredIfValid, vd.RuneLength(1, webhooktemplate.MaxTemplateLength), webhookTemplateRule),
+		vd.Field(&r.Payload, vd.Required),
+	)
+}
+
+// PreviewWebhookTemplate POST /webhooks/:webhookID/preview
+func (h *Handlers) PreviewWebhookTemplate(c echo.Context) error {
+	w := getParamWebhook(c)
+
+	var req PostWebhookPreviewRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	tmpl := w.GetTemplate()
+	if req.Template.Valid {
+		tmpl = req.Template.V
+	}
+	if len(tmpl) == 0 {
+		return herror.BadRequest("template is not set")
+	}
+
+	if !webhookTemplateLimiter.Allow(w.GetID()) {
+		return herror.HTTPError(http.StatusTooManyRequests, "too many template renderings")
+	}
+	content, err := webhooktemplate.Render(c.Request().Context(), tmpl, req.Payload)
+	if err != nil {
+		return herror.BadRequest(fmt.Sprintf("failed to render template: %s", err))
+	}
+	return c.JSON(http.StatusOK, echo.Map{"content": content})
+}
+
 // PostWebhook POST /webhooks/:webhookID
 func (h *Handlers) PostWebhook(c echo.Context) error {
 	w := getParamWebhook(c)
-	channelID := w.GetChannelID()
+	templated := len(w.GetTemplate()) > 0
 
-	// text/plainのみ受け付ける
-	switch strings.ToLower(c.Request().Header.Get(echo.HeaderContentType)) {
-	case echo.MIMETextPlain, strings.ToLower(echo.MIMETextPlainCharsetUTF8):
-		break
-	default:
-		return echo.NewHTTPError(http.StatusUnsupportedMediaType)
+	// テンプレートが設定されている場合はapplication/json、それ以外はtext/plainのみ受け付ける
+	contentType := strings.ToLower(c.Request().Header.Get(echo.HeaderContentType))
+	if templated {
+		if !strings.HasPrefix(contentType, echo.MIMEApplicationJSON) {
+			return echo.NewHTTPError(http.StatusUnsupportedMediaType)
+		}
+	} else {
+		switch contentType {
+		case echo.MIMETextPlain, strings.ToLower(echo.MIMETextPlainCharsetUTF8):
+			break
+		default:
+			return echo.NewHTTPError(http.StatusUnsupportedMediaType)
+		}
 	}
 
 	body, err := io.ReadAll(c.Request().Body)
@@ -192,6 +283,124 @@ func (h *Handlers) PostWebhook(c echo.Context) error {
 		}
 	}
 
+	text := string(body)
+
+	// テンプレート適用
+	if templated {
+		if !webhookTemplateLimiter.Allow(w.GetID()) {
+			return herror.HTTPError(http.StatusTooManyRequests, "too many template renderings")
+		}
+		text, err = webhooktemplate.Render(c.Request().Context(), w.GetTemplate(), body)
+		if err != nil {
+			return herror.BadRequest(fmt.Sprintf("failed to render template: %s", err))
+		}
+	}
+
+	// 埋め込み変換
+	if isTrue(c.QueryParam("embed")) {
+		text = h.Replacer.Replace(text)
+	}
+
+	return h.postWebhookMessage(c, w, text)
+}
+
+// PostWebhookGitHub POST /webhooks/:webhookID/github
+func (h *Handlers) PostWebhookGitHub(c echo.Context) error {
+	w := getParamWebhook(c)
+
+	body, err := readWebhookJSONBody(c)
+	if err != nil {
+		return err
+	}
+
+	// Webhookシークレット確認
+	if len(w.GetSecret()) > 0 {
+		sig := c.Request().Header.Get(consts.HeaderGitHubSignature)
+		if len(sig) == 0 {
+			return herror.BadRequest(fmt.Sprintf("missing %s header", consts.HeaderGitHubSignature))
+		}
+		if !vcswebhook.VerifyGitHubSignature(body, w.GetSecret(), sig) {
+			return herror.BadRequest(fmt.Sprintf("%s is wrong", consts.HeaderGitHubSignature))
+		}
+	}
+
+	text, err := vcswebhook.RenderGitHub(c.Request().Header.Get(consts.HeaderGitHubEvent), body)
+	if err != nil {
+		return renderVCSWebhookError(err)
+	}
+	if len(text) == 0 {
+		return c.NoContent(http.StatusNoContent)
+	}
+	return h.postWebhookMessage(c, w, text)
+}
+
+// PostWebhookGitLab POST /webhooks/:webhookID/gitlab
+func (h *Handlers) PostWebhookGitLab(c echo.Context) error {
+	w := getParamWebhook(c)
+
+	body, err := readWebhookJSONBody(c)
+	if err != nil {
+		return err
+	}
+
+	// Webhookシークレット確認
+	if len(w.GetSecret()) > 0 {
+		token := c.Request().Header.Get(consts.HeaderGitLabToken)
+		if len(token) == 0 {
+			return herror.BadRequest(fmt.Sprintf("missing %s header", consts.HeaderGitLabToken))
+		}
+		if !vcswebhook.VerifyGitLabToken(w.GetSecret(), token) {
+			return herror.BadRequest(fmt.Sprintf("%s is wrong", consts.HeaderGitLabToken))
+		}
+	}
+
+	text, err := vcswebhook.RenderGitLab(c.Request().Header.Get(consts.HeaderGitLabEvent), body)
+	if err != nil {
+		return renderVCSWebhookError(err)
+	}
+	if len(text) == 0 {
+		return c.NoContent(http.StatusNoContent)
+	}
+	return h.postWebhookMessage(c, w, text)
+}
+
+// readWebhookJSONBody application/jsonのリクエストボディを読み込みます
+//
+// vcsWebhookBodyLimitを超えるボディは読み込まずに413を返します
+func readWebhookJSONBody(c echo.Context) ([]byte, error) {
+	if !strings.HasPrefix(strings.ToLower(c.Request().Header.Get(echo.HeaderContentType)), echo.MIMEApplicationJSON) {
+		return nil, echo.NewHTTPError(http.StatusUnsupportedMediaType)
+	}
+	if c.Request().ContentLength > vcsWebhookBodyLimit {
+		return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("the request must be smaller than %dKiB", vcsWebhookBodyLimit>>10))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, vcsWebhookBodyLimit+1))
+	if err != nil {
+		return nil, herror.InternalServerError(err)
+	}
+	if len(body) > vcsWebhookBodyLimit {
+		return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, fmt.Sprintf("the request must be smaller than %dKiB", vcsWebhookBodyLimit>>10))
+	}
+	if len(body) == 0 {
+		return nil, herror.BadRequest("empty body")
+	}
+	return body, nil
+}
+
+func renderVCSWebhookError(err error) error {
+	switch err {
+	case vcswebhook.ErrUnsupportedEvent:
+		return herror.BadRequest("unsupported event")
+	default:
+		return herror.BadRequest(fmt.Sprintf("invalid payload: %s", err))
+	}
+}
+
+// postWebhookMessage Webhookユーザーとしてメッセージを投稿します
+func (h *Handlers) postWebhookMessage(c echo.Context, w model.Webhook, text string) error {
+	channelID := w.GetChannelID()
+
 	// 投稿先チャンネル変更
 	if cid := c.Request().Header.Get(consts.HeaderChannelID); len(cid) > 0 {
 		id, err := uuid.FromString(cid)
@@ -206,13 +415,8 @@ func (h *Handlers) PostWebhook(c echo.Context) error {
 		return herror.BadRequest("invalid channel")
 	}
 
-	// 埋め込み変換
-	if isTrue(c.QueryParam("embed")) {
-		body = []byte(h.Replacer.Replace(string(body)))
-	}
-
 	// メッセージ投稿
-	if _, err := h.MessageManager.Create(channelID, w.GetBotUserID(), string(body)); err != nil {
+	if _, err := h.MessageManager.Create(channelID, w.GetBotUserID(), text); err != nil {
 		switch err {
 		case message.ErrChannelArchived:
 			return herror.BadRequest("the channel has been archived")
@@ -231,6 +435,7 @@ func (h *Handlers) DeleteWebhook(c echo.Context) error {
 	if err := h.Repo.DeleteWebhook(w.GetID()); err != nil {
 		return herror.InternalServerError(err)
 	}
+	h.recordAuditLog(c, model.AuditActionWebhookDeleted, w.GetID().String(), nil)
 
 	return c.NoContent(http.StatusNoContent)
 }
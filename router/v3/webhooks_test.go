@@ -3,8 +3,10 @@ package v3
 import (
 	"crypto/hmac"
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/hex"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/gavv/httpexpect/v2"
@@ -28,6 +30,7 @@ func webhookEquals(t *testing.T, expect model.Webhook, actual *httpexpect.Object
 	actual.Value("displayName").String().IsEqual(expect.GetName())
 	actual.Value("description").String().IsEqual(expect.GetDescription())
 	actual.Value("secure").Boolean().IsEqual(len(expect.GetSecret()) > 0)
+	actual.Value("template").String().IsEqual(expect.GetTemplate())
 	actual.Value("channelId").String().IsEqual(expect.GetChannelID().String())
 	actual.Value("ownerId").String().IsEqual(expect.GetCreatorID().String())
 	actual.Value("createdAt").String().NotEmpty()
@@ -290,6 +293,31 @@ func TestHandlers_EditWebhook(t *testing.T) {
 			Status(http.StatusNotFound)
 	})
 
+	t.Run("bad request (invalid template)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PatchWebhookRequest{Template: optional.From("{{.title")}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success (template)", func(t *testing.T) {
+		t.Parallel()
+		wh := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+		e := env.R(t)
+		e.PATCH(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PatchWebhookRequest{Template: optional.From("**{{.title}}**")}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		wh, err := env.Repository.GetWebhook(wh.GetID())
+		require.NoError(t, err)
+		assert.EqualValues(t, "**{{.title}}**", wh.GetTemplate())
+	})
+
 	t.Run("success", func(t *testing.T) {
 		t.Parallel()
 		e := env.R(t)
@@ -447,6 +475,332 @@ func TestHandlers_PostWebhook(t *testing.T) {
 	})
 }
 
+func TestHandlers_PostWebhook_Template(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/webhooks/{webhookId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	wh := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+	require.NoError(t, env.Repository.UpdateWebhook(wh.GetID(), repository.UpdateWebhookArgs{
+		Template: optional.From("{{.status | upper}}: {{.title}}"),
+	}))
+
+	calcHMACSHA1 := func(t *testing.T, message, secret string) string {
+		t.Helper()
+		mac := hmac.New(sha1.New, []byte(secret))
+		_, _ = mac.Write([]byte(message))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	payload := `{"status":"firing","title":"High CPU"}`
+
+	t.Run("unsupported media type", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-TRAQ-Signature", calcHMACSHA1(t, payload, wh.GetSecret())).
+			WithText(payload).
+			Expect().
+			Status(http.StatusUnsupportedMediaType)
+	})
+
+	t.Run("bad request (invalid json)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-TRAQ-Signature", calcHMACSHA1(t, "po", wh.GetSecret())).
+			WithBytes([]byte("po")).
+			WithHeader("Content-Type", "application/json").
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-TRAQ-Signature", calcHMACSHA1(t, payload, wh.GetSecret())).
+			WithBytes([]byte(payload)).
+			WithHeader("Content-Type", "application/json").
+			Expect().
+			Status(http.StatusNoContent)
+
+		tl, err := env.MM.GetTimeline(message.TimelineQuery{Channel: ch.ID})
+		require.NoError(t, err)
+		if assert.Len(t, tl.Records(), 1) {
+			m := tl.Records()[0]
+			assert.EqualValues(t, wh.GetBotUserID(), m.GetUserID())
+			assert.EqualValues(t, "FIRING: High CPU", m.GetText())
+		}
+	})
+}
+
+func TestHandlers_PreviewWebhookTemplate(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/webhooks/{webhookId}/preview"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	wh := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+	templated := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+	require.NoError(t, env.Repository.UpdateWebhook(templated.GetID(), repository.UpdateWebhookArgs{
+		Template: optional.From("{{.title}}"),
+	}))
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+	payload := map[string]interface{}{"title": "High CPU", "count": 3}
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithJSON(map[string]interface{}{"template": "{{.title}}", "payload": payload}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithCookie(session.CookieName, s2).
+			WithJSON(map[string]interface{}{"template": "{{.title}}", "payload": payload}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request (invalid template)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"template": "{{.title", "payload": payload}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (no template)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"payload": payload}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"template": "{{.title}} x{{.count}}", "payload": payload}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			Value("content").
+			String().
+			IsEqual("High CPU x3")
+	})
+
+	t.Run("success (saved template)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, templated.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(map[string]interface{}{"payload": payload}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			Value("content").
+			String().
+			IsEqual("High CPU")
+	})
+}
+
+func TestHandlers_PostWebhookGitHub(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/webhooks/{webhookId}/github"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	wh := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+
+	calcSignature := func(t *testing.T, message, secret string) string {
+		t.Helper()
+		mac := hmac.New(sha256.New, []byte(secret))
+		_, _ = mac.Write([]byte(message))
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	issue := `{"action":"opened","issue":{"number":1,"title":"Bug","html_url":"https://github.com/traPtitech/traQ/issues/1"},"repository":{"full_name":"traPtitech/traQ","html_url":"https://github.com/traPtitech/traQ"},"sender":{"login":"takashi_trap"}}`
+
+	t.Run("bad request (no signature)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-GitHub-Event", "issues").
+			WithBytes([]byte(issue)).
+			WithHeader("Content-Type", "application/json").
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (bad signature)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-GitHub-Event", "issues").
+			WithHeader("X-Hub-Signature-256", calcSignature(t, "po", wh.GetSecret())).
+			WithBytes([]byte(issue)).
+			WithHeader("Content-Type", "application/json").
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (unsupported event)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-GitHub-Event", "fork").
+			WithHeader("X-Hub-Signature-256", calcSignature(t, issue, wh.GetSecret())).
+			WithBytes([]byte(issue)).
+			WithHeader("Content-Type", "application/json").
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("request entity too large", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		body := []byte(`{"po":"` + strings.Repeat("a", vcsWebhookBodyLimit) + `"}`)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-GitHub-Event", "issues").
+			WithHeader("X-Hub-Signature-256", calcSignature(t, string(body), wh.GetSecret())).
+			WithBytes(body).
+			WithHeader("Content-Type", "application/json").
+			Expect().
+			Status(http.StatusRequestEntityTooLarge)
+	})
+
+	t.Run("unsupported media type", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-GitHub-Event", "issues").
+			WithHeader("X-Hub-Signature-256", calcSignature(t, issue, wh.GetSecret())).
+			WithText(issue).
+			Expect().
+			Status(http.StatusUnsupportedMediaType)
+	})
+
+	t.Run("success (ping)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		ping := `{"zen":"Keep it logically awesome."}`
+		e.POST(path, wh.GetID()).
+			WithHeader("X-GitHub-Event", "ping").
+			WithHeader("X-Hub-Signature-256", calcSignature(t, ping, wh.GetSecret())).
+			WithBytes([]byte(ping)).
+			WithHeader("Content-Type", "application/json").
+			Expect().
+			Status(http.StatusNoContent)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ch := env.CreateChannel(t, rand)
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-GitHub-Event", "issues").
+			WithHeader("X-Hub-Signature-256", calcSignature(t, issue, wh.GetSecret())).
+			WithHeader("X-TRAQ-Channel-Id", ch.ID.String()).
+			WithBytes([]byte(issue)).
+			WithHeader("Content-Type", "application/json").
+			Expect().
+			Status(http.StatusNoContent)
+
+		tl, err := env.MM.GetTimeline(message.TimelineQuery{Channel: ch.ID})
+		require.NoError(t, err)
+		if assert.Len(t, tl.Records(), 1) {
+			m := tl.Records()[0]
+			assert.EqualValues(t, wh.GetBotUserID(), m.GetUserID())
+			assert.Contains(t, m.GetText(), "[#1 Bug](https://github.com/traPtitech/traQ/issues/1)")
+		}
+	})
+}
+
+func TestHandlers_PostWebhookGitLab(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/webhooks/{webhookId}/gitlab"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	wh := env.CreateWebhook(t, rand, user.GetID(), ch.ID)
+
+	pipeline := map[string]interface{}{
+		"object_attributes": map[string]interface{}{"id": 100, "ref": "main", "status": "failed"},
+		"project":           map[string]interface{}{"path_with_namespace": "trap/traq", "web_url": "https://gitlab.example.com/trap/traq"},
+	}
+
+	t.Run("bad request (no token)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-Gitlab-Event", "Pipeline Hook").
+			WithJSON(pipeline).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (wrong token)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-Gitlab-Event", "Pipeline Hook").
+			WithHeader("X-Gitlab-Token", "wrong").
+			WithJSON(pipeline).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4())).
+			WithHeader("X-Gitlab-Event", "Pipeline Hook").
+			WithHeader("X-Gitlab-Token", wh.GetSecret()).
+			WithJSON(pipeline).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, wh.GetID()).
+			WithHeader("X-Gitlab-Event", "Pipeline Hook").
+			WithHeader("X-Gitlab-Token", wh.GetSecret()).
+			WithJSON(pipeline).
+			Expect().
+			Status(http.StatusNoContent)
+
+		tl, err := env.MM.GetTimeline(message.TimelineQuery{Channel: ch.ID})
+		require.NoError(t, err)
+		if assert.Len(t, tl.Records(), 1) {
+			m := tl.Records()[0]
+			assert.EqualValues(t, wh.GetBotUserID(), m.GetUserID())
+			assert.Contains(t, m.GetText(), "パイプライン [#100](https://gitlab.example.com/trap/traq/-/pipelines/100)")
+		}
+	})
+}
+
 func TestHandlers_DeleteWebhook(t *testing.T) {
 	t.Parallel()
 
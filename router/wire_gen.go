@@ -52,26 +52,28 @@ func newRouter(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, ss *servi
 	webrtcv3Manager := ss.WebRTCv3
 	processor := ss.Imaging
 	engine := ss.Search
+	outgoingwebhookService := ss.OutgoingWebhook
 	v3Config := provideV3Config(config)
 	v3Handlers := &v3.Handlers{
-		RBAC:           rbac,
-		Repo:           repo,
-		WS:             streamer,
-		BotWS:          wsStreamer,
-		Hub:            hub2,
-		Logger:         logger,
-		OC:             onlineCounter,
-		OGP:            ogpService,
-		VM:             viewerManager,
-		WebRTC:         webrtcv3Manager,
-		Imaging:        processor,
-		SessStore:      store,
-		SearchEngine:   engine,
-		ChannelManager: manager,
-		MessageManager: messageManager,
-		FileManager:    fileManager,
-		Replacer:       replacer,
-		Config:         v3Config,
+		RBAC:            rbac,
+		Repo:            repo,
+		WS:              streamer,
+		BotWS:           wsStreamer,
+		Hub:             hub2,
+		Logger:          logger,
+		OC:              onlineCounter,
+		OGP:             ogpService,
+		VM:              viewerManager,
+		WebRTC:          webrtcv3Manager,
+		Imaging:         processor,
+		SessStore:       store,
+		SearchEngine:    engine,
+		ChannelManager:  manager,
+		MessageManager:  messageManager,
+		FileManager:     fileManager,
+		Replacer:        replacer,
+		OutgoingWebhook: outgoingwebhookService,
+		Config:          v3Config,
 	}
 	oauth2Config := provideOAuth2Config(config)
 	handler := &oauth2.Handler{
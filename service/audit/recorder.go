@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// Recorder 監査ログレコーダー
+//
+// event.AuditActionPerformedを購読し、監査ログとしてDBに記録します
+type Recorder struct {
+	repo   repository.AuditLogRepository
+	sub    hub.Subscription
+	logger *zap.Logger
+}
+
+// NewRecorder 監査ログレコーダーを生成します
+func NewRecorder(hub *hub.Hub, repo repository.Repository, logger *zap.Logger) *Recorder {
+	return &Recorder{
+		repo:   repo,
+		sub:    hub.Subscribe(100, event.AuditActionPerformed),
+		logger: logger.Named("audit"),
+	}
+}
+
+// Start 記録を開始します
+func (r *Recorder) Start() {
+	go func() {
+		for ev := range r.sub.Receiver {
+			r.record(ev)
+		}
+	}()
+}
+
+func (r *Recorder) record(ev hub.Message) {
+	log := &model.AuditLog{
+		ID:        uuid.Must(uuid.NewV4()),
+		Action:    ev.Fields["action"].(model.AuditAction),
+		Target:    ev.Fields["target"].(string),
+		Detail:    ev.Fields["detail"].(model.AuditLogDetail),
+		IPAddress: ev.Fields["ip_address"].(string),
+		CreatedAt: ev.Fields["datetime"].(time.Time),
+	}
+	if actorID := ev.Fields["actor_id"].(uuid.UUID); actorID != uuid.Nil {
+		log.ActorID = optional.From(actorID)
+	}
+	if err := r.repo.CreateAuditLog(log); err != nil {
+		// 記録に失敗した操作はログに残す
+		r.logger.Error("failed to record audit log",
+			zap.Error(err),
+			zap.String("action", string(log.Action)),
+			zap.Stringer("actorId", ev.Fields["actor_id"].(uuid.UUID)),
+			zap.String("target", log.Target),
+		)
+	}
+}
+
+// RecordSystemAction リクエストを介さないシステムによる操作を監査ログとしてDBに記録します
+//
+// Botの自動停止など、event.AuditActionPerformedを発行するルーター以外で行われる操作に使います。
+// 操作者とIPアドレスは記録されません。
+func RecordSystemAction(repo repository.AuditLogRepository, action model.AuditAction, target string, detail model.AuditLogDetail) error {
+	if detail == nil {
+		detail = model.AuditLogDetail{}
+	}
+	return repo.CreateAuditLog(&model.AuditLog{
+		ID:        uuid.Must(uuid.NewV4()),
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+		CreatedAt: time.Now(),
+	})
+}
@@ -14,6 +14,10 @@ import (
 type Dispatcher interface {
 	// Send Botにイベントを送信します
 	Send(b *model.Bot, event model.BotEventType, body []byte) (ok bool)
+	// Redeliver アウトボックスに保存されたイベントを再送します
+	//
+	// 成功した場合はアウトボックスから削除し、失敗した場合は試行回数を更新します。
+	Redeliver(b *model.Bot, d *model.BotEventDelivery) (ok bool)
 }
 
 // Unicast 単一のBOTにイベントを送信
@@ -3,6 +3,7 @@ package event
 import (
 	"bytes"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -15,7 +16,10 @@ import (
 const (
 	headerTRAQBotEvent             = "X-TRAQ-BOT-EVENT"
 	headerTRAQBotRequestID         = "X-TRAQ-BOT-REQUEST-ID"
+	headerTRAQBotDeliveryID        = "X-TRAQ-BOT-DELIVERY-ID"
 	headerTRAQBotVerificationToken = "X-TRAQ-BOT-TOKEN"
+	headerTRAQBotSignature         = "X-TRAQ-BOT-SIGNATURE"
+	headerTRAQBotTimestamp         = "X-TRAQ-BOT-TIMESTAMP"
 	headerUserAgent                = "User-Agent"
 	ua                             = "traQ_Bot_Processor/1.0"
 )
@@ -38,15 +42,18 @@ func newHTTPDispatcher(logger *zap.Logger) *httpDispatcher {
 	}
 }
 
-func (d *httpDispatcher) send(b *model.Bot, event model.BotEventType, reqID uuid.UUID, body []byte) (ok bool, log *model.BotEventLog) {
+func (d *httpDispatcher) send(b *model.Bot, event model.BotEventType, reqID, deliveryID uuid.UUID, body []byte) (ok bool, log *model.BotEventLog) {
 	req, _ := http.NewRequest(http.MethodPost, b.PostURL, bytes.NewReader(body))
 	req.Header.Set(headerUserAgent, ua)
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
 	req.Header.Set(headerTRAQBotEvent, event.String())
 	req.Header.Set(headerTRAQBotRequestID, reqID.String())
+	req.Header.Set(headerTRAQBotDeliveryID, deliveryID.String())
 	req.Header.Set(headerTRAQBotVerificationToken, b.VerificationToken)
 
 	start := time.Now()
+	req.Header.Set(headerTRAQBotTimestamp, strconv.FormatInt(start.Unix(), 10))
+	req.Header.Set(headerTRAQBotSignature, signaturePrefix+Sign(b.SigningSecret, start.Unix(), body))
 	res, err := d.client.Do(req)
 	latency := time.Since(start)
 
@@ -68,7 +75,7 @@ func (d *httpDispatcher) send(b *model.Bot, event model.BotEventType, reqID uuid
 	}
 
 	_ = res.Body.Close()
-	if res.StatusCode == http.StatusNoContent {
+	if 200 <= res.StatusCode && res.StatusCode < 300 {
 		log.Result = resultOK
 	} else {
 		log.Result = resultNG
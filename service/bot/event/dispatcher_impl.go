@@ -1,6 +1,11 @@
 package event
 
 import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
 	"github.com/gofrs/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -8,7 +13,9 @@ import (
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/audit"
 	botWS "github.com/traPtitech/traQ/service/bot/ws"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 var eventSendCounter = promauto.NewCounterVec(prometheus.CounterOpts{
@@ -23,37 +30,179 @@ const (
 	resultDropped      = "dp"
 )
 
+const (
+	// MaxDeliveryAttempts イベント配送の最大試行回数。超えた場合はデッドレターになります
+	MaxDeliveryAttempts = 8
+	// MaxPendingDeliveries Botごとの再送待ちイベントの最大数。超えた場合、未接続のWebSocketモードのBotへのイベントは保存されません
+	MaxPendingDeliveries = 1000
+	// deliveryLeaseDuration 再送処理権の期限。HTTPのタイムアウトより十分長くする
+	deliveryLeaseDuration = time.Minute
+	// retryBaseInterval 初回の再送間隔
+	retryBaseInterval = 30 * time.Second
+	// retryMaxInterval 再送間隔の上限
+	retryMaxInterval = time.Hour
+	// failureWindowSize 自動一時停止の判定に用いる直近の配送結果の数
+	failureWindowSize = 20
+	// failureRateThreshold 自動一時停止する配送失敗率
+	failureRateThreshold = 0.8
+)
+
+// Repository Dispatcherが必要とするリポジトリ
+type Repository interface {
+	repository.BotRepository
+	repository.BotEventDeliveryRepository
+	repository.AuditLogRepository
+}
+
 type dispatcherImpl struct {
-	http *httpDispatcher
-	ws   *wsDispatcher
-	l    *zap.Logger
-	repo repository.BotRepository
+	http     *httpDispatcher
+	ws       *wsDispatcher
+	l        *zap.Logger
+	repo     Repository
+	failures *failureWindow
 }
 
-func NewDispatcher(logger *zap.Logger, repo repository.BotRepository, s *botWS.Streamer) Dispatcher {
+func NewDispatcher(logger *zap.Logger, repo Repository, s *botWS.Streamer) Dispatcher {
 	return &dispatcherImpl{
-		http: newHTTPDispatcher(logger),
-		ws:   newWSDispatcher(s, logger),
-		l:    logger.Named("bot.dispatcher"),
-		repo: repo,
+		http:     newHTTPDispatcher(logger),
+		ws:       newWSDispatcher(s, logger),
+		l:        logger.Named("bot.dispatcher"),
+		repo:     repo,
+		failures: newFailureWindow(failureWindowSize, failureRateThreshold),
 	}
 }
 
 func (d *dispatcherImpl) Send(b *model.Bot, event model.BotEventType, body []byte) (ok bool) {
 	reqID := uuid.Must(uuid.NewV4())
 
-	var log *model.BotEventLog
+	ok, log := d.send(b, event, reqID, reqID, body)
+	if ok || log == nil || !retryable(log) {
+		return ok
+	}
+
+	if log.Result == resultDropped {
+		// 接続されないままのBotのイベントが溜まり続けないようにする
+		n, err := d.repo.CountBotEventDeliveries(b.ID, model.BotEventDeliveryPending)
+		if err != nil {
+			d.l.Warn("failed to count deliveries", zap.Error(err), zap.Stringer("botID", b.ID))
+			return false
+		}
+		if n >= MaxPendingDeliveries {
+			d.l.Debug("bot event was discarded because too many events are pending", zap.Stringer("botID", b.ID), zap.Stringer("event", event))
+			return false
+		}
+	}
+
+	// 失敗したイベントはアウトボックスに保存して後で再送する
+	delivery := &model.BotEventDelivery{
+		ID:     reqID,
+		BotID:  b.ID,
+		Event:  event,
+		Body:   string(body),
+		Status: model.BotEventDeliveryPending,
+	}
+	d.recordFailure(b, delivery, log)
+	return false
+}
+
+func (d *dispatcherImpl) Redeliver(b *model.Bot, delivery *model.BotEventDelivery) (ok bool) {
+	// 複数のインスタンスが同じイベントを再送しないよう、処理権を得る
+	claimed, err := d.repo.ClaimBotEventDelivery(delivery.ID, time.Now().Add(deliveryLeaseDuration))
+	if err != nil {
+		d.l.Warn("failed to claim delivery", zap.Error(err), zap.Stringer("deliveryID", delivery.ID))
+		return false
+	}
+	if !claimed {
+		return false // 他のインスタンスが再送中
+	}
+
+	ok, log := d.send(b, delivery.Event, uuid.Must(uuid.NewV4()), delivery.ID, []byte(delivery.Body))
+	if log == nil {
+		d.release(delivery)
+		return false
+	}
+	if ok {
+		if err := d.repo.DeleteBotEventDelivery(delivery.ID); err != nil {
+			d.l.Warn("failed to delete delivery", zap.Error(err), zap.Stringer("deliveryID", delivery.ID))
+		}
+		return true
+	}
+	if log.Result == resultDropped {
+		// WSが未接続の場合は試行回数に数えず、次の接続時に再送する
+		d.release(delivery)
+		return false
+	}
+	if !retryable(log) {
+		// Botが受け取った上で拒否したため再送しない
+		d.l.Info("bot event redelivery was rejected by the bot", zap.Stringer("botID", b.ID), zap.Stringer("deliveryID", delivery.ID), zap.Int("code", log.Code))
+		if err := d.repo.DeleteBotEventDelivery(delivery.ID); err != nil {
+			d.l.Warn("failed to delete delivery", zap.Error(err), zap.Stringer("deliveryID", delivery.ID))
+		}
+		return false
+	}
+	d.recordFailure(b, delivery, log)
+	return false
+}
+
+func (d *dispatcherImpl) send(b *model.Bot, event model.BotEventType, reqID, deliveryID uuid.UUID, body []byte) (ok bool, log *model.BotEventLog) {
 	switch b.Mode {
 	case model.BotModeHTTP:
-		ok, log = d.http.send(b, event, reqID, body)
+		ok, log = d.http.send(b, event, reqID, deliveryID, body)
+		// 4xxはBotが受け取った上での応答のため、一時的な失敗として数えない
+		if ok || retryable(log) {
+			d.recordResult(b, ok)
+		}
 	case model.BotModeWebSocket:
 		ok, log = d.ws.send(b, event, reqID, body)
 	default:
-		return false
+		return false, nil
 	}
 
 	d.writeLog(log)
-	return ok
+	return ok, log
+}
+
+// recordFailure 配送の失敗をアウトボックスに記録します
+func (d *dispatcherImpl) recordFailure(b *model.Bot, delivery *model.BotEventDelivery, log *model.BotEventLog) {
+	if log.Result != resultDropped {
+		delivery.Attempts++
+	}
+	delivery.LastError = describeFailure(log)
+	delivery.LeaseUntil = optional.Of[time.Time]{}
+	if delivery.Attempts >= MaxDeliveryAttempts {
+		delivery.Status = model.BotEventDeliveryDead
+		d.l.Info("bot event was dead-lettered", zap.Stringer("botID", b.ID), zap.Stringer("deliveryID", delivery.ID), zap.Stringer("event", delivery.Event))
+	} else {
+		delivery.Status = model.BotEventDeliveryPending
+		delivery.NextAttemptAt = time.Now().Add(RetryInterval(delivery.Attempts))
+	}
+	if err := d.repo.SaveBotEventDelivery(delivery); err != nil {
+		d.l.Warn("failed to save delivery", zap.Error(err), zap.Stringer("deliveryID", delivery.ID))
+	}
+}
+
+// release 再送処理権を手放し、再送待ちに戻します
+func (d *dispatcherImpl) release(delivery *model.BotEventDelivery) {
+	delivery.Status = model.BotEventDeliveryPending
+	delivery.LeaseUntil = optional.Of[time.Time]{}
+	if err := d.repo.SaveBotEventDelivery(delivery); err != nil {
+		d.l.Warn("failed to save delivery", zap.Error(err), zap.Stringer("deliveryID", delivery.ID))
+	}
+}
+
+// recordResult HTTPモードのBotの配送結果を記録し、失敗率が閾値を超えたBotを一時停止します
+func (d *dispatcherImpl) recordResult(b *model.Bot, ok bool) {
+	if !d.failures.record(b.ID, ok) {
+		return
+	}
+	if err := d.repo.ChangeBotState(b.ID, model.BotPaused); err != nil {
+		d.l.Error("failed to pause bot", zap.Error(err), zap.Stringer("botID", b.ID))
+		return
+	}
+	d.l.Warn("bot was paused because of too many delivery failures", zap.Stringer("botID", b.ID))
+	if err := audit.RecordSystemAction(d.repo, model.AuditActionBotStateChanged, b.ID.String(), model.AuditLogDetail{"operation": "pause", "reason": "delivery_failures"}); err != nil {
+		d.l.Error("failed to record audit log", zap.Error(err), zap.Stringer("botID", b.ID))
+	}
 }
 
 func (d *dispatcherImpl) writeLog(log *model.BotEventLog) {
@@ -61,3 +210,83 @@ func (d *dispatcherImpl) writeLog(log *model.BotEventLog) {
 		d.l.Warn("failed to write log", zap.Error(err), zap.Any("eventLog", log))
 	}
 }
+
+// RetryInterval attempts回目の失敗後の再送間隔を返します
+func RetryInterval(attempts int) time.Duration {
+	interval := retryBaseInterval
+	for i := 1; i < attempts; i++ {
+		interval *= 2
+		if interval >= retryMaxInterval {
+			return retryMaxInterval
+		}
+	}
+	return interval
+}
+
+// retryable 失敗した配送を再送すべきかどうか
+//
+// ネットワークエラー、WSの未接続、5xx、429の場合のみ再送します
+func retryable(log *model.BotEventLog) bool {
+	switch log.Result {
+	case resultNetworkError, resultDropped:
+		return true
+	case resultNG:
+		return log.Code >= 500 || log.Code == http.StatusTooManyRequests
+	default:
+		return false
+	}
+}
+
+func describeFailure(log *model.BotEventLog) string {
+	switch log.Result {
+	case resultNG:
+		return "unexpected status code: " + strconv.Itoa(log.Code)
+	case resultDropped:
+		return "bot is not connected"
+	default:
+		return log.Error
+	}
+}
+
+// failureWindow Botごとの直近の配送結果
+type failureWindow struct {
+	size      int
+	threshold float64
+	mu        sync.Mutex
+	results   map[uuid.UUID][]bool
+}
+
+func newFailureWindow(size int, threshold float64) *failureWindow {
+	return &failureWindow{
+		size:      size,
+		threshold: threshold,
+		results:   map[uuid.UUID][]bool{},
+	}
+}
+
+// record 配送結果を記録します。直近の失敗率が閾値以上になった場合、記録をリセットしてtrueを返します
+func (w *failureWindow) record(botID uuid.UUID, ok bool) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	results := append(w.results[botID], ok)
+	if len(results) > w.size {
+		results = results[len(results)-w.size:]
+	}
+	w.results[botID] = results
+	if len(results) < w.size {
+		return false
+	}
+
+	failed := 0
+	for _, ok := range results {
+		if !ok {
+			failed++
+		}
+	}
+	if float64(failed)/float64(len(results)) < w.threshold {
+		return false
+	}
+	delete(w.results, botID)
+	return true
+}
@@ -0,0 +1,270 @@
+package event
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+)
+
+type dispatcherRepo struct {
+	*mock_repository.MockBotRepository
+	*mock_repository.MockBotEventDeliveryRepository
+	*mock_repository.MockAuditLogRepository
+}
+
+func newTestDispatcher(t *testing.T, ctrl *gomock.Controller) (*dispatcherImpl, *dispatcherRepo) {
+	t.Helper()
+	repo := &dispatcherRepo{
+		MockBotRepository:              mock_repository.NewMockBotRepository(ctrl),
+		MockBotEventDeliveryRepository: mock_repository.NewMockBotEventDeliveryRepository(ctrl),
+		MockAuditLogRepository:         mock_repository.NewMockAuditLogRepository(ctrl),
+	}
+	repo.MockBotRepository.EXPECT().WriteBotEventLog(gomock.Any()).Return(nil).AnyTimes()
+	return NewDispatcher(zap.NewNop(), repo, nil).(*dispatcherImpl), repo
+}
+
+func newTestBotServer(t *testing.T, status int) *httptest.Server {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestDispatcherImpl_Send(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		d, _ := newTestDispatcher(t, ctrl)
+		s := newTestBotServer(t, http.StatusNoContent)
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: s.URL}
+
+		assert.True(t, d.Send(b, Ping, []byte("{}")))
+	})
+
+	t.Run("success (200)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		d, _ := newTestDispatcher(t, ctrl)
+		s := newTestBotServer(t, http.StatusOK)
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: s.URL}
+
+		assert.True(t, d.Send(b, Ping, []byte("{}")))
+	})
+
+	t.Run("rejected by bot", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		d, _ := newTestDispatcher(t, ctrl)
+		s := newTestBotServer(t, http.StatusBadRequest)
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: s.URL}
+
+		// 4xxは再送しないため、アウトボックスに保存されない
+		assert.False(t, d.Send(b, Ping, []byte("{}")))
+	})
+
+	t.Run("failure (429)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		d, repo := newTestDispatcher(t, ctrl)
+		s := newTestBotServer(t, http.StatusTooManyRequests)
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: s.URL}
+
+		repo.MockBotEventDeliveryRepository.EXPECT().SaveBotEventDelivery(gomock.Any()).Return(nil).Times(1)
+
+		assert.False(t, d.Send(b, Ping, []byte("{}")))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		d, repo := newTestDispatcher(t, ctrl)
+		s := newTestBotServer(t, http.StatusInternalServerError)
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: s.URL}
+
+		repo.MockBotEventDeliveryRepository.EXPECT().
+			SaveBotEventDelivery(gomock.Any()).
+			DoAndReturn(func(delivery *model.BotEventDelivery) error {
+				assert.Equal(t, b.ID, delivery.BotID)
+				assert.Equal(t, Ping, delivery.Event)
+				assert.Equal(t, "{}", delivery.Body)
+				assert.Equal(t, model.BotEventDeliveryPending, delivery.Status)
+				assert.Equal(t, 1, delivery.Attempts)
+				assert.True(t, delivery.NextAttemptAt.After(time.Now()))
+				return nil
+			}).
+			Times(1)
+
+		assert.False(t, d.Send(b, Ping, []byte("{}")))
+	})
+}
+
+func TestDispatcherImpl_Redeliver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		d, repo := newTestDispatcher(t, ctrl)
+		s := newTestBotServer(t, http.StatusNoContent)
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: s.URL}
+		delivery := &model.BotEventDelivery{ID: uuid.Must(uuid.NewV4()), BotID: b.ID, Event: Ping, Body: "{}", Status: model.BotEventDeliveryPending, Attempts: 3}
+
+		repo.MockBotEventDeliveryRepository.EXPECT().ClaimBotEventDelivery(delivery.ID, gomock.Any()).Return(true, nil).Times(1)
+		repo.MockBotEventDeliveryRepository.EXPECT().DeleteBotEventDelivery(delivery.ID).Return(nil).Times(1)
+
+		assert.True(t, d.Redeliver(b, delivery))
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		d, repo := newTestDispatcher(t, ctrl)
+		s := newTestBotServer(t, http.StatusBadGateway)
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: s.URL}
+		delivery := &model.BotEventDelivery{ID: uuid.Must(uuid.NewV4()), BotID: b.ID, Event: Ping, Body: "{}", Status: model.BotEventDeliveryPending, Attempts: 3}
+
+		repo.MockBotEventDeliveryRepository.EXPECT().ClaimBotEventDelivery(delivery.ID, gomock.Any()).Return(true, nil).Times(1)
+		repo.MockBotEventDeliveryRepository.EXPECT().SaveBotEventDelivery(delivery).Return(nil).Times(1)
+
+		assert.False(t, d.Redeliver(b, delivery))
+		assert.Equal(t, 4, delivery.Attempts)
+		assert.Equal(t, model.BotEventDeliveryPending, delivery.Status)
+		assert.Equal(t, "unexpected status code: 502", delivery.LastError)
+		assert.False(t, delivery.LeaseUntil.Valid)
+	})
+
+	t.Run("rejected by bot", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		d, repo := newTestDispatcher(t, ctrl)
+		s := newTestBotServer(t, http.StatusNotFound)
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: s.URL}
+		delivery := &model.BotEventDelivery{ID: uuid.Must(uuid.NewV4()), BotID: b.ID, Event: Ping, Body: "{}", Status: model.BotEventDeliveryPending, Attempts: 3}
+
+		repo.MockBotEventDeliveryRepository.EXPECT().ClaimBotEventDelivery(delivery.ID, gomock.Any()).Return(true, nil).Times(1)
+		repo.MockBotEventDeliveryRepository.EXPECT().DeleteBotEventDelivery(delivery.ID).Return(nil).Times(1)
+
+		assert.False(t, d.Redeliver(b, delivery))
+	})
+
+	t.Run("dead letter", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		d, repo := newTestDispatcher(t, ctrl)
+		s := newTestBotServer(t, http.StatusBadGateway)
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: s.URL}
+		delivery := &model.BotEventDelivery{ID: uuid.Must(uuid.NewV4()), BotID: b.ID, Event: Ping, Body: "{}", Status: model.BotEventDeliveryPending, Attempts: MaxDeliveryAttempts - 1}
+
+		repo.MockBotEventDeliveryRepository.EXPECT().ClaimBotEventDelivery(delivery.ID, gomock.Any()).Return(true, nil).Times(1)
+		repo.MockBotEventDeliveryRepository.EXPECT().SaveBotEventDelivery(delivery).Return(nil).Times(1)
+
+		assert.False(t, d.Redeliver(b, delivery))
+		assert.Equal(t, model.BotEventDeliveryDead, delivery.Status)
+	})
+
+	t.Run("claimed by another instance", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		d, repo := newTestDispatcher(t, ctrl)
+		var called bool
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		t.Cleanup(s.Close)
+		b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: s.URL}
+		delivery := &model.BotEventDelivery{ID: uuid.Must(uuid.NewV4()), BotID: b.ID, Event: Ping, Body: "{}", Status: model.BotEventDeliveryPending, Attempts: 3}
+
+		repo.MockBotEventDeliveryRepository.EXPECT().ClaimBotEventDelivery(delivery.ID, gomock.Any()).Return(false, nil).Times(1)
+
+		assert.False(t, d.Redeliver(b, delivery))
+		assert.False(t, called)
+	})
+}
+
+func TestDispatcherImpl_AutoPause(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	d, repo := newTestDispatcher(t, ctrl)
+	s := newTestBotServer(t, http.StatusInternalServerError)
+	b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: s.URL}
+
+	repo.MockBotEventDeliveryRepository.EXPECT().SaveBotEventDelivery(gomock.Any()).Return(nil).Times(failureWindowSize)
+	repo.MockBotRepository.EXPECT().ChangeBotState(b.ID, model.BotPaused).Return(nil).Times(1)
+	repo.MockAuditLogRepository.EXPECT().CreateAuditLog(gomock.Any()).
+		Do(func(log *model.AuditLog) {
+			assert.Equal(t, model.AuditActionBotStateChanged, log.Action)
+			assert.Equal(t, b.ID.String(), log.Target)
+			assert.False(t, log.ActorID.Valid)
+		}).
+		Return(nil).
+		Times(1)
+
+	for i := 0; i < failureWindowSize; i++ {
+		d.Send(b, Ping, []byte("{}"))
+	}
+}
+
+func TestDispatcherImpl_AutoPause_ClientError(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	d, _ := newTestDispatcher(t, ctrl)
+	s := newTestBotServer(t, http.StatusBadRequest)
+	b := &model.Bot{ID: uuid.Must(uuid.NewV4()), Mode: model.BotModeHTTP, PostURL: s.URL}
+
+	// 4xxは一時停止の判定に数えないため、ChangeBotStateは呼ばれない
+	for i := 0; i < failureWindowSize; i++ {
+		d.Send(b, Ping, []byte("{}"))
+	}
+}
+
+func TestRetryInterval(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 30*time.Second, RetryInterval(1))
+	assert.Equal(t, time.Minute, RetryInterval(2))
+	assert.Equal(t, 2*time.Minute, RetryInterval(3))
+	assert.Equal(t, 32*time.Minute, RetryInterval(7))
+	assert.Equal(t, time.Hour, RetryInterval(8))
+	assert.Equal(t, time.Hour, RetryInterval(100))
+}
+
+func TestFailureWindow_Record(t *testing.T) {
+	t.Parallel()
+
+	w := newFailureWindow(5, 0.8)
+	id := uuid.Must(uuid.NewV4())
+
+	// 結果が揃うまでは判定しない
+	for i := 0; i < 4; i++ {
+		assert.False(t, w.record(id, false))
+	}
+	// 4/5 = 0.8
+	assert.True(t, w.record(id, true))
+
+	// リセットされている
+	for i := 0; i < 3; i++ {
+		assert.False(t, w.record(id, false))
+	}
+	assert.False(t, w.record(id, true))
+	assert.False(t, w.record(id, true))
+	// 古い結果は押し出されるため、直近5件の失敗は3件のまま
+	assert.False(t, w.record(id, false))
+	assert.False(t, w.record(id, false))
+	assert.False(t, w.record(id, false))
+	// 直近5件のうち失敗は4件
+	assert.True(t, w.record(id, false))
+}
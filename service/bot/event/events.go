@@ -15,6 +15,12 @@ const (
 	MessageDeleted model.BotEventType = "MESSAGE_DELETED"
 	// MessageUpdated メッセージ編集イベント
 	MessageUpdated model.BotEventType = "MESSAGE_UPDATED"
+	// MessagePinned メッセージピン留めイベント
+	MessagePinned model.BotEventType = "MESSAGE_PINNED"
+	// MessageUnpinned メッセージピン留め解除イベント
+	MessageUnpinned model.BotEventType = "MESSAGE_UNPINNED"
+	// MessageCited BOTメッセージ引用イベント
+	MessageCited model.BotEventType = "MESSAGE_CITED"
 	// BotMessageStampsUpdated BOTメッセージスタンプ更新イベント
 	BotMessageStampsUpdated model.BotEventType = "BOT_MESSAGE_STAMPS_UPDATED"
 	// MentionMessageCreated メンションメッセージ作成イベント
@@ -29,10 +35,24 @@ const (
 	ChannelCreated model.BotEventType = "CHANNEL_CREATED"
 	// ChannelTopicChanged チャンネルトピック変更イベント
 	ChannelTopicChanged model.BotEventType = "CHANNEL_TOPIC_CHANGED"
+	// ChannelUpdated チャンネル更新イベント
+	ChannelUpdated model.BotEventType = "CHANNEL_UPDATED"
 	// UserCreated ユーザー作成イベント
 	UserCreated model.BotEventType = "USER_CREATED"
+	// UserUpdated ユーザー更新イベント
+	UserUpdated model.BotEventType = "USER_UPDATED"
+	// UserIconUpdated ユーザーアイコン更新イベント
+	UserIconUpdated model.BotEventType = "USER_ICON_UPDATED"
+	// UserOnline ユーザーオンラインイベント
+	UserOnline model.BotEventType = "USER_ONLINE"
+	// UserOffline ユーザーオフラインイベント
+	UserOffline model.BotEventType = "USER_OFFLINE"
 	// StampCreated スタンプ作成イベント
 	StampCreated model.BotEventType = "STAMP_CREATED"
+	// StampUpdated スタンプ更新イベント
+	StampUpdated model.BotEventType = "STAMP_UPDATED"
+	// StampDeleted スタンプ削除イベント
+	StampDeleted model.BotEventType = "STAMP_DELETED"
 	// TagAdded タグ追加イベント
 	TagAdded model.BotEventType = "TAG_ADDED"
 	// TagRemoved タグ削除イベント
@@ -53,6 +73,12 @@ const (
 	UserGroupAdminAdded model.BotEventType = "USER_GROUP_ADMIN_ADDED"
 	// UserGroupAdminRemoved グループ管理者削除イベント
 	UserGroupAdminRemoved model.BotEventType = "USER_GROUP_ADMIN_REMOVED"
+	// CommandInvoked コマンド実行イベント
+	CommandInvoked model.BotEventType = "COMMAND_INVOKED"
+	// ButtonClicked ボタン押下イベント
+	ButtonClicked model.BotEventType = "BUTTON_CLICKED"
+	// SelectChanged セレクトメニュー選択イベント
+	SelectChanged model.BotEventType = "SELECT_CHANGED"
 )
 
 var Types model.BotEventTypes
@@ -67,6 +93,9 @@ func init() {
 		MessageCreated,
 		MessageDeleted,
 		MessageUpdated,
+		MessagePinned,
+		MessageUnpinned,
+		MessageCited,
 		BotMessageStampsUpdated,
 		MentionMessageCreated,
 		DirectMessageCreated,
@@ -74,8 +103,15 @@ func init() {
 		DirectMessageDeleted,
 		ChannelCreated,
 		ChannelTopicChanged,
+		ChannelUpdated,
 		UserCreated,
+		UserUpdated,
+		UserIconUpdated,
+		UserOnline,
+		UserOffline,
 		StampCreated,
+		StampUpdated,
+		StampDeleted,
 		TagAdded,
 		TagRemoved,
 		UserGroupCreated,
@@ -86,6 +122,9 @@ func init() {
 		UserGroupMemberRemoved,
 		UserGroupAdminAdded,
 		UserGroupAdminRemoved,
+		CommandInvoked,
+		ButtonClicked,
+		SelectChanged,
 	} {
 		Types[t] = struct{}{}
 	}
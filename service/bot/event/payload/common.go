@@ -7,6 +7,7 @@ import (
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/utils/message"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // Base 全イベントに埋め込まれるペイロード
@@ -29,6 +30,7 @@ type Message struct {
 	Embedded  []*message.EmbeddedInfo `json:"embedded"`
 	CreatedAt time.Time               `json:"createdAt"`
 	UpdatedAt time.Time               `json:"updatedAt"`
+	ThreadID  optional.Of[uuid.UUID]  `json:"threadId"`
 }
 
 func MakeMessage(message *model.Message, user model.UserInfo, embedded []*message.EmbeddedInfo, plain string) Message {
@@ -41,6 +43,7 @@ func MakeMessage(message *model.Message, user model.UserInfo, embedded []*messag
 		Embedded:  embedded,
 		CreatedAt: message.CreatedAt,
 		UpdatedAt: message.UpdatedAt,
+		ThreadID:  message.ParentMessageID,
 	}
 }
 
@@ -0,0 +1,28 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// ButtonClicked BUTTON_CLICKEDイベントペイロード
+type ButtonClicked struct {
+	Base
+	MessageID uuid.UUID `json:"messageId"`
+	ChannelID uuid.UUID `json:"channelId"`
+	CustomID  string    `json:"customId"`
+	User      User      `json:"user"`
+}
+
+func MakeButtonClicked(et time.Time, messageID, channelID uuid.UUID, customID string, user model.UserInfo) *ButtonClicked {
+	return &ButtonClicked{
+		Base:      MakeBase(et),
+		MessageID: messageID,
+		ChannelID: channelID,
+		CustomID:  customID,
+		User:      MakeUser(user),
+	}
+}
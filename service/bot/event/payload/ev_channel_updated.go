@@ -0,0 +1,22 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// ChannelUpdated CHANNEL_UPDATEDイベントペイロード
+type ChannelUpdated struct {
+	Base
+	Channel  Channel `json:"channel"`
+	Archived bool    `json:"archived"`
+}
+
+func MakeChannelUpdated(eventTime time.Time, ch *model.Channel, chPath string, user model.UserInfo) *ChannelUpdated {
+	return &ChannelUpdated{
+		Base:     MakeBase(eventTime),
+		Channel:  MakeChannel(ch, chPath, user),
+		Archived: ch.IsArchived(),
+	}
+}
@@ -0,0 +1,32 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// CommandInvoked COMMAND_INVOKEDイベントペイロード
+type CommandInvoked struct {
+	Base
+	InvocationID uuid.UUID              `json:"invocationId"`
+	Command      string                 `json:"command"`
+	Text         string                 `json:"text"`
+	Args         map[string]interface{} `json:"args"`
+	User         User                   `json:"user"`
+	ChannelID    uuid.UUID              `json:"channelId"`
+}
+
+func MakeCommandInvoked(et time.Time, inv *model.BotCommandInvocation, cmd *model.BotCommand, args map[string]interface{}, user model.UserInfo) *CommandInvoked {
+	return &CommandInvoked{
+		Base:         MakeBase(et),
+		InvocationID: inv.ID,
+		Command:      cmd.Name,
+		Text:         inv.Text,
+		Args:         args,
+		User:         MakeUser(user),
+		ChannelID:    inv.ChannelID,
+	}
+}
@@ -0,0 +1,26 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+// MessageCited MESSAGE_CITEDイベントペイロード
+type MessageCited struct {
+	Base
+	Message        Message   `json:"message"`
+	CitedMessageID uuid.UUID `json:"citedMessageId"`
+}
+
+func MakeMessageCited(et time.Time, m *model.Message, user model.UserInfo, parsed *message.ParseResult, citedID uuid.UUID) *MessageCited {
+	embedded, _ := message.ExtractEmbedding(m.Text)
+	return &MessageCited{
+		Base:           MakeBase(et),
+		Message:        MakeMessage(m, user, embedded, parsed.PlainText),
+		CitedMessageID: citedID,
+	}
+}
@@ -0,0 +1,22 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+// MessagePinned MESSAGE_PINNEDイベントペイロード
+type MessagePinned struct {
+	Base
+	Message Message `json:"message"`
+}
+
+func MakeMessagePinned(et time.Time, m *model.Message, user model.UserInfo, parsed *message.ParseResult) *MessagePinned {
+	embedded, _ := message.ExtractEmbedding(m.Text)
+	return &MessagePinned{
+		Base:    MakeBase(et),
+		Message: MakeMessage(m, user, embedded, parsed.PlainText),
+	}
+}
@@ -0,0 +1,22 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+// MessageUnpinned MESSAGE_UNPINNEDイベントペイロード
+type MessageUnpinned struct {
+	Base
+	Message Message `json:"message"`
+}
+
+func MakeMessageUnpinned(et time.Time, m *model.Message, user model.UserInfo, parsed *message.ParseResult) *MessageUnpinned {
+	embedded, _ := message.ExtractEmbedding(m.Text)
+	return &MessageUnpinned{
+		Base:    MakeBase(et),
+		Message: MakeMessage(m, user, embedded, parsed.PlainText),
+	}
+}
@@ -0,0 +1,30 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// SelectChanged SELECT_CHANGEDイベントペイロード
+type SelectChanged struct {
+	Base
+	MessageID uuid.UUID `json:"messageId"`
+	ChannelID uuid.UUID `json:"channelId"`
+	CustomID  string    `json:"customId"`
+	Values    []string  `json:"values"`
+	User      User      `json:"user"`
+}
+
+func MakeSelectChanged(et time.Time, messageID, channelID uuid.UUID, customID string, values []string, user model.UserInfo) *SelectChanged {
+	return &SelectChanged{
+		Base:      MakeBase(et),
+		MessageID: messageID,
+		ChannelID: channelID,
+		CustomID:  customID,
+		Values:    values,
+		User:      MakeUser(user),
+	}
+}
@@ -0,0 +1,20 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// StampDeleted STAMP_DELETEDイベントペイロード
+type StampDeleted struct {
+	Base
+	ID uuid.UUID `json:"id"`
+}
+
+func MakeStampDeleted(et time.Time, id uuid.UUID) *StampDeleted {
+	return &StampDeleted{
+		Base: MakeBase(et),
+		ID:   id,
+	}
+}
@@ -0,0 +1,20 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// UserIconUpdated USER_ICON_UPDATEDイベントペイロード
+type UserIconUpdated struct {
+	Base
+	User User `json:"user"`
+}
+
+func MakeUserIconUpdated(et time.Time, user model.UserInfo) *UserIconUpdated {
+	return &UserIconUpdated{
+		Base: MakeBase(et),
+		User: MakeUser(user),
+	}
+}
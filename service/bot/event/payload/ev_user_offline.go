@@ -0,0 +1,20 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// UserOffline USER_OFFLINEイベントペイロード
+type UserOffline struct {
+	Base
+	User User `json:"user"`
+}
+
+func MakeUserOffline(et time.Time, user model.UserInfo) *UserOffline {
+	return &UserOffline{
+		Base: MakeBase(et),
+		User: MakeUser(user),
+	}
+}
@@ -0,0 +1,20 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// UserOnline USER_ONLINEイベントペイロード
+type UserOnline struct {
+	Base
+	User User `json:"user"`
+}
+
+func MakeUserOnline(et time.Time, user model.UserInfo) *UserOnline {
+	return &UserOnline{
+		Base: MakeBase(et),
+		User: MakeUser(user),
+	}
+}
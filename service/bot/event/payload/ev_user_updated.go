@@ -0,0 +1,20 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// UserUpdated USER_UPDATEDイベントペイロード
+type UserUpdated struct {
+	Base
+	User User `json:"user"`
+}
+
+func MakeUserUpdated(et time.Time, user model.UserInfo) *UserUpdated {
+	return &UserUpdated{
+		Base: MakeBase(et),
+		User: MakeUser(user),
+	}
+}
@@ -0,0 +1,62 @@
+package event
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/traPtitech/traQ/utils/hmac"
+)
+
+const (
+	// signaturePrefix X-TRAQ-BOT-SIGNATUREヘッダーの値の接頭辞
+	signaturePrefix = "sha256="
+	// SignatureTolerance 署名の検証時に許容するタイムスタンプのずれ
+	SignatureTolerance = 5 * time.Minute
+)
+
+var (
+	// ErrInvalidSignature 署名が不正です
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrInvalidTimestamp タイムスタンプが不正、或いは許容範囲外です
+	ErrInvalidTimestamp = errors.New("invalid timestamp")
+)
+
+// Sign Botイベントのリクエストの署名を計算します
+//
+// タイムスタンプ(UNIX秒)と"."とリクエストボディを連結した文字列のHMAC-SHA256を16進数で返します。
+// X-TRAQ-BOT-SIGNATUREヘッダーには"sha256="に続けてこの値が設定されます
+func Sign(secret string, timestamp int64, body []byte) string {
+	msg := make([]byte, 0, 20+len(body))
+	msg = strconv.AppendInt(msg, timestamp, 10)
+	msg = append(msg, '.')
+	msg = append(msg, body...)
+	return hex.EncodeToString(hmac.SHA256(msg, secret))
+}
+
+// Verify Botイベントのリクエストの署名を検証します
+//
+// signature, timestampにはそれぞれX-TRAQ-BOT-SIGNATURE, X-TRAQ-BOT-TIMESTAMPヘッダーの値を渡します。
+// タイムスタンプがnowからSignatureTolerance以上ずれている場合はErrInvalidTimestampを返します
+func Verify(secret, signature, timestamp string, body []byte, now time.Time) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	if d := now.Sub(time.Unix(ts, 0)); d >= SignatureTolerance || d <= -SignatureTolerance {
+		return ErrInvalidTimestamp
+	}
+
+	sig, ok := strings.CutPrefix(signature, signaturePrefix)
+	if !ok {
+		return ErrInvalidSignature
+	}
+	expected := Sign(secret, ts, body)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(sig)) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package event
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	body := []byte("{\"eventTime\":\"2023-11-14T22:13:20Z\"}\n")
+	assert.Equal(t, "c937cc13d0cc67edf7c609bdd787b0a639103152484fd995ca03f96d0f4d396a", Sign("secret", 1700000000, body))
+	assert.NotEqual(t, Sign("secret", 1700000000, body), Sign("secret", 1700000001, body))
+	assert.NotEqual(t, Sign("secret", 1700000000, body), Sign("secret2", 1700000000, body))
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	const secret = "secret"
+	body := []byte(`{"eventTime":"2023-11-14T22:13:20Z"}`)
+	now := time.Unix(1700000000, 0)
+	ts := strconv.FormatInt(now.Unix(), 10)
+	sig := signaturePrefix + Sign(secret, now.Unix(), body)
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, Verify(secret, sig, ts, body, now))
+		assert.NoError(t, Verify(secret, sig, ts, body, now.Add(SignatureTolerance-time.Second)))
+		assert.NoError(t, Verify(secret, sig, ts, body, now.Add(-SignatureTolerance+time.Second)))
+	})
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		t.Parallel()
+		assert.ErrorIs(t, Verify(secret, sig, "", body, now), ErrInvalidTimestamp)
+		assert.ErrorIs(t, Verify(secret, sig, "abc", body, now), ErrInvalidTimestamp)
+		assert.ErrorIs(t, Verify(secret, sig, ts, body, now.Add(SignatureTolerance)), ErrInvalidTimestamp)
+		assert.ErrorIs(t, Verify(secret, sig, ts, body, now.Add(-SignatureTolerance)), ErrInvalidTimestamp)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		t.Parallel()
+		assert.ErrorIs(t, Verify(secret, "", ts, body, now), ErrInvalidSignature)
+		assert.ErrorIs(t, Verify(secret, Sign(secret, now.Unix(), body), ts, body, now), ErrInvalidSignature)
+		assert.ErrorIs(t, Verify("wrong", sig, ts, body, now), ErrInvalidSignature)
+		assert.ErrorIs(t, Verify(secret, sig, ts, []byte(`{"eventTime":"2023-11-14T22:13:21Z"}`), now), ErrInvalidSignature)
+	})
+
+	t.Run("replayed with another timestamp", func(t *testing.T) {
+		t.Parallel()
+		later := now.Add(time.Minute)
+		assert.ErrorIs(t, Verify(secret, sig, strconv.FormatInt(later.Unix(), 10), body, later), ErrInvalidSignature)
+	})
+}
+
+func TestHTTPDispatcher_Signature(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:                uuid.Must(uuid.NewV4()),
+		Mode:              model.BotModeHTTP,
+		VerificationToken: "token",
+		SigningSecret:     "secret",
+	}
+	body := []byte(`{"eventTime":"2023-11-14T22:13:20Z"}`)
+
+	var verifyErr error
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, _ := io.ReadAll(r.Body)
+		verifyErr = Verify(b.SigningSecret, r.Header.Get(headerTRAQBotSignature), r.Header.Get(headerTRAQBotTimestamp), buf, time.Now())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+	b.PostURL = s.URL
+
+	reqID := uuid.Must(uuid.NewV4())
+	ok, _ := newHTTPDispatcher(zap.NewNop()).send(b, Ping, reqID, reqID, body)
+	assert.True(t, ok)
+	assert.NoError(t, verifyErr)
+}
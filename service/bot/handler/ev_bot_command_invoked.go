@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func BotCommandInvoked(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	inv := fields["invocation"].(*model.BotCommandInvocation)
+	cmd := fields["command"].(*model.BotCommand)
+	args := fields["args"].(map[string]interface{})
+
+	bot, err := ctx.GetBot(inv.BotID)
+	if err != nil {
+		return fmt.Errorf("failed to GetBot: %w", err)
+	}
+	if bot == nil {
+		return nil
+	}
+
+	user, err := ctx.R().GetUser(inv.UserID, false)
+	if err != nil {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	// コマンドを登録したBOTにのみ送信
+	if err := ctx.Unicast(
+		event.CommandInvoked,
+		payload.MakeCommandInvoked(datetime, inv, cmd, args, user),
+		bot,
+	); err != nil {
+		return fmt.Errorf("failed to unicast: %w", err)
+	}
+	return nil
+}
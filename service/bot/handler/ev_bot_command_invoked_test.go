@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func TestBotCommandInvoked(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypes{},
+		State:           model.BotActive,
+	}
+	u := &model.User{
+		ID:   uuid.NewV3(uuid.Nil, "u"),
+		Name: "testman",
+	}
+	cmd := &model.BotCommand{
+		ID:    uuid.NewV3(uuid.Nil, "cmd"),
+		BotID: b.ID,
+		Name:  "echo",
+		Args:  model.BotCommandArgs{{Name: "text", Type: model.BotCommandArgTypeString, Required: true}},
+	}
+	args := map[string]interface{}{"text": "po"}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+		registerUser(repo, u)
+
+		inv := &model.BotCommandInvocation{
+			ID:        uuid.NewV3(uuid.Nil, "inv"),
+			CommandID: cmd.ID,
+			BotID:     b.ID,
+			UserID:    u.ID,
+			ChannelID: uuid.NewV3(uuid.Nil, "c"),
+			Text:      "/echo po",
+		}
+		et := time.Now()
+
+		expectUnicast(handlerCtx, event.CommandInvoked, payload.MakeCommandInvoked(et, inv, cmd, args, u), b)
+		assert.NoError(t, BotCommandInvoked(handlerCtx, et, intevent.BotCommandInvoked, hub.Fields{
+			"invocation": inv,
+			"command":    cmd,
+			"args":       args,
+		}))
+	})
+
+	t.Run("inactive bot", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+		botID := uuid.NewV3(uuid.Nil, "inactive")
+		handlerCtx.EXPECT().GetBot(botID).Return(nil, nil).Times(1)
+
+		inv := &model.BotCommandInvocation{
+			ID:     uuid.NewV3(uuid.Nil, "inv"),
+			BotID:  botID,
+			UserID: u.ID,
+		}
+		assert.NoError(t, BotCommandInvoked(handlerCtx, time.Now(), intevent.BotCommandInvoked, hub.Fields{
+			"invocation": inv,
+			"command":    cmd,
+			"args":       args,
+		}))
+	})
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+func BotEventDeliveriesRequeued(ctx Context, _ time.Time, _ string, fields hub.Fields) error {
+	botID := fields["bot_id"].(uuid.UUID)
+
+	bot, err := ctx.GetBot(botID)
+	if err != nil {
+		return fmt.Errorf("failed to GetBot: %w", err)
+	}
+	// HTTPモードのBotは定期的な再送処理に任せる
+	if bot == nil || bot.Mode != model.BotModeWebSocket {
+		return nil
+	}
+	return replayDeliveries(ctx, bot)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event/mock_event"
+)
+
+func TestBotEventDeliveriesRequeued(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ws bot", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		d := mock_event.NewMockDispatcher(ctrl)
+		handlerCtx.EXPECT().D().Return(d).AnyTimes()
+
+		b := &model.Bot{
+			ID:        uuid.NewV3(uuid.Nil, "b"),
+			BotUserID: uuid.NewV3(uuid.Nil, "bu"),
+			State:     model.BotActive,
+			Mode:      model.BotModeWebSocket,
+		}
+		registerBot(t, handlerCtx, b)
+		delivery := &model.BotEventDelivery{ID: uuid.NewV3(uuid.Nil, "d"), BotID: b.ID, Status: model.BotEventDeliveryPending}
+
+		repo.MockBotEventDeliveryRepository.EXPECT().
+			GetBotEventDeliveries(b.ID, model.BotEventDeliveryPending, gomock.Any(), 0).
+			Return([]*model.BotEventDelivery{delivery}, nil).
+			Times(1)
+		d.EXPECT().Redeliver(b, delivery).Return(true).Times(1)
+
+		assert.NoError(t, BotEventDeliveriesRequeued(handlerCtx, time.Now(), intevent.BotEventDeliveriesRequeued, hub.Fields{
+			"bot_id": b.ID,
+			"count":  1,
+		}))
+	})
+
+	t.Run("http bot", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+
+		b := &model.Bot{
+			ID:        uuid.NewV3(uuid.Nil, "b2"),
+			BotUserID: uuid.NewV3(uuid.Nil, "bu2"),
+			State:     model.BotActive,
+			Mode:      model.BotModeHTTP,
+		}
+		registerBot(t, handlerCtx, b)
+
+		assert.NoError(t, BotEventDeliveriesRequeued(handlerCtx, time.Now(), intevent.BotEventDeliveriesRequeued, hub.Fields{
+			"bot_id": b.ID,
+			"count":  1,
+		}))
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/leandro-lugaresi/hub"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/audit"
 	"github.com/traPtitech/traQ/service/bot/event"
 	"github.com/traPtitech/traQ/service/bot/event/payload"
 )
@@ -30,6 +31,11 @@ func BotPingRequest(ctx Context, datetime time.Time, _ string, fields hub.Fields
 		if err := ctx.R().ChangeBotState(bot.ID, model.BotPaused); err != nil {
 			return fmt.Errorf("failed to ChangeBotState: %w", err)
 		}
+		if bot.State != model.BotPaused {
+			if err := audit.RecordSystemAction(ctx.R(), model.AuditActionBotStateChanged, bot.ID.String(), model.AuditLogDetail{"operation": "pause", "reason": "ping_failed"}); err != nil {
+				return fmt.Errorf("failed to RecordSystemAction: %w", err)
+			}
+		}
 	}
 	return nil
 }
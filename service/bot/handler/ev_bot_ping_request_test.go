@@ -61,6 +61,7 @@ func TestBotPingRequest(t *testing.T) {
 		require.NoError(t, err)
 
 		repo.MockBotRepository.EXPECT().ChangeBotState(b.ID, model.BotPaused).Return(nil).Times(1)
+		repo.MockAuditLogRepository.EXPECT().CreateAuditLog(gomock.Any()).Return(nil).Times(1)
 		d.EXPECT().Send(b, event.Ping, buf).Return(false).Times(1)
 
 		assert.NoError(t, BotPingRequest(handlerCtx, et, intevent.BotPingRequest, hub.Fields{
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// replayDeliveriesLimit 一度に再送するイベントの最大数
+const replayDeliveriesLimit = 1000
+
+func BotWSConnected(ctx Context, _ time.Time, _ string, fields hub.Fields) error {
+	userID := fields["user_id"].(uuid.UUID)
+
+	bot, err := ctx.GetBotByBotUserID(userID)
+	if err != nil {
+		return fmt.Errorf("failed to GetBotByBotUserID: %w", err)
+	}
+	if bot == nil || bot.Mode != model.BotModeWebSocket {
+		return nil
+	}
+	return replayDeliveries(ctx, bot)
+}
+
+// replayDeliveries 再送待ちのイベントを古い順に再送します
+func replayDeliveries(ctx Context, bot *model.Bot) error {
+	deliveries, err := ctx.R().GetBotEventDeliveries(bot.ID, model.BotEventDeliveryPending, replayDeliveriesLimit, 0)
+	if err != nil {
+		return fmt.Errorf("failed to GetBotEventDeliveries: %w", err)
+	}
+	for _, d := range deliveries {
+		if !ctx.D().Redeliver(bot, d) {
+			// 順序を保つため、失敗した時点で中断する
+			break
+		}
+	}
+	return nil
+}
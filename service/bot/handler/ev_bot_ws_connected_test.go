@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event/mock_event"
+)
+
+func TestBotWSConnected(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypes{},
+		State:           model.BotActive,
+		Mode:            model.BotModeWebSocket,
+	}
+	deliveries := []*model.BotEventDelivery{
+		{ID: uuid.NewV3(uuid.Nil, "d1"), BotID: b.ID, Status: model.BotEventDeliveryPending},
+		{ID: uuid.NewV3(uuid.Nil, "d2"), BotID: b.ID, Status: model.BotEventDeliveryPending},
+		{ID: uuid.NewV3(uuid.Nil, "d3"), BotID: b.ID, Status: model.BotEventDeliveryPending},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		d := mock_event.NewMockDispatcher(ctrl)
+		handlerCtx.EXPECT().D().Return(d).AnyTimes()
+		registerBot(t, handlerCtx, b)
+
+		repo.MockBotEventDeliveryRepository.EXPECT().
+			GetBotEventDeliveries(b.ID, model.BotEventDeliveryPending, gomock.Any(), 0).
+			Return(deliveries, nil).
+			Times(1)
+		gomock.InOrder(
+			d.EXPECT().Redeliver(b, deliveries[0]).Return(true).Times(1),
+			d.EXPECT().Redeliver(b, deliveries[1]).Return(true).Times(1),
+			d.EXPECT().Redeliver(b, deliveries[2]).Return(true).Times(1),
+		)
+
+		assert.NoError(t, BotWSConnected(handlerCtx, time.Now(), intevent.BotWSConnected, hub.Fields{
+			"user_id": b.BotUserID,
+		}))
+	})
+
+	t.Run("stop on failure", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		d := mock_event.NewMockDispatcher(ctrl)
+		handlerCtx.EXPECT().D().Return(d).AnyTimes()
+		registerBot(t, handlerCtx, b)
+
+		repo.MockBotEventDeliveryRepository.EXPECT().
+			GetBotEventDeliveries(b.ID, model.BotEventDeliveryPending, gomock.Any(), 0).
+			Return(deliveries, nil).
+			Times(1)
+		gomock.InOrder(
+			d.EXPECT().Redeliver(b, deliveries[0]).Return(true).Times(1),
+			d.EXPECT().Redeliver(b, deliveries[1]).Return(false).Times(1),
+		)
+
+		assert.NoError(t, BotWSConnected(handlerCtx, time.Now(), intevent.BotWSConnected, hub.Fields{
+			"user_id": b.BotUserID,
+		}))
+	})
+
+	t.Run("http bot", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+		b := &model.Bot{
+			ID:        uuid.NewV3(uuid.Nil, "b2"),
+			BotUserID: uuid.NewV3(uuid.Nil, "bu2"),
+			State:     model.BotActive,
+			Mode:      model.BotModeHTTP,
+		}
+		registerBot(t, handlerCtx, b)
+
+		assert.NoError(t, BotWSConnected(handlerCtx, time.Now(), intevent.BotWSConnected, hub.Fields{
+			"user_id": b.BotUserID,
+		}))
+	})
+
+	t.Run("not a bot", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+		userID := uuid.NewV3(uuid.Nil, "u")
+		handlerCtx.EXPECT().GetBotByBotUserID(userID).Return(nil, nil).Times(1)
+
+		assert.NoError(t, BotWSConnected(handlerCtx, time.Now(), intevent.BotWSConnected, hub.Fields{
+			"user_id": userID,
+		}))
+	})
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func ChannelUpdated(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	chID := fields["channel_id"].(uuid.UUID)
+	if fields["private"].(bool) {
+		return nil
+	}
+
+	bots, err := ctx.GetBots(event.ChannelUpdated)
+	if err != nil {
+		return fmt.Errorf("failed to GetBots: %w", err)
+	}
+	bots = filterBotsByEventFilter(bots, event.ChannelUpdated, filterTarget{channelID: chID})
+	if len(bots) == 0 {
+		return nil
+	}
+
+	ch, err := ctx.CM().GetChannel(chID)
+	if err != nil {
+		return fmt.Errorf("failed to GetChannel: %w", err)
+	}
+
+	chCreator, err := ctx.R().GetUser(ch.CreatorID, false)
+	if err != nil && err != repository.ErrNotFound {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	if err := ctx.Multicast(
+		event.ChannelUpdated,
+		payload.MakeChannelUpdated(datetime, ch, ctx.CM().PublicChannelTree().GetChannelPath(ch.ID), chCreator),
+		bots,
+	); err != nil {
+		return fmt.Errorf("failed to multicast: %w", err)
+	}
+	return nil
+}
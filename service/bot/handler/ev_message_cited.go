@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+func MessageCited(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	m := fields["message"].(*model.Message)
+	citedIDs := fields["cited_ids"].([]uuid.UUID)
+
+	var (
+		user   model.UserInfo
+		parsed *message.ParseResult
+	)
+	for _, citedID := range citedIDs {
+		cited, err := ctx.R().GetMessageByID(citedID)
+		if err != nil {
+			if err == repository.ErrNotFound {
+				continue
+			}
+			return fmt.Errorf("failed to GetMessageByID: %w", err)
+		}
+		// 引用されたメッセージを投稿したBOTにのみ送信
+		if cited.UserID == m.UserID {
+			continue
+		}
+		bot, err := ctx.GetBotByBotUserID(cited.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to GetBotByBotUserID: %w", err)
+		}
+		if bot == nil || !bot.SubscribeEvents.Contains(event.MessageCited) {
+			continue
+		}
+
+		// 引用元のメッセージを閲覧できないBOTには送信しない
+		ok, err := ctx.CM().IsChannelAccessibleToUser(bot.BotUserID, m.ChannelID)
+		if err != nil {
+			return fmt.Errorf("failed to IsChannelAccessibleToUser: %w", err)
+		}
+		if !ok {
+			continue
+		}
+
+		if user == nil {
+			user, err = ctx.R().GetUser(m.UserID, false)
+			if err != nil {
+				return fmt.Errorf("failed to GetUser: %w", err)
+			}
+			parsed = message.Parse(m.Text)
+		}
+		if !matchFilter(bot, event.MessageCited, filterTarget{channelID: m.ChannelID, message: m, authorIsBot: user.IsBot()}) {
+			continue
+		}
+
+		if err := ctx.Unicast(
+			event.MessageCited,
+			payload.MakeMessageCited(datetime, m, user, parsed, citedID),
+			bot,
+		); err != nil {
+			return fmt.Errorf("failed to unicast: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+func TestMessageCited(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.MessageCited.String()}),
+		State:           model.BotActive,
+	}
+	u := &model.User{
+		ID:     uuid.NewV3(uuid.Nil, "u"),
+		Name:   "testman",
+		Status: model.UserAccountStatusActive,
+	}
+	ch := &model.Channel{
+		ID:       uuid.NewV3(uuid.Nil, "c"),
+		Name:     "test",
+		IsPublic: true,
+	}
+	cited := &model.Message{
+		ID:        uuid.NewV3(uuid.Nil, "cited"),
+		UserID:    b.BotUserID,
+		ChannelID: ch.ID,
+		Text:      "bot message",
+	}
+	m := &model.Message{
+		ID:        uuid.NewV3(uuid.Nil, "m"),
+		UserID:    u.ID,
+		ChannelID: ch.ID,
+		Text:      "https://example.com/messages/" + cited.ID.String(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+		registerUser(repo, u)
+		registerMessage(repo, cited)
+
+		cm.EXPECT().
+			IsChannelAccessibleToUser(b.BotUserID, ch.ID).
+			Return(true, nil).
+			AnyTimes()
+
+		et := time.Now()
+		expectUnicast(handlerCtx, event.MessageCited, payload.MakeMessageCited(et, m, u, message.Parse(m.Text), cited.ID), b)
+		assert.NoError(t, MessageCited(handlerCtx, et, intevent.MessageCited, hub.Fields{
+			"message_id": m.ID,
+			"message":    m,
+			"cited_ids":  []uuid.UUID{cited.ID},
+		}))
+	})
+
+	t.Run("inaccessible channel", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+		registerUser(repo, u)
+		registerMessage(repo, cited)
+
+		cm.EXPECT().
+			IsChannelAccessibleToUser(b.BotUserID, ch.ID).
+			Return(false, nil).
+			AnyTimes()
+
+		assert.NoError(t, MessageCited(handlerCtx, time.Now(), intevent.MessageCited, hub.Fields{
+			"message_id": m.ID,
+			"message":    m,
+			"cited_ids":  []uuid.UUID{cited.ID},
+		}))
+	})
+
+	t.Run("not a bot message", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+
+		other := &model.Message{
+			ID:        uuid.NewV3(uuid.Nil, "other"),
+			UserID:    uuid.NewV3(uuid.Nil, "u2"),
+			ChannelID: ch.ID,
+		}
+		registerMessage(repo, other)
+		handlerCtx.EXPECT().
+			GetBotByBotUserID(other.UserID).
+			Return(nil, nil).
+			AnyTimes()
+
+		assert.NoError(t, MessageCited(handlerCtx, time.Now(), intevent.MessageCited, hub.Fields{
+			"message_id": m.ID,
+			"message":    m,
+			"cited_ids":  []uuid.UUID{other.ID},
+		}))
+	})
+}
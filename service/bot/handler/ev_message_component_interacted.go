@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func MessageComponentInteracted(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	messageID := fields["message_id"].(uuid.UUID)
+	channelID := fields["channel_id"].(uuid.UUID)
+	botUserID := fields["bot_user_id"].(uuid.UUID)
+	userID := fields["user_id"].(uuid.UUID)
+	component := fields["component"].(model.MessageComponent)
+	values := fields["values"].([]string)
+
+	bot, err := ctx.GetBotByBotUserID(botUserID)
+	if err != nil {
+		return fmt.Errorf("failed to GetBotByBotUserID: %w", err)
+	}
+	if bot == nil {
+		return nil
+	}
+
+	user, err := ctx.R().GetUser(userID, false)
+	if err != nil {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	// メッセージを投稿したBOTにのみ送信
+	switch component.Type {
+	case model.MessageComponentTypeButton:
+		err = ctx.Unicast(
+			event.ButtonClicked,
+			payload.MakeButtonClicked(datetime, messageID, channelID, component.CustomID, user),
+			bot,
+		)
+	case model.MessageComponentTypeSelect:
+		err = ctx.Unicast(
+			event.SelectChanged,
+			payload.MakeSelectChanged(datetime, messageID, channelID, component.CustomID, values, user),
+			bot,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to unicast: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func TestMessageComponentInteracted(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypes{},
+		State:           model.BotActive,
+	}
+	u := &model.User{
+		ID:   uuid.NewV3(uuid.Nil, "u"),
+		Name: "testman",
+	}
+	messageID := uuid.NewV3(uuid.Nil, "m")
+	channelID := uuid.NewV3(uuid.Nil, "c")
+
+	t.Run("button", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+		registerUser(repo, u)
+
+		et := time.Now()
+		component := model.MessageComponent{Type: model.MessageComponentTypeButton, CustomID: "approve", Label: "承認"}
+
+		expectUnicast(handlerCtx, event.ButtonClicked, payload.MakeButtonClicked(et, messageID, channelID, "approve", u), b)
+		assert.NoError(t, MessageComponentInteracted(handlerCtx, et, intevent.MessageComponentInteracted, hub.Fields{
+			"message_id":  messageID,
+			"channel_id":  channelID,
+			"bot_user_id": b.BotUserID,
+			"user_id":     u.ID,
+			"component":   component,
+			"values":      []string{},
+		}))
+	})
+
+	t.Run("select", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+		registerUser(repo, u)
+
+		et := time.Now()
+		component := model.MessageComponent{
+			Type:     model.MessageComponentTypeSelect,
+			CustomID: "vote",
+			Options:  []model.MessageComponentOption{{Label: "A", Value: "a"}, {Label: "B", Value: "b"}},
+		}
+
+		expectUnicast(handlerCtx, event.SelectChanged, payload.MakeSelectChanged(et, messageID, channelID, "vote", []string{"b"}, u), b)
+		assert.NoError(t, MessageComponentInteracted(handlerCtx, et, intevent.MessageComponentInteracted, hub.Fields{
+			"message_id":  messageID,
+			"channel_id":  channelID,
+			"bot_user_id": b.BotUserID,
+			"user_id":     u.ID,
+			"component":   component,
+			"values":      []string{"b"},
+		}))
+	})
+
+	t.Run("inactive bot", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+		botUserID := uuid.NewV3(uuid.Nil, "inactive")
+		handlerCtx.EXPECT().GetBotByBotUserID(botUserID).Return(nil, nil).Times(1)
+
+		assert.NoError(t, MessageComponentInteracted(handlerCtx, time.Now(), intevent.MessageComponentInteracted, hub.Fields{
+			"message_id":  messageID,
+			"channel_id":  channelID,
+			"bot_user_id": botUserID,
+			"user_id":     u.ID,
+			"component":   model.MessageComponent{Type: model.MessageComponentTypeButton, CustomID: "approve"},
+			"values":      []string{},
+		}))
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/traPtitech/traQ/service/bot/event"
 	"github.com/traPtitech/traQ/service/bot/event/payload"
 	"github.com/traPtitech/traQ/utils/message"
+	"github.com/traPtitech/traQ/utils/set"
 )
 
 func MessageCreated(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
@@ -27,6 +28,7 @@ func MessageCreated(ctx Context, datetime time.Time, _ string, fields hub.Fields
 	if err != nil {
 		return fmt.Errorf("failed to GetUser: %w", err)
 	}
+	target := filterTarget{channelID: m.ChannelID, message: m, authorIsBot: user.IsBot()}
 
 	if ch.IsDMChannel() {
 		ids, err := ctx.CM().GetDMChannelMembers(ch.ID)
@@ -46,7 +48,7 @@ func MessageCreated(ctx Context, datetime time.Time, _ string, fields hub.Fields
 		if err != nil {
 			return fmt.Errorf("failed to GetBotByBotUserID: %w", err)
 		}
-		if bot == nil || !bot.SubscribeEvents.Contains(event.DirectMessageCreated) {
+		if bot == nil || !bot.SubscribeEvents.Contains(event.DirectMessageCreated) || !matchFilter(bot, event.DirectMessageCreated, target) {
 			return nil
 		}
 
@@ -63,6 +65,7 @@ func MessageCreated(ctx Context, datetime time.Time, _ string, fields hub.Fields
 		if err != nil {
 			return fmt.Errorf("failed to GetChannelBots: %w", err)
 		}
+		bots = filterBotsByEventFilter(bots, event.MessageCreated, target)
 
 		// メンションBOT
 		done := make(map[uuid.UUID]bool)
@@ -77,12 +80,21 @@ func MessageCreated(ctx Context, datetime time.Time, _ string, fields hub.Fields
 				if b == nil {
 					continue
 				}
-				if b.SubscribeEvents.Contains(event.MentionMessageCreated) {
+				if b.SubscribeEvents.Contains(event.MentionMessageCreated) && matchFilter(b, event.MentionMessageCreated, target) {
 					bots = append(bots, b)
 				}
 			}
 		}
 
+		if ch.IsPrivateChannel() {
+			// プライベートチャンネルではメンバーのBOTのみに送信
+			members, err := ctx.CM().GetPrivateChannelMembers(ch.ID)
+			if err != nil {
+				return fmt.Errorf("failed to GetPrivateChannelMembers: %w", err)
+			}
+			bots = filterBotUserIDIn(bots, set.UUIDSetFromArray(members))
+		}
+
 		bots = filterBotUserIDNotEquals(bots, m.UserID)
 		if len(bots) == 0 {
 			return nil
@@ -108,3 +120,13 @@ func filterBotUserIDNotEquals(bots []*model.Bot, id uuid.UUID) []*model.Bot {
 	}
 	return result
 }
+
+func filterBotUserIDIn(bots []*model.Bot, ids set.UUID) []*model.Bot {
+	result := make([]*model.Bot, 0, len(bots))
+	for _, bot := range bots {
+		if ids.Contains(bot.BotUserID) {
+			result = append(result, bot)
+		}
+	}
+	return result
+}
@@ -109,6 +109,147 @@ func TestMessageCreated(t *testing.T) {
 		}))
 	})
 
+	t.Run("success (public message, filtered)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, repo := setup(t, ctrl)
+
+		prefixBot := &model.Bot{
+			ID:              uuid.NewV3(uuid.Nil, "pb"),
+			BotUserID:       uuid.NewV3(uuid.Nil, "pbu"),
+			SubscribeEvents: b.SubscribeEvents,
+			EventFilters: model.BotEventFilters{
+				event.MessageCreated: {Prefix: "!"},
+			},
+			State: model.BotActive,
+		}
+		channelBot := &model.Bot{
+			ID:              uuid.NewV3(uuid.Nil, "cb"),
+			BotUserID:       uuid.NewV3(uuid.Nil, "cbu"),
+			SubscribeEvents: b.SubscribeEvents,
+			EventFilters: model.BotEventFilters{
+				event.MessageCreated: {Channels: []uuid.UUID{ch.ID}, Pattern: "^test"},
+			},
+			State: model.BotActive,
+		}
+
+		m := &model.Message{
+			ID:        uuid.NewV3(uuid.Nil, "m"),
+			UserID:    uuid.NewV3(uuid.Nil, "u"),
+			ChannelID: ch.ID,
+			Text:      "test message",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		parsed := message.Parse(m.Text)
+		mu := &model.User{
+			ID:   m.UserID,
+			Name: "testman",
+		}
+		registerUser(repo, mu)
+		registerChannel(cm, ch)
+		et := time.Now()
+
+		handlerCtx.EXPECT().
+			GetChannelBots(m.ChannelID, event.MessageCreated).
+			Return([]*model.Bot{prefixBot, channelBot}, nil).
+			AnyTimes()
+
+		expectMulticast(handlerCtx, event.MessageCreated, payload.MakeMessageCreated(et, m, mu, parsed), []*model.Bot{channelBot})
+		assert.NoError(t, MessageCreated(handlerCtx, et, intevent.MessageCreated, hub.Fields{
+			"message_id":   m.ID,
+			"message":      m,
+			"parse_result": parsed,
+		}))
+	})
+
+	t.Run("success (private channel, sent to member)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+
+		pc := &model.Channel{
+			ID:       uuid.NewV3(uuid.Nil, "pc"),
+			Name:     "private",
+			ParentID: uuid.Must(uuid.FromString(model.PrivateChannelRootID)),
+		}
+		m := &model.Message{
+			ID:        uuid.NewV3(uuid.Nil, "m"),
+			UserID:    uuid.NewV3(uuid.Nil, "u"),
+			ChannelID: pc.ID,
+			Text:      "test message",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		parsed := message.Parse(m.Text)
+		mu := &model.User{
+			ID:   m.UserID,
+			Name: "testman",
+		}
+		registerUser(repo, mu)
+		registerChannel(cm, pc)
+		et := time.Now()
+
+		handlerCtx.EXPECT().
+			GetChannelBots(m.ChannelID, event.MessageCreated).
+			Return([]*model.Bot{b}, nil).
+			AnyTimes()
+		cm.EXPECT().
+			GetPrivateChannelMembers(pc.ID).
+			Return([]uuid.UUID{m.UserID, b.BotUserID}, nil).
+			AnyTimes()
+
+		expectMulticast(handlerCtx, event.MessageCreated, payload.MakeMessageCreated(et, m, mu, parsed), []*model.Bot{b})
+		assert.NoError(t, MessageCreated(handlerCtx, et, intevent.MessageCreated, hub.Fields{
+			"message_id":   m.ID,
+			"message":      m,
+			"parse_result": parsed,
+		}))
+	})
+
+	t.Run("success (private channel, not member)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, cm, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+
+		pc := &model.Channel{
+			ID:       uuid.NewV3(uuid.Nil, "pc"),
+			Name:     "private",
+			ParentID: uuid.Must(uuid.FromString(model.PrivateChannelRootID)),
+		}
+		m := &model.Message{
+			ID:        uuid.NewV3(uuid.Nil, "m"),
+			UserID:    uuid.NewV3(uuid.Nil, "u"),
+			ChannelID: pc.ID,
+			Text:      "test message",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		mu := &model.User{
+			ID:   m.UserID,
+			Name: "testman",
+		}
+		registerUser(repo, mu)
+		registerChannel(cm, pc)
+
+		handlerCtx.EXPECT().
+			GetChannelBots(m.ChannelID, event.MessageCreated).
+			Return([]*model.Bot{b}, nil).
+			AnyTimes()
+		cm.EXPECT().
+			GetPrivateChannelMembers(pc.ID).
+			Return([]uuid.UUID{m.UserID}, nil).
+			AnyTimes()
+
+		assert.NoError(t, MessageCreated(handlerCtx, time.Now(), intevent.MessageCreated, hub.Fields{
+			"message_id":   m.ID,
+			"message":      m,
+			"parse_result": message.Parse(m.Text),
+		}))
+	})
+
 	t.Run("success (dm)", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
@@ -38,7 +38,7 @@ func MessageDeleted(ctx Context, datetime time.Time, _ string, fields hub.Fields
 		if err != nil {
 			return fmt.Errorf("failed to GetBotByBotUserID: %w", err)
 		}
-		if bot == nil || !bot.SubscribeEvents.Contains(event.DirectMessageDeleted) {
+		if bot == nil || !bot.SubscribeEvents.Contains(event.DirectMessageDeleted) || !matchFilter(bot, event.DirectMessageDeleted, filterTarget{channelID: m.ChannelID}) {
 			return nil
 		}
 
@@ -55,6 +55,7 @@ func MessageDeleted(ctx Context, datetime time.Time, _ string, fields hub.Fields
 			return fmt.Errorf("failed to GetChannelBots: %w", err)
 		}
 
+		bots = filterBotsByEventFilter(bots, event.MessageDeleted, filterTarget{channelID: m.ChannelID})
 		bots = filterBotUserIDNotEquals(bots, m.UserID)
 		if len(bots) == 0 {
 			return nil
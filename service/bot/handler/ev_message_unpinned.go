@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+func MessageUnpinned(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	messageID := fields["message_id"].(uuid.UUID)
+	channelID := fields["channel_id"].(uuid.UUID)
+
+	bots, err := ctx.GetChannelBots(channelID, event.MessageUnpinned)
+	if err != nil {
+		return fmt.Errorf("failed to GetChannelBots: %w", err)
+	}
+	if len(bots) == 0 {
+		return nil
+	}
+
+	m, err := ctx.R().GetMessageByID(messageID)
+	if err != nil {
+		return fmt.Errorf("failed to GetMessageByID: %w", err)
+	}
+	user, err := ctx.R().GetUser(m.UserID, false)
+	if err != nil {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	bots = filterBotsByEventFilter(bots, event.MessageUnpinned, filterTarget{channelID: channelID, message: m, authorIsBot: user.IsBot()})
+	if len(bots) == 0 {
+		return nil
+	}
+
+	if err := ctx.Multicast(
+		event.MessageUnpinned,
+		payload.MakeMessageUnpinned(datetime, m, user, message.Parse(m.Text)),
+		bots,
+	); err != nil {
+		return fmt.Errorf("failed to multicast: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+func TestMessageUnpinned(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.MessageUnpinned.String()}),
+		State:           model.BotActive,
+	}
+	u := &model.User{
+		ID:     uuid.NewV3(uuid.Nil, "u"),
+		Name:   "testman",
+		Status: model.UserAccountStatusActive,
+	}
+	ch := &model.Channel{
+		ID:       uuid.NewV3(uuid.Nil, "c"),
+		Name:     "test",
+		IsPublic: true,
+	}
+	m := &model.Message{
+		ID:        uuid.NewV3(uuid.Nil, "m"),
+		UserID:    u.ID,
+		ChannelID: ch.ID,
+		Text:      "pin me",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+		registerUser(repo, u)
+		registerMessage(repo, m)
+
+		handlerCtx.EXPECT().
+			GetChannelBots(ch.ID, event.MessageUnpinned).
+			Return([]*model.Bot{b}, nil).
+			AnyTimes()
+
+		et := time.Now()
+		expectMulticast(handlerCtx, event.MessageUnpinned, payload.MakeMessageUnpinned(et, m, u, message.Parse(m.Text)), []*model.Bot{b})
+		assert.NoError(t, MessageUnpinned(handlerCtx, et, intevent.MessageUnpinned, hub.Fields{
+			"message_id": m.ID,
+			"channel_id": ch.ID,
+		}))
+	})
+
+	t.Run("no bots", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+
+		handlerCtx.EXPECT().
+			GetChannelBots(ch.ID, event.MessageUnpinned).
+			Return([]*model.Bot{}, nil).
+			AnyTimes()
+
+		assert.NoError(t, MessageUnpinned(handlerCtx, time.Now(), intevent.MessageUnpinned, hub.Fields{
+			"message_id": m.ID,
+			"channel_id": ch.ID,
+		}))
+	})
+}
@@ -26,6 +26,7 @@ func MessageUpdated(ctx Context, datetime time.Time, _ string, fields hub.Fields
 	if err != nil {
 		return fmt.Errorf("failed to GetUser: %w", err)
 	}
+	target := filterTarget{channelID: m.ChannelID, message: m, authorIsBot: user.IsBot()}
 
 	if ch.IsDMChannel() {
 		ids, err := ctx.CM().GetDMChannelMembers(ch.ID)
@@ -45,7 +46,7 @@ func MessageUpdated(ctx Context, datetime time.Time, _ string, fields hub.Fields
 		if err != nil {
 			return fmt.Errorf("failed to GetBotByBotUserID: %w", err)
 		}
-		if bot == nil || !bot.SubscribeEvents.Contains(event.DirectMessageUpdated) {
+		if bot == nil || !bot.SubscribeEvents.Contains(event.DirectMessageUpdated) || !matchFilter(bot, event.DirectMessageUpdated, target) {
 			return nil
 		}
 
@@ -63,6 +64,7 @@ func MessageUpdated(ctx Context, datetime time.Time, _ string, fields hub.Fields
 			return fmt.Errorf("failed to GetChannelBots: %w", err)
 		}
 
+		bots = filterBotsByEventFilter(bots, event.MessageUpdated, target)
 		// ev_message_created.go で定義済み
 		bots = filterBotUserIDNotEquals(bots, m.UserID)
 		if len(bots) == 0 {
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+	"github.com/traPtitech/traQ/service/bot/handler/mock_handler"
+)
+
+func TestStampDeleted(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.StampDeleted.String()}),
+		State:           model.BotActive,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx := mock_handler.NewMockContext(ctrl)
+		registerBot(t, handlerCtx, b)
+
+		stampID := uuid.NewV3(uuid.Nil, "s")
+		et := time.Now()
+
+		expectMulticast(handlerCtx, event.StampDeleted, payload.MakeStampDeleted(et, stampID), []*model.Bot{b})
+		assert.NoError(t, StampDeleted(handlerCtx, et, intevent.StampDeleted, hub.Fields{
+			"stamp_id": stampID,
+		}))
+	})
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func StampUpdated(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	stampID := fields["stamp_id"].(uuid.UUID)
+
+	bots, err := ctx.GetBots(event.StampUpdated)
+	if err != nil {
+		return fmt.Errorf("failed to GetBots: %w", err)
+	}
+	if len(bots) == 0 {
+		return nil
+	}
+
+	stamp, err := ctx.R().GetStamp(stampID)
+	if err != nil {
+		return fmt.Errorf("failed to GetStamp: %w", err)
+	}
+
+	var user model.UserInfo
+	if !stamp.IsSystemStamp() {
+		user, err = ctx.R().GetUser(stamp.CreatorID, false)
+		if err != nil {
+			return fmt.Errorf("failed to GetUser: %w", err)
+		}
+	}
+
+	if err := ctx.Multicast(
+		event.StampUpdated,
+		payload.MakeStampUpdated(datetime, stamp, user),
+		bots,
+	); err != nil {
+		return fmt.Errorf("failed to multicast: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func TestUserIconUpdated(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.UserIconUpdated.String()}),
+		State:           model.BotActive,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+
+		user := &model.User{
+			ID:     uuid.NewV3(uuid.Nil, "u"),
+			Name:   "user",
+			Status: model.UserAccountStatusActive,
+			Icon:   uuid.NewV3(uuid.Nil, "f"),
+		}
+		registerUser(repo, user)
+		et := time.Now()
+
+		expectMulticast(handlerCtx, event.UserIconUpdated, payload.MakeUserIconUpdated(et, user), []*model.Bot{b})
+		assert.NoError(t, UserIconUpdated(handlerCtx, et, intevent.UserIconUpdated, hub.Fields{
+			"user_id": user.ID,
+			"file_id": user.Icon,
+		}))
+	})
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func TestUserOffline(t *testing.T) {
+	t.Parallel()
+
+	privileged := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.UserOffline.String()}),
+		Privileged:      true,
+		State:           model.BotActive,
+	}
+	normal := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b2"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu2"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.UserOffline.String()}),
+		State:           model.BotActive,
+	}
+	user := &model.User{
+		ID:     uuid.NewV3(uuid.Nil, "u"),
+		Name:   "user",
+		Status: model.UserAccountStatusActive,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		registerUser(repo, user)
+
+		handlerCtx.EXPECT().
+			GetBots(event.UserOffline).
+			Return([]*model.Bot{privileged, normal}, nil).
+			AnyTimes()
+
+		et := time.Now()
+		expectMulticast(handlerCtx, event.UserOffline, payload.MakeUserOffline(et, user), []*model.Bot{privileged})
+		assert.NoError(t, UserOffline(handlerCtx, et, intevent.UserOffline, hub.Fields{
+			"user_id":  user.ID,
+			"datetime": et,
+		}))
+	})
+
+	t.Run("no privileged bots", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+		registerBot(t, handlerCtx, normal)
+
+		assert.NoError(t, UserOffline(handlerCtx, time.Now(), intevent.UserOffline, hub.Fields{
+			"user_id":  user.ID,
+			"datetime": time.Now(),
+		}))
+	})
+}
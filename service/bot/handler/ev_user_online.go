@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func UserOnline(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	userID := fields["user_id"].(uuid.UUID)
+
+	bots, err := ctx.GetBots(event.UserOnline)
+	if err != nil {
+		return fmt.Errorf("failed to GetBots: %w", err)
+	}
+	// オンライン状態は特権BOTにのみ送信
+	bots = filterPrivilegedBots(bots)
+	if len(bots) == 0 {
+		return nil
+	}
+
+	user, err := ctx.R().GetUser(userID, false)
+	if err != nil {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	if err := ctx.Multicast(
+		event.UserOnline,
+		payload.MakeUserOnline(datetime, user),
+		bots,
+	); err != nil {
+		return fmt.Errorf("failed to multicast: %w", err)
+	}
+	return nil
+}
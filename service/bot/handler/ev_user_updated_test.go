@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func TestUserUpdated(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.UserUpdated.String()}),
+		State:           model.BotActive,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+		registerBot(t, handlerCtx, b)
+
+		user := &model.User{
+			ID:     uuid.NewV3(uuid.Nil, "u"),
+			Name:   "user",
+			Status: model.UserAccountStatusActive,
+			Icon:   uuid.NewV3(uuid.Nil, "f"),
+		}
+		registerUser(repo, user)
+		et := time.Now()
+
+		expectMulticast(handlerCtx, event.UserUpdated, payload.MakeUserUpdated(et, user), []*model.Bot{b})
+		assert.NoError(t, UserUpdated(handlerCtx, et, intevent.UserUpdated, hub.Fields{
+			"user_id": user.ID,
+		}))
+	})
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/motoki317/sc"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+const (
+	patternCacheSize = 1000
+	patternCacheTTL  = time.Hour
+)
+
+// patternCache コンパイル済みの購読フィルターの正規表現
+var patternCache = sc.NewMust(func(_ context.Context, pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		// 不正な正規表現は何にもマッチしない (保存時に検証されるため通常は発生しない)
+		return nil, nil
+	}
+	return re, nil
+}, patternCacheTTL, patternCacheTTL, sc.WithLRUBackend(patternCacheSize))
+
+func compilePattern(pattern string) *regexp.Regexp {
+	re, _ := patternCache.Get(context.Background(), pattern)
+	return re
+}
+
+// filterTarget 購読フィルターの評価対象
+type filterTarget struct {
+	channelID uuid.UUID
+	// message メッセージに関するイベントの場合のみ設定
+	message *model.Message
+	// authorIsBot メッセージの投稿者がBotかどうか
+	authorIsBot bool
+}
+
+// matchFilter イベントがBotの購読フィルターの条件を満たすかどうか
+func matchFilter(bot *model.Bot, ev model.BotEventType, t filterTarget) bool {
+	f := bot.EventFilters.Get(ev)
+	if f == nil {
+		return true
+	}
+	if !f.MatchChannel(t.channelID) {
+		return false
+	}
+	if t.message == nil {
+		return true
+	}
+	if f.ExcludeBots && t.authorIsBot {
+		return false
+	}
+	if !f.MatchPrefix(t.message.Text) {
+		return false
+	}
+	if len(f.Pattern) > 0 {
+		re := compilePattern(f.Pattern)
+		if re == nil || !re.MatchString(t.message.Text) {
+			return false
+		}
+	}
+	return true
+}
+
+// filterBotsByEventFilter 購読フィルターの条件を満たすBotのみを返します
+func filterBotsByEventFilter(bots []*model.Bot, ev model.BotEventType, t filterTarget) []*model.Bot {
+	result := make([]*model.Bot, 0, len(bots))
+	for _, bot := range bots {
+		if matchFilter(bot, ev, t) {
+			result = append(result, bot)
+		}
+	}
+	return result
+}
+
+// filterPrivilegedBots 特権BOTのみを返します
+func filterPrivilegedBots(bots []*model.Bot) []*model.Bot {
+	result := make([]*model.Bot, 0, len(bots))
+	for _, bot := range bots {
+		if bot.Privileged {
+			result = append(result, bot)
+		}
+	}
+	return result
+}
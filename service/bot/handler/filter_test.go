@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+)
+
+func TestMatchFilter(t *testing.T) {
+	t.Parallel()
+
+	ch1 := uuid.NewV3(uuid.Nil, "c1")
+	ch2 := uuid.NewV3(uuid.Nil, "c2")
+	newBot := func(f *model.BotEventFilter) *model.Bot {
+		return &model.Bot{EventFilters: model.BotEventFilters{event.MessageCreated: f}}
+	}
+	msg := func(text string) *model.Message {
+		return &model.Message{ChannelID: ch1, Text: text}
+	}
+
+	cases := []struct {
+		name   string
+		bot    *model.Bot
+		ev     model.BotEventType
+		target filterTarget
+		want   bool
+	}{
+		{"no filter", &model.Bot{}, event.MessageCreated, filterTarget{channelID: ch1, message: msg("po")}, true},
+		{"other event", newBot(&model.BotEventFilter{Prefix: "!"}), event.MessageUpdated, filterTarget{channelID: ch1, message: msg("po")}, true},
+		{"channel matched", newBot(&model.BotEventFilter{Channels: []uuid.UUID{ch1}}), event.MessageCreated, filterTarget{channelID: ch1}, true},
+		{"channel not matched", newBot(&model.BotEventFilter{Channels: []uuid.UUID{ch2}}), event.MessageCreated, filterTarget{channelID: ch1}, false},
+		{"message filter without message", newBot(&model.BotEventFilter{Prefix: "!"}), event.MessageCreated, filterTarget{channelID: ch1}, true},
+		{"prefix matched", newBot(&model.BotEventFilter{Prefix: "!"}), event.MessageCreated, filterTarget{channelID: ch1, message: msg("!help")}, true},
+		{"prefix not matched", newBot(&model.BotEventFilter{Prefix: "!"}), event.MessageCreated, filterTarget{channelID: ch1, message: msg("help")}, false},
+		{"pattern matched", newBot(&model.BotEventFilter{Pattern: `^/\w+`}), event.MessageCreated, filterTarget{channelID: ch1, message: msg("/deploy now")}, true},
+		{"pattern not matched", newBot(&model.BotEventFilter{Pattern: `^/\w+`}), event.MessageCreated, filterTarget{channelID: ch1, message: msg("deploy now")}, false},
+		{"invalid pattern", newBot(&model.BotEventFilter{Pattern: `(`}), event.MessageCreated, filterTarget{channelID: ch1, message: msg("(")}, false},
+		{"bot author excluded", newBot(&model.BotEventFilter{ExcludeBots: true}), event.MessageCreated, filterTarget{channelID: ch1, message: msg("po"), authorIsBot: true}, false},
+		{"human author", newBot(&model.BotEventFilter{ExcludeBots: true}), event.MessageCreated, filterTarget{channelID: ch1, message: msg("po")}, true},
+		{"all matched", newBot(&model.BotEventFilter{Channels: []uuid.UUID{ch1}, Prefix: "!", Pattern: "deploy$", ExcludeBots: true}), event.MessageCreated, filterTarget{channelID: ch1, message: msg("!deploy")}, true},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, c.want, matchFilter(c.bot, c.ev, c.target))
+		})
+	}
+}
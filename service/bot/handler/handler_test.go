@@ -19,6 +19,10 @@ type Repo struct {
 	*mock_repository.MockTagRepository
 	*mock_repository.MockUserRepository
 	*mock_repository.MockBotRepository
+	*mock_repository.MockBotEventDeliveryRepository
+	*mock_repository.MockMessageRepository
+	*mock_repository.MockStampRepository
+	*mock_repository.MockAuditLogRepository
 	testutils.EmptyTestRepository
 }
 
@@ -30,6 +34,11 @@ func setup(_ *testing.T, ctrl *gomock.Controller) (*mock_handler.MockContext, *m
 		MockTagRepository:  mock_repository.NewMockTagRepository(ctrl),
 		MockUserRepository: mock_repository.NewMockUserRepository(ctrl),
 		MockBotRepository:  mock_repository.NewMockBotRepository(ctrl),
+
+		MockBotEventDeliveryRepository: mock_repository.NewMockBotEventDeliveryRepository(ctrl),
+		MockMessageRepository:          mock_repository.NewMockMessageRepository(ctrl),
+		MockStampRepository:            mock_repository.NewMockStampRepository(ctrl),
+		MockAuditLogRepository:         mock_repository.NewMockAuditLogRepository(ctrl),
 	}
 
 	handlerCtx.EXPECT().
@@ -78,6 +87,13 @@ func registerChannel(cm *mock_channel.MockManager, ch *model.Channel) {
 		AnyTimes()
 }
 
+func registerMessage(repo *Repo, m *model.Message) {
+	repo.MockMessageRepository.EXPECT().
+		GetMessageByID(m.ID).
+		Return(m, nil).
+		AnyTimes()
+}
+
 func registerTag(repo *Repo, t *model.Tag) {
 	repo.MockTagRepository.EXPECT().
 		GetTagByID(t.ID).
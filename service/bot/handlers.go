@@ -12,25 +12,39 @@ import (
 type eventHandler func(ctx handler.Context, datetime time.Time, event string, fields hub.Fields) error
 
 var eventHandlerSet = map[string]eventHandler{
-	intevent.BotJoined:              handler.BotJoined,
-	intevent.BotLeft:                handler.BotLeft,
-	intevent.BotPingRequest:         handler.BotPingRequest,
-	intevent.MessageCreated:         handler.MessageCreated,
-	intevent.MessageDeleted:         handler.MessageDeleted,
-	intevent.MessageUpdated:         handler.MessageUpdated,
-	intevent.UserCreated:            handler.UserCreated,
-	intevent.ChannelCreated:         handler.ChannelCreated,
-	intevent.ChannelTopicUpdated:    handler.ChannelTopicUpdated,
-	intevent.StampCreated:           handler.StampCreated,
-	intevent.UserTagAdded:           handler.UserTagAdded,
-	intevent.UserTagRemoved:         handler.UserTagRemoved,
-	intevent.MessageStampsUpdated:   handler.MessageStampsUpdated,
-	intevent.UserGroupCreated:       handler.UserGroupCreated,
-	intevent.UserGroupUpdated:       handler.UserGroupUpdated,
-	intevent.UserGroupDeleted:       handler.UserGroupDeleted,
-	intevent.UserGroupMemberAdded:   handler.UserGroupMemberAdded,
-	intevent.UserGroupMemberUpdated: handler.UserGroupMemberUpdated,
-	intevent.UserGroupMemberRemoved: handler.UserGroupMemberRemoved,
-	intevent.UserGroupAdminAdded:    handler.UserGroupAdminAdded,
-	intevent.UserGroupAdminRemoved:  handler.UserGroupAdminRemoved,
+	intevent.BotJoined:                  handler.BotJoined,
+	intevent.BotLeft:                    handler.BotLeft,
+	intevent.BotPingRequest:             handler.BotPingRequest,
+	intevent.BotCommandInvoked:          handler.BotCommandInvoked,
+	intevent.BotEventDeliveriesRequeued: handler.BotEventDeliveriesRequeued,
+	intevent.BotWSConnected:             handler.BotWSConnected,
+	intevent.MessageComponentInteracted: handler.MessageComponentInteracted,
+	intevent.MessageCreated:             handler.MessageCreated,
+	intevent.MessageDeleted:             handler.MessageDeleted,
+	intevent.MessageUpdated:             handler.MessageUpdated,
+	intevent.MessagePinned:              handler.MessagePinned,
+	intevent.MessageUnpinned:            handler.MessageUnpinned,
+	intevent.MessageCited:               handler.MessageCited,
+	intevent.UserCreated:                handler.UserCreated,
+	intevent.UserUpdated:                handler.UserUpdated,
+	intevent.UserIconUpdated:            handler.UserIconUpdated,
+	intevent.UserOnline:                 handler.UserOnline,
+	intevent.UserOffline:                handler.UserOffline,
+	intevent.ChannelCreated:             handler.ChannelCreated,
+	intevent.ChannelUpdated:             handler.ChannelUpdated,
+	intevent.ChannelTopicUpdated:        handler.ChannelTopicUpdated,
+	intevent.StampCreated:               handler.StampCreated,
+	intevent.StampUpdated:               handler.StampUpdated,
+	intevent.StampDeleted:               handler.StampDeleted,
+	intevent.UserTagAdded:               handler.UserTagAdded,
+	intevent.UserTagRemoved:             handler.UserTagRemoved,
+	intevent.MessageStampsUpdated:       handler.MessageStampsUpdated,
+	intevent.UserGroupCreated:           handler.UserGroupCreated,
+	intevent.UserGroupUpdated:           handler.UserGroupUpdated,
+	intevent.UserGroupDeleted:           handler.UserGroupDeleted,
+	intevent.UserGroupMemberAdded:       handler.UserGroupMemberAdded,
+	intevent.UserGroupMemberUpdated:     handler.UserGroupMemberUpdated,
+	intevent.UserGroupMemberRemoved:     handler.UserGroupMemberRemoved,
+	intevent.UserGroupAdminAdded:        handler.UserGroupAdminAdded,
+	intevent.UserGroupAdminRemoved:      handler.UserGroupAdminRemoved,
 }
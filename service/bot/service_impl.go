@@ -18,7 +18,11 @@ import (
 )
 
 const (
-	botEventLogPurgeBefore = time.Hour * 24 * 365 // BOTイベントログを1年間保持
+	botEventLogPurgeBefore      = time.Hour * 24 * 365 // BOTイベントログを1年間保持
+	botEventDeliveryPurgeBefore = time.Hour * 24 * 7   // 配送に失敗したBOTイベントを1週間保持
+	retryInterval               = 10 * time.Second     // 再送待ちイベントの確認間隔
+	retryBatchSize              = 100                  // 一度に再送するイベントの最大数
+	retryConcurrency            = 10                   // 再送の並列数
 )
 
 type serviceImpl struct {
@@ -30,9 +34,11 @@ type serviceImpl struct {
 
 	sub         hub.Subscription
 	logPurger   *jitterbug.Ticker
+	retrier     *time.Ticker
 	serviceDone chan struct{}
 	hubDone     chan struct{}
 	purgerDone  chan struct{}
+	retrierDone chan struct{}
 }
 
 // NewService ボットサービスを生成します
@@ -47,6 +53,7 @@ func NewService(repo repository.Repository, cm channel.Manager, hub *hub.Hub, s
 		serviceDone: make(chan struct{}),
 		hubDone:     make(chan struct{}),
 		purgerDone:  make(chan struct{}),
+		retrierDone: make(chan struct{}),
 	}
 	p.start()
 	return p
@@ -94,6 +101,23 @@ func (p *serviceImpl) start() {
 				if err := p.repo.PurgeBotEventLogs(time.Now().Add(-botEventLogPurgeBefore)); err != nil {
 					p.logger.Error("an error occurred while purging old bot event logs", zap.Error(err))
 				}
+				if err := p.repo.PurgeBotEventDeliveries(time.Now().Add(-botEventDeliveryPurgeBefore)); err != nil {
+					p.logger.Error("an error occurred while purging old bot event deliveries", zap.Error(err))
+				}
+			case <-p.serviceDone:
+				return
+			}
+		}
+	}()
+
+	// 配送に失敗したBOTイベントの定期的再送
+	p.retrier = time.NewTicker(retryInterval)
+	go func() {
+		defer close(p.retrierDone)
+		for {
+			select {
+			case <-p.retrier.C:
+				p.retryDeliveries()
 			case <-p.serviceDone:
 				return
 			}
@@ -106,12 +130,52 @@ func (p *serviceImpl) start() {
 func (p *serviceImpl) Shutdown(_ context.Context) error {
 	p.hub.Unsubscribe(p.sub)
 	p.logPurger.Stop()
+	p.retrier.Stop()
 	close(p.serviceDone)
 	<-p.hubDone
 	<-p.purgerDone
+	<-p.retrierDone
 	return nil
 }
 
+// retryDeliveries 再送時刻を過ぎたBOTイベントを再送します
+func (p *serviceImpl) retryDeliveries() {
+	deliveries, err := p.repo.GetRetryableBotEventDeliveries(time.Now(), retryBatchSize)
+	if err != nil {
+		p.logger.Error("an error occurred while fetching bot event deliveries", zap.Error(err))
+		return
+	}
+
+	bots := make(map[uuid.UUID]*model.Bot)
+	sem := make(chan struct{}, retryConcurrency)
+	var wg sync.WaitGroup
+	for _, d := range deliveries {
+		b, ok := bots[d.BotID]
+		if !ok {
+			b, err = p.GetBot(d.BotID)
+			if err != nil {
+				p.logger.Error("an error occurred while fetching bot", zap.Error(err), zap.Stringer("botID", d.BotID))
+				continue
+			}
+			bots[d.BotID] = b
+		}
+		if b == nil {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(b *model.Bot, d *model.BotEventDelivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			p.dispatcher.Redeliver(b, d)
+		}(b, d)
+	}
+	wg.Wait()
+}
+
 func (p *serviceImpl) CM() channel.Manager {
 	return p.cm
 }
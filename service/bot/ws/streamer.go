@@ -7,11 +7,13 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/gorilla/websocket"
+	jsonIter "github.com/json-iterator/go"
 	"github.com/leandro-lugaresi/hub"
 	"go.uber.org/zap"
 
 	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/router/extension/ctxkey"
+	"github.com/traPtitech/traQ/service/relay"
 	"github.com/traPtitech/traQ/service/webrtcv3"
 )
 
@@ -22,25 +24,51 @@ var (
 	ErrBufferIsFull = errors.New("buffer is full")
 )
 
+const (
+	relayTopicSessions = "bot.ws.sessions"
+	relayTopicMessage  = "bot.ws.message"
+)
+
+// relayedSessions 他ノードに通知する、自ノードでのBOTのセッション数
+type relayedSessions struct {
+	BotUserID uuid.UUID `json:"botUserId"`
+	Count     int       `json:"count"`
+}
+
+// relayedMessage 他ノードに中継するメッセージ
+type relayedMessage struct {
+	BotUserID uuid.UUID           `json:"botUserId"`
+	Data      jsonIter.RawMessage `json:"data"`
+}
+
 // Streamer WebSocketストリーマー
 type Streamer struct {
 	hub      *hub.Hub
 	webrtc   *webrtcv3.Manager
+	relay    relay.Relay
 	logger   *zap.Logger
 	sessions map[uuid.UUID][]*session
-	closed   bool
-	mu       sync.RWMutex
+	// remoteSessions 他ノードに接続しているBOTのセッション数 (BOTユーザーID -> ノードID -> セッション数)
+	remoteSessions map[uuid.UUID]map[string]int
+	closed         bool
+	mu             sync.RWMutex
 }
 
 // NewStreamer WebSocketストリーマーを生成し起動します
-func NewStreamer(hub *hub.Hub, webrtc *webrtcv3.Manager, logger *zap.Logger) *Streamer {
+func NewStreamer(hub *hub.Hub, webrtc *webrtcv3.Manager, r relay.Relay, logger *zap.Logger) *Streamer {
 	h := &Streamer{
-		hub:      hub,
-		webrtc:   webrtc,
-		logger:   logger.Named("bot.ws"),
-		sessions: make(map[uuid.UUID][]*session),
-		closed:   false,
+		hub:            hub,
+		webrtc:         webrtc,
+		relay:          r,
+		logger:         logger.Named("bot.ws"),
+		sessions:       make(map[uuid.UUID][]*session),
+		remoteSessions: make(map[uuid.UUID]map[string]int),
+		closed:         false,
 	}
+	r.Subscribe(relayTopicSessions, h.handleRelayedSessions)
+	r.Subscribe(relayTopicMessage, h.handleRelayedMessage)
+	r.OnNodeJoined(h.announceSessions)
+	r.OnNodeLeft(h.removeRemoteSessions)
 	return h
 }
 
@@ -48,6 +76,7 @@ func (s *Streamer) register(session *session) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.sessions[session.userID] = append(s.sessions[session.userID], session)
+	s.publishSessions(session.userID, len(s.sessions[session.userID]))
 }
 
 func (s *Streamer) unregister(session *session) {
@@ -55,12 +84,72 @@ func (s *Streamer) unregister(session *session) {
 	defer s.mu.Unlock()
 	if sessions, ok := s.sessions[session.userID]; ok {
 		s.sessions[session.userID] = filterSession(sessions, session)
+		s.publishSessions(session.userID, len(s.sessions[session.userID]))
 		if len(s.sessions[session.userID]) == 0 {
 			delete(s.sessions, session.userID)
 		}
 	}
 }
 
+func (s *Streamer) publishSessions(botUserID uuid.UUID, count int) {
+	if err := s.relay.Publish(relayTopicSessions, &relayedSessions{BotUserID: botUserID, Count: count}); err != nil {
+		s.logger.Warn("failed to relay sessions", zap.Error(err), zap.Stringer("userID", botUserID))
+	}
+}
+
+// announceSessions 自ノードの全BOTのセッション数を他ノードに通知します
+func (s *Streamer) announceSessions(_ string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for botUserID, sessions := range s.sessions {
+		s.publishSessions(botUserID, len(sessions))
+	}
+}
+
+func (s *Streamer) handleRelayedSessions(nodeID string, body []byte) {
+	var m relayedSessions
+	if err := json.Unmarshal(body, &m); err != nil {
+		s.logger.Warn("received malformed relayed sessions", zap.Error(err))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nodes, ok := s.remoteSessions[m.BotUserID]
+	if !ok {
+		nodes = make(map[string]int)
+		s.remoteSessions[m.BotUserID] = nodes
+	}
+	if m.Count > 0 {
+		nodes[nodeID] = m.Count
+	} else {
+		delete(nodes, nodeID)
+		if len(nodes) == 0 {
+			delete(s.remoteSessions, m.BotUserID)
+		}
+	}
+}
+
+func (s *Streamer) removeRemoteSessions(nodeID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for botUserID, nodes := range s.remoteSessions {
+		delete(nodes, nodeID)
+		if len(nodes) == 0 {
+			delete(s.remoteSessions, botUserID)
+		}
+	}
+}
+
+func (s *Streamer) handleRelayedMessage(_ string, body []byte) {
+	var m relayedMessage
+	if err := json.Unmarshal(body, &m); err != nil {
+		s.logger.Warn("received a malformed relayed message", zap.Error(err))
+		return
+	}
+	s.writeMessage(m.Data, m.BotUserID)
+}
+
 func filterSession(sessions []*session, target *session) []*session {
 	s := make([]*session, 0, len(sessions)-1)
 	for _, session := range sessions {
@@ -72,10 +161,28 @@ func filterSession(sessions []*session, target *session) []*session {
 }
 
 // WriteMessage 指定したセッションにメッセージを書き込みます
+//
+// BOTが他ノードに接続している場合は、そのノードにも中継されます
 func (s *Streamer) WriteMessage(t string, reqID uuid.UUID, body []byte, botUserID uuid.UUID) (errs []error, attempted bool) {
+	data := makeEventMessage(t, reqID, body).toJSON()
+	errs, attempted = s.writeMessage(data, botUserID)
+
+	s.mu.RLock()
+	remote := len(s.remoteSessions[botUserID]) > 0
+	s.mu.RUnlock()
+	if remote {
+		if err := s.relay.Publish(relayTopicMessage, &relayedMessage{BotUserID: botUserID, Data: data}); err != nil {
+			errs = append(errs, err)
+		}
+		attempted = true
+	}
+	return
+}
+
+func (s *Streamer) writeMessage(data []byte, botUserID uuid.UUID) (errs []error, attempted bool) {
 	m := &rawMessage{
 		t:    websocket.TextMessage,
-		data: makeEventMessage(t, reqID, body).toJSON(),
+		data: data,
 	}
 	s.mu.RLock()
 	for _, session := range s.sessions[botUserID] {
@@ -83,9 +190,7 @@ func (s *Streamer) WriteMessage(t string, reqID uuid.UUID, body []byte, botUserI
 			errs = append(errs, err)
 			if err == ErrBufferIsFull {
 				s.logger.Warn("Discarded a message because the session's buffer was full.",
-					zap.String("type", t),
-					zap.Stringer("reqID", reqID),
-					zap.Any("body", body),
+					zap.ByteString("message", data),
 					zap.Stringer("userID", session.userID))
 			}
 		}
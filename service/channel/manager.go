@@ -8,22 +8,28 @@ import (
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/set"
 )
 
 var (
-	ErrChannelNotFound      = errors.New("channel not found")
-	ErrChannelNameConflicts = errors.New("channel name conflicts")
-	ErrInvalidChannelName   = errors.New("invalid channel name")
-	ErrInvalidParentChannel = errors.New("invalid parent channel")
-	ErrTooDeepChannel       = errors.New("too deep channel")
-	ErrChannelArchived      = errors.New("channel archived")
-	ErrForcedNotification   = errors.New("forced notification channel")
-	ErrInvalidChannel       = errors.New("invalid channel")
+	ErrChannelNotFound         = errors.New("channel not found")
+	ErrChannelNameConflicts    = errors.New("channel name conflicts")
+	ErrInvalidChannelName      = errors.New("invalid channel name")
+	ErrInvalidParentChannel    = errors.New("invalid parent channel")
+	ErrTooDeepChannel          = errors.New("too deep channel")
+	ErrChannelArchived         = errors.New("channel archived")
+	ErrForcedNotification      = errors.New("forced notification channel")
+	ErrInvalidChannel          = errors.New("invalid channel")
+	ErrNoPrivateChannelMembers = errors.New("private channel must have at least one member")
 )
 
 type Manager interface {
 	GetChannel(id uuid.UUID) (*model.Channel, error)
 	CreatePublicChannel(name string, parent, creatorID uuid.UUID) (*model.Channel, error)
+	// CreatePrivateChannel プライベートチャンネルを作成します
+	//
+	// 名前が不正な場合や、既に同名のプライベートチャンネルが存在する場合はErrInvalidChannelNameを返します。
+	CreatePrivateChannel(name string, creatorID uuid.UUID, members set.UUID) (*model.Channel, error)
 	UpdateChannel(id uuid.UUID, args repository.UpdateChannelArgs) error
 	PublicChannelTree() Tree
 
@@ -31,11 +37,19 @@ type Manager interface {
 
 	ArchiveChannel(id uuid.UUID, updaterID uuid.UUID) error
 	UnarchiveChannel(id uuid.UUID, updaterID uuid.UUID) error
+	// DeleteChannel 指定した公開チャンネルを子孫チャンネル・メッセージ・ファイルのメタデータと共に完全に削除します
+	//
+	// 成功した場合、削除したファイルを返します。ストレージ上のファイルの実体は呼び出し側で削除してください。
+	DeleteChannel(id uuid.UUID, updaterID uuid.UUID) ([]*model.FileMeta, error)
 
 	GetDMChannel(user1, user2 uuid.UUID) (*model.Channel, error)
 	GetDMChannelMembers(id uuid.UUID) ([]uuid.UUID, error)
 	GetDMChannelMapping(userID uuid.UUID) (map[uuid.UUID]uuid.UUID, error)
 
+	GetPrivateChannels(userID uuid.UUID) ([]*model.Channel, error)
+	GetPrivateChannelMembers(id uuid.UUID) ([]uuid.UUID, error)
+	ChangePrivateChannelMembers(id uuid.UUID, add, remove []uuid.UUID, updaterID uuid.UUID) error
+
 	IsChannelAccessibleToUser(userID, channelID uuid.UUID) (bool, error)
 	IsPublicChannel(id uuid.UUID) bool
 
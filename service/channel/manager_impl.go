@@ -10,6 +10,8 @@ import (
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/relay"
+	"github.com/traPtitech/traQ/utils/gormutil"
 	"github.com/traPtitech/traQ/utils/optional"
 	"github.com/traPtitech/traQ/utils/random"
 	"github.com/traPtitech/traQ/utils/set"
@@ -17,20 +19,25 @@ import (
 )
 
 var (
-	dmChannelRootUUID  = uuid.Must(uuid.FromString(model.DirectMessageChannelRootID))
-	pubChannelRootUUID = uuid.Nil
+	dmChannelRootUUID      = uuid.Must(uuid.FromString(model.DirectMessageChannelRootID))
+	privateChannelRootUUID = uuid.Must(uuid.FromString(model.PrivateChannelRootID))
+	pubChannelRootUUID     = uuid.Nil
 )
 
+// relayTopic 公開チャンネルツリーの変更を他ノードに通知するトピック
+const relayTopic = "channel.tree_changed"
+
 type managerImpl struct {
-	R repository.ChannelRepository
-	L *zap.Logger
-	T *treeImpl
-	P sync.WaitGroup
+	R     repository.ChannelRepository
+	L     *zap.Logger
+	T     *treeImpl
+	P     sync.WaitGroup
+	relay relay.Relay
 
 	MaxChannelDepth int
 }
 
-func InitChannelManager(repo repository.ChannelRepository, logger *zap.Logger) (Manager, error) {
+func InitChannelManager(repo repository.ChannelRepository, r relay.Relay, logger *zap.Logger) (Manager, error) {
 	channels, err := repo.GetPublicChannels()
 	if err != nil {
 		return nil, fmt.Errorf("failed to init channel.Manager: %w", err)
@@ -39,16 +46,43 @@ func InitChannelManager(repo repository.ChannelRepository, logger *zap.Logger) (
 	m := &managerImpl{
 		R:               repo,
 		L:               logger.Named("channel_manager"),
+		relay:           r,
 		MaxChannelDepth: 5,
 	}
 	m.T, err = makeChannelTree(channels)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init channel.Manager: %w", err)
 	}
+	r.Subscribe(relayTopic, m.handleRelayedTreeChange)
 
 	return m, nil
 }
 
+// publishTreeChange 公開チャンネルツリーを変更したことを他ノードに通知します
+func (m *managerImpl) publishTreeChange() {
+	if err := m.relay.Publish(relayTopic, struct{}{}); err != nil {
+		m.L.Error("failed to publish channel tree change", zap.Error(err))
+	}
+}
+
+// handleRelayedTreeChange 他ノードで公開チャンネルツリーが変更された場合に、DBから読み込み直します
+func (m *managerImpl) handleRelayedTreeChange(nodeID string, _ []byte) {
+	m.T.Lock()
+	defer m.T.Unlock()
+
+	channels, err := m.R.GetPublicChannels()
+	if err != nil {
+		m.L.Error("failed to reload channel tree", zap.Error(err), zap.String("nodeId", nodeID))
+		return
+	}
+	t, err := makeChannelTree(channels)
+	if err != nil {
+		m.L.Error("failed to reload channel tree", zap.Error(err), zap.String("nodeId", nodeID))
+		return
+	}
+	m.T.replace(t)
+}
+
 func (m *managerImpl) GetChannel(id uuid.UUID) (*model.Channel, error) {
 	ch, err := m.T.GetModel(id)
 	if err == nil {
@@ -108,6 +142,7 @@ func (m *managerImpl) CreatePublicChannel(name string, parent, creatorID uuid.UU
 		return nil, fmt.Errorf("failed to CreateChannel: %w", err)
 	}
 	m.T.add(ch)
+	m.publishTreeChange()
 	if parent != pubChannelRootUUID {
 		// ロギング
 		m.recordChannelEvent(ch.ParentID, model.ChannelEventChildCreated, model.ChannelEventDetail{
@@ -119,6 +154,36 @@ func (m *managerImpl) CreatePublicChannel(name string, parent, creatorID uuid.UU
 	return ch, nil
 }
 
+func (m *managerImpl) CreatePrivateChannel(name string, creatorID uuid.UUID, members set.UUID) (*model.Channel, error) {
+	// チャンネル名の制約を確認
+	if !validator.ChannelRegex.MatchString(name) {
+		return nil, ErrInvalidChannelName
+	}
+
+	privateMembers := members.Clone()
+	privateMembers.Add(creatorID)
+
+	// チャンネル作成
+	ch, err := m.R.CreateChannel(model.Channel{
+		Name:      name,
+		ParentID:  privateChannelRootUUID,
+		CreatorID: creatorID,
+		UpdaterID: creatorID,
+		IsForced:  false,
+		IsVisible: true,
+	}, privateMembers, false)
+	if err != nil {
+		if gormutil.IsMySQLDuplicatedRecordErr(err) {
+			// 他人のプライベートチャンネルの存在を漏らさないよう、重複を区別せず不正な名前として扱う
+			return nil, ErrInvalidChannelName
+		}
+		return nil, fmt.Errorf("failed to CreateChannel: %w", err)
+	}
+	ch.ChildrenID = make([]uuid.UUID, 0)
+	m.L.Info(fmt.Sprintf("private channel %s was created", ch.Name), zap.Stringer("cid", ch.ID))
+	return ch, nil
+}
+
 func (m *managerImpl) UpdateChannel(id uuid.UUID, args repository.UpdateChannelArgs) error {
 	ch, err := m.GetChannel(id)
 	if err != nil {
@@ -133,6 +198,12 @@ func (m *managerImpl) UpdateChannel(id uuid.UUID, args repository.UpdateChannelA
 	m.T.Lock()
 	defer m.T.Unlock()
 
+	// 公開チャンネル以外はトピックのみ変更可能
+	public := m.T.isChannelPresent(id)
+	if !public && (args.Name.Valid || args.Parent.Valid || args.ForcedNotification.Valid || args.Visibility.Valid) {
+		return ErrInvalidChannel
+	}
+
 	eventRecords := map[model.ChannelEventType]model.ChannelEventDetail{}
 	if args.Topic.Valid {
 		if ch.IsArchived() {
@@ -228,10 +299,13 @@ func (m *managerImpl) UpdateChannel(id uuid.UUID, args repository.UpdateChannelA
 		return fmt.Errorf("failed to UpdateChannel: %w", err)
 	}
 
-	if args.Name.Valid || args.Parent.Valid {
-		m.T.move(id, args.Parent, args.Name)
+	if public {
+		if args.Name.Valid || args.Parent.Valid {
+			m.T.move(id, args.Parent, args.Name)
+		}
+		m.T.updateSingle(id, ch)
+		m.publishTreeChange()
 	}
-	m.T.updateSingle(id, ch)
 
 	updated := time.Now()
 	for eventType, detail := range eventRecords {
@@ -249,8 +323,8 @@ func (m *managerImpl) ArchiveChannel(id uuid.UUID, updaterID uuid.UUID) error {
 	if ch.IsArchived() {
 		return nil // 既にアーカイブされている
 	}
-	if ch.IsDMChannel() {
-		return ErrInvalidChannel // DMチャンネルはアーカイブ不可
+	if ch.IsDMChannel() || ch.IsPrivateChannel() {
+		return ErrInvalidChannel // DM・プライベートチャンネルはアーカイブ不可
 	}
 
 	m.T.Lock()
@@ -278,6 +352,7 @@ func (m *managerImpl) ArchiveChannel(id uuid.UUID, updaterID uuid.UUID) error {
 	}
 
 	m.T.updateMultiple(chs)
+	m.publishTreeChange()
 
 	updated := time.Now()
 	for _, ch := range chs {
@@ -311,6 +386,7 @@ func (m *managerImpl) UnarchiveChannel(id uuid.UUID, updaterID uuid.UUID) error
 	}
 
 	m.T.updateSingle(id, ch)
+	m.publishTreeChange()
 
 	m.recordChannelEvent(ch.ID, model.ChannelEventVisibilityChanged, model.ChannelEventDetail{
 		"userId":     updaterID,
@@ -319,6 +395,39 @@ func (m *managerImpl) UnarchiveChannel(id uuid.UUID, updaterID uuid.UUID) error
 	return nil
 }
 
+func (m *managerImpl) DeleteChannel(id uuid.UUID, updaterID uuid.UUID) ([]*model.FileMeta, error) {
+	ch, err := m.GetChannel(id)
+	if err != nil {
+		return nil, err
+	}
+	if ch.IsDMChannel() || ch.IsPrivateChannel() {
+		return nil, ErrInvalidChannel // DM・プライベートチャンネルは削除不可
+	}
+
+	m.T.Lock()
+	defer m.T.Unlock()
+
+	path := m.T.getChannelPath(id)
+	targets := append([]uuid.UUID{id}, m.T.getDescendantIDs(id)...)
+	files, err := m.R.DeleteChannels(targets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to DeleteChannels: %w", err)
+	}
+
+	m.T.remove(id)
+	m.publishTreeChange()
+
+	if ch.ParentID != pubChannelRootUUID {
+		// ロギング
+		m.recordChannelEvent(ch.ParentID, model.ChannelEventChildDeleted, model.ChannelEventDetail{
+			"userId":    updaterID,
+			"channelId": id,
+		}, time.Now())
+	}
+	m.L.Info(fmt.Sprintf("channel #%s was deleted", path), zap.Stringer("cid", id), zap.Int("channels", len(targets)))
+	return files, nil
+}
+
 func (m *managerImpl) PublicChannelTree() Tree {
 	return m.T
 }
@@ -384,6 +493,51 @@ func (m *managerImpl) GetDMChannelMembers(id uuid.UUID) ([]uuid.UUID, error) {
 	return members, nil
 }
 
+func (m *managerImpl) GetPrivateChannels(userID uuid.UUID) ([]*model.Channel, error) {
+	chs, err := m.R.GetPrivateChannelsByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to GetPrivateChannelsByUser: %w", err)
+	}
+	for _, ch := range chs {
+		ch.ChildrenID = make([]uuid.UUID, 0)
+	}
+	return chs, nil
+}
+
+func (m *managerImpl) GetPrivateChannelMembers(id uuid.UUID) ([]uuid.UUID, error) {
+	members, err := m.R.GetPrivateChannelMemberIDs(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to GetPrivateChannelMemberIDs: %w", err)
+	}
+	return members, nil
+}
+
+func (m *managerImpl) ChangePrivateChannelMembers(id uuid.UUID, add, remove []uuid.UUID, updaterID uuid.UUID) error {
+	ch, err := m.GetChannel(id)
+	if err != nil {
+		return err
+	}
+	if !ch.IsPrivateChannel() {
+		return ErrInvalidChannel
+	}
+
+	added, removed, err := m.R.ChangePrivateChannelMembers(id, add, remove)
+	if err != nil {
+		if err == repository.ErrForbidden {
+			return ErrNoPrivateChannelMembers // 全員を削除することはできない
+		}
+		return fmt.Errorf("failed to ChangePrivateChannelMembers: %w", err)
+	}
+	if len(added) > 0 || len(removed) > 0 {
+		m.recordChannelEvent(id, model.ChannelEventMembersChanged, model.ChannelEventDetail{
+			"userId":  updaterID,
+			"added":   added,
+			"removed": removed,
+		}, time.Now())
+	}
+	return nil
+}
+
 func (m *managerImpl) GetDMChannelMapping(userID uuid.UUID) (map[uuid.UUID]uuid.UUID, error) {
 	mappings, err := m.R.GetDirectMessageChannelMapping(userID)
 	if err != nil {
@@ -406,7 +560,7 @@ func (m *managerImpl) IsChannelAccessibleToUser(userID, channelID uuid.UUID) (bo
 		return true, nil // 公開チャンネルは全員アクセス可能
 	}
 
-	// DMチャンネル
+	// DM・プライベートチャンネル
 	members, err := m.R.GetPrivateChannelMemberIDs(channelID)
 	if err != nil {
 		return false, fmt.Errorf("failed to IsChannelAccessibleToUser: %w", err)
@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/gofrs/uuid"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -18,6 +19,7 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/relay"
 	"github.com/traPtitech/traQ/utils/optional"
 	"github.com/traPtitech/traQ/utils/random"
 	"github.com/traPtitech/traQ/utils/set"
@@ -28,6 +30,7 @@ func initCM(t *testing.T, repo repository.ChannelRepository) *managerImpl {
 		R:               repo,
 		L:               zap.NewNop(),
 		T:               makeTestChannelTree(t),
+		relay:           relay.NewNullRelay(),
 		MaxChannelDepth: 5,
 	}
 }
@@ -46,7 +49,7 @@ func TestInitChannelManager(t *testing.T) {
 			Return(nil, mockErr).
 			Times(1)
 
-		_, err := InitChannelManager(repo, zap.NewNop())
+		_, err := InitChannelManager(repo, relay.NewNullRelay(), zap.NewNop())
 		if assert.Error(t, err) {
 			assert.Equal(t, mockErr, errors.Unwrap(err))
 		}
@@ -66,7 +69,7 @@ func TestInitChannelManager(t *testing.T) {
 			}, nil).
 			Times(1)
 
-		_, err := InitChannelManager(repo, zap.NewNop())
+		_, err := InitChannelManager(repo, relay.NewNullRelay(), zap.NewNop())
 		assert.Error(t, err)
 	})
 
@@ -80,13 +83,47 @@ func TestInitChannelManager(t *testing.T) {
 			Return([]*model.Channel{}, nil).
 			Times(1)
 
-		m, err := InitChannelManager(repo, zap.NewNop())
+		m, err := InitChannelManager(repo, relay.NewNullRelay(), zap.NewNop())
 		if assert.NoError(t, err) {
 			assert.NotNil(t, m)
 		}
 	})
 }
 
+func TestManagerImpl_Relay(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	net := relay.NewMemoryNetwork()
+	r1 := net.Join(zap.NewNop())
+	defer r1.Close()
+	r2 := net.Join(zap.NewNop())
+	defer r2.Close()
+
+	ch := &model.Channel{ID: uuid.Must(uuid.NewV4()), Name: "relay", ParentID: uuid.Nil, IsPublic: true, IsVisible: true}
+
+	repo1 := mock_repository.NewMockChannelRepository(ctrl)
+	repo1.EXPECT().GetPublicChannels().Return([]*model.Channel{}, nil).Times(1)
+	repo1.EXPECT().CreateChannel(gomock.Any(), gomock.Any(), false).Return(ch, nil).Times(1)
+	repo2 := mock_repository.NewMockChannelRepository(ctrl)
+	gomock.InOrder(
+		repo2.EXPECT().GetPublicChannels().Return([]*model.Channel{}, nil).Times(1),
+		// 他ノードでの変更通知を受けてDBから読み込み直す
+		repo2.EXPECT().GetPublicChannels().Return([]*model.Channel{ch}, nil).Times(1),
+	)
+
+	cm1, err := InitChannelManager(repo1, r1, zap.NewNop())
+	require.NoError(t, err)
+	cm2, err := InitChannelManager(repo2, r2, zap.NewNop())
+	require.NoError(t, err)
+
+	_, err = cm1.CreatePublicChannel(ch.Name, uuid.Nil, uuid.Nil)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return cm2.PublicChannelTree().IsChannelPresent(ch.ID)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, ch.Name, cm2.PublicChannelTree().GetChannelPath(ch.ID))
+}
+
 func TestManagerImpl_GetChannel(t *testing.T) {
 	t.Parallel()
 
@@ -418,6 +455,22 @@ func TestManagerImpl_UpdateChannel(t *testing.T) {
 		assert.EqualError(t, err, ErrTooDeepChannel.Error())
 	})
 
+	t.Run("ErrInvalidChannel (private)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockChannelRepository(ctrl)
+		cm := initCM(t, repo)
+
+		pcID := uuid.NewV3(uuid.Nil, "private")
+		repo.EXPECT().
+			GetChannel(pcID).
+			Return(&model.Channel{ID: pcID, Name: "private", ParentID: privateChannelRootUUID}, nil).
+			Times(1)
+
+		err := cm.UpdateChannel(pcID, repository.UpdateChannelArgs{Name: optional.From("renamed")})
+		assert.EqualError(t, err, ErrInvalidChannel.Error())
+	})
+
 	t.Run("repository error", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
@@ -765,6 +818,112 @@ func TestManagerImpl_ArchiveChannel(t *testing.T) {
 	})
 }
 
+func TestManagerImpl_DeleteChannel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ErrChannelNotFound", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockChannelRepository(ctrl)
+		cm := initCM(t, repo)
+
+		repo.EXPECT().
+			GetChannel(gomock.Any()).
+			Return(nil, repository.ErrNotFound).
+			AnyTimes()
+
+		_, err := cm.DeleteChannel(cNotFound, uuid.Nil)
+		assert.EqualError(t, err, ErrChannelNotFound.Error())
+	})
+
+	t.Run("ErrInvalidChannel", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockChannelRepository(ctrl)
+		cm := initCM(t, repo)
+
+		dm1 := &model.Channel{
+			ID:        uuid.NewV3(uuid.Nil, "c 1-1"),
+			Name:      "a",
+			ParentID:  dmChannelRootUUID,
+			IsForced:  false,
+			IsPublic:  false,
+			IsVisible: true,
+		}
+
+		repo.EXPECT().
+			GetChannel(dm1.ID).
+			Return(dm1, nil).
+			AnyTimes()
+
+		_, err := cm.DeleteChannel(dm1.ID, uuid.Nil)
+		assert.EqualError(t, err, ErrInvalidChannel.Error())
+	})
+
+	t.Run("success (root)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockChannelRepository(ctrl)
+		cm := initCM(t, repo)
+
+		repo.EXPECT().
+			DeleteChannels(gomock.Len(7)).
+			Return(nil, nil).
+			Times(1)
+
+		_, err := cm.DeleteChannel(cE, uuid.Nil)
+		cm.P.Wait()
+		if assert.NoError(t, err) {
+			for _, id := range []uuid.UUID{cE, cEF, cEFG, cEFGH, cEFGHI, cEFGJ, cEK} {
+				assert.False(t, cm.PublicChannelTree().IsChannelPresent(id))
+			}
+			assert.True(t, cm.PublicChannelTree().IsChannelPresent(cA))
+		}
+	})
+
+	t.Run("success (child)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockChannelRepository(ctrl)
+		cm := initCM(t, repo)
+		user := uuid.Must(uuid.NewV4())
+
+		repo.EXPECT().
+			DeleteChannels(gomock.Len(3)).
+			Return([]*model.FileMeta{{ID: uuid.Must(uuid.NewV4())}}, nil).
+			Times(1)
+		repo.EXPECT().
+			RecordChannelEvent(cAB, model.ChannelEventChildDeleted, model.ChannelEventDetail{"userId": user, "channelId": cABC}, gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		files, err := cm.DeleteChannel(cABC, user)
+		cm.P.Wait()
+		if assert.NoError(t, err) {
+			assert.Len(t, files, 1)
+			assert.False(t, cm.PublicChannelTree().IsChannelPresent(cABC))
+			assert.False(t, cm.PublicChannelTree().IsChannelPresent(cABCD))
+			assert.ElementsMatch(t, cm.PublicChannelTree().GetChildrenIDs(cAB), []uuid.UUID{cABF, cABB})
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockChannelRepository(ctrl)
+		cm := initCM(t, repo)
+
+		repo.EXPECT().
+			DeleteChannels(gomock.Any()).
+			Return(nil, errors.New("error")).
+			Times(1)
+
+		_, err := cm.DeleteChannel(cEK, uuid.Nil)
+		assert.Error(t, err)
+		assert.True(t, cm.PublicChannelTree().IsChannelPresent(cEK))
+	})
+}
+
 func TestManagerImpl_UnarchiveChannel(t *testing.T) {
 	t.Parallel()
 
@@ -1155,3 +1314,116 @@ func TestManagerImpl_IsPublicChannel(t *testing.T) {
 	assert.True(t, cm.IsPublicChannel(cA))
 	assert.False(t, cm.IsPublicChannel(cNotFound))
 }
+
+func TestManagerImpl_CreatePrivateChannel(t *testing.T) {
+	t.Parallel()
+
+	uid1 := uuid.NewV3(uuid.Nil, "u1")
+	uid2 := uuid.NewV3(uuid.Nil, "u2")
+
+	t.Run("ErrInvalidChannelName", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockChannelRepository(ctrl)
+		cm := initCM(t, repo)
+
+		_, err := cm.CreatePrivateChannel("ああああ", uid1, nil)
+		assert.EqualError(t, err, ErrInvalidChannelName.Error())
+	})
+
+	t.Run("name conflicts", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockChannelRepository(ctrl)
+		cm := initCM(t, repo)
+
+		repo.EXPECT().
+			CreateChannel(gomock.Any(), gomock.Any(), false).
+			Return(nil, &mysql.MySQLError{Number: 1062}).
+			Times(1)
+
+		// 重複は名前の不正と区別しない
+		_, err := cm.CreatePrivateChannel("test", uid1, nil)
+		assert.EqualError(t, err, ErrInvalidChannelName.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockChannelRepository(ctrl)
+		cm := initCM(t, repo)
+
+		repo.EXPECT().
+			CreateChannel(gomock.Any(), gomock.Any(), false).
+			DoAndReturn(func(ch model.Channel, privateMembers set.UUID, _ bool) (*model.Channel, error) {
+				assert.Equal(t, "test", ch.Name)
+				assert.Equal(t, privateChannelRootUUID, ch.ParentID)
+				assert.ElementsMatch(t, []uuid.UUID{uid1, uid2}, privateMembers.Array())
+				ch.ID = uuid.Must(uuid.NewV4())
+				return &ch, nil
+			}).
+			Times(1)
+
+		ch, err := cm.CreatePrivateChannel("test", uid1, set.UUIDSetFromArray([]uuid.UUID{uid2}))
+		if assert.NoError(t, err) {
+			assert.True(t, ch.IsPrivateChannel())
+			assert.False(t, cm.IsPublicChannel(ch.ID))
+		}
+	})
+}
+
+func TestManagerImpl_ChangePrivateChannelMembers(t *testing.T) {
+	t.Parallel()
+
+	uid1 := uuid.NewV3(uuid.Nil, "u1")
+	uid2 := uuid.NewV3(uuid.Nil, "u2")
+	pcID := uuid.NewV3(uuid.Nil, "private")
+	pc := &model.Channel{ID: pcID, Name: "private", ParentID: privateChannelRootUUID, IsVisible: true}
+
+	t.Run("ErrInvalidChannel (public)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockChannelRepository(ctrl)
+		cm := initCM(t, repo)
+
+		err := cm.ChangePrivateChannelMembers(cA, []uuid.UUID{uid1}, nil, uid1)
+		assert.EqualError(t, err, ErrInvalidChannel.Error())
+	})
+
+	t.Run("ErrNoPrivateChannelMembers", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockChannelRepository(ctrl)
+		cm := initCM(t, repo)
+
+		repo.EXPECT().GetChannel(pcID).Return(pc, nil).Times(1)
+		repo.EXPECT().
+			ChangePrivateChannelMembers(pcID, nil, []uuid.UUID{uid1}).
+			Return(nil, nil, repository.ErrForbidden).
+			Times(1)
+
+		err := cm.ChangePrivateChannelMembers(pcID, nil, []uuid.UUID{uid1}, uid1)
+		assert.EqualError(t, err, ErrNoPrivateChannelMembers.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockChannelRepository(ctrl)
+		cm := initCM(t, repo)
+
+		repo.EXPECT().GetChannel(pcID).Return(pc, nil).Times(1)
+		repo.EXPECT().
+			ChangePrivateChannelMembers(pcID, []uuid.UUID{uid2}, []uuid.UUID{uid1}).
+			Return([]uuid.UUID{uid2}, []uuid.UUID{uid1}, nil).
+			Times(1)
+		repo.EXPECT().
+			RecordChannelEvent(pcID, model.ChannelEventMembersChanged, gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		err := cm.ChangePrivateChannelMembers(pcID, []uuid.UUID{uid2}, []uuid.UUID{uid1}, uid1)
+		assert.NoError(t, err)
+		cm.Wait()
+	})
+}
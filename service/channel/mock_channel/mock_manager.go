@@ -12,6 +12,7 @@ import (
 	model "github.com/traPtitech/traQ/model"
 	repository "github.com/traPtitech/traQ/repository"
 	channel "github.com/traPtitech/traQ/service/channel"
+	set "github.com/traPtitech/traQ/utils/set"
 )
 
 // MockManager is a mock of Manager interface.
@@ -65,6 +66,35 @@ func (mr *MockManagerMockRecorder) ChangeChannelSubscriptions(channelID, subscri
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeChannelSubscriptions", reflect.TypeOf((*MockManager)(nil).ChangeChannelSubscriptions), channelID, subscriptions, keepOffLevel, updaterID)
 }
 
+// ChangePrivateChannelMembers mocks base method.
+func (m *MockManager) ChangePrivateChannelMembers(id uuid.UUID, add, remove []uuid.UUID, updaterID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePrivateChannelMembers", id, add, remove, updaterID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePrivateChannelMembers indicates an expected call of ChangePrivateChannelMembers.
+func (mr *MockManagerMockRecorder) ChangePrivateChannelMembers(id, add, remove, updaterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePrivateChannelMembers", reflect.TypeOf((*MockManager)(nil).ChangePrivateChannelMembers), id, add, remove, updaterID)
+}
+
+// CreatePrivateChannel mocks base method.
+func (m *MockManager) CreatePrivateChannel(name string, creatorID uuid.UUID, members set.UUID) (*model.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePrivateChannel", name, creatorID, members)
+	ret0, _ := ret[0].(*model.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePrivateChannel indicates an expected call of CreatePrivateChannel.
+func (mr *MockManagerMockRecorder) CreatePrivateChannel(name, creatorID, members interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePrivateChannel", reflect.TypeOf((*MockManager)(nil).CreatePrivateChannel), name, creatorID, members)
+}
+
 // CreatePublicChannel mocks base method.
 func (m *MockManager) CreatePublicChannel(name string, parent, creatorID uuid.UUID) (*model.Channel, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +110,21 @@ func (mr *MockManagerMockRecorder) CreatePublicChannel(name, parent, creatorID i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePublicChannel", reflect.TypeOf((*MockManager)(nil).CreatePublicChannel), name, parent, creatorID)
 }
 
+// DeleteChannel mocks base method.
+func (m *MockManager) DeleteChannel(id, updaterID uuid.UUID) ([]*model.FileMeta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannel", id, updaterID)
+	ret0, _ := ret[0].([]*model.FileMeta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteChannel indicates an expected call of DeleteChannel.
+func (mr *MockManagerMockRecorder) DeleteChannel(id, updaterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannel", reflect.TypeOf((*MockManager)(nil).DeleteChannel), id, updaterID)
+}
+
 // GetChannel mocks base method.
 func (m *MockManager) GetChannel(id uuid.UUID) (*model.Channel, error) {
 	m.ctrl.T.Helper()
@@ -140,6 +185,36 @@ func (mr *MockManagerMockRecorder) GetDMChannelMembers(id interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDMChannelMembers", reflect.TypeOf((*MockManager)(nil).GetDMChannelMembers), id)
 }
 
+// GetPrivateChannelMembers mocks base method.
+func (m *MockManager) GetPrivateChannelMembers(id uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateChannelMembers", id)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateChannelMembers indicates an expected call of GetPrivateChannelMembers.
+func (mr *MockManagerMockRecorder) GetPrivateChannelMembers(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateChannelMembers", reflect.TypeOf((*MockManager)(nil).GetPrivateChannelMembers), id)
+}
+
+// GetPrivateChannels mocks base method.
+func (m *MockManager) GetPrivateChannels(userID uuid.UUID) ([]*model.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateChannels", userID)
+	ret0, _ := ret[0].([]*model.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateChannels indicates an expected call of GetPrivateChannels.
+func (mr *MockManagerMockRecorder) GetPrivateChannels(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateChannels", reflect.TypeOf((*MockManager)(nil).GetPrivateChannels), userID)
+}
+
 // IsChannelAccessibleToUser mocks base method.
 func (m *MockManager) IsChannelAccessibleToUser(userID, channelID uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
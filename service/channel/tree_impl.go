@@ -243,6 +243,24 @@ func (ct *treeImpl) move(id uuid.UUID, newParent optional.Of[uuid.UUID], newName
 	ct.regenerateJSON()
 }
 
+func (ct *treeImpl) remove(id uuid.UUID) {
+	n, ok := ct.nodes[id]
+	if !ok {
+		panic("assert !ok = false")
+	}
+
+	if n.parent != nil {
+		delete(n.parent.children, n.id)
+	} else {
+		delete(ct.roots, n.id)
+	}
+	for _, cid := range append(n.getDescendantIDs(), n.id) {
+		delete(ct.nodes, cid)
+		delete(ct.paths, cid)
+	}
+	ct.regenerateJSON()
+}
+
 func (ct *treeImpl) updateSingle(id uuid.UUID, ch *model.Channel) {
 	ct.update(id, ch)
 	ct.regenerateJSON()
@@ -270,6 +288,14 @@ func (ct *treeImpl) update(id uuid.UUID, ch *model.Channel) {
 	n.Unlock()
 }
 
+// replace ツリーの内容をsrcの内容に置き換えます
+func (ct *treeImpl) replace(src *treeImpl) {
+	ct.nodes = src.nodes
+	ct.roots = src.roots
+	ct.paths = src.paths
+	ct.json = src.json
+}
+
 func (ct *treeImpl) recalculatePath(n *channelNode) {
 	if n.parent == nil {
 		ct.paths[n.id] = n.name
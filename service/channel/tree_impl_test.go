@@ -107,6 +107,27 @@ func TestTreeImpl_move(t *testing.T) {
 
 }
 
+func TestTreeImpl_remove(t *testing.T) {
+	t.Parallel()
+	original := makeTestChannelTree(t)
+	tree := makeTestChannelTree(t)
+
+	// (root)/a/bを削除
+	tree.remove(cAB)
+	assert.Len(t, tree.nodes, len(original.nodes)-8)
+	assert.False(t, tree.isChildPresent("b", cA))
+	assert.False(t, tree.isChannelPresent(cAB))
+	assert.False(t, tree.isChannelPresent(cABCD))
+	assert.True(t, tree.isChannelPresent(cAD))
+	assert.ElementsMatch(t, tree.getChildrenIDs(cA), []uuid.UUID{cAD})
+
+	// (root)/eを削除
+	tree.remove(cE)
+	assert.Len(t, tree.roots, len(original.roots)-1)
+	assert.False(t, tree.isChannelPresent(cEFGHI))
+	assert.Equal(t, "", tree.getChannelPath(cEK))
+}
+
 func TestChannelTreeImpl_GetChildrenIDs(t *testing.T) {
 	t.Parallel()
 	tree := makeTestChannelTree(t)
@@ -5,13 +5,17 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	jsonIter "github.com/json-iterator/go"
 	"github.com/leandro-lugaresi/hub"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/service/relay"
 )
 
+const onlineRelayTopic = "counter.online"
+
 var (
 	onlineUsersCounter = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "traq",
@@ -24,18 +28,31 @@ var (
 )
 
 // OnlineCounter オンラインユーザーカウンター
+//
+// 他ノードのコネクション数も含めてオンラインかどうかを判定します
 type OnlineCounter struct {
 	hub          *hub.Hub
+	relay        relay.Relay
 	counters     map[uuid.UUID]*counter
 	countersLock sync.Mutex
 }
 
+// relayedCount 他ノードに通知する、自ノードでのユーザーのコネクション数
+type relayedCount struct {
+	UserID uuid.UUID `json:"userId"`
+	Count  int       `json:"count"`
+}
+
 // NewOnlineCounter オンラインユーザーカウンターを生成します
-func NewOnlineCounter(hub *hub.Hub) *OnlineCounter {
+func NewOnlineCounter(hub *hub.Hub, r relay.Relay) *OnlineCounter {
 	oc := &OnlineCounter{
 		hub:      hub,
+		relay:    r,
 		counters: map[uuid.UUID]*counter{},
 	}
+	r.Subscribe(onlineRelayTopic, oc.handleRelayedCount)
+	r.OnNodeJoined(oc.announceCounts)
+	r.OnNodeLeft(oc.removeNodeCounts)
 	go func() {
 		for e := range hub.Subscribe(8, event.WSConnected, event.WSDisconnected, event.BotWSConnected, event.BotWSDisconnected).Receiver {
 			switch e.Topic() {
@@ -57,19 +74,27 @@ func NewOnlineCounter(hub *hub.Hub) *OnlineCounter {
 	return oc
 }
 
-// inc 指定したユーザーのカウンタをインクリメントします
-func (oc *OnlineCounter) inc(userID uuid.UUID, userType string) (toOnline bool) {
+// getOrCreate 指定したユーザーのカウンタを取得します。存在しない場合は作成します
+func (oc *OnlineCounter) getOrCreate(userID uuid.UUID) *counter {
 	oc.countersLock.Lock()
+	defer oc.countersLock.Unlock()
 	c, ok := oc.counters[userID]
 	if !ok {
 		c = &counter{
 			userID: userID,
+			remote: map[string]int{},
 		}
 		oc.counters[userID] = c
 	}
-	oc.countersLock.Unlock()
+	return c
+}
 
-	toOnline = c.inc()
+// inc 指定したユーザーのカウンタをインクリメントします
+func (oc *OnlineCounter) inc(userID uuid.UUID, userType string) (toOnline bool) {
+	c := oc.getOrCreate(userID)
+
+	toOnline, count := c.inc()
+	oc.publish(userID, count)
 	if toOnline {
 		onlineUsersCounter.WithLabelValues(userType).Inc()
 		oc.hub.Publish(hub.Message{
@@ -93,7 +118,8 @@ func (oc *OnlineCounter) dec(userID uuid.UUID, userType string) (toOffline bool)
 	}
 	oc.countersLock.Unlock()
 
-	toOffline = c.dec()
+	toOffline, count := c.dec()
+	oc.publish(userID, count)
 	if toOffline {
 		onlineUsersCounter.WithLabelValues(userType).Dec()
 		oc.hub.Publish(hub.Message{
@@ -107,6 +133,60 @@ func (oc *OnlineCounter) dec(userID uuid.UUID, userType string) (toOffline bool)
 	return
 }
 
+// publish 自ノードでのコネクション数を他ノードに通知します
+func (oc *OnlineCounter) publish(userID uuid.UUID, count int) {
+	_ = oc.relay.Publish(onlineRelayTopic, &relayedCount{UserID: userID, Count: count})
+}
+
+func (oc *OnlineCounter) handleRelayedCount(nodeID string, body []byte) {
+	var rc relayedCount
+	if err := jsonIter.ConfigFastest.Unmarshal(body, &rc); err != nil {
+		return
+	}
+	// 状態の変化に伴うイベントは通知元のノードで発行される
+	oc.getOrCreate(rc.UserID).setRemote(nodeID, rc.Count)
+}
+
+// announceCounts 自ノードでの全ユーザーのコネクション数を他ノードに通知します
+func (oc *OnlineCounter) announceCounts(_ string) {
+	oc.countersLock.Lock()
+	counters := make([]*counter, 0, len(oc.counters))
+	for _, c := range oc.counters {
+		counters = append(counters, c)
+	}
+	oc.countersLock.Unlock()
+
+	for _, c := range counters {
+		if count := c.localCount(); count > 0 {
+			oc.publish(c.userID, count)
+		}
+	}
+}
+
+// removeNodeCounts 離脱したノードでのコネクション数を削除します
+func (oc *OnlineCounter) removeNodeCounts(nodeID string) {
+	oc.countersLock.Lock()
+	counters := make([]*counter, 0, len(oc.counters))
+	for _, c := range oc.counters {
+		counters = append(counters, c)
+	}
+	oc.countersLock.Unlock()
+
+	// 離脱に伴うイベントは代表ノードのみが発行する
+	leader := oc.relay.IsLeader()
+	for _, c := range counters {
+		if c.removeRemote(nodeID) && leader {
+			oc.hub.Publish(hub.Message{
+				Name: event.UserOffline,
+				Fields: hub.Fields{
+					"user_id":  c.userID,
+					"datetime": c.getLastUpdated(),
+				},
+			})
+		}
+	}
+}
+
 // IsOnline 指定したユーザーがオンラインかどうかを取得します
 func (oc *OnlineCounter) IsOnline(userID uuid.UUID) bool {
 	oc.countersLock.Lock()
@@ -131,37 +211,71 @@ func (oc *OnlineCounter) GetOnlineUserIDs() []uuid.UUID {
 
 type counter struct {
 	sync.RWMutex
-	userID      uuid.UUID
-	count       int
+	userID uuid.UUID
+	count  int
+	// remote 他ノードでのコネクション数 (ノードID -> コネクション数)
+	remote      map[string]int
 	lastUpdated time.Time
 }
 
+func (s *counter) online() bool {
+	return s.count > 0 || len(s.remote) > 0
+}
+
 func (s *counter) isOnline() (r bool) {
 	s.RLock()
-	r = s.count > 0
+	r = s.online()
 	s.RUnlock()
 	return
 }
 
-func (s *counter) inc() (toOnline bool) {
+func (s *counter) localCount() (n int) {
+	s.RLock()
+	n = s.count
+	s.RUnlock()
+	return
+}
+
+func (s *counter) inc() (toOnline bool, count int) {
 	s.Lock()
+	wasOnline := s.online()
 	s.count++
 	s.lastUpdated = time.Now()
-	if s.count == 1 {
-		toOnline = true
-	}
+	toOnline = !wasOnline
+	count = s.count
 	s.Unlock()
 	return
 }
 
-func (s *counter) dec() (toOffline bool) {
+func (s *counter) dec() (toOffline bool, count int) {
 	s.Lock()
 	if s.count > 0 {
 		s.count--
 		s.lastUpdated = time.Now()
-		if s.count == 0 {
-			toOffline = true
-		}
+		toOffline = !s.online()
+	}
+	count = s.count
+	s.Unlock()
+	return
+}
+
+func (s *counter) setRemote(nodeID string, count int) {
+	s.Lock()
+	if count > 0 {
+		s.remote[nodeID] = count
+	} else {
+		delete(s.remote, nodeID)
+	}
+	s.lastUpdated = time.Now()
+	s.Unlock()
+}
+
+func (s *counter) removeRemote(nodeID string) (toOffline bool) {
+	s.Lock()
+	if _, ok := s.remote[nodeID]; ok {
+		delete(s.remote, nodeID)
+		s.lastUpdated = time.Now()
+		toOffline = !s.online()
 	}
 	s.Unlock()
 	return
@@ -0,0 +1,52 @@
+package counter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/service/relay"
+)
+
+func TestOnlineCounter_Relay(t *testing.T) {
+	t.Parallel()
+
+	net := relay.NewMemoryNetwork()
+	r1 := net.Join(zap.NewNop())
+	r2 := net.Join(zap.NewNop())
+	defer r2.Close()
+	oc1 := NewOnlineCounter(hub.New(), r1)
+	oc2 := NewOnlineCounter(hub.New(), r2)
+
+	user := uuid.Must(uuid.NewV4())
+
+	assert.True(t, oc1.inc(user, "user"))
+	assert.Eventually(t, func() bool { return oc2.IsOnline(user) }, time.Second, 10*time.Millisecond)
+	assert.Contains(t, oc2.GetOnlineUserIDs(), user)
+
+	// 他ノードでオンラインなので、オンラインへの変化にはならない
+	assert.False(t, oc2.inc(user, "user"))
+	assert.Eventually(t, func() bool { return remoteCount(oc1, user) == 1 }, time.Second, 10*time.Millisecond)
+	assert.False(t, oc1.dec(user, "user"))
+	assert.True(t, oc1.IsOnline(user))
+	assert.Eventually(t, func() bool { return remoteCount(oc2, user) == 0 }, time.Second, 10*time.Millisecond)
+	assert.True(t, oc2.dec(user, "user"))
+	assert.Eventually(t, func() bool { return !oc1.IsOnline(user) }, time.Second, 10*time.Millisecond)
+
+	// ノードの離脱でオフラインになる
+	assert.True(t, oc1.inc(user, "user"))
+	assert.Eventually(t, func() bool { return oc2.IsOnline(user) }, time.Second, 10*time.Millisecond)
+	assert.NoError(t, r1.Close())
+	assert.Eventually(t, func() bool { return !oc2.IsOnline(user) }, time.Second, 10*time.Millisecond)
+}
+
+func remoteCount(oc *OnlineCounter, userID uuid.UUID) int {
+	c := oc.getOrCreate(userID)
+	c.RLock()
+	defer c.RUnlock()
+	return len(c.remote)
+}
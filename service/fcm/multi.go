@@ -0,0 +1,27 @@
+package fcm
+
+import (
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+type multiClient []Client
+
+// NewMultiClient 複数のプッシュ通知クライアントに同じペイロードを送信するクライアントを返します
+func NewMultiClient(clients ...Client) Client {
+	if len(clients) == 1 {
+		return clients[0]
+	}
+	return multiClient(clients)
+}
+
+func (m multiClient) Send(targetUserIDs set.UUID, payload *Payload, withUnreadCount bool) {
+	for _, c := range m {
+		c.Send(targetUserIDs, payload, withUnreadCount)
+	}
+}
+
+func (m multiClient) Close() {
+	for _, c := range m {
+		c.Close()
+	}
+}
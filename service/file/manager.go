@@ -89,6 +89,10 @@ type Manager interface {
 	//
 	// 成功した場合、nilを返します。
 	Delete(id uuid.UUID) error
+	// DeleteStorage メタデータを削除済みのファイルの実体とサムネイルをストレージから削除します
+	//
+	// 全て成功した場合、nilを返します。一部の削除に失敗しても残りのファイルの削除は続行します。
+	DeleteStorage(files []*model.FileMeta) error
 	// Accessible ユーザーがファイルへのアクセス権限を持っているかを確認します
 	//
 	// ユーザーがアクセス権限を持っている場合、trueを返します。
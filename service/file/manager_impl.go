@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/md5"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"image/png"
 	"io"
@@ -249,15 +250,33 @@ func (m *managerImpl) Delete(id uuid.UUID) error {
 	if err := m.repo.DeleteFileMeta(id); err != nil {
 		return fmt.Errorf("failed to DeleteFileMeta: %w", err)
 	}
-	if err := m.fs.DeleteByKey(meta.ID.String(), meta.Type); err != nil {
+	if err := m.deleteStorage(meta); err != nil {
 		m.l.Warn("failed to delete file from storage", zap.Error(err), zap.Stringer("fid", meta.ID))
 	}
+	return nil
+}
+
+func (m *managerImpl) DeleteStorage(files []*model.FileMeta) error {
+	var errs []error
+	for _, meta := range files {
+		if err := m.deleteStorage(meta); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete file %s: %w", meta.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *managerImpl) deleteStorage(meta *model.FileMeta) error {
+	var errs []error
+	if err := m.fs.DeleteByKey(meta.ID.String(), meta.Type); err != nil && err != storage.ErrFileNotFound {
+		errs = append(errs, err)
+	}
 	for _, t := range meta.Thumbnails {
-		if err := m.fs.DeleteByKey(meta.ID.String()+"-"+t.Type.Suffix(), model.FileTypeThumbnail); err != nil {
-			m.l.Warn("failed to delete thumbnail from storage", zap.Error(err), zap.Stringer("fid", meta.ID))
+		if err := m.fs.DeleteByKey(meta.ID.String()+"-"+t.Type.Suffix(), model.FileTypeThumbnail); err != nil && err != storage.ErrFileNotFound {
+			errs = append(errs, err)
 		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 func (m *managerImpl) Accessible(fileID, userID uuid.UUID) (bool, error) {
@@ -16,21 +16,27 @@ var (
 	ErrAlreadyExists    = errors.New("already exists")
 	ErrChannelArchived  = errors.New("channel archived")
 	ErrPinLimitExceeded = errors.New("the pin limit exceeded")
+	ErrInvalidChannel   = errors.New("invalid channel")
+	ErrReplyMessage     = errors.New("reply messages cannot be moved without the parent message")
 )
 
 type TimelineQuery struct {
 	User    uuid.UUID
 	Channel uuid.UUID
+	// IncludeReplies Channel指定時にスレッドの返信メッセージも含めるかどうか
+	IncludeReplies bool
 	// ChannelsSubscribedByUser 指定したユーザーが購読しているチャンネルのメッセージを指定
 	ChannelsSubscribedByUser uuid.UUID
-	Since                    optional.Of[time.Time]
-	Until                    optional.Of[time.Time]
-	Inclusive                bool
-	Limit                    int
-	Offset                   int
-	Asc                      bool
-	ExcludeDMs               bool
-	DisablePreload           bool
+	// Parent 指定したメッセージを親とするスレッドの返信メッセージを指定
+	Parent         uuid.UUID
+	Since          optional.Of[time.Time]
+	Until          optional.Of[time.Time]
+	Inclusive      bool
+	Limit          int
+	Offset         int
+	Asc            bool
+	ExcludeDMs     bool
+	DisablePreload bool
 }
 
 type Manager interface {
@@ -63,6 +69,14 @@ type Manager interface {
 	// 成功した場合、メッセージとnilを返します。
 	// DBによるエラーを返すことがあります。
 	CreateDM(from, to uuid.UUID, content string) (Message, error)
+	// CreateReply 指定したメッセージのスレッドに返信メッセージを作成します
+	//
+	// 成功した場合、メッセージとnilを返します。
+	// 返信への返信は、スレッドの大本の親メッセージへの返信として作成されます。
+	// アーカイブされているチャンネルのメッセージを指定すると、ErrChannelArchivedを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	CreateReply(parentID, userID uuid.UUID, content string) (Message, error)
 	// Edit 指定したメッセージを編集します
 	//
 	// 成功した場合、nilを返します。
@@ -77,6 +91,23 @@ type Manager interface {
 	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
 	// DBによるエラーを返すことがあります。
 	Delete(id uuid.UUID) error
+	// Move 指定したチャンネルのメッセージを別のチャンネルに移動します
+	//
+	// 成功した場合、nilを返します。
+	// スレッドの親メッセージを指定した場合、スレッドの返信メッセージも移動します。
+	// 移動元・移動先が公開チャンネルでない場合、或いは移動元チャンネル以外のメッセージを指定した場合、ErrInvalidChannelを返します。
+	// 親メッセージを指定せずにスレッドの返信メッセージを指定した場合、ErrReplyMessageを返します。
+	// アーカイブされているチャンネルを移動先に指定すると、ErrChannelArchivedを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	Move(ids []uuid.UUID, fromChannelID, toChannelID, userID uuid.UUID) error
+	// MoveAll 指定したチャンネルの全てのメッセージを別のチャンネルに移動します
+	//
+	// 成功した場合、nilを返します。
+	// 移動元・移動先が公開チャンネルでない場合、ErrInvalidChannelを返します。
+	// アーカイブされているチャンネルを移動先に指定すると、ErrChannelArchivedを返します。
+	// DBによるエラーを返すことがあります。
+	MoveAll(fromChannelID, toChannelID, userID uuid.UUID) error
 	// Pin 指定したユーザーによって指定したメッセージをピン留めします
 	//
 	// 成功した場合は、ピンとnilを返します。
@@ -108,6 +139,14 @@ type Manager interface {
 	// 存在しないメッセージを指定した場合は、ErrNotFoundを返します。
 	// DBによるエラーを返すことがあります。
 	RemoveStamps(id, stampID, userID uuid.UUID) error
+	// SetComponents 指定したメッセージのコンポーネントを置き換えます
+	//
+	// 成功した場合、nilを返します。
+	// 空配列を指定した場合、コンポーネントを全て削除します。
+	// アーカイブされているチャンネルを指定すると、ErrChannelArchivedを返します。
+	// 存在しないメッセージを指定した場合は、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	SetComponents(id uuid.UUID, components model.MessageComponentList) error
 
 	Wait(ctx context.Context) error
 }
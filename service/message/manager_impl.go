@@ -7,14 +7,17 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	jsonIter "github.com/json-iterator/go"
 	"github.com/motoki317/sc"
 	"go.uber.org/zap"
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/relay"
 	"github.com/traPtitech/traQ/utils"
 	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/set"
 )
 
 const (
@@ -24,20 +27,25 @@ const (
 
 const PinLimit = 100 // ピン留めの上限数
 
+// relayTopic メッセージキャッシュの破棄を他ノードに通知するトピック
+const relayTopic = "message.cache_forget"
+
 type manager struct {
-	CM channel.Manager
-	R  repository.Repository
-	L  *zap.Logger
-	P  sync.WaitGroup
+	CM    channel.Manager
+	R     repository.Repository
+	L     *zap.Logger
+	P     sync.WaitGroup
+	relay relay.Relay
 
 	cache *sc.Cache[uuid.UUID, *message]
 }
 
-func NewMessageManager(repo repository.Repository, cm channel.Manager, logger *zap.Logger) (Manager, error) {
-	return &manager{
-		CM: cm,
-		R:  repo,
-		L:  logger.Named("message_manager"),
+func NewMessageManager(repo repository.Repository, cm channel.Manager, r relay.Relay, logger *zap.Logger) (Manager, error) {
+	m := &manager{
+		CM:    cm,
+		R:     repo,
+		L:     logger.Named("message_manager"),
+		relay: r,
 		cache: sc.NewMust(func(_ context.Context, key uuid.UUID) (*message, error) {
 			m, err := repo.GetMessageByID(key)
 			if err != nil {
@@ -48,7 +56,33 @@ func NewMessageManager(repo repository.Repository, cm channel.Manager, logger *z
 			}
 			return &message{Model: m}, nil
 		}, cacheTTL, cacheTTL*2, sc.With2QBackend(cacheSize)),
-	}, nil
+	}
+	r.Subscribe(relayTopic, m.handleRelayedForget)
+	return m, nil
+}
+
+// forget メッセージのキャッシュを破棄し、他ノードにも破棄させます
+func (m *manager) forget(ids ...uuid.UUID) {
+	if len(ids) == 0 {
+		return
+	}
+	for _, id := range ids {
+		m.cache.Forget(id)
+	}
+	if err := m.relay.Publish(relayTopic, ids); err != nil {
+		m.L.Error("failed to publish message cache forget", zap.Error(err))
+	}
+}
+
+func (m *manager) handleRelayedForget(nodeID string, body []byte) {
+	var ids []uuid.UUID
+	if err := jsonIter.ConfigFastest.Unmarshal(body, &ids); err != nil {
+		m.L.Error("failed to decode relayed message cache forget", zap.Error(err), zap.String("nodeId", nodeID))
+		return
+	}
+	for _, id := range ids {
+		m.cache.Forget(id)
+	}
 }
 
 func (m *manager) Get(id uuid.UUID) (Message, error) {
@@ -79,7 +113,9 @@ func (m *manager) GetTimeline(query TimelineQuery) (Timeline, error) {
 	q := repository.MessagesQuery{
 		User:                     query.User,
 		Channel:                  query.Channel,
+		IncludeReplies:           query.IncludeReplies,
 		ChannelsSubscribedByUser: query.ChannelsSubscribedByUser,
+		Parent:                   query.Parent,
 		Since:                    query.Since,
 		Until:                    query.Until,
 		Inclusive:                query.Inclusive,
@@ -123,6 +159,31 @@ func (m *manager) Create(channelID, userID uuid.UUID, content string) (Message,
 	return m.create(channelID, userID, content)
 }
 
+func (m *manager) CreateReply(parentID, userID uuid.UUID, content string) (Message, error) {
+	// 親メッセージ取得
+	parent, err := m.get(parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// チャンネルがアーカイブされているかどうか確認
+	if m.CM.IsPublicChannel(parent.GetChannelID()) && m.CM.PublicChannelTree().IsArchivedChannel(parent.GetChannelID()) {
+		return nil, ErrChannelArchived
+	}
+
+	// 作成
+	msg, err := m.R.CreateReplyMessage(userID, parentID, content)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return nil, ErrNotFound
+		default:
+			return nil, fmt.Errorf("failed to CreateReplyMessage: %w", err)
+		}
+	}
+	return &message{Model: msg}, nil
+}
+
 func (m *manager) create(channelID, userID uuid.UUID, content string) (Message, error) {
 	// 作成
 	msg, err := m.R.CreateMessage(userID, channelID, content)
@@ -153,7 +214,7 @@ func (m *manager) Edit(id uuid.UUID, content string) error {
 			return fmt.Errorf("failed to UpdateMessage: %w", err)
 		}
 	}
-	m.cache.Forget(id)
+	m.forget(id)
 
 	return nil
 }
@@ -179,8 +240,73 @@ func (m *manager) Delete(id uuid.UUID) error {
 			return fmt.Errorf("failed to DeleteMessage: %w", err)
 		}
 	}
-	m.cache.Forget(id)
+	m.forget(id)
+
+	return nil
+}
+
+func (m *manager) Move(ids []uuid.UUID, fromChannelID, toChannelID, userID uuid.UUID) error {
+	idSet := set.UUIDSetFromArray(ids)
+	if len(idSet) == 0 {
+		return nil
+	}
+
+	// メッセージ取得
+	msgs, err := m.GetIn(idSet.Array())
+	if err != nil {
+		return fmt.Errorf("failed to GetMessages: %w", err)
+	}
+	if len(msgs) != len(idSet) {
+		return ErrNotFound
+	}
+
+	for _, msg := range msgs {
+		if msg.GetChannelID() != fromChannelID {
+			return ErrInvalidChannel
+		}
+		// スレッドを分割しない
+		if parentID := msg.GetParentMessageID(); parentID.Valid && !idSet.Contains(parentID.V) {
+			return ErrReplyMessage
+		}
+	}
+
+	return m.move(fromChannelID, toChannelID, userID, optional.From(idSet.Array()))
+}
 
+func (m *manager) MoveAll(fromChannelID, toChannelID, userID uuid.UUID) error {
+	return m.move(fromChannelID, toChannelID, userID, optional.Of[[]uuid.UUID]{})
+}
+
+func (m *manager) move(from, to, userID uuid.UUID, ids optional.Of[[]uuid.UUID]) error {
+	// 移動元・移動先は公開チャンネルのみ
+	if from == to || !m.CM.IsPublicChannel(from) || !m.CM.IsPublicChannel(to) {
+		return ErrInvalidChannel
+	}
+	// 移動先のチャンネルがアーカイブされているかどうか確認
+	if m.CM.PublicChannelTree().IsArchivedChannel(to) {
+		return ErrChannelArchived
+	}
+
+	// 移動
+	moved, err := m.R.MoveMessages(from, to, ids)
+	if err != nil {
+		return fmt.Errorf("failed to MoveMessages: %w", err)
+	}
+	m.forget(moved...)
+	if len(moved) == 0 {
+		return nil
+	}
+
+	// ロギング
+	detail := model.ChannelEventDetail{
+		"userId": userID,
+		"from":   from,
+		"to":     to,
+		"count":  len(moved),
+	}
+	now := time.Now()
+	m.recordChannelEvent(from, model.ChannelEventMessagesMoved, detail, now)
+	m.recordChannelEvent(to, model.ChannelEventMessagesMoved, detail, now)
 	return nil
 }
 
@@ -222,7 +348,7 @@ func (m *manager) Pin(id uuid.UUID, userID uuid.UUID) (*model.Pin, error) {
 			return nil, fmt.Errorf("failed to PinMessage: %w", err)
 		}
 	}
-	m.cache.Forget(id)
+	m.forget(id)
 
 	// ロギング
 	m.recordChannelEvent(pin.Message.ChannelID, model.ChannelEventPinAdded, model.ChannelEventDetail{
@@ -259,7 +385,7 @@ func (m *manager) Unpin(id uuid.UUID, userID uuid.UUID) error {
 			return fmt.Errorf("failed to UnpinMessage: %w", err)
 		}
 	}
-	m.cache.Forget(id)
+	m.forget(id)
 
 	// ロギング
 	m.recordChannelEvent(pin.Message.ChannelID, model.ChannelEventPinRemoved, model.ChannelEventDetail{
@@ -288,7 +414,7 @@ func (m *manager) AddStamps(id, stampID, userID uuid.UUID, n int) (*model.Messag
 	}
 
 	// キャッシュ削除
-	m.cache.Forget(id)
+	m.forget(id)
 
 	return ms, nil
 }
@@ -311,7 +437,35 @@ func (m *manager) RemoveStamps(id, stampID, userID uuid.UUID) error {
 	}
 
 	// キャッシュ削除
-	m.cache.Forget(id)
+	m.forget(id)
+
+	return nil
+}
+
+func (m *manager) SetComponents(id uuid.UUID, components model.MessageComponentList) error {
+	// メッセージ取得
+	msg, err := m.get(id)
+	if err != nil {
+		return err
+	}
+
+	// チャンネルがアーカイブされているかどうか確認
+	if m.CM.IsPublicChannel(msg.GetChannelID()) && m.CM.PublicChannelTree().IsArchivedChannel(msg.GetChannelID()) {
+		return ErrChannelArchived
+	}
+
+	// 更新
+	if err := m.R.SetMessageComponents(id, components); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return ErrNotFound
+		default:
+			return fmt.Errorf("failed to SetMessageComponents: %w", err)
+		}
+	}
+
+	// キャッシュ削除
+	m.forget(id)
 
 	return nil
 }
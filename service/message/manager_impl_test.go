@@ -1,17 +1,22 @@
 package message
 
 import (
+	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/service/relay"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 func setupM(ctrl *gomock.Controller) (Manager, *mock_channel.MockManager, *Repo, *mock_channel.MockTree) {
@@ -19,7 +24,7 @@ func setupM(ctrl *gomock.Controller) (Manager, *mock_channel.MockManager, *Repo,
 	tree := mock_channel.NewMockTree(ctrl)
 	cm.EXPECT().PublicChannelTree().Return(tree).AnyTimes()
 	repo := NewMockRepo(ctrl)
-	m, _ := NewMessageManager(repo, cm, zap.NewNop())
+	m, _ := NewMessageManager(repo, cm, relay.NewNullRelay(), zap.NewNop())
 	return m, cm, repo, tree
 }
 
@@ -214,6 +219,113 @@ func TestManager_CreateDM(t *testing.T) {
 	})
 }
 
+func TestManager_CreateReply(t *testing.T) {
+	t.Parallel()
+	const content = "content"
+
+	t.Run("parent not found", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		pid := uuid.NewV3(uuid.Nil, "m1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(pid).
+			Return(nil, repository.ErrNotFound).
+			Times(1)
+
+		_, err := m.CreateReply(pid, uuid.NewV3(uuid.Nil, "u1"), content)
+		assert.EqualError(t, err, ErrNotFound.Error())
+	})
+
+	t.Run("channel archived", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		pid := uuid.NewV3(uuid.Nil, "m1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(pid).
+			Return(&model.Message{ID: pid, UserID: uuid.NewV3(uuid.Nil, "u2"), ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(true).Times(1)
+
+		_, err := m.CreateReply(pid, uuid.NewV3(uuid.Nil, "u1"), content)
+		assert.EqualError(t, err, ErrChannelArchived.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		uid := uuid.NewV3(uuid.Nil, "u1")
+		pid := uuid.NewV3(uuid.Nil, "m1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(pid).
+			Return(&model.Message{ID: pid, UserID: uuid.NewV3(uuid.Nil, "u2"), ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			CreateReplyMessage(uid, pid, content).
+			Return(&model.Message{ID: uuid.NewV3(uuid.Nil, "m2"), UserID: uid, ChannelID: cid, Text: content, ParentMessageID: optional.From(pid)}, nil).
+			Times(1)
+
+		msg, err := m.CreateReply(pid, uid, content)
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, cid, msg.GetChannelID())
+			assert.EqualValues(t, uid, msg.GetUserID())
+			assert.EqualValues(t, content, msg.GetText())
+			assert.EqualValues(t, optional.From(pid), msg.GetParentMessageID())
+		}
+	})
+}
+
+func TestManager_RelayForget(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	net := relay.NewMemoryNetwork()
+	r1 := net.Join(zap.NewNop())
+	defer r1.Close()
+	r2 := net.Join(zap.NewNop())
+	defer r2.Close()
+
+	cm := mock_channel.NewMockManager(ctrl)
+	repo := NewMockRepo(ctrl)
+	m1, _ := NewMessageManager(repo, cm, r1, zap.NewNop())
+	m2, _ := NewMessageManager(repo, cm, r2, zap.NewNop())
+
+	msg := &model.Message{ID: uuid.Must(uuid.NewV4()), Text: "test"}
+	var loaded atomic.Int32
+	repo.MockMessageRepository.
+		EXPECT().
+		GetMessageByID(msg.ID).
+		DoAndReturn(func(uuid.UUID) (*model.Message, error) {
+			loaded.Add(1)
+			return msg, nil
+		}).
+		Times(2)
+
+	_, err := m2.Get(msg.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, loaded.Load())
+
+	// 他ノードでのキャッシュ破棄が伝播し、次の取得でDBから読み込み直す
+	m1.(*manager).forget(msg.ID)
+	assert.Eventually(t, func() bool {
+		_, err := m2.Get(msg.ID)
+		return err == nil && loaded.Load() == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
 func TestManager_Edit(t *testing.T) {
 	t.Parallel()
 	const newContent = "new message"
@@ -555,3 +667,236 @@ func TestManager_RemoveStamps(t *testing.T) {
 		}
 	})
 }
+
+func TestManager_Move(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from = uuid.NewV3(uuid.Nil, "c1")
+		to   = uuid.NewV3(uuid.Nil, "c2")
+		m1   = uuid.NewV3(uuid.Nil, "m1")
+		m2   = uuid.NewV3(uuid.Nil, "m2")
+		user = uuid.NewV3(uuid.Nil, "u1")
+	)
+
+	t.Run("message not found", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessages(gomock.Any()).
+			Return([]*model.Message{{ID: m1, ChannelID: from}}, false, nil).
+			Times(1)
+
+		err := m.Move([]uuid.UUID{m1, m2}, from, to, user)
+		assert.EqualError(t, err, ErrNotFound.Error())
+	})
+
+	t.Run("message in another channel", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessages(gomock.Any()).
+			Return([]*model.Message{{ID: m1, ChannelID: from}, {ID: m2, ChannelID: to}}, false, nil).
+			Times(1)
+
+		err := m.Move([]uuid.UUID{m1, m2}, from, to, user)
+		assert.EqualError(t, err, ErrInvalidChannel.Error())
+	})
+
+	t.Run("reply without parent", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessages(gomock.Any()).
+			Return([]*model.Message{{ID: m2, ChannelID: from, ParentMessageID: optional.From(m1)}}, false, nil).
+			Times(1)
+
+		err := m.Move([]uuid.UUID{m2}, from, to, user)
+		assert.EqualError(t, err, ErrReplyMessage.Error())
+	})
+
+	t.Run("same channel", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessages(gomock.Any()).
+			Return([]*model.Message{{ID: m1, ChannelID: from}}, false, nil).
+			Times(1)
+
+		err := m.Move([]uuid.UUID{m1}, from, from, user)
+		assert.EqualError(t, err, ErrInvalidChannel.Error())
+	})
+
+	t.Run("destination archived", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessages(gomock.Any()).
+			Return([]*model.Message{{ID: m1, ChannelID: from}}, false, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(gomock.Any()).Return(true).AnyTimes()
+		tree.EXPECT().IsArchivedChannel(to).Return(true).Times(1)
+
+		err := m.Move([]uuid.UUID{m1}, from, to, user)
+		assert.EqualError(t, err, ErrChannelArchived.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessages(gomock.Any()).
+			Return([]*model.Message{{ID: m1, ChannelID: from}, {ID: m2, ChannelID: from, ParentMessageID: optional.From(m1)}}, false, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(gomock.Any()).Return(true).AnyTimes()
+		tree.EXPECT().IsArchivedChannel(to).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			MoveMessages(from, to, gomock.Any()).
+			Return([]uuid.UUID{m1, m2}, nil).
+			Times(1)
+		detail := model.ChannelEventDetail{"userId": user, "from": from, "to": to, "count": 2}
+		repo.MockChannelRepository.
+			EXPECT().
+			RecordChannelEvent(from, model.ChannelEventMessagesMoved, detail, gomock.Any()).
+			Return(nil).
+			Times(1)
+		repo.MockChannelRepository.
+			EXPECT().
+			RecordChannelEvent(to, model.ChannelEventMessagesMoved, detail, gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		err := m.Move([]uuid.UUID{m1, m2}, from, to, user)
+		if assert.NoError(t, err) {
+			assert.NoError(t, m.Wait(context.Background()))
+		}
+	})
+}
+
+func TestManager_MoveAll(t *testing.T) {
+	t.Parallel()
+
+	var (
+		from = uuid.NewV3(uuid.Nil, "c1")
+		to   = uuid.NewV3(uuid.Nil, "c2")
+		user = uuid.NewV3(uuid.Nil, "u1")
+	)
+
+	t.Run("not public channel", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, _, _ := setupM(ctrl)
+
+		cm.EXPECT().IsPublicChannel(from).Return(true).AnyTimes()
+		cm.EXPECT().IsPublicChannel(to).Return(false).AnyTimes()
+
+		err := m.MoveAll(from, to, user)
+		assert.EqualError(t, err, ErrInvalidChannel.Error())
+	})
+
+	t.Run("no messages", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		cm.EXPECT().IsPublicChannel(gomock.Any()).Return(true).AnyTimes()
+		tree.EXPECT().IsArchivedChannel(to).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			MoveMessages(from, to, optional.Of[[]uuid.UUID]{}).
+			Return([]uuid.UUID{}, nil).
+			Times(1)
+
+		err := m.MoveAll(from, to, user)
+		assert.NoError(t, err)
+	})
+}
+
+func TestManager_SetComponents(t *testing.T) {
+	t.Parallel()
+	components := model.MessageComponentList{
+		{Type: model.MessageComponentTypeButton, CustomID: "ok", Label: "OK"},
+	}
+
+	t.Run("message not found", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		id := uuid.NewV3(uuid.Nil, "m1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(id).
+			Return(nil, repository.ErrNotFound).
+			Times(1)
+
+		err := m.SetComponents(id, components)
+		assert.EqualError(t, err, ErrNotFound.Error())
+	})
+
+	t.Run("channel archived", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		id := uuid.NewV3(uuid.Nil, "m1")
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(id).
+			Return(&model.Message{ID: id, ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(true).Times(1)
+
+		err := m.SetComponents(id, components)
+		assert.EqualError(t, err, ErrChannelArchived.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		id := uuid.NewV3(uuid.Nil, "m1")
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(id).
+			Return(&model.Message{ID: id, ChannelID: cid}, nil).
+			Times(2)
+		cm.EXPECT().IsPublicChannel(cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			SetMessageComponents(id, components).
+			Return(nil).
+			Times(1)
+
+		err := m.SetComponents(id, components)
+		assert.NoError(t, err)
+
+		// キャッシュが破棄されている
+		_, err = m.Get(id)
+		assert.NoError(t, err)
+	})
+}
@@ -124,29 +124,50 @@ func (m *message) GetPin() *model.Pin {
 	return m.Model.Pin
 }
 
+func (m *message) GetComponents() model.MessageComponentList {
+	m.RLock()
+	defer m.RUnlock()
+	if m.Model.Components == nil {
+		return model.MessageComponentList{}
+	}
+	return m.Model.Components.Components
+}
+
+func (m *message) GetParentMessageID() optional.Of[uuid.UUID] {
+	m.RLock()
+	defer m.RUnlock()
+	return m.Model.ParentMessageID
+}
+
 func (m *message) MarshalJSON() ([]byte, error) {
 	type obj struct {
-		ID        uuid.UUID              `json:"id"`
-		UserID    uuid.UUID              `json:"userId"`
-		ChannelID uuid.UUID              `json:"channelId"`
-		Content   string                 `json:"content"`
-		CreatedAt time.Time              `json:"createdAt"`
-		UpdatedAt time.Time              `json:"updatedAt"`
-		Pinned    bool                   `json:"pinned"`
-		Stamps    []model.MessageStamp   `json:"stamps"`
-		ThreadID  optional.Of[uuid.UUID] `json:"threadId"` // TODO
+		ID         uuid.UUID                  `json:"id"`
+		UserID     uuid.UUID                  `json:"userId"`
+		ChannelID  uuid.UUID                  `json:"channelId"`
+		Content    string                     `json:"content"`
+		CreatedAt  time.Time                  `json:"createdAt"`
+		UpdatedAt  time.Time                  `json:"updatedAt"`
+		Pinned     bool                       `json:"pinned"`
+		Stamps     []model.MessageStamp       `json:"stamps"`
+		ThreadID   optional.Of[uuid.UUID]     `json:"threadId"`
+		Edited     bool                       `json:"edited"`
+		Components model.MessageComponentList `json:"components"`
 	}
 	stamps := m.GetStamps()
+	components := m.GetComponents()
 	m.RLock()
 	v := &obj{
-		ID:        m.Model.ID,
-		UserID:    m.Model.UserID,
-		ChannelID: m.Model.ChannelID,
-		Content:   m.Model.Text,
-		CreatedAt: m.Model.CreatedAt,
-		UpdatedAt: m.Model.UpdatedAt,
-		Pinned:    m.Model.Pin != nil,
-		Stamps:    stamps,
+		ID:         m.Model.ID,
+		UserID:     m.Model.UserID,
+		ChannelID:  m.Model.ChannelID,
+		Content:    m.Model.Text,
+		CreatedAt:  m.Model.CreatedAt,
+		UpdatedAt:  m.Model.UpdatedAt,
+		Pinned:     m.Model.Pin != nil,
+		Stamps:     stamps,
+		ThreadID:   m.Model.ParentMessageID,
+		Edited:     m.Model.IsEdited(),
+		Components: components,
 	}
 	m.RUnlock()
 	return jsonIter.ConfigFastest.Marshal(v)
@@ -76,6 +76,17 @@ func (m *timelineMessage) GetPin() *model.Pin {
 	return m.Model.Pin
 }
 
+func (m *timelineMessage) GetComponents() model.MessageComponentList {
+	if m.Model.Components == nil {
+		return model.MessageComponentList{}
+	}
+	return m.Model.Components.Components
+}
+
+func (m *timelineMessage) GetParentMessageID() optional.Of[uuid.UUID] {
+	return m.Model.ParentMessageID
+}
+
 func (m *timelineMessage) MarshalJSON() ([]byte, error) {
 	type object struct {
 		ID        uuid.UUID `json:"id"`
@@ -87,9 +98,11 @@ func (m *timelineMessage) MarshalJSON() ([]byte, error) {
 	}
 	type objectWithPreload struct {
 		object
-		Pinned   bool                   `json:"pinned"`
-		Stamps   []model.MessageStamp   `json:"stamps"`
-		ThreadID optional.Of[uuid.UUID] `json:"threadId"` // TODO
+		Pinned     bool                       `json:"pinned"`
+		Stamps     []model.MessageStamp       `json:"stamps"`
+		ThreadID   optional.Of[uuid.UUID]     `json:"threadId"`
+		ReplyCount int                        `json:"replyCount"`
+		Components model.MessageComponentList `json:"components"`
 	}
 	var v interface{}
 	if m.preloaded {
@@ -102,8 +115,11 @@ func (m *timelineMessage) MarshalJSON() ([]byte, error) {
 				CreatedAt: m.Model.CreatedAt,
 				UpdatedAt: m.Model.UpdatedAt,
 			},
-			Pinned: m.Model.Pin != nil,
-			Stamps: m.Model.Stamps,
+			Pinned:     m.Model.Pin != nil,
+			Stamps:     m.Model.Stamps,
+			ThreadID:   m.Model.ParentMessageID,
+			ReplyCount: m.Model.ReplyCount,
+			Components: m.GetComponents(),
 		}
 	} else {
 		v = &object{
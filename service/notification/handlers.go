@@ -15,6 +15,7 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/fcm"
+	"github.com/traPtitech/traQ/service/rbac/permission"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/ws"
 	"github.com/traPtitech/traQ/utils/message"
@@ -26,11 +27,16 @@ type eventHandler func(ns *Service, ev hub.Message)
 var handlerMap = map[string]eventHandler{
 	event.MessageCreated:            messageCreatedHandler,
 	event.MessageUpdated:            messageUpdatedHandler,
+	event.MessageComponentsUpdated:  messageUpdatedHandler,
 	event.MessageDeleted:            messageDeletedHandler,
 	event.MessagePinned:             messagePinnedHandler,
 	event.MessageUnpinned:           messageUnpinnedHandler,
 	event.MessageStamped:            messageStampedHandler,
 	event.MessageUnstamped:          messageUnstampedHandler,
+	event.MessageReplied:            messageRepliedHandler,
+	event.MessagesMoved:             messagesMovedHandler,
+	event.MessageReported:           messageReportedHandler,
+	event.ScheduledMessageFailed:    scheduledMessageFailedHandler,
 	event.ChannelCreated:            channelCreatedHandler,
 	event.ChannelUpdated:            channelUpdatedHandler,
 	event.ChannelDeleted:            channelDeletedHandler,
@@ -39,6 +45,7 @@ var handlerMap = map[string]eventHandler{
 	event.ChannelRead:               channelReadHandler,
 	event.ChannelViewersChanged:     channelViewersChangedHandler,
 	event.ChannelSubscribersChanged: channelSubscribersChangedHandler,
+	event.ChannelMembersChanged:     channelMembersChangedHandler,
 	event.UserCreated:               userCreatedHandler,
 	event.UserUpdated:               userUpdatedHandler,
 	event.UserIconUpdated:           userIconUpdatedHandler,
@@ -116,6 +123,12 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 		fcmPayload.Title = "#" + path
 		fcmPayload.Path = "/channels/" + path
 		fcmPayload.SetBodyWithEllipsis(mUser.GetResponseDisplayName() + ": " + parsed.NotificationText())
+	} else if ch, err := ns.cm.GetChannel(chID); err == nil && ch.IsPrivateChannel() {
+		// プライベートチャンネル
+		fcmPayload.Title = "#" + ch.Name
+		// プライベートチャンネルは公開チャンネルツリーに含まれず名前では遷移できないため、メッセージのIDで遷移先を指定する
+		fcmPayload.Path = "/messages/" + m.ID.String()
+		fcmPayload.SetBodyWithEllipsis(mUser.GetResponseDisplayName() + ": " + parsed.NotificationText())
 	} else {
 		// DM
 		fcmPayload.Title = "@" + mUser.GetResponseDisplayName()
@@ -193,9 +206,34 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 				logger.Error("failed to GetUserGroupMemberIDs", zap.Error(err), zap.Stringer("groupId", gid)) // 失敗
 				return
 			}
-			notifiedUsers.Add(gs...)
-			markedUsers.Add(gs...)
-			noticeable.Add(gs...)
+			muted, err := ns.repo.GetGroupMentionMutedUserIDs(gid)
+			if err != nil {
+				logger.Error("failed to GetGroupMentionMutedUserIDs", zap.Error(err), zap.Stringer("groupId", gid)) // 失敗
+				return
+			}
+			// グループメンションをミュートしているユーザーの除外
+			members := set.UUIDSetFromArray(gs)
+			members.Remove(muted...)
+			notifiedUsers.Plus(members)
+			markedUsers.Plus(members)
+			noticeable.Plus(members)
+		}
+		// 通知キーワードを登録しているユーザーへの通知
+		keywordUsers, err := ns.repo.GetUserIDsByNotificationKeyword(parsed.PlainText)
+		if err != nil {
+			logger.Error("failed to GetUserIDsByNotificationKeyword", zap.Error(err)) // 失敗
+			return
+		}
+		if len(keywordUsers) > 0 {
+			// 凍結ユーザー / Botの除外
+			keywordUsers, err = ns.repo.GetUserIDs(q.IDIn(keywordUsers))
+			if err != nil {
+				logger.Error("failed to GetUserIDs", zap.Error(err)) // 失敗
+				return
+			}
+			notifiedUsers.Add(keywordUsers...)
+			markedUsers.Add(keywordUsers...)
+			noticeable.Add(keywordUsers...)
 		}
 		// メッセージを引用されたユーザーへの通知
 		for _, mid := range parsed.Citation {
@@ -230,6 +268,15 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 				notifiedUsers.Add(uid)
 			}
 		}
+		// スレッド参加者への通知
+		if m.ParentMessageID.Valid {
+			participants, err := getThreadParticipants(ns, m.ParentMessageID.V)
+			if err != nil {
+				logger.Error("failed to getThreadParticipants", zap.Error(err), zap.Stringer("parentMessageId", m.ParentMessageID.V)) // 失敗
+				return
+			}
+			notifiedUsers.Plus(participants)
+		}
 	}
 
 	// チャンネル閲覧者取得
@@ -272,9 +319,118 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 	// FCM送信
 	targets := notifiedUsers.Clone()
 	targets.Remove(m.UserID)
+	if !forceNotify && !isDM {
+		// プッシュ通知をミュートしているユーザーの除外 (直接メンションされたユーザーを除く)
+		muted, err := ns.repo.GetChannelPushMutedUserIDs(chID)
+		if err != nil {
+			logger.Error("failed to GetChannelPushMutedUserIDs", zap.Error(err), zap.Stringer("channelId", chID)) // 失敗
+			return
+		}
+		mentioned := set.UUIDSetFromArray(parsed.Mentions)
+		for _, uid := range muted {
+			if !mentioned.Contains(uid) {
+				targets.Remove(uid)
+			}
+		}
+	}
+	// おやすみモード中のユーザーの除外
+	dnd, err := ns.repo.GetDoNotDisturbEnabledSettings(targets.Array())
+	if err != nil {
+		logger.Error("failed to GetDoNotDisturbEnabledSettings", zap.Error(err)) // 失敗
+		return
+	}
+	now := time.Now()
+	for _, us := range dnd {
+		if us.IsDoNotDisturb(now) {
+			targets.Remove(us.UserID)
+		}
+	}
 	ns.fcm.Send(targets, fcmPayload, true)
 }
 
+func messageRepliedHandler(ns *Service, ev hub.Message) {
+	m := ev.Fields["message"].(*model.Message)
+	parentID := ev.Fields["parent_message_id"].(uuid.UUID)
+	logger := ns.logger.With(zap.Stringer("messageId", m.ID), zap.Stringer("parentMessageId", parentID))
+
+	participants, err := getThreadParticipants(ns, parentID)
+	if err != nil {
+		logger.Error("failed to getThreadParticipants", zap.Error(err)) // 失敗
+		return
+	}
+
+	// WS送信
+	// FCMはMESSAGE_CREATEDでスレッド参加者を含めて送信済み
+	go ns.ws.WriteMessage("MESSAGE_REPLIED", map[string]interface{}{
+		"id":        m.ID,
+		"parent_id": parentID,
+	}, ws.Or(
+		ws.TargetUserSets(participants),
+		ws.TargetChannelViewers(m.ChannelID),
+	))
+}
+
+// maxThreadParticipants 通知対象とするスレッド参加者の最大数
+const maxThreadParticipants = 500
+
+// getThreadParticipants スレッド参加者(親メッセージ投稿者・返信投稿者)のうち、凍結ユーザーとBotを除いたものを取得します
+func getThreadParticipants(ns *Service, parentID uuid.UUID) (set.UUID, error) {
+	parent, err := ns.repo.GetMessageByID(parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to GetMessageByID: %w", err)
+	}
+	ids, err := ns.repo.GetThreadParticipantIDs(parentID, maxThreadParticipants)
+	if err != nil {
+		return nil, fmt.Errorf("failed to GetThreadParticipantIDs: %w", err)
+	}
+
+	participants := set.UUIDSetFromArray(ids)
+	participants.Add(parent.UserID)
+	for uid := range participants {
+		user, err := ns.repo.GetUser(uid, false)
+		if err != nil {
+			ns.logger.Error("failed to GetUser", zap.Error(err), zap.Stringer("userId", uid)) // 失敗
+			participants.Remove(uid)
+			continue
+		}
+		// 凍結ユーザー / Botの除外
+		if !user.IsActive() || user.IsBot() {
+			participants.Remove(uid)
+		}
+	}
+	return participants, nil
+}
+
+func messageReportedHandler(ns *Service, ev hub.Message) {
+	reportID := ev.Fields["report_id"].(uuid.UUID)
+
+	// 通報を対応できるユーザーに通知
+	users, err := ns.repo.GetUsers(repository.UsersQuery{}.Active().NotBot())
+	if err != nil {
+		ns.logger.Error("failed to GetUsers", zap.Error(err), zap.Stringer("reportId", reportID)) // 失敗
+		return
+	}
+	moderators := set.UUID{}
+	for _, u := range users {
+		if ns.rbac.IsGranted(u.GetRole(), permission.HandleMessageReports) {
+			moderators.Add(u.GetID())
+		}
+	}
+
+	go ns.ws.WriteMessage("MESSAGE_REPORTED", map[string]interface{}{
+		"id":         reportID,
+		"message_id": ev.Fields["message_id"].(uuid.UUID),
+	}, ws.TargetUserSets(moderators))
+}
+
+func scheduledMessageFailedHandler(ns *Service, ev hub.Message) {
+	sm := ev.Fields["scheduled_message"].(*model.ScheduledMessage)
+	go ns.ws.WriteMessage("SCHEDULED_MESSAGE_FAILED", map[string]interface{}{
+		"id":     sm.ID,
+		"reason": sm.Error,
+	}, ws.TargetUsers(sm.UserID))
+}
+
 func messageUpdatedHandler(ns *Service, ev hub.Message) {
 	cid := ev.Fields["message"].(*model.Message).ChannelID
 	wsEventType := "MESSAGE_UPDATED"
@@ -319,6 +475,18 @@ func messageDeletedHandler(ns *Service, ev hub.Message) {
 	go ns.ws.WriteMessage(wsEventType, wsPayload, targetFunc)
 }
 
+func messagesMovedHandler(ns *Service, ev hub.Message) {
+	// 移動は公開チャンネル間でのみ行われる
+	broadcast(ns,
+		"MESSAGES_MOVED",
+		map[string]interface{}{
+			"ids":  ev.Fields["message_ids"].([]uuid.UUID),
+			"from": ev.Fields["from_channel_id"].(uuid.UUID),
+			"to":   ev.Fields["to_channel_id"].(uuid.UUID),
+		},
+	)
+}
+
 func messagePinnedHandler(ns *Service, ev hub.Message) {
 	channelViewerMulticast(ns, ev.Fields["channel_id"].(uuid.UUID),
 		"MESSAGE_PINNED",
@@ -425,6 +593,25 @@ func channelSubscribersChangedHandler(ns *Service, ev hub.Message) {
 	)
 }
 
+func channelMembersChangedHandler(ns *Service, ev hub.Message) {
+	cid := ev.Fields["channel_id"].(uuid.UUID)
+	members, err := ns.cm.GetPrivateChannelMembers(cid)
+	if err != nil {
+		ns.logger.Error("failed to GetPrivateChannelMembers", zap.Error(err), zap.Stringer("channelId", cid))
+		return
+	}
+	// 削除されたユーザーにも通知する
+	targets := set.UUIDSetFromArray(members)
+	targets.Add(ev.Fields["removed_ids"].([]uuid.UUID)...)
+	go ns.ws.WriteMessage(
+		"CHANNEL_MEMBERS_CHANGED",
+		map[string]interface{}{
+			"id": cid,
+		},
+		ws.TargetUserSets(targets),
+	)
+}
+
 func userCreatedHandler(ns *Service, ev hub.Message) {
 	broadcast(ns,
 		"USER_JOINED",
@@ -662,6 +849,18 @@ func channelHandler(ns *Service, ev hub.Message, eventType string) {
 			return
 		}
 
+		ch, ok := ev.Fields["channel"].(*model.Channel)
+		if !ok {
+			ch, _ = ns.cm.GetChannel(cid)
+		}
+		if ch != nil && ch.IsPrivateChannel() {
+			// DM以外のプライベートチャンネル
+			go ns.ws.WriteMessage(eventType, map[string]interface{}{
+				"id": cid,
+			}, ws.TargetUsers(members...))
+			return
+		}
+
 		switch len(members) {
 		case 1:
 			go ns.ws.WriteMessage(eventType, map[string]interface{}{
@@ -9,6 +9,7 @@ import (
 	"github.com/traPtitech/traQ/service/fcm"
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/rbac"
 	"github.com/traPtitech/traQ/service/variable"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/ws"
@@ -26,10 +27,11 @@ type Service struct {
 	ws     *ws.Streamer
 	vm     *viewer.Manager
 	origin string
+	rbac   rbac.RBAC
 }
 
 // NewService 通知サービスを作成して起動します
-func NewService(repo repository.Repository, cm channel.Manager, mm message.Manager, fm file.Manager, hub *hub.Hub, logger *zap.Logger, fcm fcm.Client, ws *ws.Streamer, vm *viewer.Manager, origin variable.ServerOriginString) *Service {
+func NewService(repo repository.Repository, cm channel.Manager, mm message.Manager, fm file.Manager, hub *hub.Hub, logger *zap.Logger, fcm fcm.Client, ws *ws.Streamer, vm *viewer.Manager, origin variable.ServerOriginString, rbac rbac.RBAC) *Service {
 	service := &Service{
 		repo:   repo,
 		cm:     cm,
@@ -41,6 +43,7 @@ func NewService(repo repository.Repository, cm channel.Manager, mm message.Manag
 		ws:     ws,
 		vm:     vm,
 		origin: string(origin),
+		rbac:   rbac,
 	}
 	go func() {
 		topics := make([]string, 0, len(handlerMap))
@@ -0,0 +1,72 @@
+package outgoingwebhook
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+// MessageCreated MESSAGE_CREATEDイベントペイロード
+type MessageCreated struct {
+	payload.Base
+	Message payload.Message `json:"message"`
+}
+
+// MessageStamped MESSAGE_STAMPEDイベントペイロード
+type MessageStamped struct {
+	payload.Base
+	Message payload.Message `json:"message"`
+	Stamp   Stamp           `json:"stamp"`
+}
+
+// MessagePinned MESSAGE_PINNEDイベントペイロード
+type MessagePinned struct {
+	payload.Base
+	Message payload.Message `json:"message"`
+}
+
+// Stamp メッセージに押されたスタンプ
+type Stamp struct {
+	ID        uuid.UUID    `json:"id"`
+	Name      string       `json:"name"`
+	User      payload.User `json:"user"`
+	Count     int          `json:"count"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
+func makeMessage(m *model.Message, user model.UserInfo) payload.Message {
+	embedded, plain := message.ExtractEmbedding(m.Text)
+	return payload.MakeMessage(m, user, embedded, plain)
+}
+
+func makeMessageCreated(et time.Time, m *model.Message, user model.UserInfo) *MessageCreated {
+	return &MessageCreated{
+		Base:    payload.MakeBase(et),
+		Message: makeMessage(m, user),
+	}
+}
+
+func makeMessageStamped(et time.Time, m *model.Message, user model.UserInfo, stamp *model.Stamp, stampUser model.UserInfo, count int, createdAt time.Time) *MessageStamped {
+	return &MessageStamped{
+		Base:    payload.MakeBase(et),
+		Message: makeMessage(m, user),
+		Stamp: Stamp{
+			ID:        stamp.ID,
+			Name:      stamp.Name,
+			User:      payload.MakeUser(stampUser),
+			Count:     count,
+			CreatedAt: createdAt,
+		},
+	}
+}
+
+func makeMessagePinned(et time.Time, m *model.Message, user model.UserInfo) *MessagePinned {
+	return &MessagePinned{
+		Base:    payload.MakeBase(et),
+		Message: makeMessage(m, user),
+	}
+}
@@ -0,0 +1,143 @@
+package outgoingwebhook
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/hmac"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+const (
+	headerEvent          = "X-TRAQ-WEBHOOK-EVENT"
+	headerDeliveryID     = "X-TRAQ-WEBHOOK-DELIVERY-ID"
+	headerSignature      = "X-TRAQ-WEBHOOK-SIGNATURE"
+	headerUserAgent      = "User-Agent"
+	ua                   = "traQ_Outgoing_Webhook/1.0"
+	signaturePrefix      = "sha256="
+	resultOK             = "ok"
+	resultNG             = "ng"
+	resultNetworkError   = "ne"
+	requestTimeout       = 5 * time.Second
+	defaultMaxAttempts   = 5
+	defaultRetryInterval = 10 * time.Second
+	// deliveryLeaseDuration 再試行の処理権の期限。requestTimeoutより十分長くする
+	deliveryLeaseDuration = time.Minute
+)
+
+// Sign 外部送信Webhookのリクエストボディの署名を計算します
+//
+// X-TRAQ-WEBHOOK-SIGNATUREヘッダーには"sha256="に続けてこの値が設定されます
+func Sign(body []byte, secret string) string {
+	return hex.EncodeToString(hmac.SHA256(body, secret))
+}
+
+// send 外部送信Webhookにリクエストを1回送信します
+//
+// 再試行すべき場合はretryableがtrueになります
+func (s *Service) send(w *model.OutgoingWebhook, ev model.OutgoingWebhookEvent, deliveryID uuid.UUID, attempt int, body []byte) (log *model.OutgoingWebhookDeliveryLog, retryable bool) {
+	log = &model.OutgoingWebhookDeliveryLog{
+		ID:         uuid.Must(uuid.NewV4()),
+		DeliveryID: deliveryID,
+		WebhookID:  w.ID,
+		Event:      ev,
+		Body:       string(body),
+		Attempt:    attempt,
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		log.Result = resultNetworkError
+		log.Error = err.Error()
+		log.Code = -1
+		log.DateTime = time.Now()
+		return log, false
+	}
+	req.Header.Set(headerUserAgent, ua)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+	req.Header.Set(headerEvent, ev.String())
+	req.Header.Set(headerDeliveryID, deliveryID.String())
+	req.Header.Set(headerSignature, signaturePrefix+Sign(body, w.Secret))
+
+	start := time.Now()
+	res, err := s.client.Do(req)
+	log.Latency = time.Since(start).Nanoseconds()
+	log.DateTime = start
+
+	if err != nil {
+		log.Result = resultNetworkError
+		log.Error = err.Error()
+		log.Code = -1
+		return log, true
+	}
+	_ = res.Body.Close()
+
+	log.Code = res.StatusCode
+	if 200 <= res.StatusCode && res.StatusCode < 300 {
+		log.Result = resultOK
+		return log, false
+	}
+	log.Result = resultNG
+	return log, res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests
+}
+
+// deliver 外部送信Webhookにイベントを配送します
+//
+// 再試行すべき失敗の場合は、アウトボックスに保存して後で再試行します
+func (s *Service) deliver(w *model.OutgoingWebhook, ev model.OutgoingWebhookEvent, body []byte) {
+	d := &model.OutgoingWebhookDelivery{
+		ID:        uuid.Must(uuid.NewV4()),
+		WebhookID: w.ID,
+		Event:     ev,
+		Body:      string(body),
+	}
+	log, retryable := s.send(w, ev, d.ID, 1, body)
+	s.writeLog(log)
+	if !retryable || s.maxAttempts <= 1 {
+		return
+	}
+
+	d.Attempts = 1
+	d.NextAttemptAt = time.Now().Add(s.retryInterval)
+	if err := s.repo.SaveOutgoingWebhookDelivery(d); err != nil {
+		s.logger.Warn("failed to save delivery", zap.Error(err), zap.Stringer("deliveryId", d.ID))
+	}
+}
+
+// retry アウトボックスに保存された配送を再試行します
+//
+// 失敗した場合は指数バックオフで最大maxAttempts回まで試行します
+func (s *Service) retry(w *model.OutgoingWebhook, d *model.OutgoingWebhookDelivery) {
+	// 複数のインスタンスが同じ配送を再試行しないよう、処理権を得る
+	claimed, err := s.repo.ClaimOutgoingWebhookDelivery(d.ID, time.Now().Add(deliveryLeaseDuration))
+	if err != nil {
+		s.logger.Warn("failed to claim delivery", zap.Error(err), zap.Stringer("deliveryId", d.ID))
+		return
+	}
+	if !claimed {
+		return // 他のインスタンスが再試行中
+	}
+
+	d.Attempts++
+	log, retryable := s.send(w, d.Event, d.ID, d.Attempts, []byte(d.Body))
+	s.writeLog(log)
+	if !retryable || d.Attempts >= s.maxAttempts {
+		if err := s.repo.DeleteOutgoingWebhookDelivery(d.ID); err != nil {
+			s.logger.Warn("failed to delete delivery", zap.Error(err), zap.Stringer("deliveryId", d.ID))
+		}
+		return
+	}
+
+	d.NextAttemptAt = time.Now().Add(s.retryInterval << (d.Attempts - 1))
+	d.LeaseUntil = optional.Of[time.Time]{}
+	if err := s.repo.SaveOutgoingWebhookDelivery(d); err != nil {
+		s.logger.Warn("failed to save delivery", zap.Error(err), zap.Stringer("deliveryId", d.ID))
+	}
+}
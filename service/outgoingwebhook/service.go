@@ -0,0 +1,266 @@
+package outgoingwebhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	jsonIter "github.com/json-iterator/go"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/lthibault/jitterbug/v2"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+const (
+	deliveryLogPurgeBefore = time.Hour * 24 * 30 // 配送ログを30日間保持
+	retryPollInterval      = 5 * time.Second     // 再試行待ちの配送の確認間隔
+	retryBatchSize         = 100                 // 一度に再試行する配送の最大数
+)
+
+// Service 外部送信Webhookサービス
+//
+// 購読されているチャンネルのイベントを外部送信WebhookのURLにPOSTします
+type Service struct {
+	repo   repository.Repository
+	hub    *hub.Hub
+	logger *zap.Logger
+	client http.Client
+
+	maxAttempts       int
+	retryInterval     time.Duration
+	retryPollInterval time.Duration
+
+	sub       hub.Subscription
+	logPurger *jitterbug.Ticker
+	closer    chan struct{}
+	wg        sync.WaitGroup
+	once      sync.Once
+}
+
+// NewService 外部送信Webhookサービスを生成します
+func NewService(hub *hub.Hub, repo repository.Repository, logger *zap.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		hub:    hub,
+		logger: logger.Named("outgoing_webhook"),
+		client: http.Client{
+			Jar:     nil,
+			Timeout: requestTimeout,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		maxAttempts:       defaultMaxAttempts,
+		retryInterval:     defaultRetryInterval,
+		retryPollInterval: retryPollInterval,
+		closer:            make(chan struct{}),
+	}
+}
+
+// Start イベントの配送を開始します
+func (s *Service) Start() {
+	s.sub = s.hub.Subscribe(100, event.MessageCreated, event.MessageStamped, event.MessagePinned)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for ev := range s.sub.Receiver {
+			if err := s.handle(time.Now(), ev); err != nil {
+				s.logger.Error("an error occurred while processing event", zap.Error(err), zap.String("event", ev.Name))
+			}
+		}
+	}()
+
+	// 配送ログの定期的消去
+	s.logPurger = jitterbug.New(time.Hour*24, &jitterbug.Uniform{
+		Min: time.Hour * 23,
+	})
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			select {
+			case _, ok := <-s.logPurger.C:
+				if !ok {
+					return
+				}
+				if err := s.repo.PurgeOutgoingWebhookDeliveryLogs(time.Now().Add(-deliveryLogPurgeBefore)); err != nil {
+					s.logger.Error("an error occurred while purging old delivery logs", zap.Error(err))
+				}
+			case <-s.closer:
+				return
+			}
+		}
+	}()
+
+	// 再試行待ちの配送の定期的再試行
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		t := time.NewTicker(s.retryPollInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				s.retryDeliveries(time.Now())
+			case <-s.closer:
+				return
+			}
+		}
+	}()
+}
+
+// Shutdown サービスを停止します
+//
+// 再試行待ちの配送は、次回起動時或いは他のインスタンスで再試行されます
+func (s *Service) Shutdown(ctx context.Context) error {
+	s.once.Do(func() {
+		s.hub.Unsubscribe(s.sub)
+		s.logPurger.Stop()
+		close(s.closer)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Redeliver 配送ログと同じ内容のイベントを外部送信Webhookに再配送します
+//
+// 配送は非同期で行われ、新しい配送IDで記録されます
+func (s *Service) Redeliver(w *model.OutgoingWebhook, log *model.OutgoingWebhookDeliveryLog) {
+	s.dispatch(w, log.Event, []byte(log.Body))
+}
+
+func (s *Service) handle(et time.Time, ev hub.Message) error {
+	switch ev.Name {
+	case event.MessageCreated:
+		m := ev.Fields["message"].(*model.Message)
+		return s.multicast(m.ChannelID, model.OutgoingWebhookEventMessageCreated, func() (interface{}, error) {
+			user, err := s.repo.GetUser(m.UserID, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to GetUser: %w", err)
+			}
+			return makeMessageCreated(et, m, user), nil
+		})
+	case event.MessageStamped:
+		m, err := s.repo.GetMessageByID(ev.Fields["message_id"].(uuid.UUID))
+		if err != nil {
+			return fmt.Errorf("failed to GetMessageByID: %w", err)
+		}
+		return s.multicast(m.ChannelID, model.OutgoingWebhookEventMessageStamped, func() (interface{}, error) {
+			user, err := s.repo.GetUser(m.UserID, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to GetUser: %w", err)
+			}
+			stamp, err := s.repo.GetStamp(ev.Fields["stamp_id"].(uuid.UUID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to GetStamp: %w", err)
+			}
+			stampUser, err := s.repo.GetUser(ev.Fields["user_id"].(uuid.UUID), false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to GetUser: %w", err)
+			}
+			return makeMessageStamped(et, m, user, stamp, stampUser, ev.Fields["count"].(int), ev.Fields["created_at"].(time.Time)), nil
+		})
+	case event.MessagePinned:
+		return s.multicast(ev.Fields["channel_id"].(uuid.UUID), model.OutgoingWebhookEventMessagePinned, func() (interface{}, error) {
+			m, err := s.repo.GetMessageByID(ev.Fields["message_id"].(uuid.UUID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to GetMessageByID: %w", err)
+			}
+			user, err := s.repo.GetUser(m.UserID, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to GetUser: %w", err)
+			}
+			return makeMessagePinned(et, m, user), nil
+		})
+	}
+	return nil
+}
+
+// multicast 指定したチャンネルのイベントを購読している外部送信Webhookに配送します
+//
+// ペイロードは購読している外部送信Webhookがある場合のみ生成されます
+func (s *Service) multicast(channelID uuid.UUID, ev model.OutgoingWebhookEvent, makePayload func() (interface{}, error)) error {
+	webhooks, err := s.repo.GetOutgoingWebhooks(repository.OutgoingWebhooksQuery{
+		ChannelID: optional.From(channelID),
+		Event:     optional.From(ev),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to GetOutgoingWebhooks: %w", err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := makePayload()
+	if err != nil {
+		return err
+	}
+	body, err := jsonIter.ConfigFastest.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	for _, w := range webhooks {
+		s.dispatch(w, ev, body)
+	}
+	return nil
+}
+
+// retryDeliveries 再試行時刻を過ぎた配送を再試行します
+func (s *Service) retryDeliveries(now time.Time) {
+	deliveries, err := s.repo.GetRetryableOutgoingWebhookDeliveries(now, retryBatchSize)
+	if err != nil {
+		s.logger.Error("failed to GetRetryableOutgoingWebhookDeliveries", zap.Error(err))
+		return
+	}
+	webhooks := make(map[uuid.UUID]*model.OutgoingWebhook)
+	for _, d := range deliveries {
+		w, ok := webhooks[d.WebhookID]
+		if !ok {
+			w, err = s.repo.GetOutgoingWebhook(d.WebhookID)
+			if err != nil {
+				s.logger.Error("failed to GetOutgoingWebhook", zap.Error(err), zap.Stringer("webhookId", d.WebhookID))
+				continue
+			}
+			webhooks[d.WebhookID] = w
+		}
+
+		s.wg.Add(1)
+		go func(d *model.OutgoingWebhookDelivery) {
+			defer s.wg.Done()
+			s.retry(w, d)
+		}(d)
+	}
+}
+
+// dispatch 配送をバックグラウンドで開始します
+func (s *Service) dispatch(w *model.OutgoingWebhook, ev model.OutgoingWebhookEvent, body []byte) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.deliver(w, ev, body)
+	}()
+}
+
+func (s *Service) writeLog(log *model.OutgoingWebhookDeliveryLog) {
+	if err := s.repo.WriteOutgoingWebhookDeliveryLog(log); err != nil {
+		s.logger.Warn("failed to write delivery log", zap.Error(err), zap.Any("deliveryLog", log))
+	}
+}
@@ -0,0 +1,336 @@
+package outgoingwebhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+type fakeRepository struct {
+	repository.Repository
+	mu         sync.Mutex
+	users      map[uuid.UUID]*model.User
+	webhooks   []*model.OutgoingWebhook
+	logs       []*model.OutgoingWebhookDeliveryLog
+	deliveries map[uuid.UUID]*model.OutgoingWebhookDelivery
+}
+
+func (r *fakeRepository) GetUser(id uuid.UUID, _ bool) (model.UserInfo, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return u, nil
+}
+
+func (r *fakeRepository) GetOutgoingWebhooks(query repository.OutgoingWebhooksQuery) ([]*model.OutgoingWebhook, error) {
+	res := make([]*model.OutgoingWebhook, 0)
+	for _, w := range r.webhooks {
+		if query.ChannelID.Valid && w.ChannelID != query.ChannelID.V {
+			continue
+		}
+		if query.Event.Valid && !w.Events.Contains(query.Event.V) {
+			continue
+		}
+		res = append(res, w)
+	}
+	return res, nil
+}
+
+func (r *fakeRepository) WriteOutgoingWebhookDeliveryLog(log *model.OutgoingWebhookDeliveryLog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *fakeRepository) GetOutgoingWebhook(id uuid.UUID) (*model.OutgoingWebhook, error) {
+	for _, w := range r.webhooks {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *fakeRepository) SaveOutgoingWebhookDelivery(d *model.OutgoingWebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.deliveries == nil {
+		r.deliveries = map[uuid.UUID]*model.OutgoingWebhookDelivery{}
+	}
+	c := *d
+	r.deliveries[d.ID] = &c
+	return nil
+}
+
+func (r *fakeRepository) DeleteOutgoingWebhookDelivery(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.deliveries, id)
+	return nil
+}
+
+func (r *fakeRepository) GetRetryableOutgoingWebhookDeliveries(now time.Time, _ int) ([]*model.OutgoingWebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make([]*model.OutgoingWebhookDelivery, 0)
+	for _, d := range r.deliveries {
+		if !d.NextAttemptAt.After(now) && (!d.LeaseUntil.Valid || !d.LeaseUntil.V.After(now)) {
+			c := *d
+			res = append(res, &c)
+		}
+	}
+	return res, nil
+}
+
+func (r *fakeRepository) ClaimOutgoingWebhookDelivery(id uuid.UUID, leaseUntil time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.deliveries[id]
+	if !ok || d.LeaseUntil.Valid && d.LeaseUntil.V.After(time.Now()) {
+		return false, nil
+	}
+	d.LeaseUntil = optional.From(leaseUntil)
+	return true, nil
+}
+
+func (r *fakeRepository) getDeliveries() []*model.OutgoingWebhookDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make([]*model.OutgoingWebhookDelivery, 0, len(r.deliveries))
+	for _, d := range r.deliveries {
+		res = append(res, d)
+	}
+	return res
+}
+
+func (r *fakeRepository) getLogs() []*model.OutgoingWebhookDeliveryLog {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*model.OutgoingWebhookDeliveryLog{}, r.logs...)
+}
+
+func newTestService(repo repository.Repository) *Service {
+	s := NewService(hub.New(), repo, zap.NewNop())
+	s.retryInterval = 10 * time.Millisecond
+	return s
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+	// echo -n '{"a":1}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "aa9e2e3575f5d7098b6caccd790888c36d5fdb63342a73bada2d6a51747a8494", Sign([]byte(`{"a":1}`), "secret"))
+}
+
+func TestService_deliver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		body := []byte(`{"eventTime":"2020-01-01T00:00:00Z"}`)
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.Add(1)
+			b, _ := io.ReadAll(r.Body)
+			assert.Equal(t, body, b)
+			assert.Equal(t, "MESSAGE_CREATED", r.Header.Get(headerEvent))
+			assert.Equal(t, signaturePrefix+Sign(body, "secret"), r.Header.Get(headerSignature))
+			assert.NotEmpty(t, r.Header.Get(headerDeliveryID))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		repo := &fakeRepository{}
+		s := newTestService(repo)
+		w := &model.OutgoingWebhook{ID: uuid.Must(uuid.NewV4()), URL: server.URL, Secret: "secret"}
+		s.deliver(w, model.OutgoingWebhookEventMessageCreated, body)
+
+		assert.EqualValues(t, 1, received.Load())
+		logs := repo.getLogs()
+		if assert.Len(t, logs, 1) {
+			assert.Equal(t, resultOK, logs[0].Result)
+			assert.Equal(t, http.StatusNoContent, logs[0].Code)
+			assert.Equal(t, 1, logs[0].Attempt)
+			assert.Equal(t, w.ID, logs[0].WebhookID)
+		}
+	})
+
+	t.Run("retry until success", func(t *testing.T) {
+		t.Parallel()
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if received.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		w := &model.OutgoingWebhook{ID: uuid.Must(uuid.NewV4()), URL: server.URL, Secret: "secret"}
+		repo := &fakeRepository{webhooks: []*model.OutgoingWebhook{w}}
+		s := newTestService(repo)
+		s.deliver(w, model.OutgoingWebhookEventMessagePinned, []byte(`{}`))
+
+		// 再試行待ちの配送はアウトボックスに保存される
+		if ds := repo.getDeliveries(); assert.Len(t, ds, 1) {
+			assert.Equal(t, 1, ds[0].Attempts)
+			assert.True(t, ds[0].NextAttemptAt.After(time.Now()))
+		}
+		retryAll(s, 2)
+
+		assert.EqualValues(t, 3, received.Load())
+		assert.Len(t, repo.getDeliveries(), 0)
+		logs := repo.getLogs()
+		if assert.Len(t, logs, 3) {
+			for i, log := range logs {
+				assert.Equal(t, i+1, log.Attempt)
+				assert.Equal(t, logs[0].DeliveryID, log.DeliveryID)
+			}
+			assert.Equal(t, resultNG, logs[0].Result)
+			assert.Equal(t, resultOK, logs[2].Result)
+		}
+	})
+
+	t.Run("give up after max attempts", func(t *testing.T) {
+		t.Parallel()
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.Add(1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		w := &model.OutgoingWebhook{ID: uuid.Must(uuid.NewV4()), URL: server.URL, Secret: "secret"}
+		repo := &fakeRepository{webhooks: []*model.OutgoingWebhook{w}}
+		s := newTestService(repo)
+		s.maxAttempts = 3
+		s.deliver(w, model.OutgoingWebhookEventMessagePinned, []byte(`{}`))
+		retryAll(s, 5)
+
+		assert.EqualValues(t, 3, received.Load())
+		assert.Len(t, repo.getLogs(), 3)
+		assert.Len(t, repo.getDeliveries(), 0)
+	})
+
+	t.Run("no retry on client error", func(t *testing.T) {
+		t.Parallel()
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.Add(1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		repo := &fakeRepository{}
+		s := newTestService(repo)
+		w := &model.OutgoingWebhook{ID: uuid.Must(uuid.NewV4()), URL: server.URL, Secret: "secret"}
+		s.deliver(w, model.OutgoingWebhookEventMessagePinned, []byte(`{}`))
+
+		assert.EqualValues(t, 1, received.Load())
+		assert.Len(t, repo.getDeliveries(), 0)
+		logs := repo.getLogs()
+		if assert.Len(t, logs, 1) {
+			assert.Equal(t, resultNG, logs[0].Result)
+			assert.Equal(t, http.StatusBadRequest, logs[0].Code)
+		}
+	})
+
+	t.Run("claimed by another instance", func(t *testing.T) {
+		t.Parallel()
+		var received atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received.Add(1)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		w := &model.OutgoingWebhook{ID: uuid.Must(uuid.NewV4()), URL: server.URL, Secret: "secret"}
+		repo := &fakeRepository{webhooks: []*model.OutgoingWebhook{w}}
+		s := newTestService(repo)
+		d := &model.OutgoingWebhookDelivery{ID: uuid.Must(uuid.NewV4()), WebhookID: w.ID, Event: model.OutgoingWebhookEventMessagePinned, Body: "{}", Attempts: 1}
+		require.NoError(t, repo.SaveOutgoingWebhookDelivery(d))
+		ok, err := repo.ClaimOutgoingWebhookDelivery(d.ID, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		s.retry(w, d)
+		assert.EqualValues(t, 0, received.Load())
+		assert.Len(t, repo.getDeliveries(), 1)
+	})
+}
+
+// retryAll 再試行時刻に関わらず、再試行待ちの配送をn回再試行します
+func retryAll(s *Service, n int) {
+	for i := 0; i < n; i++ {
+		s.retryDeliveries(time.Now().Add(time.Hour))
+		s.wg.Wait()
+	}
+}
+
+func TestService_handle(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan []byte, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		received <- b
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	user := &model.User{ID: uuid.Must(uuid.NewV4()), Name: "po"}
+	channelID := uuid.Must(uuid.NewV4())
+	repo := &fakeRepository{
+		users: map[uuid.UUID]*model.User{user.ID: user},
+		webhooks: []*model.OutgoingWebhook{
+			{
+				ID:        uuid.Must(uuid.NewV4()),
+				ChannelID: channelID,
+				URL:       server.URL,
+				Secret:    "secret",
+				Events:    model.OutgoingWebhookEvents{model.OutgoingWebhookEventMessageCreated: {}},
+			},
+		},
+	}
+	s := newTestService(repo)
+
+	m := &model.Message{ID: uuid.Must(uuid.NewV4()), UserID: user.ID, ChannelID: channelID, Text: "test"}
+	require.NoError(t, s.handle(time.Now(), hub.Message{
+		Name:   event.MessageCreated,
+		Fields: hub.Fields{"message_id": m.ID, "message": m},
+	}))
+	select {
+	case b := <-received:
+		assert.Contains(t, string(b), m.ID.String())
+		assert.Contains(t, string(b), `"plainText":"test"`)
+	case <-time.After(3 * time.Second):
+		t.Fatal("the event was not delivered")
+	}
+
+	// 購読されていないチャンネル
+	other := &model.Message{ID: uuid.Must(uuid.NewV4()), UserID: user.ID, ChannelID: uuid.Must(uuid.NewV4()), Text: "test"}
+	require.NoError(t, s.handle(time.Now(), hub.Message{
+		Name:   event.MessageCreated,
+		Fields: hub.Fields{"message_id": other.ID, "message": other},
+	}))
+	s.wg.Wait()
+	assert.Len(t, received, 0)
+	assert.Len(t, repo.getLogs(), 1)
+}
@@ -0,0 +1,6 @@
+package permission
+
+const (
+	// GetAuditLogs 監査ログ取得権限
+	GetAuditLogs = Permission("get_audit_logs")
+)
@@ -22,6 +22,8 @@ const (
 	DeleteBot = Permission("delete_bot")
 	// AccessOthersBot 他人のBotのアクセス権限
 	AccessOthersBot = Permission("access_others_bot")
+	// ManageBotCommands Botコマンド管理権限
+	ManageBotCommands = Permission("manage_bot_commands")
 
 	// BotActionJoinChannel BOTアクション実行権限：チャンネル参加
 	BotActionJoinChannel = Permission("bot_action_join_channel")
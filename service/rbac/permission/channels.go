@@ -17,4 +17,21 @@ const (
 	GetChannelStar = Permission("get_channel_star")
 	// EditChannelStar チャンネルスター編集権限
 	EditChannelStar = Permission("edit_channel_star")
+	// ManageChannelRole チャンネルロール・チャンネル権限上書き設定の管理権限
+	ManageChannelRole = Permission("manage_channel_role")
+	// EditPrivateChannelMembers プライベートチャンネルメンバー変更権限
+	EditPrivateChannelMembers = Permission("edit_private_channel_members")
 )
+
+// ChannelScoped チャンネル単位で上書き可能な権限のセット
+var ChannelScoped = PermissionsFromArray([]Permission{
+	PostMessage,
+	EditMessage,
+	DeleteMessage,
+	DeleteOthersMessage,
+	CreateMessagePin,
+	DeleteMessagePin,
+	AddMessageStamp,
+	RemoveMessageStamp,
+	EditChannelTopic,
+})
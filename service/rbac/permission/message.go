@@ -9,12 +9,26 @@ const (
 	EditMessage = Permission("edit_message")
 	// DeleteMessage メッセージ削除権限
 	DeleteMessage = Permission("delete_message")
+	// DeleteOthersMessage 他ユーザーのメッセージ削除権限
+	DeleteOthersMessage = Permission("delete_others_message")
 	// ReportMessage メッセージ通報権限
 	ReportMessage = Permission("report_message")
 	// GetMessageReports メッセージ通報取得権限
 	GetMessageReports = Permission("get_message_reports")
+	// HandleMessageReports メッセージ通報対応権限
+	HandleMessageReports = Permission("handle_message_reports")
+	// GetScheduledMessage 予約投稿メッセージ取得権限
+	GetScheduledMessage = Permission("get_scheduled_message")
+	// CreateScheduledMessage 予約投稿メッセージ作成権限
+	CreateScheduledMessage = Permission("create_scheduled_message")
+	// EditScheduledMessage 予約投稿メッセージ編集権限
+	EditScheduledMessage = Permission("edit_scheduled_message")
+	// DeleteScheduledMessage 予約投稿メッセージ取り消し権限
+	DeleteScheduledMessage = Permission("delete_scheduled_message")
 	// CreateMessagePin ピン留め作成権限
 	CreateMessagePin = Permission("create_message_pin")
 	// DeleteMessagePin ピン留め削除権限
 	DeleteMessagePin = Permission("delete_message_pin")
+	// MoveMessages メッセージのチャンネル間移動権限
+	MoveMessages = Permission("move_messages")
 )
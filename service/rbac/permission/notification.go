@@ -9,4 +9,6 @@ const (
 	ConnectNotificationStream = Permission("connect_notification_stream")
 	// RegisterFCMDevice FCMデバイスの登録権限
 	RegisterFCMDevice = Permission("register_fcm_device")
+	// RegisterWebPushSubscription Web Push購読の登録権限
+	RegisterWebPushSubscription = Permission("register_webpush_subscription")
 )
@@ -56,6 +56,7 @@ var List = []Permission{
 	EditBot,
 	DeleteBot,
 	AccessOthersBot,
+	ManageBotCommands,
 
 	BotActionJoinChannel,
 	BotActionLeaveChannel,
@@ -66,6 +67,8 @@ var List = []Permission{
 	DeleteChannel,
 	ChangeParentChannel,
 	EditChannelTopic,
+	ManageChannelRole,
+	EditPrivateChannelMembers,
 
 	GetMyTokens,
 	RevokeMyToken,
@@ -83,13 +86,21 @@ var List = []Permission{
 	PostMessage,
 	EditMessage,
 	DeleteMessage,
+	DeleteOthersMessage,
 	ReportMessage,
 	GetMessageReports,
+	HandleMessageReports,
+	GetScheduledMessage,
+	CreateScheduledMessage,
+	EditScheduledMessage,
+	DeleteScheduledMessage,
+	MoveMessages,
 
 	GetChannelSubscription,
 	EditChannelSubscription,
 	ConnectNotificationStream,
 	RegisterFCMDevice,
+	RegisterWebPushSubscription,
 
 	CreateMessagePin,
 	DeleteMessagePin,
@@ -144,4 +155,9 @@ var List = []Permission{
 	CreateStampPalette,
 	EditStampPalette,
 	DeleteStampPalette,
+
+	GetRole,
+	ManageRole,
+
+	GetAuditLogs,
 }
@@ -0,0 +1,8 @@
+package permission
+
+const (
+	// GetRole ロール情報取得権限
+	GetRole = Permission("get_role")
+	// ManageRole ロール作成・編集・削除権限
+	ManageRole = Permission("manage_role")
+)
@@ -1,10 +1,20 @@
 package rbac
 
-import "github.com/traPtitech/traQ/service/rbac/permission"
+import (
+	"errors"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+)
+
+// ErrInheritanceCycle ロールの継承関係に循環がある
+var ErrInheritanceCycle = errors.New("role inheritance cycle detected")
 
 // RBAC Role-based Access Controllerインターフェース
 type RBAC interface {
-	// Reload 全権限を読み込み直します
+	// Reload 全権限を読み込み直します。他ノードにも再読み込みを通知します
 	Reload() error
 
 	// IsGranted 指定したロールで指定した権限が許可されているかどうか
@@ -15,4 +25,55 @@ type RBAC interface {
 	IsAnyGranted(roles []string, perm permission.Permission) bool
 	// GetGrantedPermissions 指定したロールに与えられている全ての権限を取得します
 	GetGrantedPermissions(role string) []permission.Permission
+	// IsGrantedInChannel 指定したロールのユーザーに指定したチャンネルで指定した権限が許可されているかどうか
+	//
+	// permission.ChannelScopedの権限について、チャンネルロールとチャンネル権限上書き設定を考慮します。
+	// 1. ユーザーのチャンネルロールで許可されている場合は許可
+	// 2. ロールに対するチャンネル権限上書き設定がある場合はその設定に従う
+	// 3. それ以外はロールの権限に従う
+	IsGrantedInChannel(role string, userID, channelID uuid.UUID, perm permission.Permission) bool
+}
+
+// CheckInheritanceCycle ロールの継承関係に循環がないかどうかを検査します
+//
+// 循環がある場合、ErrInheritanceCycleを返します。
+func CheckInheritanceCycle(roles []*model.UserRole) error {
+	graph := make(map[string][]string, len(roles))
+	for _, r := range roles {
+		subs := make([]string, 0, len(r.Inheritances))
+		for _, i := range r.Inheritances {
+			subs = append(subs, i.Name)
+		}
+		graph[r.Name] = subs
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(graph))
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		switch state[name] {
+		case visiting:
+			return false
+		case visited:
+			return true
+		}
+		state[name] = visiting
+		for _, sub := range graph[name] {
+			if !visit(sub) {
+				return false
+			}
+		}
+		state[name] = visited
+		return true
+	}
+	for name := range graph {
+		if !visit(name) {
+			return ErrInheritanceCycle
+		}
+	}
+	return nil
 }
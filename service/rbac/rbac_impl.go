@@ -4,27 +4,41 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/rbac/permission"
 	"github.com/traPtitech/traQ/service/rbac/role"
+	"github.com/traPtitech/traQ/service/relay"
 )
 
+// relayTopic ロール・権限の再読み込みを他ノードに通知するトピック
+const relayTopic = "rbac.reload"
+
 type rbacImpl struct {
-	roles      role.Roles
-	rolesMutex sync.RWMutex
-	repo       repository.Repository
+	roles            role.Roles
+	channelRoles     map[uuid.UUID]map[uuid.UUID]string
+	channelOverrides map[uuid.UUID]map[string]map[permission.Permission]bool
+	rolesMutex       sync.RWMutex
+	repo             repository.Repository
+	relay            relay.Relay
+	logger           *zap.Logger
 }
 
 // New RBACを初期化
-func New(repo repository.Repository) (RBAC, error) {
+func New(repo repository.Repository, r relay.Relay, logger *zap.Logger) (RBAC, error) {
 	rbac := &rbacImpl{
-		roles: role.Roles{},
-		repo:  repo,
+		roles:  role.Roles{},
+		repo:   repo,
+		relay:  r,
+		logger: logger.Named("rbac"),
 	}
 	if err := rbac.reload(); err != nil {
 		return nil, fmt.Errorf("failed to init rbac: %w", err)
 	}
+	r.Subscribe(relayTopic, rbac.handleRelayedReload)
 	return rbac, nil
 }
 
@@ -64,7 +78,20 @@ func (r *rbacImpl) IsAnyGranted(roles []string, perm permission.Permission) bool
 }
 
 func (r *rbacImpl) Reload() error {
-	return r.reload()
+	if err := r.reload(); err != nil {
+		return err
+	}
+	// 他ノードのキャッシュも更新させる
+	if err := r.relay.Publish(relayTopic, struct{}{}); err != nil {
+		r.logger.Error("failed to publish rbac reload", zap.Error(err))
+	}
+	return nil
+}
+
+func (r *rbacImpl) handleRelayedReload(nodeID string, _ []byte) {
+	if err := r.reload(); err != nil {
+		r.logger.Error("failed to reload rbac", zap.Error(err), zap.String("nodeId", nodeID))
+	}
 }
 
 func (r *rbacImpl) reload() error {
@@ -72,6 +99,9 @@ func (r *rbacImpl) reload() error {
 	if err != nil {
 		return err
 	}
+	if err := CheckInheritanceCycle(rs); err != nil {
+		return err
+	}
 
 	roles := map[string]*roleImpl{}
 	roleMap := map[string]*model.UserRole{}
@@ -94,22 +124,75 @@ func (r *rbacImpl) reload() error {
 	for _, v := range roleMap {
 		p := roles[v.Name]
 		for _, i := range v.Inheritances {
-			p.inheritances.Add(roles[i.Name])
+			if sub, ok := roles[i.Name]; ok {
+				p.inheritances.Add(sub)
+			}
 		}
 	}
 
-	// TODO 木の循環検知
-
 	result := role.Roles{}
 	for _, v := range roles {
 		result.Add(v)
 	}
+
+	crs, err := r.repo.GetAllChannelRoles()
+	if err != nil {
+		return err
+	}
+	channelRoles := map[uuid.UUID]map[uuid.UUID]string{}
+	for _, v := range crs {
+		if _, ok := channelRoles[v.ChannelID]; !ok {
+			channelRoles[v.ChannelID] = map[uuid.UUID]string{}
+		}
+		channelRoles[v.ChannelID][v.UserID] = v.Role
+	}
+
+	cos, err := r.repo.GetAllChannelPermissionOverrides()
+	if err != nil {
+		return err
+	}
+	channelOverrides := map[uuid.UUID]map[string]map[permission.Permission]bool{}
+	for _, v := range cos {
+		if _, ok := channelOverrides[v.ChannelID]; !ok {
+			channelOverrides[v.ChannelID] = map[string]map[permission.Permission]bool{}
+		}
+		if _, ok := channelOverrides[v.ChannelID][v.Role]; !ok {
+			channelOverrides[v.ChannelID][v.Role] = map[permission.Permission]bool{}
+		}
+		channelOverrides[v.ChannelID][v.Role][permission.Permission(v.Permission)] = v.Allow
+	}
+
 	r.rolesMutex.Lock()
 	r.roles = result
+	r.channelRoles = channelRoles
+	r.channelOverrides = channelOverrides
 	r.rolesMutex.Unlock()
 	return nil
 }
 
+func (r *rbacImpl) IsGrantedInChannel(roleName string, userID, channelID uuid.UUID, p permission.Permission) bool {
+	r.rolesMutex.RLock()
+	defer r.rolesMutex.RUnlock()
+	if roleName == role.Admin || !permission.ChannelScoped.Contains(p) {
+		return r.isGranted(roleName, p)
+	}
+
+	overrides := r.channelOverrides[channelID]
+	if cr, ok := r.channelRoles[channelID][userID]; ok {
+		if allow, ok := overrides[cr][p]; ok {
+			if allow {
+				return true
+			}
+		} else if r.isGranted(cr, p) {
+			return true
+		}
+	}
+	if allow, ok := overrides[roleName][p]; ok {
+		return allow
+	}
+	return r.isGranted(roleName, p)
+}
+
 func (r *rbacImpl) GetGrantedPermissions(roleName string) []permission.Permission {
 	if roleName == role.Admin {
 		return permission.List
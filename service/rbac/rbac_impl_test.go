@@ -3,13 +3,16 @@ package rbac_test
 import (
 	"testing"
 
+	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/service/rbac"
 	"github.com/traPtitech/traQ/service/rbac/permission"
 	"github.com/traPtitech/traQ/service/rbac/role"
+	"github.com/traPtitech/traQ/service/relay"
 	"github.com/traPtitech/traQ/testutils"
 )
 
@@ -29,10 +32,18 @@ func (r *Repo) GetAllUserRoles() ([]*model.UserRole, error) {
 	return []*model.UserRole{r1, r2, r3, r4}, nil
 }
 
+func (r *Repo) GetAllChannelRoles() ([]*model.ChannelRole, error) {
+	return nil, nil
+}
+
+func (r *Repo) GetAllChannelPermissionOverrides() ([]*model.ChannelPermissionOverride, error) {
+	return nil, nil
+}
+
 func setup(t *testing.T) rbac.RBAC {
 	t.Helper()
 	repo := new(Repo)
-	r, err := rbac.New(repo)
+	r, err := rbac.New(repo, relay.NewNullRelay(), zap.NewNop())
 	require.NoError(t, err)
 	return r
 }
@@ -145,3 +156,138 @@ func Test_rbacImpl_GetGrantedPermissions(t *testing.T) {
 		assert.ElementsMatch(t, r.GetGrantedPermissions("r4"), []permission.Permission{"p4"})
 	})
 }
+
+type cyclicRepo struct {
+	testutils.EmptyTestRepository
+}
+
+func (r *cyclicRepo) GetAllUserRoles() ([]*model.UserRole, error) {
+	r1 := &model.UserRole{Name: "r1"}
+	r2 := &model.UserRole{Name: "r2"}
+	r3 := &model.UserRole{Name: "r3"}
+
+	r1.Inheritances = append(r1.Inheritances, r2)
+	r2.Inheritances = append(r2.Inheritances, r3)
+	r3.Inheritances = append(r3.Inheritances, r1)
+
+	return []*model.UserRole{r1, r2, r3}, nil
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	t.Run("inheritance cycle", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := rbac.New(new(cyclicRepo), relay.NewNullRelay(), zap.NewNop())
+		assert.ErrorIs(t, err, rbac.ErrInheritanceCycle)
+	})
+}
+
+func TestCheckInheritanceCycle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no cycle", func(t *testing.T) {
+		t.Parallel()
+
+		roles, _ := new(Repo).GetAllUserRoles()
+		assert.NoError(t, rbac.CheckInheritanceCycle(roles))
+	})
+
+	t.Run("self inheritance", func(t *testing.T) {
+		t.Parallel()
+
+		r1 := &model.UserRole{Name: "r1"}
+		r1.Inheritances = append(r1.Inheritances, r1)
+		assert.ErrorIs(t, rbac.CheckInheritanceCycle([]*model.UserRole{r1}), rbac.ErrInheritanceCycle)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+
+		roles, _ := new(cyclicRepo).GetAllUserRoles()
+		assert.ErrorIs(t, rbac.CheckInheritanceCycle(roles), rbac.ErrInheritanceCycle)
+	})
+}
+
+var (
+	testChannelID = uuid.Must(uuid.FromString("7ee8fd8c-7b8e-4a1c-8b31-9c5bd4d6b4b1"))
+	testUserID    = uuid.Must(uuid.FromString("0d3f9d2a-45d5-4ef7-9b35-1a3c0c57a0a2"))
+)
+
+type channelRepo struct {
+	testutils.EmptyTestRepository
+}
+
+func (r *channelRepo) GetAllUserRoles() ([]*model.UserRole, error) {
+	member := &model.UserRole{Name: "member", Permissions: []model.RolePermission{
+		{Permission: permission.PostMessage.Name()},
+		{Permission: permission.DeleteMessagePin.Name()},
+	}}
+	moderator := &model.UserRole{Name: "moderator", Permissions: []model.RolePermission{
+		{Permission: permission.DeleteOthersMessage.Name()},
+		{Permission: permission.DeleteMessagePin.Name()},
+	}}
+	return []*model.UserRole{member, moderator}, nil
+}
+
+func (r *channelRepo) GetAllChannelRoles() ([]*model.ChannelRole, error) {
+	return []*model.ChannelRole{
+		{ChannelID: testChannelID, UserID: testUserID, Role: "moderator"},
+	}, nil
+}
+
+func (r *channelRepo) GetAllChannelPermissionOverrides() ([]*model.ChannelPermissionOverride, error) {
+	return []*model.ChannelPermissionOverride{
+		{ChannelID: testChannelID, Role: "member", Permission: permission.PostMessage.Name(), Allow: false},
+		{ChannelID: testChannelID, Role: "member", Permission: permission.CreateMessagePin.Name(), Allow: true},
+		{ChannelID: testChannelID, Role: "moderator", Permission: permission.PostMessage.Name(), Allow: true},
+		{ChannelID: testChannelID, Role: "moderator", Permission: permission.DeleteMessagePin.Name(), Allow: false},
+	}, nil
+}
+
+func Test_rbacImpl_IsGrantedInChannel(t *testing.T) {
+	t.Parallel()
+
+	r, err := rbac.New(new(channelRepo), relay.NewNullRelay(), zap.NewNop())
+	require.NoError(t, err)
+	otherChannelID := uuid.Must(uuid.NewV4())
+	otherUserID := uuid.Must(uuid.NewV4())
+
+	t.Run("no overrides", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, r.IsGrantedInChannel("member", otherUserID, otherChannelID, permission.PostMessage))
+		assert.False(t, r.IsGrantedInChannel("member", otherUserID, otherChannelID, permission.CreateMessagePin))
+		assert.False(t, r.IsGrantedInChannel("member", testUserID, otherChannelID, permission.DeleteOthersMessage))
+	})
+
+	t.Run("role overrides", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, r.IsGrantedInChannel("member", otherUserID, testChannelID, permission.PostMessage))
+		assert.True(t, r.IsGrantedInChannel("member", otherUserID, testChannelID, permission.CreateMessagePin))
+		assert.True(t, r.IsGrantedInChannel("member", otherUserID, testChannelID, permission.DeleteMessagePin))
+	})
+
+	t.Run("channel role", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, r.IsGrantedInChannel("member", testUserID, testChannelID, permission.PostMessage))
+		assert.True(t, r.IsGrantedInChannel("member", testUserID, testChannelID, permission.DeleteOthersMessage))
+		// チャンネルロールで拒否されていても全体ロールの権限に従う
+		assert.True(t, r.IsGrantedInChannel("member", testUserID, testChannelID, permission.DeleteMessagePin))
+	})
+
+	t.Run("not channel scoped permission", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, r.IsGrantedInChannel("member", testUserID, testChannelID, permission.GetMessage))
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		t.Parallel()
+
+		assert.True(t, r.IsGrantedInChannel(role.Admin, otherUserID, testChannelID, permission.PostMessage))
+	})
+}
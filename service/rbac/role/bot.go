@@ -37,5 +37,6 @@ var botPerms = []permission.Permission{
 	permission.DeleteFile,
 	permission.BotActionJoinChannel,
 	permission.BotActionLeaveChannel,
+	permission.ManageBotCommands,
 	permission.WebRTC,
 }
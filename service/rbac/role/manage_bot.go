@@ -19,6 +19,7 @@ var manageBotPerms = []permission.Permission{
 	permission.CreateBot,
 	permission.EditBot,
 	permission.DeleteBot,
+	permission.ManageBotCommands,
 	permission.BotActionJoinChannel,
 	permission.BotActionLeaveChannel,
 	permission.GetClients,
@@ -10,6 +10,7 @@ const Read = "read"
 var readPerms = []permission.Permission{
 	permission.GetChannel,
 	permission.GetMessage,
+	permission.GetScheduledMessage,
 	permission.GetChannelSubscription,
 	permission.ConnectNotificationStream,
 	permission.GetUser,
@@ -27,6 +27,7 @@ var userPerms = []permission.Permission{
 	permission.CreateBot,
 	permission.EditBot,
 	permission.DeleteBot,
+	permission.ManageBotCommands,
 	permission.BotActionJoinChannel,
 	permission.BotActionLeaveChannel,
 	permission.WebRTC,
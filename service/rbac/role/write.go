@@ -10,14 +10,19 @@ const Write = "write"
 var writePerms = []permission.Permission{
 	permission.CreateChannel,
 	permission.EditChannelTopic,
+	permission.EditPrivateChannelMembers,
 	permission.PostMessage,
 	permission.EditMessage,
 	permission.DeleteMessage,
 	permission.ReportMessage,
+	permission.CreateScheduledMessage,
+	permission.EditScheduledMessage,
+	permission.DeleteScheduledMessage,
 	permission.CreateMessagePin,
 	permission.DeleteMessagePin,
 	permission.EditChannelSubscription,
 	permission.RegisterFCMDevice,
+	permission.RegisterWebPushSubscription,
 	permission.EditMe,
 	permission.ChangeMyIcon,
 	permission.EditChannelStar,
@@ -0,0 +1,75 @@
+package relay
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+const memoryInboxSize = 1024
+
+// MemoryNetwork プロセス内で複数ノードを模擬するネットワーク
+//
+// テスト用です
+type MemoryNetwork struct {
+	members map[*memoryRelay]struct{}
+	mu      sync.RWMutex
+}
+
+// NewMemoryNetwork プロセス内ネットワークを生成します
+func NewMemoryNetwork() *MemoryNetwork {
+	return &MemoryNetwork{
+		members: map[*memoryRelay]struct{}{},
+	}
+}
+
+// memoryRelay Relay のプロセス内実装
+type memoryRelay struct {
+	*node
+	network *MemoryNetwork
+	inbox   chan []byte
+}
+
+// Join ネットワークに新しいノードを参加させます
+func (net *MemoryNetwork) Join(logger *zap.Logger) Relay {
+	r := &memoryRelay{
+		network: net,
+		inbox:   make(chan []byte, memoryInboxSize),
+	}
+	r.node = newNode(r.broadcast, logger.Named("relay"))
+
+	net.mu.Lock()
+	net.members[r] = struct{}{}
+	net.mu.Unlock()
+
+	go func() {
+		for {
+			select {
+			case data := <-r.inbox:
+				r.receive(data)
+			case <-r.done:
+				return
+			}
+		}
+	}()
+	r.start()
+	return r
+}
+
+func (r *memoryRelay) broadcast(data []byte) error {
+	r.network.mu.RLock()
+	defer r.network.mu.RUnlock()
+	for m := range r.network.members {
+		if m != r {
+			m.inbox <- data
+		}
+	}
+	return nil
+}
+
+func (r *memoryRelay) Close() error {
+	r.network.mu.Lock()
+	delete(r.network.members, r)
+	r.network.mu.Unlock()
+	return r.close()
+}
@@ -0,0 +1,87 @@
+package relay
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestMemoryNetwork(t *testing.T) {
+	t.Parallel()
+
+	net := NewMemoryNetwork()
+	r1 := net.Join(zap.NewNop())
+	r2 := net.Join(zap.NewNop())
+	defer r2.Close()
+
+	var (
+		mu       sync.Mutex
+		received []string
+		self     int
+	)
+	r1.Subscribe("test", func(_ string, _ []byte) {
+		mu.Lock()
+		self++
+		mu.Unlock()
+	})
+	r2.Subscribe("test", func(nodeID string, body []byte) {
+		mu.Lock()
+		received = append(received, nodeID+":"+string(body))
+		mu.Unlock()
+	})
+	left := make(chan string, 1)
+	r2.OnNodeLeft(func(nodeID string) {
+		left <- nodeID
+	})
+
+	assert.NoError(t, r1.Publish("test", "a"))
+	assert.NoError(t, r1.Publish("test", "b"))
+	assert.NoError(t, r2.Publish("hello", nil))
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	assert.Equal(t, []string{r1.NodeID() + `:"a"`, r1.NodeID() + `:"b"`}, received)
+	// 自ノードには配送されない
+	assert.Zero(t, self)
+	mu.Unlock()
+
+	// 代表ノードはIDが最小のノード
+	assert.Eventually(t, func() bool {
+		return r1.IsLeader() == (r1.NodeID() < r2.NodeID()) && r2.IsLeader() == (r2.NodeID() < r1.NodeID())
+	}, time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, r1.Close())
+	select {
+	case nodeID := <-left:
+		assert.Equal(t, r1.NodeID(), nodeID)
+	case <-time.After(time.Second):
+		t.Fatal("OnNodeLeft was not called")
+	}
+	assert.True(t, r2.IsLeader())
+	assert.ErrorIs(t, r1.Publish("test", "c"), ErrClosed)
+}
+
+func TestNode_expire(t *testing.T) {
+	t.Parallel()
+
+	n := newNode(func([]byte) error { return nil }, zap.NewNop())
+	var left []string
+	n.OnNodeLeft(func(nodeID string) {
+		left = append(left, nodeID)
+	})
+
+	now := time.Now()
+	n.nodes["a"] = now
+	n.nodes["b"] = now.Add(-nodeTimeout - time.Second)
+	n.expire(now)
+
+	assert.Equal(t, []string{"b"}, left)
+	assert.Len(t, n.nodes, 1)
+}
@@ -0,0 +1,214 @@
+package relay
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	topicHeartbeat = "relay.heartbeat"
+	topicLeave     = "relay.leave"
+
+	heartbeatInterval = 5 * time.Second
+	nodeTimeout       = 3 * heartbeatInterval
+)
+
+// envelope ノード間でやり取りされるメッセージ
+type envelope struct {
+	Node  string          `json:"node"`
+	Topic string          `json:"topic"`
+	Body  json.RawMessage `json:"body,omitempty"`
+}
+
+// node 各Relay実装で共通のノード管理・メッセージ配送処理
+type node struct {
+	id     string
+	send   func(data []byte) error
+	logger *zap.Logger
+
+	handlers map[string][]Handler
+	joined   []func(nodeID string)
+	left     []func(nodeID string)
+	nodes    map[string]time.Time
+	closed   bool
+	done     chan struct{}
+	mu       sync.RWMutex
+}
+
+func newNode(send func(data []byte) error, logger *zap.Logger) *node {
+	return &node{
+		id:       uuid.Must(uuid.NewV4()).String(),
+		send:     send,
+		logger:   logger,
+		handlers: map[string][]Handler{},
+		nodes:    map[string]time.Time{},
+		done:     make(chan struct{}),
+	}
+}
+
+// start 生存通知ループを開始します
+//
+// 他ノードは自ノードからの最初のメッセージを受け取った時点で参加を検知します。
+// 参加直後は各サービスの購読の登録が終わっていない可能性があるため、最初の生存通知は1周期待ってから送ります
+func (n *node) start() {
+	go func() {
+		t := time.NewTicker(heartbeatInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				if err := n.publish(topicHeartbeat, nil); err != nil {
+					n.logger.Warn("failed to send heartbeat", zap.Error(err))
+				}
+				n.expire(time.Now())
+			case <-n.done:
+				return
+			}
+		}
+	}()
+}
+
+func (n *node) NodeID() string {
+	return n.id
+}
+
+func (n *node) Publish(topic string, body any) error {
+	return n.publish(topic, body)
+}
+
+func (n *node) publish(topic string, body any) error {
+	n.mu.RLock()
+	closed := n.closed
+	n.mu.RUnlock()
+	if closed {
+		return ErrClosed
+	}
+
+	e := envelope{Node: n.id, Topic: topic}
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		e.Body = b
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return n.send(data)
+}
+
+func (n *node) Subscribe(topic string, handler Handler) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.handlers[topic] = append(n.handlers[topic], handler)
+}
+
+func (n *node) OnNodeJoined(f func(nodeID string)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.joined = append(n.joined, f)
+}
+
+func (n *node) OnNodeLeft(f func(nodeID string)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.left = append(n.left, f)
+}
+
+func (n *node) IsLeader() bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	for id := range n.nodes {
+		if id < n.id {
+			return false
+		}
+	}
+	return true
+}
+
+// receive 他ノードから受信したメッセージを処理します
+func (n *node) receive(data []byte) {
+	var e envelope
+	if err := json.Unmarshal(data, &e); err != nil {
+		n.logger.Warn("received a malformed message", zap.Error(err))
+		return
+	}
+	if e.Node == n.id {
+		return
+	}
+
+	n.mu.Lock()
+	if n.closed {
+		n.mu.Unlock()
+		return
+	}
+	if e.Topic == topicLeave {
+		_, ok := n.nodes[e.Node]
+		delete(n.nodes, e.Node)
+		left := n.left
+		n.mu.Unlock()
+		if ok {
+			for _, f := range left {
+				f(e.Node)
+			}
+		}
+		return
+	}
+	_, known := n.nodes[e.Node]
+	n.nodes[e.Node] = time.Now()
+	joined := n.joined
+	handlers := n.handlers[e.Topic]
+	n.mu.Unlock()
+
+	if !known {
+		n.logger.Info("node joined", zap.String("nodeID", e.Node))
+		for _, f := range joined {
+			// 参加時の処理で自ノードから送信を行うことがあるため、受信処理をブロックしない
+			go f(e.Node)
+		}
+	}
+	for _, h := range handlers {
+		h(e.Node, e.Body)
+	}
+}
+
+// expire タイムアウトしたノードを離脱したものとして扱います
+func (n *node) expire(now time.Time) {
+	n.mu.Lock()
+	var expired []string
+	for id, last := range n.nodes {
+		if now.Sub(last) > nodeTimeout {
+			expired = append(expired, id)
+			delete(n.nodes, id)
+		}
+	}
+	left := n.left
+	n.mu.Unlock()
+
+	for _, id := range expired {
+		n.logger.Warn("node timed out", zap.String("nodeID", id))
+		for _, f := range left {
+			f(id)
+		}
+	}
+}
+
+// close 離脱を通知し、ノードを停止します
+func (n *node) close() error {
+	err := n.publish(topicLeave, nil)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.closed {
+		return ErrClosed
+	}
+	n.closed = true
+	close(n.done)
+	return err
+}
@@ -0,0 +1,32 @@
+package relay
+
+var nullR = &nullRelay{}
+
+type nullRelay struct{}
+
+// NewNullRelay 他ノードが存在しない、単一ノード用の中継器を返します
+func NewNullRelay() Relay {
+	return nullR
+}
+
+func (n *nullRelay) NodeID() string {
+	return ""
+}
+
+func (n *nullRelay) Publish(string, any) error {
+	return nil
+}
+
+func (n *nullRelay) Subscribe(string, Handler) {}
+
+func (n *nullRelay) OnNodeJoined(func(nodeID string)) {}
+
+func (n *nullRelay) OnNodeLeft(func(nodeID string)) {}
+
+func (n *nullRelay) IsLeader() bool {
+	return true
+}
+
+func (n *nullRelay) Close() error {
+	return nil
+}
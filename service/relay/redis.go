@@ -0,0 +1,180 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	redisDialTimeout       = 5 * time.Second
+	redisReadTimeout       = 3 * time.Second
+	redisWriteTimeout      = 3 * time.Second
+	redisReconnectDelay    = time.Second
+	redisPublishQueueSize  = 1024
+	redisCloseFlushTimeout = 5 * time.Second
+)
+
+// ErrPublishQueueFull 送信キューが一杯のため、メッセージが破棄された
+var ErrPublishQueueFull = errors.New("relay: publish queue is full")
+
+var (
+	relayDroppedMessagesCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "traq",
+		Name:      "relay_dropped_messages_total",
+	}, []string{"reason"})
+	relaySubscriptionInterruptionsCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "traq",
+		Name:      "relay_subscription_interruptions_total",
+	})
+)
+
+// RedisConfig Redis Pub/Sub中継設定
+type RedisConfig struct {
+	// Addr Redisのアドレス (host:port)
+	Addr string
+	// Password Redisのパスワード
+	Password string
+	// Channel Pub/Subのチャンネル名
+	Channel string
+}
+
+// redisRelay Relay のRedis Pub/Sub実装
+//
+// 送信はキューを介して別goroutineで行うため、Redisとの通信が滞っても呼び出し元はブロックされません
+type redisRelay struct {
+	*node
+	config RedisConfig
+	client *redis.Client
+	pubsub *redis.PubSub
+
+	queue       chan []byte
+	queueMu     sync.RWMutex
+	queueClosed bool
+	flushed     chan struct{}
+}
+
+// NewRedisRelay Redis Pub/Subを用いた中継器を生成します
+func NewRedisRelay(config RedisConfig, logger *zap.Logger) (Relay, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:         config.Addr,
+		Password:     config.Password,
+		DialTimeout:  redisDialTimeout,
+		ReadTimeout:  redisReadTimeout,
+		WriteTimeout: redisWriteTimeout,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisDialTimeout)
+	defer cancel()
+	// 疎通確認
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	pubsub := client.Subscribe(ctx, config.Channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to subscribe to Redis: %w", err)
+	}
+
+	r := newRedisRelay(config, client, pubsub, logger)
+	go r.publishLoop()
+	go r.subscribeLoop()
+	r.start()
+	return r, nil
+}
+
+func newRedisRelay(config RedisConfig, client *redis.Client, pubsub *redis.PubSub, logger *zap.Logger) *redisRelay {
+	r := &redisRelay{
+		config:  config,
+		client:  client,
+		pubsub:  pubsub,
+		queue:   make(chan []byte, redisPublishQueueSize),
+		flushed: make(chan struct{}),
+	}
+	r.node = newNode(r.enqueue, logger.Named("relay"))
+	return r
+}
+
+// enqueue メッセージを送信キューに追加します
+//
+// キューが一杯の場合はメッセージを破棄し、ErrPublishQueueFullを返します
+func (r *redisRelay) enqueue(data []byte) error {
+	r.queueMu.RLock()
+	defer r.queueMu.RUnlock()
+	if r.queueClosed {
+		return ErrClosed
+	}
+
+	select {
+	case r.queue <- data:
+		return nil
+	default:
+		relayDroppedMessagesCounter.WithLabelValues("queue_full").Inc()
+		return ErrPublishQueueFull
+	}
+}
+
+func (r *redisRelay) publishLoop() {
+	defer close(r.flushed)
+	for data := range r.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), redisWriteTimeout+redisReadTimeout)
+		err := r.client.Publish(ctx, r.config.Channel, data).Err()
+		cancel()
+		if err != nil {
+			relayDroppedMessagesCounter.WithLabelValues("publish_failed").Inc()
+			r.logger.Warn("failed to publish a message to Redis", zap.Error(err))
+		}
+	}
+}
+
+func (r *redisRelay) subscribeLoop() {
+	for {
+		// 切断された場合、次のReceiveで再接続・再購読されます
+		msg, err := r.pubsub.Receive(context.Background())
+		if err != nil {
+			select {
+			case <-r.done:
+				return
+			default:
+			}
+			// Redis Pub/Subは再接続までの間にPublishされたメッセージを受け取れない
+			relaySubscriptionInterruptionsCounter.Inc()
+			r.logger.Warn("Redis subscription was interrupted. messages published until reconnection are lost", zap.Error(err))
+			time.Sleep(redisReconnectDelay)
+			continue
+		}
+		if m, ok := msg.(*redis.Message); ok {
+			r.receive([]byte(m.Payload))
+		}
+	}
+}
+
+func (r *redisRelay) Close() error {
+	err := r.close()
+
+	// 離脱通知を含む送信待ちのメッセージを送り切る
+	r.queueMu.Lock()
+	if !r.queueClosed {
+		r.queueClosed = true
+		close(r.queue)
+	}
+	r.queueMu.Unlock()
+	select {
+	case <-r.flushed:
+	case <-time.After(redisCloseFlushTimeout):
+		r.logger.Warn("timed out flushing relay messages")
+	}
+
+	_ = r.pubsub.Close()
+	_ = r.client.Close()
+	return err
+}
@@ -0,0 +1,29 @@
+package relay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRedisRelay_enqueue(t *testing.T) {
+	t.Parallel()
+
+	t.Run("queue full", func(t *testing.T) {
+		t.Parallel()
+		r := newRedisRelay(RedisConfig{}, nil, nil, zap.NewNop())
+		for i := 0; i < redisPublishQueueSize; i++ {
+			assert.NoError(t, r.enqueue([]byte("{}")))
+		}
+		// 送信が滞っていてもブロックせずに破棄する
+		assert.ErrorIs(t, r.enqueue([]byte("{}")), ErrPublishQueueFull)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		t.Parallel()
+		r := newRedisRelay(RedisConfig{}, nil, nil, zap.NewNop())
+		r.queueClosed = true
+		assert.ErrorIs(t, r.enqueue([]byte("{}")), ErrClosed)
+	})
+}
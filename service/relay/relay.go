@@ -0,0 +1,37 @@
+package relay
+
+import "errors"
+
+// ErrClosed 既に閉じられています
+var ErrClosed = errors.New("relay is closed")
+
+// Relay ノード間イベント中継インターフェイス
+//
+// hub.Hub はプロセス内でのみ動作するため、複数のtraQインスタンスを動かす場合は
+// WebSocketへの送信内容や各マネージャーの状態をこのインターフェイスを通じて他ノードと共有します。
+// 自ノードが送信したメッセージは自ノードには配送されません。
+type Relay interface {
+	// NodeID 自ノードのIDを返します
+	NodeID() string
+	// Publish 指定したトピックで他の全ノードにメッセージを送信します
+	//
+	// bodyはJSONにエンコードされます
+	Publish(topic string, body any) error
+	// Subscribe 指定したトピックの他ノードからのメッセージを購読します
+	//
+	// ハンドラは送信元ノードごとに送信順で、同期的に呼び出されます
+	Subscribe(topic string, handler Handler)
+	// OnNodeJoined 他ノードが参加した時に呼び出される関数を登録します
+	OnNodeJoined(f func(nodeID string))
+	// OnNodeLeft 他ノードが離脱、またはタイムアウトした時に呼び出される関数を登録します
+	OnNodeLeft(f func(nodeID string))
+	// IsLeader 生存しているノードの中で自ノードが代表ノードかどうかを返します
+	//
+	// ノードの離脱に伴う処理を一つのノードだけで行いたい場合に用います
+	IsLeader() bool
+	// Close 中継を終了します
+	Close() error
+}
+
+// Handler 他ノードからのメッセージハンドラ
+type Handler func(nodeID string, body []byte)
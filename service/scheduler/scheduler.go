@@ -0,0 +1,238 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/rbac"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+)
+
+const (
+	// pollInterval 投稿予定の予約投稿メッセージを確認する間隔
+	pollInterval = 10 * time.Second
+	// fetchLimit 一度に処理する予約投稿メッセージの最大数
+	fetchLimit = 100
+	// sendingTimeout 投稿処理中のまま放置された予約投稿メッセージを投稿失敗とするまでの時間
+	sendingTimeout = 5 * time.Minute
+)
+
+// 投稿失敗理由 (予約したユーザーに通知されます)
+const (
+	reasonUserInactive      = "the user is not active"
+	reasonChannelForbidden  = "the channel is not accessible"
+	reasonChannelArchived   = "the channel has been archived"
+	reasonPermissionDenied  = "the user is not permitted to post messages to the channel"
+	reasonUserNotFound      = "the destination user was not found"
+	reasonInternalError     = "an internal error occurred"
+	reasonInvalidScheduling = "neither channel nor destination user is specified"
+	reasonInterrupted       = "posting was interrupted"
+)
+
+// Scheduler 予約投稿スケジューラー
+//
+// 投稿予定日時を過ぎた予約投稿メッセージを投稿します。
+// 複数のtraQインスタンスで動作させても、各予約投稿メッセージはAcquireScheduledMessageで処理権を得た一つのインスタンスのみが投稿します。
+type Scheduler struct {
+	repo   repository.Repository
+	cm     channel.Manager
+	mm     message.Manager
+	rbac   rbac.RBAC
+	logger *zap.Logger
+
+	closer chan struct{}
+	wg     sync.WaitGroup
+	once   sync.Once
+}
+
+// NewScheduler 予約投稿スケジューラーを生成します
+func NewScheduler(repo repository.Repository, cm channel.Manager, mm message.Manager, rbac rbac.RBAC, logger *zap.Logger) *Scheduler {
+	return &Scheduler{
+		repo:   repo,
+		cm:     cm,
+		mm:     mm,
+		rbac:   rbac,
+		logger: logger.Named("scheduler"),
+		closer: make(chan struct{}),
+	}
+}
+
+// Start スケジューラーを開始します
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// Shutdown スケジューラーを停止します
+//
+// 投稿処理中のメッセージがある場合は、その処理が終わるまで待ちます
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.once.Do(func() { close(s.closer) })
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) loop() {
+	defer s.wg.Done()
+	t := time.NewTicker(pollInterval)
+	defer t.Stop()
+
+	for {
+		s.process(time.Now())
+		select {
+		case <-t.C:
+		case <-s.closer:
+			return
+		}
+	}
+}
+
+// process 指定した日時までに投稿予定の予約投稿メッセージを投稿します
+func (s *Scheduler) process(now time.Time) {
+	s.failStale(now)
+	for {
+		sms, err := s.repo.GetDueScheduledMessages(now, fetchLimit)
+		if err != nil {
+			s.logger.Error("failed to GetDueScheduledMessages", zap.Error(err))
+			return
+		}
+		for _, sm := range sms {
+			select {
+			case <-s.closer:
+				return
+			default:
+			}
+			s.send(sm)
+		}
+		if len(sms) < fetchLimit {
+			return
+		}
+	}
+}
+
+// failStale 投稿処理中のままインスタンスの停止などで中断された予約投稿メッセージを投稿失敗にします
+//
+// メッセージが既に投稿されている可能性があるため、再投稿はしません
+func (s *Scheduler) failStale(now time.Time) {
+	sms, err := s.repo.GetStaleScheduledMessages(now.Add(-sendingTimeout), fetchLimit)
+	if err != nil {
+		s.logger.Error("failed to GetStaleScheduledMessages", zap.Error(err))
+		return
+	}
+	for _, sm := range sms {
+		s.logger.Warn("scheduled message was left sending", zap.Stringer("scheduledMessageId", sm.ID))
+		if err := s.repo.FailScheduledMessage(sm.ID, reasonInterrupted); err != nil {
+			s.logger.Error("failed to FailScheduledMessage", zap.Error(err), zap.Stringer("scheduledMessageId", sm.ID))
+		}
+	}
+}
+
+// send 予約投稿メッセージを投稿します
+func (s *Scheduler) send(sm *model.ScheduledMessage) {
+	ok, err := s.repo.AcquireScheduledMessage(sm.ID)
+	if err != nil {
+		s.logger.Error("failed to AcquireScheduledMessage", zap.Error(err), zap.Stringer("scheduledMessageId", sm.ID))
+		return
+	}
+	if !ok {
+		return // 他のインスタンスが処理しているか、取り消された
+	}
+
+	m, reason := s.create(sm)
+	if len(reason) > 0 {
+		if err := s.repo.FailScheduledMessage(sm.ID, reason); err != nil {
+			s.logger.Error("failed to FailScheduledMessage", zap.Error(err), zap.Stringer("scheduledMessageId", sm.ID))
+		}
+		return
+	}
+	if err := s.repo.CompleteScheduledMessage(sm.ID, m.GetID()); err != nil {
+		s.logger.Error("failed to CompleteScheduledMessage", zap.Error(err), zap.Stringer("scheduledMessageId", sm.ID))
+	}
+}
+
+// create 予約投稿メッセージからメッセージを作成します
+//
+// 失敗した場合は、予約したユーザーに通知する失敗理由を返します
+func (s *Scheduler) create(sm *model.ScheduledMessage) (message.Message, string) {
+	user, err := s.repo.GetUser(sm.UserID, false)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, reasonUserInactive
+		}
+		s.logger.Error("failed to GetUser", zap.Error(err), zap.Stringer("scheduledMessageId", sm.ID))
+		return nil, reasonInternalError
+	}
+	if !user.IsActive() {
+		return nil, reasonUserInactive
+	}
+
+	var m message.Message
+	switch {
+	case sm.DMUserID.Valid:
+		if reason := s.checkDMUser(sm.ID, sm.DMUserID.V); len(reason) > 0 {
+			return nil, reason
+		}
+		m, err = s.mm.CreateDM(sm.UserID, sm.DMUserID.V, sm.Content)
+	case sm.ChannelID.Valid:
+		if reason := s.checkChannel(sm.ID, user, sm.ChannelID.V); len(reason) > 0 {
+			return nil, reason
+		}
+		m, err = s.mm.Create(sm.ChannelID.V, sm.UserID, sm.Content)
+	default:
+		return nil, reasonInvalidScheduling
+	}
+	if err != nil {
+		if errors.Is(err, message.ErrChannelArchived) {
+			return nil, reasonChannelArchived
+		}
+		s.logger.Error("failed to create a scheduled message", zap.Error(err), zap.Stringer("scheduledMessageId", sm.ID))
+		return nil, reasonInternalError
+	}
+	return m, ""
+}
+
+func (s *Scheduler) checkDMUser(id, userID uuid.UUID) string {
+	if _, err := s.repo.GetUser(userID, false); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return reasonUserNotFound
+		}
+		s.logger.Error("failed to GetUser", zap.Error(err), zap.Stringer("scheduledMessageId", id))
+		return reasonInternalError
+	}
+	return ""
+}
+
+func (s *Scheduler) checkChannel(id uuid.UUID, user model.UserInfo, channelID uuid.UUID) string {
+	ok, err := s.cm.IsChannelAccessibleToUser(user.GetID(), channelID)
+	if err != nil {
+		s.logger.Error("failed to IsChannelAccessibleToUser", zap.Error(err), zap.Stringer("scheduledMessageId", id))
+		return reasonInternalError
+	}
+	if !ok {
+		return reasonChannelForbidden
+	}
+	// 予約後にロールやチャンネル権限設定が変更されている可能性がある
+	if !s.rbac.IsGrantedInChannel(user.GetRole(), user.GetID(), channelID, permission.PostMessage) {
+		return reasonPermissionDenied
+	}
+	return ""
+}
@@ -0,0 +1,220 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/rbac/role"
+	"github.com/traPtitech/traQ/testutils"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+type fakeRepository struct {
+	repository.Repository
+	mu       sync.Mutex
+	users    map[uuid.UUID]*model.User
+	messages map[uuid.UUID]*model.ScheduledMessage
+}
+
+func (r *fakeRepository) GetUser(id uuid.UUID, _ bool) (model.UserInfo, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return u, nil
+}
+
+func (r *fakeRepository) GetDueScheduledMessages(until time.Time, limit int) ([]*model.ScheduledMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	arr := make([]*model.ScheduledMessage, 0)
+	for _, m := range r.messages {
+		if m.Status == model.ScheduledMessageStatusPending && !m.ScheduledAt.After(until) && len(arr) < limit {
+			c := *m
+			arr = append(arr, &c)
+		}
+	}
+	return arr, nil
+}
+
+func (r *fakeRepository) GetStaleScheduledMessages(acquiredBefore time.Time, limit int) ([]*model.ScheduledMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	arr := make([]*model.ScheduledMessage, 0)
+	for _, m := range r.messages {
+		if m.Status == model.ScheduledMessageStatusSending && m.AcquiredAt.Valid && m.AcquiredAt.V.Before(acquiredBefore) && len(arr) < limit {
+			c := *m
+			arr = append(arr, &c)
+		}
+	}
+	return arr, nil
+}
+
+func (r *fakeRepository) AcquireScheduledMessage(id uuid.UUID) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.messages[id]
+	if m.Status != model.ScheduledMessageStatusPending {
+		return false, nil
+	}
+	m.Status = model.ScheduledMessageStatusSending
+	m.AcquiredAt = optional.From(time.Now())
+	return true, nil
+}
+
+func (r *fakeRepository) CompleteScheduledMessage(id, messageID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.messages[id]
+	m.Status = model.ScheduledMessageStatusSent
+	m.MessageID = optional.From(messageID)
+	return nil
+}
+
+func (r *fakeRepository) FailScheduledMessage(id uuid.UUID, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.messages[id]
+	m.Status = model.ScheduledMessageStatusFailed
+	m.Error = reason
+	return nil
+}
+
+type fakeChannelManager struct {
+	channel.Manager
+	accessible map[uuid.UUID]bool
+}
+
+func (cm *fakeChannelManager) IsChannelAccessibleToUser(_, channelID uuid.UUID) (bool, error) {
+	return cm.accessible[channelID], nil
+}
+
+type fakeMessage struct {
+	message.Message
+	id uuid.UUID
+}
+
+func (m *fakeMessage) GetID() uuid.UUID {
+	return m.id
+}
+
+type fakeMessageManager struct {
+	message.Manager
+	archived map[uuid.UUID]bool
+	created  []string
+}
+
+func (mm *fakeMessageManager) Create(channelID, _ uuid.UUID, content string) (message.Message, error) {
+	if mm.archived[channelID] {
+		return nil, message.ErrChannelArchived
+	}
+	mm.created = append(mm.created, content)
+	return &fakeMessage{id: uuid.Must(uuid.NewV4())}, nil
+}
+
+func (mm *fakeMessageManager) CreateDM(_, _ uuid.UUID, content string) (message.Message, error) {
+	mm.created = append(mm.created, content)
+	return &fakeMessage{id: uuid.Must(uuid.NewV4())}, nil
+}
+
+func TestScheduler_process(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	user := &model.User{ID: uuid.Must(uuid.NewV4()), Status: model.UserAccountStatusActive, Role: role.User}
+	inactive := &model.User{ID: uuid.Must(uuid.NewV4()), Status: model.UserAccountStatusDeactivated, Role: role.User}
+	readOnly := &model.User{ID: uuid.Must(uuid.NewV4()), Status: model.UserAccountStatusActive, Role: role.Read}
+	dmTarget := &model.User{ID: uuid.Must(uuid.NewV4()), Status: model.UserAccountStatusActive, Role: role.User}
+	ch := uuid.Must(uuid.NewV4())
+	archived := uuid.Must(uuid.NewV4())
+	private := uuid.Must(uuid.NewV4())
+
+	newMessage := func(userID uuid.UUID, channelID, dmUserID optional.Of[uuid.UUID], content string, at time.Time) *model.ScheduledMessage {
+		return &model.ScheduledMessage{
+			ID:          uuid.Must(uuid.NewV4()),
+			UserID:      userID,
+			ChannelID:   channelID,
+			DMUserID:    dmUserID,
+			Content:     content,
+			ScheduledAt: at,
+			Status:      model.ScheduledMessageStatusPending,
+		}
+	}
+	sent := newMessage(user.ID, optional.From(ch), optional.Of[uuid.UUID]{}, "channel", now.Add(-time.Minute))
+	dm := newMessage(user.ID, optional.Of[uuid.UUID]{}, optional.From(dmTarget.ID), "dm", now)
+	future := newMessage(user.ID, optional.From(ch), optional.Of[uuid.UUID]{}, "future", now.Add(time.Hour))
+	archivedMsg := newMessage(user.ID, optional.From(archived), optional.Of[uuid.UUID]{}, "archived", now)
+	privateMsg := newMessage(user.ID, optional.From(private), optional.Of[uuid.UUID]{}, "private", now)
+	inactiveMsg := newMessage(inactive.ID, optional.From(ch), optional.Of[uuid.UUID]{}, "inactive", now)
+	readOnlyMsg := newMessage(readOnly.ID, optional.From(ch), optional.Of[uuid.UUID]{}, "read only", now)
+	unknownDM := newMessage(user.ID, optional.Of[uuid.UUID]{}, optional.From(uuid.Must(uuid.NewV4())), "unknown", now)
+	stale := newMessage(user.ID, optional.From(ch), optional.Of[uuid.UUID]{}, "stale", now.Add(-time.Hour))
+	stale.Status = model.ScheduledMessageStatusSending
+	stale.AcquiredAt = optional.From(now.Add(-sendingTimeout - time.Minute))
+
+	repo := &fakeRepository{
+		users:    map[uuid.UUID]*model.User{user.ID: user, inactive.ID: inactive, readOnly.ID: readOnly, dmTarget.ID: dmTarget},
+		messages: map[uuid.UUID]*model.ScheduledMessage{},
+	}
+	for _, m := range []*model.ScheduledMessage{sent, dm, future, archivedMsg, privateMsg, inactiveMsg, readOnlyMsg, unknownDM, stale} {
+		repo.messages[m.ID] = m
+	}
+	cm := &fakeChannelManager{accessible: map[uuid.UUID]bool{ch: true, archived: true}}
+	mm := &fakeMessageManager{archived: map[uuid.UUID]bool{archived: true}}
+
+	s := NewScheduler(repo, cm, mm, testutils.NewTestRBAC(), zap.NewNop())
+	s.process(now)
+
+	assert.ElementsMatch(t, []string{"channel", "dm"}, mm.created)
+
+	assert.Equal(t, model.ScheduledMessageStatusSent, sent.Status)
+	assert.True(t, sent.MessageID.Valid)
+	assert.Equal(t, model.ScheduledMessageStatusSent, dm.Status)
+	assert.Equal(t, model.ScheduledMessageStatusPending, future.Status)
+
+	tests := []struct {
+		m      *model.ScheduledMessage
+		reason string
+	}{
+		{archivedMsg, reasonChannelArchived},
+		{privateMsg, reasonChannelForbidden},
+		{inactiveMsg, reasonUserInactive},
+		{readOnlyMsg, reasonPermissionDenied},
+		{unknownDM, reasonUserNotFound},
+		// 中断されたものは再投稿せずに投稿失敗にする
+		{stale, reasonInterrupted},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, model.ScheduledMessageStatusFailed, tt.m.Status, tt.m.Content)
+		assert.Equal(t, tt.reason, tt.m.Error, tt.m.Content)
+	}
+
+	// 処理済みのものは再度投稿されない
+	s.process(now.Add(time.Second))
+	assert.Len(t, mm.created, 2)
+}
+
+func TestScheduler_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepository{messages: map[uuid.UUID]*model.ScheduledMessage{}}
+	s := NewScheduler(repo, &fakeChannelManager{}, &fakeMessageManager{}, testutils.NewTestRBAC(), zap.NewNop())
+	s.Start()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, s.Shutdown(ctx))
+	// 2回呼んでも問題ない
+	assert.NoError(t, s.Shutdown(ctx))
+}
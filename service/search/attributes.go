@@ -0,0 +1,67 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+type attributes struct {
+	To             []uuid.UUID
+	Citation       []uuid.UUID
+	HasURL         bool
+	HasAttachments bool
+	HasImage       bool
+	HasVideo       bool
+	HasAudio       bool
+}
+
+// ユーザーがbotかどうかのcache
+type userCache map[uuid.UUID]bool
+
+// getAttributes メッセージの検索用属性を抽出します
+func getAttributes(repo repository.Repository, l *zap.Logger, m *model.Message, parseResult *message.ParseResult) *attributes {
+	attr := &attributes{}
+
+	attr.To = append(parseResult.Mentions, parseResult.GroupMentions...)
+	attr.Citation = parseResult.Citation
+	attr.HasURL = strings.Contains(m.Text, "http://") || strings.Contains(m.Text, "https://")
+	attr.HasAttachments = len(parseResult.Attachments) != 0
+
+	for _, attachmentID := range parseResult.Attachments {
+		meta, err := repo.GetFileMeta(attachmentID)
+		if err != nil {
+			l.Warn(err.Error(), zap.Error(err))
+			continue
+		}
+		if strings.HasPrefix(meta.Mime, "image/") {
+			attr.HasImage = true
+		} else if strings.HasPrefix(meta.Mime, "video/") {
+			attr.HasVideo = true
+		} else if strings.HasPrefix(meta.Mime, "audio/") {
+			attr.HasAudio = true
+		}
+	}
+
+	return attr
+}
+
+// newUserCache 全ユーザーについてbotかどうかのキャッシュを作成します
+func newUserCache(repo repository.Repository, l *zap.Logger) (userCache, error) {
+	users, err := repo.GetUsers(repository.UsersQuery{})
+	if err != nil {
+		return nil, err
+	}
+	l.Debug("making user cache of size", zap.Int("size", len(users)))
+
+	cache := make(map[uuid.UUID]bool, len(users))
+	for _, u := range users {
+		cache[u.GetID()] = u.IsBot()
+	}
+	return cache, nil
+}
@@ -12,8 +12,10 @@ import (
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
 	"go.uber.org/zap"
 
+	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/service/message"
@@ -173,7 +175,7 @@ var esSetting = m{
 }
 
 // NewESEngine Elasticsearch検索エンジンを生成します
-func NewESEngine(mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger, config ESEngineConfig) (Engine, error) {
+func NewESEngine(hub *hub.Hub, mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger, config ESEngineConfig) (Engine, error) {
 	// esクライアント作成
 	client, err := elasticsearch.NewClient(elasticsearch.Config{
 		Addresses: []string{config.URL},
@@ -253,6 +255,7 @@ func NewESEngine(mm message.Manager, cm channel.Manager, repo repository.Reposit
 	}
 
 	go engine.syncLoop(done)
+	go engine.eventLoop(hub.Subscribe(10, event.MessagesMoved, event.ChannelDeleted))
 
 	return engine, nil
 }
@@ -330,8 +333,8 @@ func (e *esEngine) Do(q *Query) (Result, error) {
 		}}})
 	}
 
-	// チャンネル指定があるときはそのチャンネルを検索
-	// そうでないときはPublicチャンネルを検索
+	// チャンネル指定があるときはそのチャンネルを検索 (アクセス権はハンドラで確認済み)
+	// そうでないときはPublicチャンネルを検索 (DM・プライベートチャンネルのメッセージはIsPublic=falseで索引される)
 	if q.In.Valid {
 		musts = append(musts, searchQuery{"term": termQuery{"channelId": termQueryParameter{Value: q.In}}})
 	} else {
@@ -5,16 +5,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v8/esutil"
 	"github.com/gofrs/uuid"
 	json "github.com/json-iterator/go"
+	"github.com/leandro-lugaresi/hub"
 	"go.uber.org/zap"
 
+	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/model"
-	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/utils/message"
 )
 
@@ -23,19 +23,6 @@ const (
 	syncMessageBulk = 250
 )
 
-type attributes struct {
-	To             []uuid.UUID
-	Citation       []uuid.UUID
-	HasURL         bool
-	HasAttachments bool
-	HasImage       bool
-	HasVideo       bool
-	HasAudio       bool
-}
-
-// ユーザーがbotかどうかのcache
-type userCache map[uuid.UUID]bool
-
 // convertMessageCreated 新規メッセージをesへ入れる型に変換する
 func (e *esEngine) convertMessageCreated(m *model.Message, parseResult *message.ParseResult, userCache userCache) (*esMessageDoc, error) {
 	var isBot, ok bool
@@ -48,7 +35,7 @@ func (e *esEngine) convertMessageCreated(m *model.Message, parseResult *message.
 		isBot = user.IsBot()
 	}
 
-	attr := e.getAttributes(m, parseResult)
+	attr := getAttributes(e.repo, e.l, m, parseResult)
 
 	return &esMessageDoc{
 		UserID:         m.UserID,
@@ -70,7 +57,7 @@ func (e *esEngine) convertMessageCreated(m *model.Message, parseResult *message.
 
 // convertMessageUpdated 既存メッセージの更新情報をesへ入れる型に変換する
 func (e *esEngine) convertMessageUpdated(m *model.Message, parseResult *message.ParseResult) *esMessageDocUpdate {
-	attr := e.getAttributes(m, parseResult)
+	attr := getAttributes(e.repo, e.l, m, parseResult)
 	// Updateする項目のみ
 	return &esMessageDocUpdate{
 		Text:           m.Text,
@@ -84,32 +71,6 @@ func (e *esEngine) convertMessageUpdated(m *model.Message, parseResult *message.
 	}
 }
 
-func (e *esEngine) getAttributes(m *model.Message, parseResult *message.ParseResult) *attributes {
-	attr := &attributes{}
-
-	attr.To = append(parseResult.Mentions, parseResult.GroupMentions...)
-	attr.Citation = parseResult.Citation
-	attr.HasURL = strings.Contains(m.Text, "http://") || strings.Contains(m.Text, "https://")
-	attr.HasAttachments = len(parseResult.Attachments) != 0
-
-	for _, attachmentID := range parseResult.Attachments {
-		meta, err := e.repo.GetFileMeta(attachmentID)
-		if err != nil {
-			e.l.Warn(err.Error(), zap.Error(err))
-			continue
-		}
-		if strings.HasPrefix(meta.Mime, "image/") {
-			attr.HasImage = true
-		} else if strings.HasPrefix(meta.Mime, "video/") {
-			attr.HasVideo = true
-		} else if strings.HasPrefix(meta.Mime, "audio/") {
-			attr.HasAudio = true
-		}
-	}
-
-	return attr
-}
-
 func (e *esEngine) syncLoop(done <-chan struct{}) {
 	t := time.NewTicker(syncInterval)
 	defer t.Stop()
@@ -128,18 +89,77 @@ loop:
 	}
 }
 
-func (e *esEngine) newUserCache() (userCache, error) {
-	users, err := e.repo.GetUsers(repository.UsersQuery{})
+// eventLoop メッセージの移動・チャンネルの削除をesへ反映します
+//
+// これらの操作ではメッセージのupdatedAtが変化しないため、syncでは反映されません
+func (e *esEngine) eventLoop(sub hub.Subscription) {
+	for ev := range sub.Receiver {
+		var err error
+		switch ev.Topic() {
+		case event.MessagesMoved:
+			err = e.moveMessages(ev.Fields["message_ids"].([]uuid.UUID), ev.Fields["to_channel_id"].(uuid.UUID))
+		case event.ChannelDeleted:
+			err = e.deleteChannelMessages(ev.Fields["channel_id"].(uuid.UUID))
+		}
+		if err != nil {
+			e.l.Error(err.Error(), zap.Error(err))
+		}
+	}
+}
+
+// moveMessages index上のメッセージのチャンネルを変更します
+func (e *esEngine) moveMessages(ids []uuid.UUID, channelID uuid.UUID) error {
+	data, err := json.Marshal(map[string]any{"doc": map[string]any{
+		"channelId": channelID,
+		"isPublic":  e.cm.IsPublicChannel(channelID),
+	}})
+	if err != nil {
+		return err
+	}
+
+	bulkIndexer, _ := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
+		Client: e.client,
+		Index:  getIndexName(esMessageIndex),
+	})
+	for _, id := range ids {
+		err = bulkIndexer.Add(context.Background(), esutil.BulkIndexerItem{
+			Action:     "update",
+			DocumentID: id.String(),
+			Body:       bytes.NewReader(data),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := bulkIndexer.Close(context.Background()); err != nil {
+		return err
+	}
+
+	e.l.Info(fmt.Sprintf("moved %v message(s) on index to channel %v, failed %v message(s)",
+		bulkIndexer.Stats().NumUpdated, channelID, bulkIndexer.Stats().NumFailed))
+	return nil
+}
+
+// deleteChannelMessages 指定したチャンネルのメッセージをindexから削除します
+func (e *esEngine) deleteChannelMessages(channelID uuid.UUID) error {
+	body, err := json.Marshal(newSearchBody([]searchQuery{
+		{"term": termQuery{"channelId": termQueryParameter{Value: channelID}}},
+	}))
 	if err != nil {
-		return nil, err
+		return err
 	}
-	e.l.Debug("making user cache of size", zap.Int("size", len(users)))
 
-	cache := make(map[uuid.UUID]bool, len(users))
-	for _, u := range users {
-		cache[u.GetID()] = u.IsBot()
+	res, err := e.client.DeleteByQuery([]string{getIndexName(esMessageIndex)}, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("failed to delete messages of channel %v from index: %s", channelID, res.String())
 	}
-	return cache, nil
+
+	e.l.Info(fmt.Sprintf("deleted messages of channel %v from index", channelID))
+	return nil
 }
 
 // sync メッセージを repository.MessageRepository から読み取り、esへindexします
@@ -167,7 +187,7 @@ func (e *esEngine) sync() error {
 		// ユーザーキャッシュサービスができたら書き換えても良い
 		if userCache == nil && more {
 			// 新規メッセージが2ページ以上の時のみデータが入ったキャッシュを作成
-			userCache, err = e.newUserCache()
+			userCache, err = newUserCache(e.repo, e.l)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,199 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/gormutil"
+)
+
+// MariaDBEngineConfig MariaDB組み込み検索エンジン設定
+type MariaDBEngineConfig struct {
+	// Enabled 組み込み検索エンジンを使用するかどうか
+	Enabled bool
+}
+
+// mariadbEngine search.Engine 実装
+type mariadbEngine struct {
+	db   *gorm.DB
+	mm   message.Manager
+	cm   channel.Manager
+	repo repository.Repository
+	l    *zap.Logger
+	done chan<- struct{}
+}
+
+// NewMariaDBEngine MariaDBのFULLTEXTインデックスを用いた組み込み検索エンジンを生成します
+func NewMariaDBEngine(db *gorm.DB, hub *hub.Hub, mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger) (Engine, error) {
+	if !db.Migrator().HasTable(&model.MessageSearchIndex{}) {
+		return nil, fmt.Errorf("failed to init MariaDB search engine: table %s does not exist", (&model.MessageSearchIndex{}).TableName())
+	}
+
+	done := make(chan struct{})
+	engine := &mariadbEngine{
+		db:   db,
+		mm:   mm,
+		cm:   cm,
+		repo: repo,
+		l:    logger.Named("search"),
+		done: done,
+	}
+
+	go engine.syncLoop(done)
+	go engine.eventLoop(hub.Subscribe(10, event.MessagesMoved, event.ChannelDeleted))
+
+	return engine, nil
+}
+
+func (e *mariadbEngine) Do(q *Query) (Result, error) {
+	e.l.Debug("do search", zap.Reflect("q", q))
+
+	var against []string
+	if q.Word.Valid {
+		against = append(against, buildWordQuery(q.Word.V)...)
+	}
+	if q.To.Valid {
+		against = append(against, "+"+uuidToken(tokenToPrefix, q.To.V))
+	}
+	if q.Citation.Valid {
+		against = append(against, "+"+uuidToken(tokenCitationPrefix, q.Citation.V))
+	}
+
+	where := func(db *gorm.DB) *gorm.DB {
+		db = db.Model(&model.MessageSearchIndex{})
+		if len(against) > 0 {
+			db = db.Where("MATCH(`tokens`) AGAINST(? IN BOOLEAN MODE)", strings.Join(against, " "))
+		}
+
+		if q.After.Valid {
+			db = db.Where("`created_at` > ?", q.After.V)
+		}
+		if q.Before.Valid {
+			db = db.Where("`created_at` < ?", q.Before.V)
+		}
+
+		// チャンネル指定があるときはそのチャンネルを検索 (アクセス権はハンドラで確認済み)
+		// そうでないときはPublicチャンネルを検索 (DM・プライベートチャンネルのメッセージはIsPublic=falseで索引される)
+		if q.In.Valid {
+			db = db.Where("`channel_id` = ?", q.In.V)
+		} else {
+			db = db.Where("`is_public` = ?", true)
+		}
+
+		if q.From.Valid {
+			db = db.Where("`user_id` = ?", q.From.V)
+		}
+		if q.Bot.Valid {
+			db = db.Where("`bot` = ?", q.Bot.V)
+		}
+		if q.HasURL.Valid {
+			db = db.Where("`has_url` = ?", q.HasURL.V)
+		}
+		if q.HasAttachments.Valid {
+			db = db.Where("`has_attachments` = ?", q.HasAttachments.V)
+		}
+		if q.HasImage.Valid {
+			db = db.Where("`has_image` = ?", q.HasImage.V)
+		}
+		if q.HasVideo.Valid {
+			db = db.Where("`has_video` = ?", q.HasVideo.V)
+		}
+		if q.HasAudio.Valid {
+			db = db.Where("`has_audio` = ?", q.HasAudio.V)
+		}
+		return db
+	}
+
+	limit, offset := 20, 0
+	if q.Limit.Valid {
+		limit = q.Limit.V
+	}
+	if q.Offset.Valid {
+		offset = q.Offset.V
+	}
+
+	var totalHits int64
+	if err := e.db.Scopes(where).Count(&totalHits).Error; err != nil {
+		return nil, err
+	}
+
+	var messageIDs []uuid.UUID
+	if err := e.db.
+		Scopes(where, gormutil.LimitAndOffset(limit, offset)).
+		Order(getOrderClause(q.GetSortKey())).
+		Pluck("message_id", &messageIDs).
+		Error; err != nil {
+		return nil, err
+	}
+
+	messages, err := e.mm.GetIn(messageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	messagesMap := lo.SliceToMap(messages, func(m message.Message) (uuid.UUID, message.Message) {
+		return m.GetID(), m
+	})
+	r := &mariadbResult{
+		totalHits: totalHits,
+		messages:  make([]message.Message, 0, len(messageIDs)),
+	}
+	// sort result
+	for _, id := range messageIDs {
+		msg, ok := messagesMap[id]
+		if !ok {
+			// インデックスの同期前に削除されたメッセージ
+			continue
+		}
+		r.messages = append(r.messages, msg)
+	}
+
+	return r, nil
+}
+
+// getOrderClause Query.GetSortKey の結果をORDER BY句に変換します
+func getOrderClause(sortKey string) string {
+	key, order, _ := strings.Cut(sortKey, ":")
+	column := "created_at"
+	if key == updatedAtSortKey {
+		column = "updated_at"
+	}
+	if order == ascSortKey {
+		return fmt.Sprintf("`%s` ASC, `message_id` ASC", column)
+	}
+	return fmt.Sprintf("`%s` DESC, `message_id` DESC", column)
+}
+
+func (e *mariadbEngine) Available() bool {
+	return true
+}
+
+func (e *mariadbEngine) Close() error {
+	e.done <- struct{}{}
+	return nil
+}
+
+// mariadbResult search.Result 実装
+type mariadbResult struct {
+	totalHits int64
+	messages  []message.Message
+}
+
+func (r *mariadbResult) TotalHits() int64 {
+	return r.totalHits
+}
+
+func (r *mariadbResult) Hits() []message.Message {
+	return r.messages
+}
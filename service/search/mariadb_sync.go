@@ -0,0 +1,214 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+// convertMessage メッセージをインデックスへ入れる型に変換する
+func (e *mariadbEngine) convertMessage(m *model.Message, parseResult *message.ParseResult, userCache userCache) (*model.MessageSearchIndex, error) {
+	var isBot, ok bool
+	if isBot, ok = userCache[m.UserID]; !ok {
+		// 新規ユーザー or キャッシュが存在しない
+		user, err := e.repo.GetUser(m.UserID, false)
+		if err != nil {
+			return nil, err
+		}
+		isBot = user.IsBot()
+	}
+
+	attr := getAttributes(e.repo, e.l, m, parseResult)
+
+	return &model.MessageSearchIndex{
+		MessageID:      m.ID,
+		UserID:         m.UserID,
+		ChannelID:      m.ChannelID,
+		IsPublic:       e.cm.IsPublicChannel(m.ChannelID),
+		Bot:            isBot,
+		Tokens:         tokenizeMessage(m.Text, attr),
+		HasURL:         attr.HasURL,
+		HasAttachments: attr.HasAttachments,
+		HasImage:       attr.HasImage,
+		HasVideo:       attr.HasVideo,
+		HasAudio:       attr.HasAudio,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}, nil
+}
+
+func (e *mariadbEngine) syncLoop(done <-chan struct{}) {
+	t := time.NewTicker(syncInterval)
+	defer t.Stop()
+loop:
+	for {
+		err := e.sync()
+		if err != nil {
+			e.l.Error(err.Error(), zap.Error(err))
+		}
+
+		select {
+		case <-t.C:
+		case <-done:
+			break loop
+		}
+	}
+}
+
+// eventLoop メッセージの移動・チャンネルの削除をインデックステーブルに反映します
+//
+// これらの操作ではメッセージのupdatedAtが変化しないため、syncでは反映されません
+func (e *mariadbEngine) eventLoop(sub hub.Subscription) {
+	for ev := range sub.Receiver {
+		var err error
+		switch ev.Topic() {
+		case event.MessagesMoved:
+			err = e.moveMessages(ev.Fields["message_ids"].([]uuid.UUID), ev.Fields["to_channel_id"].(uuid.UUID))
+		case event.ChannelDeleted:
+			err = e.deleteChannelMessages(ev.Fields["channel_id"].(uuid.UUID))
+		}
+		if err != nil {
+			e.l.Error(err.Error(), zap.Error(err))
+		}
+	}
+}
+
+// moveMessages インデックス上のメッセージのチャンネルを変更します
+func (e *mariadbEngine) moveMessages(ids []uuid.UUID, channelID uuid.UUID) error {
+	isPublic := e.cm.IsPublicChannel(channelID)
+	var moved int64
+	for _, chunk := range lo.Chunk(ids, syncMessageBulk) {
+		result := e.db.
+			Model(&model.MessageSearchIndex{}).
+			Where("`message_id` IN ?", chunk).
+			Updates(map[string]any{"channel_id": channelID, "is_public": isPublic})
+		if result.Error != nil {
+			return result.Error
+		}
+		moved += result.RowsAffected
+	}
+
+	e.l.Info(fmt.Sprintf("moved %v message(s) on index to channel %v", moved, channelID))
+	return nil
+}
+
+// deleteChannelMessages 指定したチャンネルのメッセージをインデックスから削除します
+func (e *mariadbEngine) deleteChannelMessages(channelID uuid.UUID) error {
+	result := e.db.Where("`channel_id` = ?", channelID).Delete(&model.MessageSearchIndex{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	e.l.Info(fmt.Sprintf("deleted %v message(s) of channel %v from index", result.RowsAffected, channelID))
+	return nil
+}
+
+// sync メッセージを repository.MessageRepository から読み取り、インデックステーブルに書き込みます
+func (e *mariadbEngine) sync() error {
+	e.l.Debug("syncing messages with search index")
+
+	lastSynced, err := e.lastInsertedUpdated()
+	if err != nil {
+		return err
+	}
+
+	var userCache userCache
+	lastInsert := lastSynced
+	for {
+		messages, more, err := e.repo.GetUpdatedMessagesAfter(lastInsert, syncMessageBulk)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			break
+		}
+		lastInsert = messages[len(messages)-1].UpdatedAt
+
+		if userCache == nil && more {
+			// 新規メッセージが2ページ以上の時のみデータが入ったキャッシュを作成
+			userCache, err = newUserCache(e.repo, e.l)
+			if err != nil {
+				return err
+			}
+		}
+
+		docs := make([]*model.MessageSearchIndex, 0, len(messages))
+		for _, v := range messages {
+			doc, err := e.convertMessage(v, message.Parse(v.Text), userCache)
+			if err != nil {
+				return err
+			}
+			docs = append(docs, doc)
+		}
+
+		// 作成・更新どちらもUPSERTで反映する
+		if err := e.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&docs).Error; err != nil {
+			return err
+		}
+
+		e.l.Info(fmt.Sprintf("indexed %v message(s), last insert %v", len(docs), lastInsert))
+
+		if !more {
+			break
+		}
+	}
+
+	lastDelete := lastSynced
+	for {
+		messages, more, err := e.repo.GetDeletedMessagesAfter(lastDelete, syncMessageBulk)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			break
+		}
+		if !messages[len(messages)-1].DeletedAt.Valid {
+			return errors.New("expected DeletedAt to exist, but found nil")
+		}
+		lastDelete = messages[len(messages)-1].DeletedAt.Time
+
+		ids := utils.Map(messages, func(m *model.Message) uuid.UUID { return m.ID })
+		result := e.db.Where("`message_id` IN ?", ids).Delete(&model.MessageSearchIndex{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		e.l.Info(fmt.Sprintf("deleted %v message(s) from index, last delete %v", result.RowsAffected, lastDelete))
+
+		if !more {
+			break
+		}
+	}
+
+	return nil
+}
+
+// lastInsertedUpdated インデックスに存在している、updatedAtが一番新しいメッセージの値を取得します
+func (e *mariadbEngine) lastInsertedUpdated() (time.Time, error) {
+	var docs []*model.MessageSearchIndex
+	if err := e.db.
+		Select("updated_at").
+		Order("`updated_at` DESC").
+		Limit(1).
+		Find(&docs).
+		Error; err != nil {
+		return time.Time{}, err
+	}
+
+	if len(docs) == 0 {
+		return time.Time{}, nil
+	}
+
+	return docs[0].UpdatedAt, nil
+}
@@ -0,0 +1,66 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenizeMessage(t *testing.T) {
+	t.Parallel()
+
+	to := uuid.Must(uuid.FromString("3b4a0b5e-0e4b-4c2e-8b6a-0c1f8b4b0c6a"))
+	cite := uuid.Must(uuid.FromString("5f1d8b0c-8a7e-4f4a-9d7c-2b6f3e0a1c9d"))
+
+	t.Run("ascii", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, "b6162 u61 u62 u63", tokenizeMessage("AB, c", &attributes{}))
+	})
+
+	t.Run("multibyte", func(t *testing.T) {
+		t.Parallel()
+		// あい -> e38182 e38184
+		assert.Equal(t, "be38182e38184 ue38182 ue38184", tokenizeMessage("あい", &attributes{}))
+	})
+
+	t.Run("attributes", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t,
+			"u61 t3b4a0b5e0e4b4c2e8b6a0c1f8b4b0c6a c5f1d8b0c8a7e4f4a9d7c2b6f3e0a1c9d",
+			tokenizeMessage("a", &attributes{To: []uuid.UUID{to}, Citation: []uuid.UUID{cite}}),
+		)
+	})
+}
+
+func TestBuildWordQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		word string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"unigram", "a", []string{"+u61"}},
+		{"phrase", "abc", []string{`+"b6162 b6263"`}},
+		{"multiple terms", "ab c", []string{`+"b6162"`, "+u63"}},
+		{"exclude", "ab -c", []string{`+"b6162"`, "-u63"}},
+		{"symbols only", "!?", nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, buildWordQuery(tt.word))
+		})
+	}
+}
+
+func TestGetOrderClause(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "`created_at` DESC, `message_id` DESC", getOrderClause(Query{}.GetSortKey()))
+	assert.Equal(t, "`created_at` ASC, `message_id` ASC", getOrderClause("createdAt:asc"))
+	assert.Equal(t, "`updated_at` DESC, `message_id` DESC", getOrderClause("updatedAt:desc"))
+}
@@ -0,0 +1,93 @@
+package search
+
+import (
+	"encoding/hex"
+	"strings"
+	"unicode"
+
+	"github.com/gofrs/uuid"
+)
+
+// MariaDBにはngramパーサーが無いため、アプリケーション側でngramに分割した上で
+// 各トークンを16進数表記に変換し、デフォルトのFULLTEXTパーサーでそのまま扱えるようにする
+const (
+	tokenUnigramPrefix  = "u" // 1文字
+	tokenBigramPrefix   = "b" // 2文字
+	tokenToPrefix       = "t" // メンション先
+	tokenCitationPrefix = "c" // 引用メッセージ
+)
+
+// splitTerms 文字列を小文字化し、空白・記号で区切ります
+func splitTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+}
+
+func unigramToken(r rune) string {
+	return tokenUnigramPrefix + hex.EncodeToString([]byte(string(r)))
+}
+
+func bigramToken(r1, r2 rune) string {
+	return tokenBigramPrefix + hex.EncodeToString([]byte(string([]rune{r1, r2})))
+}
+
+func uuidToken(prefix string, id uuid.UUID) string {
+	return prefix + hex.EncodeToString(id.Bytes())
+}
+
+// bigrams 語を2-gramトークン列に変換します
+func bigrams(term []rune) []string {
+	if len(term) < 2 {
+		return nil
+	}
+	tokens := make([]string, 0, len(term)-1)
+	for i := 0; i < len(term)-1; i++ {
+		tokens = append(tokens, bigramToken(term[i], term[i+1]))
+	}
+	return tokens
+}
+
+// tokenizeMessage メッセージをインデックス用のトークン列に変換します
+//
+// フレーズ検索のため、語ごとに2-gramを連続して並べ、その後ろに1-gramを並べます
+func tokenizeMessage(text string, attr *attributes) string {
+	var tokens []string
+	for _, term := range splitTerms(text) {
+		runes := []rune(term)
+		tokens = append(tokens, bigrams(runes)...)
+		for _, r := range runes {
+			tokens = append(tokens, unigramToken(r))
+		}
+	}
+	for _, id := range attr.To {
+		tokens = append(tokens, uuidToken(tokenToPrefix, id))
+	}
+	for _, id := range attr.Citation {
+		tokens = append(tokens, uuidToken(tokenCitationPrefix, id))
+	}
+	return strings.Join(tokens, " ")
+}
+
+// buildWordQuery 検索ワードをBOOLEAN MODEのクエリ文字列に変換します
+//
+// 空白区切りの語は全て含む必要があり、先頭に`-`が付いた語は含まないものとします
+func buildWordQuery(word string) []string {
+	var exprs []string
+	for _, field := range strings.Fields(word) {
+		op := "+"
+		if strings.HasPrefix(field, "-") {
+			op = "-"
+			field = field[1:]
+		}
+		for _, term := range splitTerms(field) {
+			runes := []rune(term)
+			if len(runes) == 1 {
+				exprs = append(exprs, op+unigramToken(runes[0]))
+				continue
+			}
+			exprs = append(exprs, op+`"`+strings.Join(bigrams(runes), " ")+`"`)
+		}
+	}
+	return exprs
+}
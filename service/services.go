@@ -1,6 +1,7 @@
 package service
 
 import (
+	"github.com/traPtitech/traQ/service/audit"
 	"github.com/traPtitech/traQ/service/bot"
 	botWS "github.com/traPtitech/traQ/service/bot/ws"
 	"github.com/traPtitech/traQ/service/channel"
@@ -12,7 +13,10 @@ import (
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/notification"
 	"github.com/traPtitech/traQ/service/ogp"
+	"github.com/traPtitech/traQ/service/outgoingwebhook"
 	"github.com/traPtitech/traQ/service/rbac"
+	"github.com/traPtitech/traQ/service/relay"
+	"github.com/traPtitech/traQ/service/scheduler"
 	"github.com/traPtitech/traQ/service/search"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/webrtcv3"
@@ -20,6 +24,7 @@ import (
 )
 
 type Services struct {
+	AuditRecorder        *audit.Recorder
 	BOT                  bot.Service
 	ChannelManager       channel.Manager
 	OnlineCounter        *counter.OnlineCounter
@@ -33,7 +38,10 @@ type Services struct {
 	MessageManager       message.Manager
 	Notification         *notification.Service
 	OGP                  ogp.Service
+	OutgoingWebhook      *outgoingwebhook.Service
 	RBAC                 rbac.RBAC
+	Relay                relay.Relay
+	Scheduler            *scheduler.Scheduler
 	Search               search.Engine
 	ViewerManager        *viewer.Manager
 	WebRTCv3             *webrtcv3.Manager
@@ -21,6 +21,7 @@ var ProviderSet = wire.NewSet(wire.FieldsOf(new(*Services),
 	"MessageManager",
 	"Notification",
 	"OGP",
+	"OutgoingWebhook",
 	"RBAC",
 	"Search",
 	"ViewerManager",
@@ -5,14 +5,19 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	jsonIter "github.com/json-iterator/go"
 	"github.com/leandro-lugaresi/hub"
 
 	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/service/relay"
 )
 
+const relayTopic = "viewer"
+
 // Manager チャンネル閲覧者マネージャ
 type Manager struct {
 	hub      *hub.Hub
+	relay    relay.Relay
 	channels map[uuid.UUID]map[*viewer]struct{}
 	users    map[uuid.UUID]map[*viewer]struct{}
 	viewers  map[interface{}]*viewer
@@ -21,20 +26,40 @@ type Manager struct {
 
 type viewer struct {
 	key       interface{}
+	nodeID    string
 	connKey   string
 	userID    uuid.UUID
 	channelID uuid.UUID
 	state     StateWithTime
 }
 
+// remoteViewerKey 他ノードのチャンネル閲覧者のキー
+type remoteViewerKey struct {
+	nodeID  string
+	connKey string
+}
+
+// relayedViewer 他ノードに通知するチャンネル閲覧者状態
+type relayedViewer struct {
+	ConnKey   string    `json:"connKey"`
+	UserID    uuid.UUID `json:"userId"`
+	ChannelID uuid.UUID `json:"channelId"`
+	State     string    `json:"state"`
+	Removed   bool      `json:"removed"`
+}
+
 // NewManager チャンネル閲覧者マネージャーを生成します
-func NewManager(hub *hub.Hub) *Manager {
+func NewManager(hub *hub.Hub, r relay.Relay) *Manager {
 	vm := &Manager{
 		hub:      hub,
+		relay:    r,
 		channels: map[uuid.UUID]map[*viewer]struct{}{},
 		users:    map[uuid.UUID]map[*viewer]struct{}{},
 		viewers:  map[interface{}]*viewer{},
 	}
+	r.Subscribe(relayTopic, vm.handleRelayedViewer)
+	r.OnNodeJoined(vm.announceViewers)
+	r.OnNodeLeft(vm.removeNodeViewers)
 
 	go func() {
 		for range time.NewTicker(5 * time.Minute).C {
@@ -58,6 +83,20 @@ func (vm *Manager) SetViewer(key interface{}, connKey string, userID uuid.UUID,
 	vm.mu.Lock()
 	defer vm.mu.Unlock()
 
+	if vm.setViewer(key, "", connKey, userID, channelID, state, true) {
+		vm.publish(&relayedViewer{
+			ConnKey:   connKey,
+			UserID:    userID,
+			ChannelID: channelID,
+			State:     state.String(),
+		})
+	}
+}
+
+// setViewer チャンネル閲覧者状態を設定し、変更があったかどうかを返します
+//
+// publishEventがfalseの場合はイベントを発行しません。他ノードの状態を反映する場合は、発行元ノードでイベントが処理されるためfalseにします
+func (vm *Manager) setViewer(key interface{}, nodeID string, connKey string, userID uuid.UUID, channelID uuid.UUID, state State, publishEvent bool) bool {
 	cv, ok := vm.channels[channelID]
 	if !ok {
 		cv = map[*viewer]struct{}{}
@@ -74,7 +113,7 @@ func (vm *Manager) SetViewer(key interface{}, connKey string, userID uuid.UUID,
 		if v.channelID == channelID {
 			if v.state.State == state {
 				// 何も変わってない
-				return
+				return false
 			}
 			// stateだけ変更
 			v.state.State = state
@@ -90,17 +129,20 @@ func (vm *Manager) SetViewer(key interface{}, connKey string, userID uuid.UUID,
 				Time:  time.Now(),
 			}
 
-			vm.hub.Publish(hub.Message{
-				Name: event.ChannelViewersChanged,
-				Fields: hub.Fields{
-					"channel_id": oldC,
-					"viewers":    calculateChannelViewers(old),
-				},
-			})
+			if publishEvent {
+				vm.hub.Publish(hub.Message{
+					Name: event.ChannelViewersChanged,
+					Fields: hub.Fields{
+						"channel_id": oldC,
+						"viewers":    calculateChannelViewers(old),
+					},
+				})
+			}
 		}
 	} else {
 		v = &viewer{
 			key:       key,
+			nodeID:    nodeID,
 			connKey:   connKey,
 			userID:    userID,
 			channelID: channelID,
@@ -114,20 +156,10 @@ func (vm *Manager) SetViewer(key interface{}, connKey string, userID uuid.UUID,
 
 	cv[v] = struct{}{}
 	uv[v] = struct{}{}
-	vm.hub.Publish(hub.Message{
-		Name: event.UserViewStateChanged,
-		Fields: hub.Fields{
-			"user_id":     userID,
-			"view_states": calculateUserViewStates(uv),
-		},
-	})
-	vm.hub.Publish(hub.Message{
-		Name: event.ChannelViewersChanged,
-		Fields: hub.Fields{
-			"channel_id": channelID,
-			"viewers":    calculateChannelViewers(cv),
-		},
-	})
+	if publishEvent {
+		vm.publishChanged(userID, channelID)
+	}
+	return true
 }
 
 // RemoveViewer 指定したキーのチャンネル閲覧者状態を削除します
@@ -135,9 +167,19 @@ func (vm *Manager) RemoveViewer(key interface{}) {
 	vm.mu.Lock()
 	defer vm.mu.Unlock()
 
+	if v := vm.removeViewer(key, true); v != nil {
+		vm.publish(&relayedViewer{
+			ConnKey: v.connKey,
+			Removed: true,
+		})
+	}
+}
+
+// removeViewer チャンネル閲覧者状態を削除し、削除したviewerを返します
+func (vm *Manager) removeViewer(key interface{}, publishEvent bool) *viewer {
 	v, ok := vm.viewers[key]
 	if !ok {
-		return
+		return nil
 	}
 
 	delete(vm.viewers, key)
@@ -146,22 +188,85 @@ func (vm *Manager) RemoveViewer(key interface{}) {
 	uv := vm.users[v.userID]
 	delete(uv, v)
 
+	if publishEvent {
+		vm.publishChanged(v.userID, v.channelID)
+	}
+	return v
+}
+
+func (vm *Manager) publishChanged(userID, channelID uuid.UUID) {
 	vm.hub.Publish(hub.Message{
 		Name: event.UserViewStateChanged,
 		Fields: hub.Fields{
-			"user_id":     v.userID,
-			"view_states": calculateUserViewStates(uv),
+			"user_id":     userID,
+			"view_states": calculateUserViewStates(vm.users[userID]),
 		},
 	})
 	vm.hub.Publish(hub.Message{
 		Name: event.ChannelViewersChanged,
 		Fields: hub.Fields{
-			"channel_id": v.channelID,
-			"viewers":    calculateChannelViewers(cv),
+			"channel_id": channelID,
+			"viewers":    calculateChannelViewers(vm.channels[channelID]),
 		},
 	})
 }
 
+// publish 自ノードのチャンネル閲覧者状態の変更を他ノードに通知します
+//
+// 通知の順序を保つため、vm.muのロックを取った状態で呼び出すこと
+func (vm *Manager) publish(v *relayedViewer) {
+	_ = vm.relay.Publish(relayTopic, v)
+}
+
+func (vm *Manager) handleRelayedViewer(nodeID string, body []byte) {
+	var rv relayedViewer
+	if err := jsonIter.ConfigFastest.Unmarshal(body, &rv); err != nil {
+		return
+	}
+
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
+	key := remoteViewerKey{nodeID: nodeID, connKey: rv.ConnKey}
+	if rv.Removed {
+		vm.removeViewer(key, false)
+		return
+	}
+	vm.setViewer(key, nodeID, rv.ConnKey, rv.UserID, rv.ChannelID, StateFromString(rv.State), false)
+}
+
+// announceViewers 自ノードの全チャンネル閲覧者状態を他ノードに通知します
+func (vm *Manager) announceViewers(_ string) {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+
+	for _, v := range vm.viewers {
+		if len(v.nodeID) > 0 {
+			continue
+		}
+		vm.publish(&relayedViewer{
+			ConnKey:   v.connKey,
+			UserID:    v.userID,
+			ChannelID: v.channelID,
+			State:     v.state.State.String(),
+		})
+	}
+}
+
+// removeNodeViewers 離脱したノードのチャンネル閲覧者状態を削除します
+func (vm *Manager) removeNodeViewers(nodeID string) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
+	// 離脱に伴うイベントは代表ノードのみが発行する
+	leader := vm.relay.IsLeader()
+	for key, v := range vm.viewers {
+		if v.nodeID == nodeID {
+			vm.removeViewer(key, leader)
+		}
+	}
+}
+
 // 5分に1回呼び出される。チャンネルマップとユーザーマップのお掃除
 func (vm *Manager) gc() {
 	for cid, cv := range vm.channels {
@@ -0,0 +1,88 @@
+package viewer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/service/relay"
+)
+
+func TestManager_Relay(t *testing.T) {
+	t.Parallel()
+
+	net := relay.NewMemoryNetwork()
+	r1 := net.Join(zap.NewNop())
+	r2 := net.Join(zap.NewNop())
+	defer r2.Close()
+	h2 := hub.New()
+	vm1 := NewManager(hub.New(), r1)
+	vm2 := NewManager(h2, r2)
+	sub := h2.Subscribe(8, event.ChannelViewersChanged)
+	defer h2.Unsubscribe(sub)
+
+	user := uuid.Must(uuid.NewV4())
+	ch1 := uuid.Must(uuid.NewV4())
+	ch2 := uuid.Must(uuid.NewV4())
+	key := "session"
+
+	viewersOf := func(vm *Manager, channelID uuid.UUID) func() bool {
+		return func() bool {
+			_, ok := vm.GetChannelViewers(channelID)[user]
+			return ok
+		}
+	}
+
+	vm1.SetViewer(key, "conn", user, ch1, StateMonitoring)
+	assert.Eventually(t, viewersOf(vm2, ch1), time.Second, 10*time.Millisecond)
+
+	vm1.SetViewer(key, "conn", user, ch2, StateEditing)
+	assert.Eventually(t, viewersOf(vm2, ch2), time.Second, 10*time.Millisecond)
+	assert.False(t, viewersOf(vm2, ch1)())
+	assert.Equal(t, StateEditing, vm2.GetChannelViewers(ch2)[user].State)
+
+	vm1.RemoveViewer(key)
+	assert.Eventually(t, func() bool { return !viewersOf(vm2, ch2)() }, time.Second, 10*time.Millisecond)
+
+	// 他ノードの状態の反映ではイベントは発行されない
+	select {
+	case <-sub.Receiver:
+		t.Fatal("unexpected event")
+	default:
+	}
+
+	// ノードの離脱で削除される
+	vm1.SetViewer(key, "conn", user, ch1, StateMonitoring)
+	assert.Eventually(t, viewersOf(vm2, ch1), time.Second, 10*time.Millisecond)
+	assert.NoError(t, r1.Close())
+	assert.Eventually(t, func() bool { return !viewersOf(vm2, ch1)() }, time.Second, 10*time.Millisecond)
+}
+
+func TestManager_RelayJoin(t *testing.T) {
+	t.Parallel()
+
+	net := relay.NewMemoryNetwork()
+	r1 := net.Join(zap.NewNop())
+	defer r1.Close()
+	vm1 := NewManager(hub.New(), r1)
+
+	user := uuid.Must(uuid.NewV4())
+	ch := uuid.Must(uuid.NewV4())
+	vm1.SetViewer("session", "conn", user, ch, StateMonitoring)
+
+	// 後から参加したノードにも既存の状態が共有される
+	r2 := net.Join(zap.NewNop())
+	defer r2.Close()
+	vm2 := NewManager(hub.New(), r2)
+	// 他ノードはr2からの最初のメッセージで参加を検知する
+	assert.NoError(t, r2.Publish("hello", nil))
+	assert.Eventually(t, func() bool {
+		_, ok := vm2.GetChannelViewers(ch)[user]
+		return ok
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,222 @@
+// Package webpush Web Push (RFC 8030 / RFC 8291 / RFC 8292) によるプッシュ通知を送信します
+package webpush
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	jsonIter "github.com/json-iterator/go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/fcm"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+const (
+	workers           = 4
+	queueSize         = 1000
+	messageTTLSeconds = 60 * 60 * 24 * 2 // 2日
+	requestTimeout    = 10 * time.Second
+)
+
+var (
+	sendCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "traq",
+		Name:      "webpush_send_count_total",
+	}, []string{"result"})
+	errClosed = errors.New("webpush client has already been closed")
+)
+
+// Config Web Push設定
+type Config struct {
+	// VAPIDPrivateKey VAPID秘密鍵 (URL-safe Base64)
+	VAPIDPrivateKey string
+	// Subject VAPIDのsubject (mailto:またはhttps:のURL)
+	Subject string
+}
+
+// Valid 有効な設定かどうか
+func (c Config) Valid() bool {
+	return len(c.VAPIDPrivateKey) > 0
+}
+
+type message struct {
+	sub  *model.WebPushSubscription
+	data []byte
+}
+
+type clientImpl struct {
+	repo          repository.Repository
+	logger        *zap.Logger
+	unreadCounter counter.UnreadMessageCounter
+	vapid         *vapid
+	hc            *http.Client
+
+	queue  chan *message
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewClient Web Pushクライアントを生成します
+//
+// fcm.Clientと同じペイロードを扱うため、fcm.NewMultiClientでFCMと併用できます。
+func NewClient(repo repository.Repository, logger *zap.Logger, unreadCounter counter.UnreadMessageCounter, config Config) (fcm.Client, error) {
+	v, err := newVAPID(config.VAPIDPrivateKey, config.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &clientImpl{
+		repo:          repo,
+		logger:        logger.Named("webpush"),
+		unreadCounter: unreadCounter,
+		vapid:         v,
+		hc: &http.Client{
+			Timeout: requestTimeout,
+			// 登録時に検証したエンドポイント以外(内部ネットワークなど)へリダイレクトされないようにする
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		queue: make(chan *message, queueSize),
+	}
+	for i := 0; i < workers; i++ {
+		c.wg.Add(1)
+		go c.worker()
+	}
+	return c, nil
+}
+
+func (c *clientImpl) Send(targetUserIDs set.UUID, p *fcm.Payload, withUnreadCount bool) {
+	_ = c.send(targetUserIDs, p, withUnreadCount)
+}
+
+func (c *clientImpl) send(targetUserIDs set.UUID, p *fcm.Payload, withUnreadCount bool) error {
+	logger := c.logger.With(zap.Reflect("payload", p))
+
+	subsMap, err := c.repo.GetWebPushSubscriptions(targetUserIDs)
+	if err != nil {
+		logger.Error("failed to GetWebPushSubscriptions", zap.Error(err), zap.Strings("target_user_ids", targetUserIDs.StringArray()))
+		return err
+	}
+
+	for uid, subs := range subsMap {
+		data := map[string]string{
+			"type":  p.Type,
+			"title": p.Title,
+			"body":  p.Body,
+			"path":  p.Path,
+			"tag":   p.Tag,
+			"icon":  p.Icon,
+		}
+		if p.Image.Valid {
+			data["image"] = p.Image.V
+		}
+		if withUnreadCount {
+			data["unread"] = strconv.Itoa(c.unreadCounter.Get(uid))
+		}
+		b, err := jsonIter.ConfigFastest.Marshal(data)
+		if err != nil {
+			return err
+		}
+		for _, sub := range subs {
+			if err := c.enqueue(&message{sub: sub, data: b}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *clientImpl) enqueue(m *message) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return errClosed
+	}
+	c.queue <- m
+	return nil
+}
+
+func (c *clientImpl) Close() {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.closed = true
+	close(c.queue)
+	c.mu.Unlock()
+	c.wg.Wait()
+}
+
+func (c *clientImpl) worker() {
+	defer c.wg.Done()
+	for m := range c.queue {
+		gone, err := c.push(m)
+		switch {
+		case err != nil:
+			sendCounter.WithLabelValues("error").Inc()
+			c.logger.Warn("webpush: "+err.Error(), zap.String("endpoint", m.sub.Endpoint))
+		case gone:
+			sendCounter.WithLabelValues("gone").Inc()
+			if err := c.repo.DeleteWebPushSubscriptions([]string{m.sub.Endpoint}); err != nil {
+				c.logger.Error("failed to DeleteWebPushSubscriptions", zap.Error(err), zap.String("endpoint", m.sub.Endpoint))
+			}
+		default:
+			sendCounter.WithLabelValues("ok").Inc()
+		}
+	}
+}
+
+// push 1件のプッシュメッセージを送信します
+//
+// 購読が無効になっていた場合はgoneにtrueを返します
+func (c *clientImpl) push(m *message) (gone bool, err error) {
+	body, err := encrypt(m.data, m.sub.P256dh, m.sub.Auth)
+	if err != nil {
+		return false, err
+	}
+	authorization, err := c.vapid.authorization(m.sub.Endpoint, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, m.sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(messageTTLSeconds))
+	req.Header.Set("Urgency", "high")
+
+	res, err := c.hc.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	switch {
+	case res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone:
+		return true, nil
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+}
@@ -0,0 +1,105 @@
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/fcm"
+	"github.com/traPtitech/traQ/testutils"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+type testRepo struct {
+	testutils.EmptyTestRepository
+	subs    map[uuid.UUID][]*model.WebPushSubscription
+	mu      sync.Mutex
+	deleted []string
+}
+
+func (r *testRepo) GetWebPushSubscriptions(userIDs set.UUID) (map[uuid.UUID][]*model.WebPushSubscription, error) {
+	res := map[uuid.UUID][]*model.WebPushSubscription{}
+	for id := range userIDs {
+		if s, ok := r.subs[id]; ok {
+			res[id] = s
+		}
+	}
+	return res, nil
+}
+
+func (r *testRepo) DeleteWebPushSubscriptions(endpoints []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deleted = append(r.deleted, endpoints...)
+	return nil
+}
+
+type testCounter struct{}
+
+func (testCounter) Get(uuid.UUID) int                 { return 3 }
+func (testCounter) GetChanges(bool) map[uuid.UUID]int { return nil }
+
+func TestClient_Send(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		received = map[string]http.Header{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received[r.URL.Path] = r.Header.Clone()
+		mu.Unlock()
+		if strings.HasSuffix(r.URL.Path, "/gone") {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	ua, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sub := func(path string) *model.WebPushSubscription {
+		return &model.WebPushSubscription{
+			Endpoint: server.URL + path,
+			P256dh:   base64.RawURLEncoding.EncodeToString(ua.PublicKey().Bytes()),
+			Auth:     base64.RawURLEncoding.EncodeToString(make([]byte, 16)),
+		}
+	}
+	user1, user2, user3 := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	repo := &testRepo{subs: map[uuid.UUID][]*model.WebPushSubscription{
+		user1: {sub("/ok")},
+		user2: {sub("/gone")},
+		user3: {sub("/other")},
+	}}
+
+	priv, _, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	c, err := NewClient(repo, zap.NewNop(), testCounter{}, Config{VAPIDPrivateKey: priv, Subject: "mailto:admin@example.com"})
+	require.NoError(t, err)
+
+	c.Send(set.UUIDSetFromArray([]uuid.UUID{user1, user2}), &fcm.Payload{Type: "new_message", Title: "title", Body: "body"}, true)
+	c.Close()
+	c.Send(set.UUIDSetFromArray([]uuid.UUID{user1}), &fcm.Payload{}, false) // closed
+
+	assert.Len(t, received, 2)
+	if h, ok := received["/ok"]; assert.True(t, ok) {
+		assert.Equal(t, "aes128gcm", h.Get("Content-Encoding"))
+		assert.True(t, strings.HasPrefix(h.Get("Authorization"), "vapid t="))
+		assert.NotEmpty(t, h.Get("TTL"))
+	}
+	assert.NotContains(t, received, "/other")
+	assert.Equal(t, []string{server.URL + "/gone"}, repo.deleted)
+}
@@ -0,0 +1,135 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// recordSize aes128gcmのレコードサイズ
+	recordSize = 4096
+	// maxPlaintextSize 1レコードに格納できる平文の最大サイズ (パディング区切り1byte, 認証タグ16byte)
+	maxPlaintextSize = recordSize - 1 - 16
+)
+
+// ErrPayloadTooLarge ペイロードが大きすぎます
+var ErrPayloadTooLarge = errors.New("payload too large")
+
+// encrypt RFC 8291に従ってペイロードを購読者の公開鍵で暗号化し、aes128gcm形式のボディを返します
+func encrypt(plaintext []byte, p256dh, auth string) ([]byte, error) {
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	return encryptWithKey(plaintext, p256dh, auth, asPrivate, salt)
+}
+
+func encryptWithKey(plaintext []byte, p256dh, auth string, asPrivate *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	if len(plaintext) > maxPlaintextSize {
+		return nil, ErrPayloadTooLarge
+	}
+
+	if err := ValidateSubscriptionKeys(p256dh, auth); err != nil {
+		return nil, err
+	}
+	uaPublicRaw, _ := decodeBase64(p256dh)
+	uaPublic, _ := ecdh.P256().NewPublicKey(uaPublicRaw)
+	authSecret, _ := decodeBase64(auth)
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+	asPublicRaw := asPrivate.PublicKey().Bytes()
+
+	// IKM = HKDF(auth_secret, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public, 32)
+	keyInfo := make([]byte, 0, 14+len(uaPublicRaw)+len(asPublicRaw))
+	keyInfo = append(keyInfo, "WebPush: info\x00"...)
+	keyInfo = append(keyInfo, uaPublicRaw...)
+	keyInfo = append(keyInfo, asPublicRaw...)
+	ikm, err := hkdfExpand(ecdhSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	// RFC 8188
+	cek, err := hkdfExpand(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdfExpand(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// header: salt(16) || rs(4) || idlen(1) || keyid(as_public)
+	body := make([]byte, 0, 16+4+1+len(asPublicRaw)+len(plaintext)+1+gcm.Overhead())
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, recordSize)
+	body = append(body, byte(len(asPublicRaw)))
+	body = append(body, asPublicRaw...)
+
+	record := make([]byte, 0, len(plaintext)+1)
+	record = append(record, plaintext...)
+	record = append(record, 0x02) // 最終レコードの区切り
+	return gcm.Seal(body, nonce, record, nil), nil
+}
+
+func hkdfExpand(secret, salt, info []byte, length int) ([]byte, error) {
+	b := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// decodeBase64 パディングの有無を問わずBase64(URL-safe / 標準)をデコードします
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	if b, err := base64.RawURLEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	return base64.RawStdEncoding.DecodeString(s)
+}
+
+// ValidateSubscriptionKeys 購読者の公開鍵と認証シークレットが正しい形式かどうかを検証します
+func ValidateSubscriptionKeys(p256dh, auth string) error {
+	uaPublicRaw, err := decodeBase64(p256dh)
+	if err != nil {
+		return fmt.Errorf("invalid p256dh: %w", err)
+	}
+	if _, err := ecdh.P256().NewPublicKey(uaPublicRaw); err != nil {
+		return fmt.Errorf("invalid p256dh: %w", err)
+	}
+	authSecret, err := decodeBase64(auth)
+	if err != nil {
+		return fmt.Errorf("invalid auth: %w", err)
+	}
+	if len(authSecret) != 16 {
+		return errors.New("invalid auth: must be 16 bytes")
+	}
+	return nil
+}
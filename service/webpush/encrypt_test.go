@@ -0,0 +1,94 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptWithKey(t *testing.T) {
+	t.Parallel()
+
+	// RFC 8291 Appendix A
+	const (
+		plaintext = "When I grow up, I want to be a watermelon"
+		asPrivate = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+		uaPublic  = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+		auth      = "BTBZMqHH6r4Tts7J_aSIgg"
+		salt      = "DGv6ra1nlYgDCS1FRnbzlw"
+		expected  = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+	)
+
+	priv, err := ecdh.P256().NewPrivateKey(mustDecode(t, asPrivate))
+	require.NoError(t, err)
+
+	body, err := encryptWithKey([]byte(plaintext), uaPublic, auth, priv, mustDecode(t, salt))
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, base64.RawURLEncoding.EncodeToString(body))
+	}
+
+	t.Run("too large", func(t *testing.T) {
+		t.Parallel()
+		_, err := encryptWithKey(make([]byte, maxPlaintextSize+1), uaPublic, auth, priv, mustDecode(t, salt))
+		assert.ErrorIs(t, err, ErrPayloadTooLarge)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		t.Parallel()
+		_, err := encryptWithKey([]byte(plaintext), "AAAA", auth, priv, mustDecode(t, salt))
+		assert.Error(t, err)
+		_, err = encryptWithKey([]byte(plaintext), uaPublic, "AAAA", priv, mustDecode(t, salt))
+		assert.Error(t, err)
+	})
+}
+
+func TestEncrypt(t *testing.T) {
+	t.Parallel()
+
+	ua, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	authSecret := make([]byte, 16)
+	_, err = rand.Read(authSecret)
+	require.NoError(t, err)
+
+	p256dh := base64.RawURLEncoding.EncodeToString(ua.PublicKey().Bytes())
+	auth := base64.RawURLEncoding.EncodeToString(authSecret)
+	body, err := encrypt([]byte("hello"), p256dh, auth)
+	require.NoError(t, err)
+
+	// 受信側の手順で復号できること
+	salt, rs, keyID := body[:16], binary.BigEndian.Uint32(body[16:20]), body[21:21+int(body[20])]
+	assert.EqualValues(t, recordSize, rs)
+	asPublic, err := ecdh.P256().NewPublicKey(keyID)
+	require.NoError(t, err)
+	secret, err := ua.ECDH(asPublic)
+	require.NoError(t, err)
+	info := append(append([]byte("WebPush: info\x00"), ua.PublicKey().Bytes()...), keyID...)
+	ikm, err := hkdfExpand(secret, authSecret, info, 32)
+	require.NoError(t, err)
+	cek, err := hkdfExpand(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	require.NoError(t, err)
+	nonce, err := hkdfExpand(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	require.NoError(t, err)
+	block, err := aes.NewCipher(cek)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	record, err := gcm.Open(nil, nonce, body[21+len(keyID):], nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello\x02"), record)
+}
+
+func mustDecode(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := decodeBase64(s)
+	require.NoError(t, err)
+	return b
+}
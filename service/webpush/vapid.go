@@ -0,0 +1,102 @@
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// vapidTokenExpiration VAPID JWTの有効期間 (最大24時間)
+const vapidTokenExpiration = 12 * time.Hour
+
+// GenerateVAPIDKeys VAPID鍵ペアを生成し、URL-safe Base64でエンコードした秘密鍵と公開鍵を返します
+func GenerateVAPIDKeys() (privateKey string, publicKey string, err error) {
+	priv, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(priv.Bytes()), base64.RawURLEncoding.EncodeToString(priv.PublicKey().Bytes()), nil
+}
+
+// VAPIDPublicKey VAPID秘密鍵に対応する公開鍵を返します
+//
+// ブラウザのPushManager.subscribeのapplicationServerKeyに渡す値です。
+func VAPIDPublicKey(privateKey string) (string, error) {
+	priv, err := parseVAPIDPrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(priv.PublicKey().Bytes()), nil
+}
+
+func parseVAPIDPrivateKey(privateKey string) (*ecdh.PrivateKey, error) {
+	b, err := decodeBase64(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+	priv, err := ecdh.P256().NewPrivateKey(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+	return priv, nil
+}
+
+// vapid VAPID (RFC 8292) 署名者
+type vapid struct {
+	key       *ecdsa.PrivateKey
+	publicKey string
+	subject   string
+}
+
+func newVAPID(privateKey, subject string) (*vapid, error) {
+	priv, err := parseVAPIDPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	// ES256署名のためにecdsaの鍵に変換
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("invalid vapid private key")
+	}
+	return &vapid{
+		key:       ecKey,
+		publicKey: base64.RawURLEncoding.EncodeToString(priv.PublicKey().Bytes()),
+		subject:   subject,
+	}, nil
+}
+
+// authorization プッシュサービスのエンドポイントに対するAuthorizationヘッダーの値を返します
+func (v *vapid) authorization(endpoint string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	claims := jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(vapidTokenExpiration).Unix(),
+	}
+	if len(v.subject) > 0 {
+		claims["sub"] = v.subject
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(v.key)
+	if err != nil {
+		return "", err
+	}
+	return "vapid t=" + token + ", k=" + v.publicKey, nil
+}
@@ -0,0 +1,55 @@
+package webpush
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateVAPIDKeys(t *testing.T) {
+	t.Parallel()
+
+	priv, pub, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	assert.Len(t, mustDecode(t, priv), 32)
+	assert.Len(t, mustDecode(t, pub), 65)
+
+	derived, err := VAPIDPublicKey(priv)
+	if assert.NoError(t, err) {
+		assert.Equal(t, pub, derived)
+	}
+
+	_, err = VAPIDPublicKey("invalid")
+	assert.Error(t, err)
+}
+
+func TestVAPID_Authorization(t *testing.T) {
+	t.Parallel()
+
+	priv, pub, err := GenerateVAPIDKeys()
+	require.NoError(t, err)
+	v, err := newVAPID(priv, "mailto:admin@example.com")
+	require.NoError(t, err)
+
+	now := time.Now()
+	header, err := v.authorization("https://push.example.com/send/abcdef?x=1", now)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(header, "vapid t="))
+	token, k, ok := strings.Cut(strings.TrimPrefix(header, "vapid t="), ", k=")
+	require.True(t, ok)
+	assert.Equal(t, pub, k)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		return &v.key.PublicKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "https://push.example.com", claims["aud"])
+		assert.Equal(t, "mailto:admin@example.com", claims["sub"])
+		assert.EqualValues(t, now.Add(vapidTokenExpiration).Unix(), claims["exp"])
+	}
+}
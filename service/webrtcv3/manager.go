@@ -5,13 +5,17 @@ import (
 	"sync"
 
 	"github.com/gofrs/uuid"
+	jsonIter "github.com/json-iterator/go"
 	"github.com/leandro-lugaresi/hub"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/service/relay"
 )
 
+const relayTopic = "webrtcv3"
+
 var (
 	ErrOccupied             = errors.New("connection has already existed")
 	webrtcUsingUsersCounter = promauto.NewGauge(prometheus.GaugeOpts{
@@ -27,18 +31,31 @@ var (
 // Manager WebRTCマネージャー
 type Manager struct {
 	eventbus      *hub.Hub
+	relay         relay.Relay
 	userStates    map[uuid.UUID]*userState
 	channelStates map[uuid.UUID]*channelState
 	statesLock    sync.RWMutex
 }
 
+// relayedState 他ノードに通知するユーザーの状態
+type relayedState struct {
+	ConnKey   string            `json:"connKey"`
+	UserID    uuid.UUID         `json:"userId"`
+	ChannelID uuid.UUID         `json:"channelId"`
+	Sessions  map[string]string `json:"sessions"`
+}
+
 // NewManager WebRTCマネージャーを生成します
-func NewManager(eventbus *hub.Hub) *Manager {
+func NewManager(eventbus *hub.Hub, r relay.Relay) *Manager {
 	manager := &Manager{
 		eventbus:      eventbus,
+		relay:         r,
 		userStates:    map[uuid.UUID]*userState{},
 		channelStates: map[uuid.UUID]*channelState{},
 	}
+	r.Subscribe(relayTopic, manager.handleRelayedState)
+	r.OnNodeJoined(manager.announceStates)
+	r.OnNodeLeft(manager.resetNodeStates)
 	return manager
 }
 
@@ -60,10 +77,20 @@ func (m *Manager) SetState(connKey string, user, channel uuid.UUID, sessions map
 	m.statesLock.Lock()
 	defer m.statesLock.Unlock()
 
+	m.setState(connKey, "", user, channel, sessions, true)
+	m.publish(&relayedState{ConnKey: connKey, UserID: user, ChannelID: channel, Sessions: sessions})
+	return nil
+}
+
+// setState 状態をセットします
+//
+// publishEventがfalseの場合はイベントを発行しません。他ノードの状態を反映する場合は、発行元ノードでイベントが処理されるためfalseにします
+func (m *Manager) setState(connKey, nodeID string, user, channel uuid.UUID, sessions map[string]string, publishEvent bool) {
 	us, ok := m.userStates[user]
 	if !ok {
 		us = &userState{
 			connKey: connKey,
+			nodeID:  nodeID,
 			userID:  user,
 		}
 		m.userStates[user] = us
@@ -88,15 +115,16 @@ func (m *Manager) SetState(connKey string, user, channel uuid.UUID, sessions map
 	us.channelID = channel
 	cs.setUser(us)
 
-	m.eventbus.Publish(hub.Message{
-		Name: event.UserWebRTCv3StateChanged,
-		Fields: hub.Fields{
-			"user_id":    us.userID,
-			"channel_id": us.channelID,
-			"sessions":   us.sessions,
-		},
-	})
-	return nil
+	if publishEvent {
+		m.eventbus.Publish(hub.Message{
+			Name: event.UserWebRTCv3StateChanged,
+			Fields: hub.Fields{
+				"user_id":    us.userID,
+				"channel_id": us.channelID,
+				"sessions":   us.sessions,
+			},
+		})
+	}
 }
 
 // ResetState 指定したユーザーの状態を削除します
@@ -113,6 +141,15 @@ func (m *Manager) ResetState(connKey string, user uuid.UUID) error {
 		return ErrOccupied
 	}
 
+	m.resetState(us, true)
+	m.publish(&relayedState{ConnKey: connKey, UserID: user})
+	return nil
+}
+
+// resetState 状態を削除します
+func (m *Manager) resetState(us *userState, publishEvent bool) {
+	user := us.userID
+
 	delete(m.userStates, user)
 	webrtcUsingUsersCounter.Dec()
 	cs := m.channelStates[us.channelID]
@@ -122,13 +159,66 @@ func (m *Manager) ResetState(connKey string, user uuid.UUID) error {
 		webrtcUsingChannelsCounter.Dec()
 	}
 
-	m.eventbus.Publish(hub.Message{
-		Name: event.UserWebRTCv3StateChanged,
-		Fields: hub.Fields{
-			"user_id":    us.userID,
-			"channel_id": us.channelID,
-			"sessions":   map[string]string{},
-		},
-	})
-	return nil
+	if publishEvent {
+		m.eventbus.Publish(hub.Message{
+			Name: event.UserWebRTCv3StateChanged,
+			Fields: hub.Fields{
+				"user_id":    us.userID,
+				"channel_id": us.channelID,
+				"sessions":   map[string]string{},
+			},
+		})
+	}
+}
+
+// publish 自ノードでの状態の変更を他ノードに通知します
+//
+// 通知の順序を保つため、m.statesLockのロックを取った状態で呼び出すこと
+func (m *Manager) publish(s *relayedState) {
+	_ = m.relay.Publish(relayTopic, s)
+}
+
+func (m *Manager) handleRelayedState(nodeID string, body []byte) {
+	var s relayedState
+	if err := jsonIter.ConfigFastest.Unmarshal(body, &s); err != nil {
+		return
+	}
+
+	m.statesLock.Lock()
+	defer m.statesLock.Unlock()
+
+	if len(s.Sessions) > 0 {
+		m.setState(s.ConnKey, nodeID, s.UserID, s.ChannelID, s.Sessions, false)
+		return
+	}
+	if us, ok := m.userStates[s.UserID]; ok && us.connKey == s.ConnKey {
+		m.resetState(us, false)
+	}
+}
+
+// announceStates 自ノードの全ユーザーの状態を他ノードに通知します
+func (m *Manager) announceStates(_ string) {
+	m.statesLock.RLock()
+	defer m.statesLock.RUnlock()
+
+	for _, us := range m.userStates {
+		if len(us.nodeID) > 0 || !us.valid() {
+			continue
+		}
+		m.publish(&relayedState{ConnKey: us.connKey, UserID: us.userID, ChannelID: us.channelID, Sessions: us.sessions})
+	}
+}
+
+// resetNodeStates 離脱したノードのユーザーの状態を削除します
+func (m *Manager) resetNodeStates(nodeID string) {
+	m.statesLock.Lock()
+	defer m.statesLock.Unlock()
+
+	// 離脱に伴うイベントは代表ノードのみが発行する
+	leader := m.relay.IsLeader()
+	for _, us := range m.userStates {
+		if us.nodeID == nodeID {
+			m.resetState(us, leader)
+		}
+	}
 }
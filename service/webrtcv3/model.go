@@ -13,6 +13,7 @@ type UserState interface {
 
 type userState struct {
 	connKey   string
+	nodeID    string
 	userID    uuid.UUID
 	channelID uuid.UUID
 	sessions  map[string]string
@@ -7,11 +7,13 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/gorilla/websocket"
+	jsonIter "github.com/json-iterator/go"
 	"github.com/leandro-lugaresi/hub"
 	"go.uber.org/zap"
 
 	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/router/extension/ctxkey"
+	"github.com/traPtitech/traQ/service/relay"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/webrtcv3"
 )
@@ -23,11 +25,20 @@ var (
 	ErrBufferIsFull = errors.New("buffer is full")
 )
 
+const relayTopic = "ws.message"
+
+// relayedMessage 他ノードに中継するメッセージ
+type relayedMessage struct {
+	Data   jsonIter.RawMessage `json:"data"`
+	Target TargetFunc          `json:"target"`
+}
+
 // Streamer WebSocketストリーマー
 type Streamer struct {
 	hub      *hub.Hub
 	vm       *viewer.Manager
 	webrtc   *webrtcv3.Manager
+	relay    relay.Relay
 	logger   *zap.Logger
 	sessions map[*session]struct{}
 	closed   bool
@@ -35,15 +46,17 @@ type Streamer struct {
 }
 
 // NewStreamer WebSocketストリーマーを生成し起動します
-func NewStreamer(hub *hub.Hub, vm *viewer.Manager, webrtc *webrtcv3.Manager, logger *zap.Logger) *Streamer {
+func NewStreamer(hub *hub.Hub, vm *viewer.Manager, webrtc *webrtcv3.Manager, r relay.Relay, logger *zap.Logger) *Streamer {
 	h := &Streamer{
 		hub:      hub,
 		vm:       vm,
 		webrtc:   webrtc,
+		relay:    r,
 		logger:   logger.Named("ws"),
 		sessions: make(map[*session]struct{}),
 		closed:   false,
 	}
+	r.Subscribe(relayTopic, h.handleRelayedMessage)
 	return h
 }
 
@@ -69,18 +82,37 @@ func (s *Streamer) IterateSessions(f func(session Session)) {
 }
 
 // WriteMessage 指定したセッションにメッセージを書き込みます
+//
+// 他ノードに接続しているセッションにも中継されます
 func (s *Streamer) WriteMessage(t string, body interface{}, targetFunc TargetFunc) {
+	data := makeMessage(t, body).toJSON()
+	s.writeMessage(data, targetFunc)
+	if err := s.relay.Publish(relayTopic, &relayedMessage{Data: data, Target: targetFunc}); err != nil {
+		s.logger.Warn("failed to relay a message", zap.Error(err), zap.String("type", t))
+	}
+}
+
+func (s *Streamer) handleRelayedMessage(_ string, body []byte) {
+	var m relayedMessage
+	if err := json.Unmarshal(body, &m); err != nil {
+		s.logger.Warn("received a malformed relayed message", zap.Error(err))
+		return
+	}
+	s.writeMessage(m.Data, m.Target)
+}
+
+func (s *Streamer) writeMessage(data []byte, targetFunc TargetFunc) {
 	m := &rawMessage{
 		t:    websocket.TextMessage,
-		data: makeMessage(t, body).toJSON(),
+		data: data,
 	}
 	s.mu.RLock()
 	for session := range s.sessions {
-		if targetFunc(session) {
+		if targetFunc.Match(session) {
 			if err := session.WriteMessage(m); err != nil {
 				if err == ErrBufferIsFull {
 					s.logger.Warn("Discard a message because the session's buffer is full.",
-						zap.String("type", t), zap.Any("body", body),
+						zap.ByteString("message", data),
 						zap.Stringer("userID", session.userID))
 					continue
 				}
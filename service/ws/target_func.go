@@ -6,89 +6,108 @@ import (
 	"github.com/traPtitech/traQ/utils/set"
 )
 
-// TargetFunc メッセージ送信対象関数
-type TargetFunc func(s Session) bool
+type targetType string
 
-// TargetAll 全セッションを対象に送信します
-func TargetAll() TargetFunc {
-	return func(_ Session) bool {
-		return true
-	}
+const (
+	targetAll                      targetType = "all"
+	targetUsers                    targetType = "users"
+	targetChannelViewers           targetType = "channelViewers"
+	targetTimelineStreamingEnabled targetType = "timelineStreaming"
+	targetNone                     targetType = "none"
+	targetOr                       targetType = "or"
+	targetAnd                      targetType = "and"
+	targetNot                      targetType = "not"
+)
+
+// TargetFunc メッセージ送信対象
+//
+// 他ノードに中継できるように、条件を関数ではなくJSONにエンコード可能な木構造で表します
+type TargetFunc struct {
+	Type      targetType   `json:"type"`
+	UserIDs   []uuid.UUID  `json:"userIds,omitempty"`
+	ChannelID uuid.UUID    `json:"channelId,omitempty"`
+	Funcs     []TargetFunc `json:"funcs,omitempty"`
 }
 
-// TargetUsers 指定したユーザーを対象に送信します
-func TargetUsers(userID ...uuid.UUID) TargetFunc {
-	return func(s Session) bool {
-		for _, u := range userID {
+// Match セッションが送信対象かどうかを返します
+func (f TargetFunc) Match(s Session) bool {
+	switch f.Type {
+	case targetAll:
+		return true
+	case targetUsers:
+		for _, u := range f.UserIDs {
 			if u == s.UserID() {
 				return true
 			}
 		}
 		return false
-	}
-}
-
-// TargetUserSets 指定したユーザーを対象に送信します
-func TargetUserSets(sets ...set.UUID) TargetFunc {
-	return func(s Session) bool {
-		for _, set := range sets {
-			if set.Contains(s.UserID()) {
+	case targetChannelViewers:
+		c, _ := s.ViewState()
+		return c == f.ChannelID
+	case targetTimelineStreamingEnabled:
+		return s.TimelineStreaming()
+	case targetOr:
+		for _, f := range f.Funcs {
+			if f.Match(s) {
 				return true
 			}
 		}
 		return false
+	case targetAnd:
+		for _, f := range f.Funcs {
+			if !f.Match(s) {
+				return false
+			}
+		}
+		return true
+	case targetNot:
+		return len(f.Funcs) == 1 && !f.Funcs[0].Match(s)
+	default:
+		return false
 	}
 }
 
+// TargetAll 全セッションを対象に送信します
+func TargetAll() TargetFunc {
+	return TargetFunc{Type: targetAll}
+}
+
+// TargetUsers 指定したユーザーを対象に送信します
+func TargetUsers(userID ...uuid.UUID) TargetFunc {
+	return TargetFunc{Type: targetUsers, UserIDs: userID}
+}
+
+// TargetUserSets 指定したユーザーを対象に送信します
+func TargetUserSets(sets ...set.UUID) TargetFunc {
+	return TargetFunc{Type: targetUsers, UserIDs: set.UnionUUIDSets(sets...).Array()}
+}
+
 // TargetChannelViewers 指定したチャンネルの閲覧者を対象に送信します
 func TargetChannelViewers(channelID uuid.UUID) TargetFunc {
-	return func(s Session) bool {
-		c, _ := s.ViewState()
-		return c == channelID
-	}
+	return TargetFunc{Type: targetChannelViewers, ChannelID: channelID}
 }
 
 // TargetTimelineStreamingEnabled タイムラインストリーミングが有効なコネクションを対象に送信します
 func TargetTimelineStreamingEnabled() TargetFunc {
-	return func(s Session) bool {
-		return s.TimelineStreaming()
-	}
+	return TargetFunc{Type: targetTimelineStreamingEnabled}
 }
 
 // TargetNone いずれのセッションにも送信しません
 func TargetNone() TargetFunc {
-	return func(s Session) bool {
-		return false
-	}
+	return TargetFunc{Type: targetNone}
 }
 
 // Or いずれかのTargetFuncの条件に該当する対象に送信します
 func Or(funcs ...TargetFunc) TargetFunc {
-	return func(s Session) bool {
-		for _, f := range funcs {
-			if f(s) {
-				return true
-			}
-		}
-		return false
-	}
+	return TargetFunc{Type: targetOr, Funcs: funcs}
 }
 
 // And すべてのTargetFuncの条件に該当する対象に送信します
 func And(funcs ...TargetFunc) TargetFunc {
-	return func(s Session) bool {
-		for _, f := range funcs {
-			if !f(s) {
-				return false
-			}
-		}
-		return true
-	}
+	return TargetFunc{Type: targetAnd, Funcs: funcs}
 }
 
 // Not TargetFuncの条件に該当しない対象に送信します
 func Not(f TargetFunc) TargetFunc {
-	return func(s Session) bool {
-		return !f(s)
-	}
+	return TargetFunc{Type: targetNot, Funcs: []TargetFunc{f}}
 }
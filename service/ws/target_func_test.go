@@ -0,0 +1,78 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/service/viewer"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+type testSession struct {
+	userID            uuid.UUID
+	channelID         uuid.UUID
+	timelineStreaming bool
+}
+
+func (s *testSession) Key() string {
+	return s.userID.String()
+}
+
+func (s *testSession) UserID() uuid.UUID {
+	return s.userID
+}
+
+func (s *testSession) ViewState() (uuid.UUID, viewer.State) {
+	return s.channelID, viewer.StateMonitoring
+}
+
+func (s *testSession) TimelineStreaming() bool {
+	return s.timelineStreaming
+}
+
+func TestTargetFunc_Match(t *testing.T) {
+	t.Parallel()
+
+	u1 := uuid.Must(uuid.NewV4())
+	u2 := uuid.Must(uuid.NewV4())
+	ch := uuid.Must(uuid.NewV4())
+	s1 := &testSession{userID: u1, channelID: ch}
+	s2 := &testSession{userID: u2, timelineStreaming: true}
+
+	tests := []struct {
+		name   string
+		target TargetFunc
+		want1  bool
+		want2  bool
+	}{
+		{"all", TargetAll(), true, true},
+		{"none", TargetNone(), false, false},
+		{"zero value", TargetFunc{}, false, false},
+		{"users", TargetUsers(u1), true, false},
+		{"user sets", TargetUserSets(set.UUIDSetFromArray([]uuid.UUID{u2})), false, true},
+		{"channel viewers", TargetChannelViewers(ch), true, false},
+		{"timeline streaming", TargetTimelineStreamingEnabled(), false, true},
+		{"or", Or(TargetUsers(u1), TargetTimelineStreamingEnabled()), true, true},
+		{"and", And(TargetAll(), TargetUsers(u2)), false, true},
+		{"not", Not(TargetUsers(u1)), false, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want1, tt.target.Match(s1))
+			assert.Equal(t, tt.want2, tt.target.Match(s2))
+
+			// 他ノードへ中継した後も同じ結果になる
+			b, err := json.Marshal(tt.target)
+			require.NoError(t, err)
+			var decoded TargetFunc
+			require.NoError(t, json.Unmarshal(b, &decoded))
+			assert.Equal(t, tt.want1, decoded.Match(s1))
+			assert.Equal(t, tt.want2, decoded.Match(s2))
+		})
+	}
+}
@@ -13,15 +13,22 @@ type EmptyTestRepository struct {
 	repository.ChannelRepository
 	repository.MessageRepository
 	repository.MessageReportRepository
+	repository.ScheduledMessageRepository
 	repository.StampRepository
 	repository.StampPaletteRepository
 	repository.StarRepository
 	repository.PinRepository
 	repository.DeviceRepository
+	repository.WebPushSubscriptionRepository
+	repository.ChannelRoleRepository
 	repository.FileRepository
 	repository.WebhookRepository
+	repository.OutgoingWebhookRepository
 	repository.OAuth2Repository
 	repository.BotRepository
+	repository.BotCommandRepository
+	repository.BotEventDeliveryRepository
 	repository.ClipRepository
 	repository.OgpCacheRepository
+	repository.AuditLogRepository
 }
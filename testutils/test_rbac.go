@@ -1,6 +1,8 @@
 package testutils
 
 import (
+	"github.com/gofrs/uuid"
+
 	"github.com/traPtitech/traQ/service/rbac"
 	"github.com/traPtitech/traQ/service/rbac/permission"
 	"github.com/traPtitech/traQ/service/rbac/role"
@@ -52,3 +54,7 @@ func (rbac *rbacImpl) GetGrantedPermissions(roleName string) []permission.Permis
 	}
 	return nil
 }
+
+func (rbac *rbacImpl) IsGrantedInChannel(r string, _, _ uuid.UUID, p permission.Permission) bool {
+	return rbac.IsGranted(r, p)
+}
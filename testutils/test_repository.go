@@ -19,6 +19,7 @@ import (
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/rbac/role"
 	"github.com/traPtitech/traQ/utils"
+	"github.com/traPtitech/traQ/utils/optional"
 	"github.com/traPtitech/traQ/utils/set"
 	"github.com/traPtitech/traQ/utils/validator"
 )
@@ -916,6 +917,34 @@ func (repo *TestRepository) CreateMessage(userID, channelID uuid.UUID, text stri
 	return m, nil
 }
 
+func (repo *TestRepository) CreateReplyMessage(userID, parentID uuid.UUID, text string) (*model.Message, error) {
+	if userID == uuid.Nil || parentID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	repo.MessagesLock.Lock()
+	defer repo.MessagesLock.Unlock()
+	parent, ok := repo.Messages[parentID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	m := &model.Message{
+		ID:              uuid.Must(uuid.NewV4()),
+		UserID:          userID,
+		ChannelID:       parent.ChannelID,
+		Text:            text,
+		ParentMessageID: optional.From(parent.ID),
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		Stamps:          make([]model.MessageStamp, 0),
+	}
+	if parent.ParentMessageID.Valid {
+		m.ParentMessageID = parent.ParentMessageID
+	}
+	repo.Messages[m.ID] = *m
+	return m, nil
+}
+
 func (repo *TestRepository) UpdateMessage(messageID uuid.UUID, text string) error {
 	if messageID == uuid.Nil {
 		return repository.ErrNilID
@@ -997,6 +1026,16 @@ func (repo *TestRepository) GetMessages(query repository.MessagesQuery) (message
 	}
 	repo.MessagesLock.RUnlock()
 
+	if query.Parent != uuid.Nil {
+		filtered := make([]*model.Message, 0, len(tmp))
+		for _, v := range tmp {
+			if v.ParentMessageID.Valid && v.ParentMessageID.V == query.Parent {
+				filtered = append(filtered, v)
+			}
+		}
+		tmp = filtered
+	}
+
 	sort.Slice(tmp, func(i, j int) bool {
 		return tmp[i].CreatedAt.After(tmp[j].CreatedAt)
 	})
@@ -0,0 +1,252 @@
+// Package archive traQのデータをポータブルなアーカイブ(JSONL + ファイル)として書き出し・読み込みします
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/uuid"
+	jsonIter "github.com/json-iterator/go"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// FormatVersion アーカイブフォーマットのバージョン
+const FormatVersion = 1
+
+const (
+	manifestFileName   = "manifest.json"
+	usersFileName      = "users.jsonl"
+	filesFileName      = "files.jsonl"
+	stampsFileName     = "stamps.jsonl"
+	channelsFileName   = "channels.jsonl"
+	messagesFileName   = "messages.jsonl"
+	clipsFileName      = "clip_folders.jsonl"
+	userGroupsFileName = "user_groups.jsonl"
+	// blobDirName ファイル実体を格納するディレクトリ名
+	blobDirName = "files"
+
+	batchSize = 500
+)
+
+var (
+	// ErrUnsupportedVersion 対応していないバージョンのアーカイブです
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+	// ErrNotEmpty 書き込み先が空ではありません
+	ErrNotEmpty = errors.New("destination is not empty")
+)
+
+var json = jsonIter.ConfigCompatibleWithStandardLibrary
+
+// Manifest アーカイブのメタデータ
+type Manifest struct {
+	Version int `json:"version"`
+	// Origin 書き出し元インスタンスのオリジン。メッセージ本文中のURLの書き換えに使用します
+	Origin     string    `json:"origin"`
+	ExportedAt time.Time `json:"exportedAt"`
+}
+
+type userRecord struct {
+	ID          uuid.UUID              `json:"id"`
+	Name        string                 `json:"name"`
+	DisplayName string                 `json:"displayName"`
+	IconFileID  uuid.UUID              `json:"iconFileId"`
+	State       int                    `json:"state"`
+	Bot         bool                   `json:"bot"`
+	Role        string                 `json:"role"`
+	Bio         string                 `json:"bio"`
+	TwitterID   string                 `json:"twitterId"`
+	HomeChannel optional.Of[uuid.UUID] `json:"homeChannel"`
+	CreatedAt   time.Time              `json:"createdAt"`
+	UpdatedAt   time.Time              `json:"updatedAt"`
+}
+
+type fileThumbnailRecord struct {
+	Type   string `json:"type"`
+	Mime   string `json:"mime"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type fileACLRecord struct {
+	UserID uuid.UUID `json:"userId"`
+	Allow  bool      `json:"allow"`
+}
+
+type fileRecord struct {
+	ID              uuid.UUID              `json:"id"`
+	Name            string                 `json:"name"`
+	Mime            string                 `json:"mime"`
+	Size            int64                  `json:"size"`
+	CreatorID       optional.Of[uuid.UUID] `json:"creatorId"`
+	Hash            string                 `json:"hash"`
+	Type            string                 `json:"type"`
+	IsAnimatedImage bool                   `json:"isAnimatedImage"`
+	ChannelID       optional.Of[uuid.UUID] `json:"channelId"`
+	CreatedAt       time.Time              `json:"createdAt"`
+	Thumbnails      []fileThumbnailRecord  `json:"thumbnails"`
+	ACL             []fileACLRecord        `json:"acl"`
+}
+
+type stampRecord struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatorID uuid.UUID `json:"creatorId"`
+	FileID    uuid.UUID `json:"fileId"`
+	IsUnicode bool      `json:"isUnicode"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type channelRecord struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	ParentID  uuid.UUID `json:"parentId"`
+	Topic     string    `json:"topic"`
+	IsForced  bool      `json:"isForced"`
+	IsPublic  bool      `json:"isPublic"`
+	IsVisible bool      `json:"isVisible"`
+	CreatorID uuid.UUID `json:"creatorId"`
+	UpdaterID uuid.UUID `json:"updaterId"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// Members プライベートチャンネルのメンバー
+	Members []uuid.UUID `json:"members,omitempty"`
+	// DMUsers DMチャンネルの当事者 (2人)
+	DMUsers []uuid.UUID `json:"dmUsers,omitempty"`
+}
+
+// IsDMChannel DMチャンネルのレコードかどうか
+func (r *channelRecord) IsDMChannel() bool {
+	return len(r.DMUsers) > 0
+}
+
+type messageStampRecord struct {
+	StampID   uuid.UUID `json:"stampId"`
+	UserID    uuid.UUID `json:"userId"`
+	Count     int       `json:"count"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type pinRecord struct {
+	UserID    uuid.UUID `json:"userId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type messageRecord struct {
+	ID              uuid.UUID              `json:"id"`
+	UserID          uuid.UUID              `json:"userId"`
+	ChannelID       uuid.UUID              `json:"channelId"`
+	ParentMessageID optional.Of[uuid.UUID] `json:"parentMessageId"`
+	Text            string                 `json:"text"`
+	CreatedAt       time.Time              `json:"createdAt"`
+	UpdatedAt       time.Time              `json:"updatedAt"`
+	Stamps          []messageStampRecord   `json:"stamps"`
+	Pin             *pinRecord             `json:"pin,omitempty"`
+}
+
+type clipFolderMessageRecord struct {
+	MessageID uuid.UUID `json:"messageId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type clipFolderRecord struct {
+	ID          uuid.UUID                 `json:"id"`
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	OwnerID     uuid.UUID                 `json:"ownerId"`
+	CreatedAt   time.Time                 `json:"createdAt"`
+	Messages    []clipFolderMessageRecord `json:"messages"`
+}
+
+type userGroupMemberRecord struct {
+	UserID uuid.UUID `json:"userId"`
+	Role   string    `json:"role"`
+}
+
+type userGroupRecord struct {
+	ID          uuid.UUID               `json:"id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Type        string                  `json:"type"`
+	IconFileID  uuid.UUID               `json:"iconFileId"`
+	CreatedAt   time.Time               `json:"createdAt"`
+	UpdatedAt   time.Time               `json:"updatedAt"`
+	Admins      []uuid.UUID             `json:"admins"`
+	Members     []userGroupMemberRecord `json:"members"`
+}
+
+// jsonlWriter 1行1レコードのJSONLファイルライター
+type jsonlWriter struct {
+	f *os.File
+	w *bufio.Writer
+}
+
+func createJSONL(dir, name string) (*jsonlWriter, error) {
+	f, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		return nil, err
+	}
+	return &jsonlWriter{f: f, w: bufio.NewWriter(f)}, nil
+}
+
+// Write vを1行として書き込みます
+func (w *jsonlWriter) Write(v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := w.w.Write(b); err != nil {
+		return err
+	}
+	return w.w.WriteByte('\n')
+}
+
+// Close バッファをフラッシュしてファイルを閉じます
+func (w *jsonlWriter) Close() error {
+	if err := w.w.Flush(); err != nil {
+		_ = w.f.Close()
+		return err
+	}
+	return w.f.Close()
+}
+
+// readJSONL JSONLファイルのレコードを先頭から順にfnに渡します
+func readJSONL[T any](dir, name string, fn func(r *T) error) error {
+	f, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	for line := 1; ; line++ {
+		b, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if len(bytes.TrimSpace(b)) > 0 {
+			var r T
+			if err := json.Unmarshal(b, &r); err != nil {
+				return fmt.Errorf("%s: line %d: %w", name, line, err)
+			}
+			if err := fn(&r); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+func blobPath(dir, key string) string {
+	return filepath.Join(dir, blobDirName, key)
+}
@@ -0,0 +1,130 @@
+package archive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestJSONL(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	records := []*messageRecord{
+		{
+			ID:        uuid.Must(uuid.NewV4()),
+			UserID:    uuid.Must(uuid.NewV4()),
+			ChannelID: uuid.Must(uuid.NewV4()),
+			Text:      "a\nb",
+			CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+			Stamps:    []messageStampRecord{{StampID: uuid.Must(uuid.NewV4()), UserID: uuid.Must(uuid.NewV4()), Count: 3}},
+		},
+		{
+			ID:              uuid.Must(uuid.NewV4()),
+			ParentMessageID: optional.From(uuid.Must(uuid.NewV4())),
+			Stamps:          []messageStampRecord{},
+			Pin:             &pinRecord{UserID: uuid.Must(uuid.NewV4())},
+		},
+	}
+
+	w, err := createJSONL(dir, messagesFileName)
+	require.NoError(t, err)
+	for _, r := range records {
+		require.NoError(t, w.Write(r))
+	}
+	require.NoError(t, w.Close())
+
+	var read []*messageRecord
+	require.NoError(t, readJSONL(dir, messagesFileName, func(r *messageRecord) error {
+		read = append(read, r)
+		return nil
+	}))
+	if assert.Len(t, read, len(records)) {
+		for i := range records {
+			assert.Equal(t, records[i].ID, read[i].ID)
+			assert.Equal(t, records[i].Text, read[i].Text)
+			assert.Equal(t, records[i].ParentMessageID, read[i].ParentMessageID)
+			assert.True(t, records[i].UpdatedAt.Equal(read[i].UpdatedAt))
+			assert.Equal(t, records[i].Stamps, read[i].Stamps)
+			assert.Equal(t, records[i].Pin, read[i].Pin)
+		}
+	}
+}
+
+func TestSortChannelsByTree(t *testing.T) {
+	t.Parallel()
+
+	dmRoot := uuid.Must(uuid.FromString(model.DirectMessageChannelRootID))
+	a := &model.Channel{ID: uuid.Must(uuid.NewV4()), ParentID: uuid.Nil}
+	b := &model.Channel{ID: uuid.Must(uuid.NewV4()), ParentID: a.ID}
+	c := &model.Channel{ID: uuid.Must(uuid.NewV4()), ParentID: b.ID}
+	dm := &model.Channel{ID: uuid.Must(uuid.NewV4()), ParentID: dmRoot}
+	orphan := &model.Channel{ID: uuid.Must(uuid.NewV4()), ParentID: uuid.Must(uuid.NewV4())}
+
+	sorted := sortChannelsByTree([]*model.Channel{c, orphan, b, dm, a})
+	assert.Equal(t, []*model.Channel{a, dm, b, c}, sorted)
+}
+
+func TestRewriteText(t *testing.T) {
+	t.Parallel()
+
+	oldUser, newUser := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	oldMessage, newMessage := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	unknown := uuid.Must(uuid.NewV4())
+	ids := map[uuid.UUID]uuid.UUID{
+		oldUser:    newUser,
+		oldMessage: newMessage,
+	}
+
+	tests := []struct {
+		name      string
+		text      string
+		srcOrigin string
+		origin    string
+		want      string
+	}{
+		{
+			name: "no ids",
+			text: "hello",
+			want: "hello",
+		},
+		{
+			name: "embedded user",
+			text: `!{"type":"user","raw":"@a","id":"` + oldUser.String() + `"} hi`,
+			want: `!{"type":"user","raw":"@a","id":"` + newUser.String() + `"} hi`,
+		},
+		{
+			name: "unknown id is kept",
+			text: "https://example.com/files/" + unknown.String(),
+			want: "https://example.com/files/" + unknown.String(),
+		},
+		{
+			name:      "message url with same origin",
+			text:      "https://q.example.com/messages/" + oldMessage.String(),
+			srcOrigin: "https://q.example.com",
+			origin:    "https://q.example.com",
+			want:      "https://q.example.com/messages/" + newMessage.String(),
+		},
+		{
+			name:      "message url with different origin",
+			text:      "see https://old.example.com/messages/" + oldMessage.String() + " and https://old.example.com.evil/",
+			srcOrigin: "https://old.example.com",
+			origin:    "https://new.example.com/",
+			want:      "see https://new.example.com/messages/" + newMessage.String() + " and https://old.example.com.evil/",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, rewriteText(tt.text, ids, tt.srcOrigin, tt.origin))
+		})
+	}
+}
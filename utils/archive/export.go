@@ -0,0 +1,382 @@
+package archive
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/storage"
+)
+
+type exporter struct {
+	db     *gorm.DB
+	fs     storage.FileStorage
+	dir    string
+	logger *zap.Logger
+
+	// channels 書き出したチャンネルのIDの集合
+	channels map[uuid.UUID]bool
+}
+
+// Export dbとfsの内容をアーカイブとしてdirに書き出します
+//
+// dirは存在しないか、空のディレクトリである必要があります。
+// 削除済みのチャンネル・メッセージ・スタンプ・ファイルは書き出されません。
+func Export(db *gorm.DB, fs storage.FileStorage, dir, origin string, logger *zap.Logger) error {
+	if err := prepareDir(dir); err != nil {
+		return err
+	}
+
+	e := &exporter{
+		db:       db,
+		fs:       fs,
+		dir:      dir,
+		logger:   logger.Named("archive"),
+		channels: map[uuid.UUID]bool{},
+	}
+	steps := []struct {
+		name string
+		fn   func(w *jsonlWriter) error
+	}{
+		{usersFileName, e.exportUsers},
+		{filesFileName, e.exportFiles},
+		{stampsFileName, e.exportStamps},
+		{channelsFileName, e.exportChannels},
+		{messagesFileName, e.exportMessages},
+		{clipsFileName, e.exportClipFolders},
+		{userGroupsFileName, e.exportUserGroups},
+	}
+	for _, step := range steps {
+		e.logger.Info("exporting " + step.name + "...")
+		if err := e.writeJSONL(step.name, step.fn); err != nil {
+			return fmt.Errorf("failed to export %s: %w", step.name, err)
+		}
+	}
+
+	// マニフェストは最後に書き出す (書き出しが完了したアーカイブにのみ存在)
+	b, err := json.MarshalIndent(&Manifest{
+		Version:    FormatVersion,
+		Origin:     origin,
+		ExportedAt: time.Now(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, manifestFileName), b, 0o644)
+}
+
+// writeJSONL nameのJSONLファイルを作成してfnで書き込みます
+func (e *exporter) writeJSONL(name string, fn func(w *jsonlWriter) error) error {
+	w, err := createJSONL(e.dir, name)
+	if err != nil {
+		return err
+	}
+	if err := fn(w); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func prepareDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if len(entries) > 0 {
+		return ErrNotEmpty
+	}
+	return os.MkdirAll(filepath.Join(dir, blobDirName), 0o755)
+}
+
+func (e *exporter) exportUsers(w *jsonlWriter) error {
+	var users []*model.User
+	return e.db.Preload("Profile").FindInBatches(&users, batchSize, func(_ *gorm.DB, _ int) error {
+		for _, u := range users {
+			r := &userRecord{
+				ID:          u.ID,
+				Name:        u.Name,
+				DisplayName: u.DisplayName,
+				IconFileID:  u.Icon,
+				State:       u.Status.Int(),
+				Bot:         u.Bot,
+				Role:        u.Role,
+				CreatedAt:   u.CreatedAt,
+				UpdatedAt:   u.UpdatedAt,
+			}
+			if u.Profile != nil {
+				r.Bio = u.Profile.Bio
+				r.TwitterID = u.Profile.TwitterID
+				r.HomeChannel = u.Profile.HomeChannel
+			}
+			if err := w.Write(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+func (e *exporter) exportFiles(w *jsonlWriter) error {
+	var files []*model.FileMeta
+	return e.db.Preload("Thumbnails").FindInBatches(&files, batchSize, func(_ *gorm.DB, _ int) error {
+		ids := make([]uuid.UUID, len(files))
+		for i, f := range files {
+			ids[i] = f.ID
+		}
+		var acl []*model.FileACLEntry
+		if err := e.db.Where("file_id IN ?", ids).Find(&acl).Error; err != nil {
+			return err
+		}
+		aclMap := map[uuid.UUID][]fileACLRecord{}
+		for _, entry := range acl {
+			aclMap[entry.FileID] = append(aclMap[entry.FileID], fileACLRecord{UserID: entry.UserID, Allow: entry.Allow})
+		}
+
+		for _, f := range files {
+			r := &fileRecord{
+				ID:              f.ID,
+				Name:            f.Name,
+				Mime:            f.Mime,
+				Size:            f.Size,
+				CreatorID:       f.CreatorID,
+				Hash:            f.Hash,
+				Type:            f.Type.String(),
+				IsAnimatedImage: f.IsAnimatedImage,
+				ChannelID:       f.ChannelID,
+				CreatedAt:       f.CreatedAt,
+				Thumbnails:      make([]fileThumbnailRecord, 0, len(f.Thumbnails)),
+				ACL:             aclMap[f.ID],
+			}
+			if err := e.copyBlob(f.ID.String(), f.Type); err != nil {
+				return err
+			}
+			for _, t := range f.Thumbnails {
+				r.Thumbnails = append(r.Thumbnails, fileThumbnailRecord{
+					Type:   t.Type.String(),
+					Mime:   t.Mime,
+					Width:  t.Width,
+					Height: t.Height,
+				})
+				if err := e.copyBlob(f.ID.String()+"-"+t.Type.Suffix(), model.FileTypeThumbnail); err != nil {
+					return err
+				}
+			}
+			if err := w.Write(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+// copyBlob ストレージのファイル実体をアーカイブにコピーします。ストレージに実体が無い場合はスキップします
+func (e *exporter) copyBlob(key string, fileType model.FileType) error {
+	src, err := e.fs.OpenFileByKey(key, fileType)
+	if err != nil {
+		if err == storage.ErrFileNotFound {
+			e.logger.Warn("file not found in storage, skipped", zap.String("key", key))
+			return nil
+		}
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.Create(blobPath(e.dir, key))
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+func (e *exporter) exportStamps(w *jsonlWriter) error {
+	var stamps []*model.Stamp
+	return e.db.FindInBatches(&stamps, batchSize, func(_ *gorm.DB, _ int) error {
+		for _, s := range stamps {
+			if err := w.Write(&stampRecord{
+				ID:        s.ID,
+				Name:      s.Name,
+				CreatorID: s.CreatorID,
+				FileID:    s.FileID,
+				IsUnicode: s.IsUnicode,
+				CreatedAt: s.CreatedAt,
+				UpdatedAt: s.UpdatedAt,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+func (e *exporter) exportChannels(w *jsonlWriter) error {
+	var channels []*model.Channel
+	if err := e.db.Find(&channels).Error; err != nil {
+		return err
+	}
+	var members []*model.UsersPrivateChannel
+	if err := e.db.Find(&members).Error; err != nil {
+		return err
+	}
+	membersMap := map[uuid.UUID][]uuid.UUID{}
+	for _, m := range members {
+		membersMap[m.ChannelID] = append(membersMap[m.ChannelID], m.UserID)
+	}
+	var mappings []*model.DMChannelMapping
+	if err := e.db.Find(&mappings).Error; err != nil {
+		return err
+	}
+	dmMap := map[uuid.UUID][]uuid.UUID{}
+	for _, m := range mappings {
+		dmMap[m.ChannelID] = []uuid.UUID{m.User1, m.User2}
+	}
+
+	for _, ch := range sortChannelsByTree(channels) {
+		if err := w.Write(&channelRecord{
+			ID:        ch.ID,
+			Name:      ch.Name,
+			ParentID:  ch.ParentID,
+			Topic:     ch.Topic,
+			IsForced:  ch.IsForced,
+			IsPublic:  ch.IsPublic,
+			IsVisible: ch.IsVisible,
+			CreatorID: ch.CreatorID,
+			UpdaterID: ch.UpdaterID,
+			CreatedAt: ch.CreatedAt,
+			UpdatedAt: ch.UpdatedAt,
+			Members:   membersMap[ch.ID],
+			DMUsers:   dmMap[ch.ID],
+		}); err != nil {
+			return err
+		}
+		e.channels[ch.ID] = true
+	}
+	return nil
+}
+
+// sortChannelsByTree 親チャンネルが子チャンネルより先に来るように並び替えます
+//
+// 親が存在しない(削除済みの)チャンネルとその子孫は除外されます。
+func sortChannelsByTree(channels []*model.Channel) []*model.Channel {
+	children := map[uuid.UUID][]*model.Channel{}
+	for _, ch := range channels {
+		children[ch.ParentID] = append(children[ch.ParentID], ch)
+	}
+
+	result := make([]*model.Channel, 0, len(channels))
+	queue := []uuid.UUID{uuid.Nil, uuid.Must(uuid.FromString(model.DirectMessageChannelRootID))}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, ch := range children[id] {
+			result = append(result, ch)
+			queue = append(queue, ch.ID)
+		}
+	}
+	return result
+}
+
+func (e *exporter) exportMessages(w *jsonlWriter) error {
+	var messages []*model.Message
+	return e.db.Preload("Stamps").Preload("Pin").FindInBatches(&messages, batchSize, func(_ *gorm.DB, _ int) error {
+		for _, m := range messages {
+			if !e.channels[m.ChannelID] {
+				continue
+			}
+			r := &messageRecord{
+				ID:              m.ID,
+				UserID:          m.UserID,
+				ChannelID:       m.ChannelID,
+				ParentMessageID: m.ParentMessageID,
+				Text:            m.Text,
+				CreatedAt:       m.CreatedAt,
+				UpdatedAt:       m.UpdatedAt,
+				Stamps:          make([]messageStampRecord, len(m.Stamps)),
+			}
+			for i, s := range m.Stamps {
+				r.Stamps[i] = messageStampRecord{
+					StampID:   s.StampID,
+					UserID:    s.UserID,
+					Count:     s.Count,
+					CreatedAt: s.CreatedAt,
+					UpdatedAt: s.UpdatedAt,
+				}
+			}
+			if m.Pin != nil {
+				r.Pin = &pinRecord{UserID: m.Pin.UserID, CreatedAt: m.Pin.CreatedAt}
+			}
+			if err := w.Write(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+func (e *exporter) exportClipFolders(w *jsonlWriter) error {
+	var folders []*model.ClipFolder
+	return e.db.FindInBatches(&folders, batchSize, func(_ *gorm.DB, _ int) error {
+		for _, f := range folders {
+			var messages []*model.ClipFolderMessage
+			if err := e.db.Where("folder_id = ?", f.ID).Order("created_at").Find(&messages).Error; err != nil {
+				return err
+			}
+			r := &clipFolderRecord{
+				ID:          f.ID,
+				Name:        f.Name,
+				Description: f.Description,
+				OwnerID:     f.OwnerID,
+				CreatedAt:   f.CreatedAt,
+				Messages:    make([]clipFolderMessageRecord, len(messages)),
+			}
+			for i, m := range messages {
+				r.Messages[i] = clipFolderMessageRecord{MessageID: m.MessageID, CreatedAt: m.CreatedAt}
+			}
+			if err := w.Write(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
+
+func (e *exporter) exportUserGroups(w *jsonlWriter) error {
+	var groups []*model.UserGroup
+	return e.db.Preload("Admins").Preload("Members").FindInBatches(&groups, batchSize, func(_ *gorm.DB, _ int) error {
+		for _, g := range groups {
+			r := &userGroupRecord{
+				ID:          g.ID,
+				Name:        g.Name,
+				Description: g.Description,
+				Type:        g.Type,
+				IconFileID:  g.Icon,
+				CreatedAt:   g.CreatedAt,
+				UpdatedAt:   g.UpdatedAt,
+				Admins:      make([]uuid.UUID, len(g.Admins)),
+				Members:     make([]userGroupMemberRecord, len(g.Members)),
+			}
+			for i, a := range g.Admins {
+				r.Admins[i] = a.UserID
+			}
+			for i, m := range g.Members {
+				r.Members[i] = userGroupMemberRecord{UserID: m.UserID, Role: m.Role}
+			}
+			if err := w.Write(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
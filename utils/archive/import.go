@@ -0,0 +1,603 @@
+package archive
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/rbac/role"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/storage"
+)
+
+var uuidRegex = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+// ImportOptions アーカイブの読み込みオプション
+type ImportOptions struct {
+	// KeepRoles 作成するユーザーにアーカイブ内のロールを引き継ぐかどうか。falseの場合は全てuserロールで作成します
+	KeepRoles bool
+}
+
+type importer struct {
+	db     *gorm.DB
+	fs     storage.FileStorage
+	dir    string
+	opts   ImportOptions
+	logger *zap.Logger
+
+	srcOrigin string
+	origin    string
+	// ids アーカイブ内のIDから読み込み先のIDへの対応
+	ids map[uuid.UUID]uuid.UUID
+	// roles 読み込み先に存在するユーザーロール
+	roles map[string]bool
+	// homeChannels ホームチャンネルの設定を保留しているユーザー (読み込み先のユーザーID → アーカイブ内のチャンネルID)
+	homeChannels map[uuid.UUID]uuid.UUID
+	// latest チャンネル毎の最新メッセージ
+	latest map[uuid.UUID]*model.ChannelLatestMessage
+}
+
+// Import dirのアーカイブをdbとfsに読み込みます
+//
+// アーカイブ内の全てのIDは新しいIDに振り直され、メッセージ本文中の埋め込みやURLも読み込み先に合わせて書き換えられます。
+// 同名のユーザー・スタンプ・ユーザーグループ、同じ親を持つ同名のチャンネルが既に存在する場合は、それらを使用します。
+// メッセージが既に存在するインスタンスには読み込めません。
+// データベースへの書き込みは1つのトランザクションで行うため、失敗した場合は何も読み込まれず、再実行できます。
+// ただし、ストレージに保存済みのファイル実体は削除されません。
+func Import(db *gorm.DB, fs storage.FileStorage, dir, origin string, opts ImportOptions, logger *zap.Logger) error {
+	b, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	if err != nil {
+		return fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(b, &manifest); err != nil {
+		return fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if manifest.Version != FormatVersion {
+		return ErrUnsupportedVersion
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		return importArchive(tx, fs, dir, origin, manifest, opts, logger)
+	})
+}
+
+func importArchive(db *gorm.DB, fs storage.FileStorage, dir, origin string, manifest Manifest, opts ImportOptions, logger *zap.Logger) error {
+	var count int64
+	if err := db.Unscoped().Model(&model.Message{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrNotEmpty
+	}
+
+	im := &importer{
+		db:           db,
+		fs:           fs,
+		dir:          dir,
+		opts:         opts,
+		logger:       logger.Named("archive"),
+		srcOrigin:    manifest.Origin,
+		origin:       origin,
+		ids:          map[uuid.UUID]uuid.UUID{},
+		roles:        map[string]bool{},
+		homeChannels: map[uuid.UUID]uuid.UUID{},
+		latest:       map[uuid.UUID]*model.ChannelLatestMessage{},
+	}
+
+	// ユーザーアイコンやメッセージ本文・スレッドから参照されるため、ファイルとメッセージのIDは先に振り直しておく
+	for _, name := range []string{filesFileName, messagesFileName} {
+		if err := readJSONL(dir, name, func(r *struct {
+			ID uuid.UUID `json:"id"`
+		}) error {
+			im.ids[r.ID] = uuid.Must(uuid.NewV4())
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	var roles []*model.UserRole
+	if err := db.Find(&roles).Error; err != nil {
+		return err
+	}
+	for _, r := range roles {
+		im.roles[r.Name] = true
+	}
+
+	steps := []struct {
+		name string
+		fn   func() error
+	}{
+		{usersFileName, func() error { return readJSONL(dir, usersFileName, im.importUser) }},
+		{channelsFileName, func() error { return readJSONL(dir, channelsFileName, im.importChannel) }},
+		{filesFileName, func() error { return readJSONL(dir, filesFileName, im.importFile) }},
+		{stampsFileName, func() error { return readJSONL(dir, stampsFileName, im.importStamp) }},
+		{messagesFileName, im.importMessages},
+		{clipsFileName, func() error { return readJSONL(dir, clipsFileName, im.importClipFolder) }},
+		{userGroupsFileName, func() error { return readJSONL(dir, userGroupsFileName, im.importUserGroup) }},
+	}
+	for _, step := range steps {
+		im.logger.Info("importing " + step.name + "...")
+		if err := step.fn(); err != nil {
+			return fmt.Errorf("failed to import %s: %w", step.name, err)
+		}
+	}
+
+	for uid, cid := range im.homeChannels {
+		if newCID, ok := im.lookup(cid); ok {
+			if err := db.Model(&model.UserProfile{}).Where("user_id = ?", uid).Update("home_channel", newCID).Error; err != nil {
+				return err
+			}
+		}
+	}
+	for _, clm := range im.latest {
+		if err := db.Clauses(clause.OnConflict{UpdateAll: true}).Create(clm).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookup アーカイブ内のIDに対応する読み込み先のIDを返します
+func (im *importer) lookup(id uuid.UUID) (uuid.UUID, bool) {
+	newID, ok := im.ids[id]
+	return newID, ok
+}
+
+// mapID アーカイブ内のIDに対応する読み込み先のIDを返します。対応が無い場合はそのまま返します
+func (im *importer) mapID(id uuid.UUID) uuid.UUID {
+	if newID, ok := im.ids[id]; ok {
+		return newID
+	}
+	return id
+}
+
+// mapOptionalID アーカイブ内のIDに対応する読み込み先のIDを返します。対応が無い場合は無効な値を返します
+func (im *importer) mapOptionalID(id optional.Of[uuid.UUID]) optional.Of[uuid.UUID] {
+	if !id.Valid {
+		return id
+	}
+	newID, ok := im.lookup(id.V)
+	return optional.New(newID, ok)
+}
+
+func (im *importer) importUser(r *userRecord) error {
+	var u model.User
+	err := im.db.Where("name = ?", r.Name).Take(&u).Error
+	if err == nil {
+		im.ids[r.ID] = u.ID
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	u = model.User{
+		ID:          uuid.Must(uuid.NewV4()),
+		Name:        r.Name,
+		DisplayName: r.DisplayName,
+		Icon:        im.mapID(r.IconFileID),
+		Status:      model.UserAccountStatus(r.State),
+		Bot:         r.Bot,
+		Role:        r.Role,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	if r.Bot {
+		// BOTの設定や認証情報は移行されないため、凍結状態で作成する
+		u.Status = model.UserAccountStatusDeactivated
+	}
+	if !im.opts.KeepRoles || !im.roles[u.Role] {
+		u.Role = role.User
+	}
+	if err := im.db.Omit(clause.Associations).Create(&u).Error; err != nil {
+		return err
+	}
+	if err := im.db.Omit(clause.Associations).Create(&model.UserProfile{
+		UserID:    u.ID,
+		Bio:       r.Bio,
+		TwitterID: r.TwitterID,
+		UpdatedAt: r.UpdatedAt,
+	}).Error; err != nil {
+		return err
+	}
+	if r.HomeChannel.Valid {
+		im.homeChannels[u.ID] = r.HomeChannel.V
+	}
+	im.ids[r.ID] = u.ID
+	return nil
+}
+
+func (im *importer) importChannel(r *channelRecord) error {
+	if r.IsDMChannel() {
+		return im.importDMChannel(r)
+	}
+
+	parentID := im.mapID(r.ParentID)
+	var ch model.Channel
+	err := im.db.Where("name = ? AND parent_id = ?", r.Name, parentID).Take(&ch).Error
+	if err == nil {
+		im.ids[r.ID] = ch.ID
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	ch = im.newChannel(r)
+	ch.ParentID = parentID
+	return im.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&ch).Error; err != nil {
+			return err
+		}
+		for _, member := range r.Members {
+			uid, ok := im.lookup(member)
+			if !ok {
+				continue
+			}
+			if err := tx.Omit(clause.Associations).Create(&model.UsersPrivateChannel{UserID: uid, ChannelID: ch.ID}).Error; err != nil {
+				return err
+			}
+		}
+		im.ids[r.ID] = ch.ID
+		return nil
+	})
+}
+
+func (im *importer) importDMChannel(r *channelRecord) error {
+	u1, ok1 := im.lookup(r.DMUsers[0])
+	u2, ok2 := im.lookup(r.DMUsers[1])
+	if !ok1 || !ok2 {
+		im.logger.Warn("dm channel user not found, skipped", zap.Stringer("channelId", r.ID))
+		return nil
+	}
+
+	var mapping model.DMChannelMapping
+	err := im.db.Where("(user1 = ? AND user2 = ?) OR (user1 = ? AND user2 = ?)", u1, u2, u2, u1).Take(&mapping).Error
+	if err == nil {
+		im.ids[r.ID] = mapping.ChannelID
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	// user1 <= user2 になるように入れかえ
+	if bytes.Compare(u1.Bytes(), u2.Bytes()) == 1 {
+		u1, u2 = u2, u1
+	}
+	members := []uuid.UUID{u1}
+	if u1 != u2 {
+		members = append(members, u2)
+	}
+
+	ch := im.newChannel(r)
+	return im.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&ch).Error; err != nil {
+			return err
+		}
+		for _, uid := range members {
+			if err := tx.Omit(clause.Associations).Create(&model.UsersPrivateChannel{UserID: uid, ChannelID: ch.ID}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Omit(clause.Associations).Create(&model.DMChannelMapping{ChannelID: ch.ID, User1: u1, User2: u2}).Error; err != nil {
+			return err
+		}
+		im.ids[r.ID] = ch.ID
+		return nil
+	})
+}
+
+func (im *importer) newChannel(r *channelRecord) model.Channel {
+	return model.Channel{
+		ID:        uuid.Must(uuid.NewV4()),
+		Name:      r.Name,
+		ParentID:  r.ParentID,
+		Topic:     r.Topic,
+		IsForced:  r.IsForced,
+		IsPublic:  r.IsPublic,
+		IsVisible: r.IsVisible,
+		CreatorID: im.mapID(r.CreatorID),
+		UpdaterID: im.mapID(r.UpdaterID),
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func (im *importer) importFile(r *fileRecord) error {
+	fileType, err := model.FileTypeFromString(r.Type)
+	if err != nil {
+		return err
+	}
+
+	f := model.FileMeta{
+		ID:              im.ids[r.ID],
+		Name:            r.Name,
+		Mime:            r.Mime,
+		Size:            r.Size,
+		CreatorID:       im.mapOptionalID(r.CreatorID),
+		Hash:            r.Hash,
+		Type:            fileType,
+		IsAnimatedImage: r.IsAnimatedImage,
+		ChannelID:       im.mapOptionalID(r.ChannelID),
+		CreatedAt:       r.CreatedAt,
+	}
+	if err := im.restoreBlob(r.ID.String(), f.ID.String(), f.Name, f.Mime, f.Type); err != nil {
+		return err
+	}
+	for _, t := range r.Thumbnails {
+		thumbnailType, err := model.ThumbnailTypeFromString(t.Type)
+		if err != nil {
+			return err
+		}
+		f.Thumbnails = append(f.Thumbnails, model.FileThumbnail{
+			FileID: f.ID,
+			Type:   thumbnailType,
+			Mime:   t.Mime,
+			Width:  t.Width,
+			Height: t.Height,
+		})
+		key := f.ID.String() + "-" + thumbnailType.Suffix()
+		if err := im.restoreBlob(r.ID.String()+"-"+thumbnailType.Suffix(), key, key, t.Mime, model.FileTypeThumbnail); err != nil {
+			return err
+		}
+	}
+
+	return im.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&f).Error; err != nil {
+			return err
+		}
+		if len(f.Thumbnails) > 0 {
+			if err := tx.Create(&f.Thumbnails).Error; err != nil {
+				return err
+			}
+		}
+		for _, entry := range r.ACL {
+			uid := im.mapID(entry.UserID) // uuid.Nil (全員) はそのまま
+			if err := tx.Omit(clause.Associations).Create(&model.FileACLEntry{FileID: f.ID, UserID: uid, Allow: entry.Allow}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// restoreBlob アーカイブ内のファイル実体をストレージに保存します。アーカイブに実体が無い場合はスキップします
+func (im *importer) restoreBlob(srcKey, key, name, contentType string, fileType model.FileType) error {
+	src, err := os.Open(blobPath(im.dir, srcKey))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			im.logger.Warn("file not found in archive, skipped", zap.String("key", srcKey))
+			return nil
+		}
+		return err
+	}
+	defer src.Close()
+	return im.fs.SaveByKey(src, key, name, contentType, fileType)
+}
+
+func (im *importer) importStamp(r *stampRecord) error {
+	var s model.Stamp
+	err := im.db.Unscoped().Where("name = ?", r.Name).Take(&s).Error
+	if err == nil {
+		im.ids[r.ID] = s.ID
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if r.FileID == uuid.Nil {
+		// 実体を持たないスタンプ (Slackの絵文字など) は読み込み先に存在する場合のみ使用する
+		im.logger.Warn("stamp not found, skipped", zap.String("name", r.Name))
+		return nil
+	}
+
+	s = model.Stamp{
+		ID:        uuid.Must(uuid.NewV4()),
+		Name:      r.Name,
+		CreatorID: im.mapID(r.CreatorID),
+		FileID:    im.mapID(r.FileID),
+		IsUnicode: r.IsUnicode,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+	if err := im.db.Omit(clause.Associations).Create(&s).Error; err != nil {
+		return err
+	}
+	im.ids[r.ID] = s.ID
+	return nil
+}
+
+func (im *importer) importMessages() error {
+	var (
+		messages []*model.Message
+		stamps   []*model.MessageStamp
+		pins     []*model.Pin
+	)
+	flush := func() error {
+		if len(messages) == 0 {
+			return nil
+		}
+		err := im.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Omit(clause.Associations).Create(&messages).Error; err != nil {
+				return err
+			}
+			if len(stamps) > 0 {
+				if err := tx.Omit(clause.Associations).CreateInBatches(&stamps, batchSize).Error; err != nil {
+					return err
+				}
+			}
+			if len(pins) > 0 {
+				if err := tx.Omit(clause.Associations).Create(&pins).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		messages, stamps, pins = nil, nil, nil
+		return err
+	}
+
+	err := readJSONL(im.dir, messagesFileName, func(r *messageRecord) error {
+		userID, ok1 := im.lookup(r.UserID)
+		channelID, ok2 := im.lookup(r.ChannelID)
+		if !ok1 || !ok2 {
+			im.logger.Warn("message user or channel not found, skipped", zap.Stringer("messageId", r.ID))
+			return nil
+		}
+
+		m := &model.Message{
+			ID:              im.ids[r.ID],
+			UserID:          userID,
+			ChannelID:       channelID,
+			Text:            rewriteText(r.Text, im.ids, im.srcOrigin, im.origin),
+			ParentMessageID: im.mapOptionalID(r.ParentMessageID),
+			CreatedAt:       r.CreatedAt,
+			UpdatedAt:       r.UpdatedAt,
+		}
+		messages = append(messages, m)
+		for _, s := range r.Stamps {
+			stampID, ok1 := im.lookup(s.StampID)
+			userID, ok2 := im.lookup(s.UserID)
+			if !ok1 || !ok2 {
+				continue
+			}
+			stamps = append(stamps, &model.MessageStamp{
+				MessageID: m.ID,
+				StampID:   stampID,
+				UserID:    userID,
+				Count:     s.Count,
+				CreatedAt: s.CreatedAt,
+				UpdatedAt: s.UpdatedAt,
+			})
+		}
+		if r.Pin != nil {
+			if pinUserID, ok := im.lookup(r.Pin.UserID); ok {
+				pins = append(pins, &model.Pin{
+					ID:        uuid.Must(uuid.NewV4()),
+					MessageID: m.ID,
+					UserID:    pinUserID,
+					CreatedAt: r.Pin.CreatedAt,
+				})
+			}
+		}
+		if clm, ok := im.latest[channelID]; !ok || clm.DateTime.Before(m.CreatedAt) {
+			im.latest[channelID] = &model.ChannelLatestMessage{ChannelID: channelID, MessageID: m.ID, DateTime: m.CreatedAt}
+		}
+
+		if len(messages) >= batchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
+func (im *importer) importClipFolder(r *clipFolderRecord) error {
+	ownerID, ok := im.lookup(r.OwnerID)
+	if !ok {
+		im.logger.Warn("clip folder owner not found, skipped", zap.Stringer("clipFolderId", r.ID))
+		return nil
+	}
+
+	f := model.ClipFolder{
+		ID:          uuid.Must(uuid.NewV4()),
+		Name:        r.Name,
+		Description: r.Description,
+		OwnerID:     ownerID,
+		CreatedAt:   r.CreatedAt,
+	}
+	return im.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&f).Error; err != nil {
+			return err
+		}
+		for _, m := range r.Messages {
+			messageID, ok := im.lookup(m.MessageID)
+			if !ok {
+				continue
+			}
+			if err := tx.Omit(clause.Associations).Create(&model.ClipFolderMessage{FolderID: f.ID, MessageID: messageID, CreatedAt: m.CreatedAt}).Error; err != nil {
+				return err
+			}
+		}
+		im.ids[r.ID] = f.ID
+		return nil
+	})
+}
+
+func (im *importer) importUserGroup(r *userGroupRecord) error {
+	var g model.UserGroup
+	err := im.db.Where("name = ?", r.Name).Take(&g).Error
+	if err == nil {
+		im.ids[r.ID] = g.ID
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	g = model.UserGroup{
+		ID:          uuid.Must(uuid.NewV4()),
+		Name:        r.Name,
+		Description: r.Description,
+		Type:        r.Type,
+		Icon:        im.mapID(r.IconFileID),
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	return im.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(&g).Error; err != nil {
+			return err
+		}
+		for _, admin := range r.Admins {
+			uid, ok := im.lookup(admin)
+			if !ok {
+				continue
+			}
+			if err := tx.Create(&model.UserGroupAdmin{GroupID: g.ID, UserID: uid}).Error; err != nil {
+				return err
+			}
+		}
+		for _, member := range r.Members {
+			uid, ok := im.lookup(member.UserID)
+			if !ok {
+				continue
+			}
+			if err := tx.Create(&model.UserGroupMember{GroupID: g.ID, UserID: uid, Role: member.Role}).Error; err != nil {
+				return err
+			}
+		}
+		im.ids[r.ID] = g.ID
+		return nil
+	})
+}
+
+// rewriteText メッセージ本文中のID(埋め込み・URL)とオリジンを読み込み先のものに書き換えます
+func rewriteText(text string, ids map[uuid.UUID]uuid.UUID, srcOrigin, origin string) string {
+	text = uuidRegex.ReplaceAllStringFunc(text, func(s string) string {
+		if newID, ok := ids[uuid.FromStringOrNil(s)]; ok {
+			return newID.String()
+		}
+		return s
+	})
+	if len(srcOrigin) > 0 && len(origin) > 0 && srcOrigin != origin {
+		text = strings.ReplaceAll(text, strings.TrimSuffix(srcOrigin, "/")+"/", strings.TrimSuffix(origin, "/")+"/")
+	}
+	return text
+}
@@ -0,0 +1,697 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"image/png"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/rbac/role"
+	"github.com/traPtitech/traQ/utils/imaging"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+const (
+	// slackBotUserName ユーザーに紐付かないSlackのBOTメッセージの投稿者として作成するユーザーの名前
+	slackBotUserName = "slack-bot"
+
+	maxUserNameLength    = 32
+	maxChannelNameLength = 20
+)
+
+var (
+	slackTokenRegex    = regexp.MustCompile(`<([^<>]+)>`)
+	slackEmojiRegex    = regexp.MustCompile(`:([a-z0-9_+'-]+)((?:::skin-tone-[2-6])?):`)
+	invalidNameRegex   = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+	slackTextUnescaper = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+)
+
+// slackEmojiAliases SlackとtraQ(Unicode絵文字スタンプ)で名前が異なる絵文字
+var slackEmojiAliases = map[string]string{
+	"+1":                    "thumbsup",
+	"-1":                    "thumbsdown",
+	"simple_smile":          "slight_smile",
+	"slightly_smiling_face": "slight_smile",
+}
+
+// SlackOptions Slackエクスポートの変換オプション
+type SlackOptions struct {
+	// ParentChannel 変換したチャンネルを配置する親チャンネルの名前。空の場合はルートに配置します
+	ParentChannel string
+}
+
+type slackUser struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	RealName string `json:"real_name"`
+	Deleted  bool   `json:"deleted"`
+	IsBot    bool   `json:"is_bot"`
+	Profile  struct {
+		DisplayName string `json:"display_name"`
+		RealName    string `json:"real_name"`
+		Title       string `json:"title"`
+	} `json:"profile"`
+}
+
+type slackChannel struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Created    int64    `json:"created"`
+	Creator    string   `json:"creator"`
+	IsArchived bool     `json:"is_archived"`
+	Members    []string `json:"members"`
+	Topic      struct {
+		Value string `json:"value"`
+	} `json:"topic"`
+	Purpose struct {
+		Value string `json:"value"`
+	} `json:"purpose"`
+}
+
+type slackMessage struct {
+	Type     string `json:"type"`
+	Subtype  string `json:"subtype"`
+	User     string `json:"user"`
+	Text     string `json:"text"`
+	Ts       string `json:"ts"`
+	ThreadTs string `json:"thread_ts"`
+	Edited   *struct {
+		Ts string `json:"ts"`
+	} `json:"edited"`
+	Reactions []struct {
+		Name  string   `json:"name"`
+		Users []string `json:"users"`
+	} `json:"reactions"`
+}
+
+type slackConverter struct {
+	files  map[string]*zip.File
+	dir    string
+	logger *zap.Logger
+
+	// users SlackのユーザーID → ユーザー
+	users     map[string]*userRecord
+	userNames map[string]bool
+	botUser   *userRecord
+	// channels SlackのチャンネルID → チャンネル
+	channels     map[string]*channelRecord
+	channelNames map[string]bool
+	// channelPaths SlackのチャンネルID → traQでのチャンネルパス
+	channelPaths map[string]string
+	// stamps スタンプ名 → アーカイブ内のスタンプID
+	stamps map[string]uuid.UUID
+	// messages SlackのチャンネルIDとts → アーカイブ内のメッセージID
+	messages map[string]uuid.UUID
+
+	fileWriter *jsonlWriter
+}
+
+// ConvertSlack SlackのエクスポートZIPをtraQのアーカイブに変換してdirに書き出します
+//
+// Slackのユーザーは同名のプレースホルダーユーザーに、メンション・チャンネルリンクは埋め込みに、
+// 絵文字・リアクションはスタンプに変換されます。添付ファイルは変換されません。
+// 書き出したアーカイブは Import で読み込めます。
+func ConvertSlack(zipPath, dir string, opts SlackOptions, logger *zap.Logger) error {
+	if err := vd.Validate(opts.ParentChannel, validator.ChannelNameRule...); err != nil {
+		return fmt.Errorf("invalid parent channel name: %w", err)
+	}
+	if err := prepareDir(dir); err != nil {
+		return err
+	}
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	c := &slackConverter{
+		files:        map[string]*zip.File{},
+		dir:          dir,
+		logger:       logger.Named("slack"),
+		users:        map[string]*userRecord{},
+		userNames:    map[string]bool{},
+		channels:     map[string]*channelRecord{},
+		channelNames: map[string]bool{},
+		channelPaths: map[string]string{},
+		stamps:       map[string]uuid.UUID{},
+		messages:     map[string]uuid.UUID{},
+	}
+	for _, f := range zr.File {
+		c.files[f.Name] = f
+	}
+
+	c.fileWriter, err = createJSONL(dir, filesFileName)
+	if err != nil {
+		return err
+	}
+	if err := c.convert(opts); err != nil {
+		_ = c.fileWriter.Close()
+		return err
+	}
+	if err := c.fileWriter.Close(); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(&Manifest{
+		Version:    FormatVersion,
+		ExportedAt: time.Now(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, manifestFileName), b, 0o644)
+}
+
+func (c *slackConverter) convert(opts SlackOptions) error {
+	// ユーザー
+	var users []*slackUser
+	if err := c.readJSON("users.json", &users, true); err != nil {
+		return err
+	}
+	for _, u := range users {
+		if err := c.addUser(u); err != nil {
+			return err
+		}
+	}
+
+	// チャンネル
+	var parent *channelRecord
+	if len(opts.ParentChannel) > 0 {
+		parent = &channelRecord{
+			ID:        uuid.Must(uuid.NewV4()),
+			Name:      opts.ParentChannel,
+			IsPublic:  true,
+			IsVisible: true,
+		}
+	}
+	type channelDir struct {
+		id  string
+		dir string
+	}
+	var dirs []channelDir
+	for _, kind := range []struct {
+		file    string
+		private bool
+	}{{"channels.json", false}, {"groups.json", true}} {
+		var channels []*slackChannel
+		if err := c.readJSON(kind.file, &channels, false); err != nil {
+			return err
+		}
+		for _, ch := range channels {
+			c.addChannel(ch, parent, kind.private)
+			dirs = append(dirs, channelDir{id: ch.ID, dir: ch.Name})
+		}
+	}
+	var dms []*slackChannel
+	if err := c.readJSON("dms.json", &dms, false); err != nil {
+		return err
+	}
+	for _, ch := range dms {
+		if c.addDMChannel(ch) {
+			dirs = append(dirs, channelDir{id: ch.ID, dir: ch.ID})
+		}
+	}
+
+	// メッセージ
+	w, err := createJSONL(c.dir, messagesFileName)
+	if err != nil {
+		return err
+	}
+	for _, d := range dirs {
+		if err := c.convertMessages(w, d.id, d.dir); err != nil {
+			_ = w.Close()
+			return fmt.Errorf("failed to convert messages of %s: %w", d.dir, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.writeRecords(parent)
+}
+
+// readJSON ZIP内のJSONファイルを読み込みます。requiredでない場合、ファイルが無ければ何もしません
+func (c *slackConverter) readJSON(name string, v any, required bool) error {
+	f, ok := c.files[name]
+	if !ok {
+		if required {
+			return fmt.Errorf("%s is not found in the zip", name)
+		}
+		return nil
+	}
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *slackConverter) addUser(u *slackUser) error {
+	displayName := u.Profile.DisplayName
+	if len(displayName) == 0 {
+		displayName = u.Profile.RealName
+	}
+	if len(displayName) == 0 {
+		displayName = u.RealName
+	}
+	r := &userRecord{
+		ID:          uuid.Must(uuid.NewV4()),
+		Name:        uniqueName(sanitizeName(u.Name, maxUserNameLength, "user"), maxUserNameLength, c.userNames),
+		DisplayName: truncateRunes(displayName, 32),
+		State:       model.UserAccountStatusActive.Int(),
+		Bot:         u.IsBot,
+		Role:        role.User,
+		Bio:         u.Profile.Title,
+	}
+	if u.Deleted {
+		r.State = model.UserAccountStatusDeactivated.Int()
+	}
+	iconID, err := c.addIcon(r.Name)
+	if err != nil {
+		return err
+	}
+	r.IconFileID = iconID
+	c.users[u.ID] = r
+	return nil
+}
+
+// getBotUser ユーザーに紐付かないBOTメッセージの投稿者を返します
+func (c *slackConverter) getBotUser() (*userRecord, error) {
+	if c.botUser != nil {
+		return c.botUser, nil
+	}
+	r := &userRecord{
+		ID:    uuid.Must(uuid.NewV4()),
+		Name:  uniqueName(slackBotUserName, maxUserNameLength, c.userNames),
+		State: model.UserAccountStatusActive.Int(),
+		Bot:   true,
+		Role:  role.User,
+	}
+	iconID, err := c.addIcon(r.Name)
+	if err != nil {
+		return nil, err
+	}
+	r.IconFileID = iconID
+	c.botUser = r
+	return r, nil
+}
+
+// addIcon アイコン画像を生成してアーカイブに追加します
+func (c *slackConverter) addIcon(salt string) (uuid.UUID, error) {
+	var img bytes.Buffer
+	icon := imaging.GenerateIcon(salt)
+	if err := png.Encode(&img, icon); err != nil {
+		return uuid.Nil, err
+	}
+	hash := md5.Sum(img.Bytes())
+
+	id := uuid.Must(uuid.NewV4())
+	for _, key := range []string{id.String(), id.String() + "-" + model.ThumbnailTypeImage.Suffix()} {
+		if err := os.WriteFile(blobPath(c.dir, key), img.Bytes(), 0o644); err != nil {
+			return uuid.Nil, err
+		}
+	}
+	return id, c.fileWriter.Write(&fileRecord{
+		ID:        id,
+		Name:      salt + ".png",
+		Mime:      "image/png",
+		Size:      int64(img.Len()),
+		Hash:      hex.EncodeToString(hash[:]),
+		Type:      model.FileTypeIcon.String(),
+		CreatedAt: time.Now(),
+		Thumbnails: []fileThumbnailRecord{{
+			Type:   model.ThumbnailTypeImage.String(),
+			Mime:   "image/png",
+			Width:  icon.Bounds().Size().X,
+			Height: icon.Bounds().Size().Y,
+		}},
+	})
+}
+
+func (c *slackConverter) addChannel(ch *slackChannel, parent *channelRecord, private bool) {
+	r := &channelRecord{
+		ID:        uuid.Must(uuid.NewV4()),
+		Name:      sanitizeName(ch.Name, maxChannelNameLength, "channel"),
+		Topic:     ch.Topic.Value,
+		IsPublic:  !private,
+		IsVisible: !ch.IsArchived,
+		CreatedAt: time.Unix(ch.Created, 0),
+		UpdatedAt: time.Unix(ch.Created, 0),
+	}
+	if len(r.Topic) == 0 {
+		r.Topic = ch.Purpose.Value
+	}
+	r.Topic = convertSlackText(r.Topic, c.users, c.channels, c.channelPaths)
+	if creator, ok := c.users[ch.Creator]; ok {
+		r.CreatorID = creator.ID
+		r.UpdaterID = creator.ID
+	}
+	if private {
+		for _, m := range ch.Members {
+			if u, ok := c.users[m]; ok {
+				r.Members = append(r.Members, u.ID)
+			}
+		}
+	}
+
+	r.Name = uniqueName(r.Name, maxChannelNameLength, c.channelNames)
+
+	path := r.Name
+	if parent != nil {
+		r.ParentID = parent.ID
+		path = parent.Name + "/" + r.Name
+	}
+	c.channels[ch.ID] = r
+	c.channelPaths[ch.ID] = path
+}
+
+func (c *slackConverter) addDMChannel(ch *slackChannel) bool {
+	if len(ch.Members) == 0 || len(ch.Members) > 2 {
+		return false
+	}
+	u1, ok1 := c.users[ch.Members[0]]
+	u2, ok2 := c.users[ch.Members[len(ch.Members)-1]]
+	if !ok1 || !ok2 {
+		c.logger.Warn("dm member not found, skipped", zap.String("channel", ch.ID))
+		return false
+	}
+	c.channels[ch.ID] = &channelRecord{
+		ID:        uuid.Must(uuid.NewV4()),
+		Name:      "dm_" + strings.ToLower(ch.ID),
+		ParentID:  uuid.Must(uuid.FromString(model.DirectMessageChannelRootID)),
+		CreatedAt: time.Unix(ch.Created, 0),
+		UpdatedAt: time.Unix(ch.Created, 0),
+		DMUsers:   []uuid.UUID{u1.ID, u2.ID},
+	}
+	return true
+}
+
+func (c *slackConverter) convertMessages(w *jsonlWriter, channelID, dir string) error {
+	ch := c.channels[channelID]
+
+	var days []string
+	for name := range c.files {
+		if path.Dir(name) == dir && path.Ext(name) == ".json" {
+			days = append(days, name)
+		}
+	}
+	sort.Strings(days)
+
+	for _, day := range days {
+		var messages []*slackMessage
+		if err := c.readJSON(day, &messages, true); err != nil {
+			return err
+		}
+		for _, m := range messages {
+			if m.Type != "message" || !isConvertibleSubtype(m.Subtype) {
+				continue
+			}
+			text := strings.TrimSpace(convertSlackText(m.Text, c.users, c.channels, c.channelPaths))
+			if len(text) == 0 {
+				continue
+			}
+			createdAt, err := parseSlackTs(m.Ts)
+			if err != nil {
+				return err
+			}
+
+			user, ok := c.users[m.User]
+			if !ok {
+				if user, err = c.getBotUser(); err != nil {
+					return err
+				}
+			}
+			r := &messageRecord{
+				ID:        c.messageID(channelID, m.Ts),
+				UserID:    user.ID,
+				ChannelID: ch.ID,
+				Text:      text,
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+				Stamps:    []messageStampRecord{},
+			}
+			if len(m.ThreadTs) > 0 && m.ThreadTs != m.Ts {
+				r.ParentMessageID = optional.From(c.messageID(channelID, m.ThreadTs))
+			}
+			if m.Edited != nil {
+				if editedAt, err := parseSlackTs(m.Edited.Ts); err == nil && editedAt.After(createdAt) {
+					r.UpdatedAt = editedAt
+				}
+			}
+			for _, reaction := range m.Reactions {
+				stampID := c.stampID(normalizeSlackEmoji(reaction.Name))
+				for _, uid := range reaction.Users {
+					if u, ok := c.users[uid]; ok {
+						r.Stamps = append(r.Stamps, messageStampRecord{
+							StampID:   stampID,
+							UserID:    u.ID,
+							Count:     1,
+							CreatedAt: createdAt,
+							UpdatedAt: createdAt,
+						})
+					}
+				}
+			}
+			if err := w.Write(r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// messageID SlackのメッセージのアーカイブにおけるIDを返します。スレッドの親より先に返信が現れても同じIDになります
+func (c *slackConverter) messageID(channelID, ts string) uuid.UUID {
+	key := channelID + "/" + ts
+	id, ok := c.messages[key]
+	if !ok {
+		id = uuid.Must(uuid.NewV4())
+		c.messages[key] = id
+	}
+	return id
+}
+
+// stampID スタンプ名のアーカイブにおけるIDを返します
+func (c *slackConverter) stampID(name string) uuid.UUID {
+	id, ok := c.stamps[name]
+	if !ok {
+		id = uuid.Must(uuid.NewV4())
+		c.stamps[name] = id
+	}
+	return id
+}
+
+func (c *slackConverter) writeRecords(parent *channelRecord) error {
+	w, err := createJSONL(c.dir, usersFileName)
+	if err != nil {
+		return err
+	}
+	for _, u := range c.users {
+		if err := w.Write(u); err != nil {
+			_ = w.Close()
+			return err
+		}
+	}
+	if c.botUser != nil {
+		if err := w.Write(c.botUser); err != nil {
+			_ = w.Close()
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	channels := make([]*channelRecord, 0, len(c.channels)+1)
+	if parent != nil {
+		channels = append(channels, parent)
+	}
+	for _, ch := range c.channels {
+		channels = append(channels, ch)
+	}
+	w, err = createJSONL(c.dir, channelsFileName)
+	if err != nil {
+		return err
+	}
+	for _, ch := range channels {
+		if err := w.Write(ch); err != nil {
+			_ = w.Close()
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	// 実体を持たないスタンプは、読み込み先に同名のスタンプがある場合のみ使用されます
+	w, err = createJSONL(c.dir, stampsFileName)
+	if err != nil {
+		return err
+	}
+	for name, id := range c.stamps {
+		if err := w.Write(&stampRecord{ID: id, Name: name}); err != nil {
+			_ = w.Close()
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	for _, name := range []string{clipsFileName, userGroupsFileName} {
+		w, err := createJSONL(c.dir, name)
+		if err != nil {
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isConvertibleSubtype 変換対象のメッセージのsubtypeかどうか
+func isConvertibleSubtype(subtype string) bool {
+	switch subtype {
+	case "", "bot_message", "thread_broadcast", "file_share", "me_message":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseSlackTs Slackのts("1503435956.000247")を時刻に変換します
+func parseSlackTs(ts string) (time.Time, error) {
+	sec, frac, _ := strings.Cut(ts, ".")
+	s, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid ts: %s", ts)
+	}
+	var usec int64
+	if len(frac) > 0 {
+		frac = (frac + "000000")[:6]
+		if usec, err = strconv.ParseInt(frac, 10, 64); err != nil {
+			return time.Time{}, fmt.Errorf("invalid ts: %s", ts)
+		}
+	}
+	return time.Unix(s, usec*1000), nil
+}
+
+// convertSlackText Slackのメッセージ記法をtraQのものに変換します
+func convertSlackText(text string, users map[string]*userRecord, channels map[string]*channelRecord, channelPaths map[string]string) string {
+	text = slackTokenRegex.ReplaceAllStringFunc(text, func(s string) string {
+		body, label, hasLabel := strings.Cut(s[1:len(s)-1], "|")
+		switch {
+		case strings.HasPrefix(body, "@"):
+			if u, ok := users[body[1:]]; ok {
+				return fmt.Sprintf(`!{"type":"user","raw":"@%s","id":"%s"}`, u.Name, u.ID)
+			}
+			if hasLabel {
+				return "@" + strings.TrimPrefix(label, "@")
+			}
+			return s
+		case strings.HasPrefix(body, "#"):
+			if ch, ok := channels[body[1:]]; ok && !ch.IsDMChannel() {
+				return fmt.Sprintf(`!{"type":"channel","raw":"#%s","id":"%s"}`, channelPaths[body[1:]], ch.ID)
+			}
+			if hasLabel {
+				return "#" + label
+			}
+			return s
+		case strings.HasPrefix(body, "!"):
+			// 特殊メンション・ユーザーグループ・日付など
+			if hasLabel {
+				return label
+			}
+			return "@" + strings.TrimPrefix(body, "!")
+		default:
+			// リンク
+			if hasLabel && label != body && label != strings.TrimPrefix(body, "mailto:") {
+				return "[" + label + "](" + body + ")"
+			}
+			if hasLabel {
+				return label
+			}
+			return body
+		}
+	})
+	text = slackEmojiRegex.ReplaceAllStringFunc(text, func(s string) string {
+		m := slackEmojiRegex.FindStringSubmatch(s)
+		name := normalizeSlackEmoji(m[1])
+		if name == m[1] && len(m[2]) == 0 {
+			return s
+		}
+		return ":" + name + ":"
+	})
+	return slackTextUnescaper.Replace(text)
+}
+
+// normalizeSlackEmoji Slackの絵文字名をtraQのスタンプ名に変換します
+func normalizeSlackEmoji(name string) string {
+	name, _, _ = strings.Cut(name, "::") // スキントーン
+	if alias, ok := slackEmojiAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// sanitizeName 名前をtraQのユーザー名・チャンネル名として使える文字列に変換します
+func sanitizeName(name string, maxLength int, fallback string) string {
+	name = invalidNameRegex.ReplaceAllString(name, "_")
+	if len(name) > maxLength {
+		name = name[:maxLength]
+	}
+	name = strings.Trim(name, "_-")
+	if len(name) == 0 {
+		return fallback
+	}
+	return name
+}
+
+// uniqueName usedに含まれないように連番を付与した名前を返し、usedに追加します
+func uniqueName(name string, maxLength int, used map[string]bool) string {
+	result := name
+	for i := 2; used[strings.ToLower(result)]; i++ {
+		suffix := "_" + strconv.Itoa(i)
+		base := name
+		if len(base)+len(suffix) > maxLength {
+			base = base[:maxLength-len(suffix)]
+		}
+		result = base + suffix
+	}
+	used[strings.ToLower(result)] = true
+	return result
+}
+
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
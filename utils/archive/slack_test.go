@@ -0,0 +1,191 @@
+package archive
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func writeSlackZip(t *testing.T, files map[string]string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "slack.zip")
+	f, err := os.Create(p)
+	require.NoError(t, err)
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	require.NoError(t, f.Close())
+	return p
+}
+
+func TestConvertSlack(t *testing.T) {
+	t.Parallel()
+
+	zipPath := writeSlackZip(t, map[string]string{
+		"users.json": `[
+			{"id":"U1","name":"alice","profile":{"display_name":"Alice"}},
+			{"id":"U2","name":"bob.smith","real_name":"Bob","deleted":true}
+		]`,
+		"channels.json": `[
+			{"id":"C1","name":"general","created":1500000000,"creator":"U1","topic":{"value":"talk with <@U2>"}},
+			{"id":"C2","name":"a-very-long-channel-name","created":1500000000,"is_archived":true,"purpose":{"value":"old"}}
+		]`,
+		"dms.json": `[{"id":"D1","created":1500000000,"members":["U1","U2"]}]`,
+		"general/2017-07-14.json": `[
+			{"type":"message","user":"U1","text":"hi <@U2> see <#C2|a-very-long-channel-name> &amp; <https://example.com|example> :+1::skin-tone-2:","ts":"1500000000.000100",
+			 "reactions":[{"name":"+1","users":["U1","U2"]},{"name":"party_parrot","users":["U2"]}]},
+			{"type":"message","subtype":"channel_join","user":"U2","text":"<@U2> has joined the channel","ts":"1500000001.000000"}
+		]`,
+		"general/2017-07-15.json": `[
+			{"type":"message","user":"U2","text":"reply","ts":"1500086400.000000","thread_ts":"1500000000.000100","edited":{"ts":"1500086500.000000"}},
+			{"type":"message","subtype":"bot_message","bot_id":"B1","text":"beep","ts":"1500086401.000000"}
+		]`,
+		"D1/2017-07-14.json": `[{"type":"message","user":"U2","text":"secret","ts":"1500000002.000000"}]`,
+	})
+	dir := filepath.Join(t.TempDir(), "archive")
+	require.NoError(t, ConvertSlack(zipPath, dir, SlackOptions{ParentChannel: "slack"}, zap.NewNop()))
+
+	users := map[string]*userRecord{}
+	require.NoError(t, readJSONL(dir, usersFileName, func(r *userRecord) error {
+		users[r.Name] = r
+		return nil
+	}))
+	if assert.Len(t, users, 3) {
+		assert.Equal(t, "Alice", users["alice"].DisplayName)
+		assert.Equal(t, 0, users["bob_smith"].State)
+		assert.True(t, users[slackBotUserName].Bot)
+	}
+	alice, bob := users["alice"], users["bob_smith"]
+
+	files := map[uuid.UUID]*fileRecord{}
+	require.NoError(t, readJSONL(dir, filesFileName, func(r *fileRecord) error {
+		files[r.ID] = r
+		return nil
+	}))
+	if assert.Contains(t, files, alice.IconFileID) {
+		assert.FileExists(t, blobPath(dir, alice.IconFileID.String()))
+	}
+
+	channels := map[string]*channelRecord{}
+	require.NoError(t, readJSONL(dir, channelsFileName, func(r *channelRecord) error {
+		channels[r.Name] = r
+		return nil
+	}))
+	if assert.Len(t, channels, 4) {
+		assert.Equal(t, uuid.Nil, channels["slack"].ParentID)
+		assert.Equal(t, channels["slack"].ID, channels["general"].ParentID)
+		assert.Equal(t, `talk with !{"type":"user","raw":"@bob_smith","id":"`+bob.ID.String()+`"}`, channels["general"].Topic)
+		assert.Equal(t, alice.ID, channels["general"].CreatorID)
+		assert.False(t, channels["a-very-long-channel"].IsVisible)
+		assert.Equal(t, "old", channels["a-very-long-channel"].Topic)
+		assert.ElementsMatch(t, []uuid.UUID{alice.ID, bob.ID}, channels["dm_d1"].DMUsers)
+	}
+
+	var messages []*messageRecord
+	require.NoError(t, readJSONL(dir, messagesFileName, func(r *messageRecord) error {
+		messages = append(messages, r)
+		return nil
+	}))
+	if assert.Len(t, messages, 4) {
+		m := messages[0]
+		assert.Equal(t, `hi !{"type":"user","raw":"@bob_smith","id":"`+bob.ID.String()+`"} see !{"type":"channel","raw":"#slack/a-very-long-channel","id":"`+channels["a-very-long-channel"].ID.String()+`"} & [example](https://example.com) :thumbsup:`, m.Text)
+		assert.Equal(t, alice.ID, m.UserID)
+		assert.Equal(t, channels["general"].ID, m.ChannelID)
+		assert.Equal(t, time.Unix(1500000000, 100000), m.CreatedAt.Local())
+		assert.Len(t, m.Stamps, 3)
+
+		reply := messages[1]
+		assert.Equal(t, optional.From(m.ID), reply.ParentMessageID)
+		assert.Equal(t, time.Unix(1500086500, 0), reply.UpdatedAt.Local())
+
+		assert.Equal(t, users[slackBotUserName].ID, messages[2].UserID)
+		assert.Equal(t, channels["dm_d1"].ID, messages[3].ChannelID)
+	}
+
+	stamps := map[string]*stampRecord{}
+	require.NoError(t, readJSONL(dir, stampsFileName, func(r *stampRecord) error {
+		stamps[r.Name] = r
+		return nil
+	}))
+	assert.Len(t, stamps, 2)
+	assert.Contains(t, stamps, "thumbsup")
+	assert.Contains(t, stamps, "party_parrot")
+
+	assert.FileExists(t, filepath.Join(dir, manifestFileName))
+}
+
+func TestConvertSlackText(t *testing.T) {
+	t.Parallel()
+
+	u := &userRecord{ID: uuid.Must(uuid.NewV4()), Name: "alice"}
+	ch := &channelRecord{ID: uuid.Must(uuid.NewV4()), Name: "general"}
+	users := map[string]*userRecord{"U1": u}
+	channels := map[string]*channelRecord{"C1": ch}
+	paths := map[string]string{"C1": "general"}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain", "hello", "hello"},
+		{"user", "<@U1>", `!{"type":"user","raw":"@alice","id":"` + u.ID.String() + `"}`},
+		{"unknown user with label", "<@U9|carol>", "@carol"},
+		{"channel", "<#C1|general>", `!{"type":"channel","raw":"#general","id":"` + ch.ID.String() + `"}`},
+		{"unknown channel", "<#C9|random>", "#random"},
+		{"special mention", "<!here>", "@here"},
+		{"user group", "<!subteam^S1|@devs>", "@devs"},
+		{"link", "<https://example.com>", "https://example.com"},
+		{"link with label", "<https://example.com|Example>", "[Example](https://example.com)"},
+		{"mail", "<mailto:a@example.com|a@example.com>", "a@example.com"},
+		{"escape", "a &lt;b&gt; &amp;amp;", "a <b> &amp;"},
+		{"emoji alias", ":+1: :smile::skin-tone-3:", ":thumbsup: :smile:"},
+		{"time", "12:30:45", "12:30:45"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, convertSlackText(tt.text, users, channels, paths))
+		})
+	}
+}
+
+func TestParseSlackTs(t *testing.T) {
+	t.Parallel()
+
+	ts, err := parseSlackTs("1503435956.000247")
+	if assert.NoError(t, err) {
+		assert.Equal(t, time.Unix(1503435956, 247000), ts)
+	}
+	ts, err = parseSlackTs("1503435956")
+	if assert.NoError(t, err) {
+		assert.Equal(t, time.Unix(1503435956, 0), ts)
+	}
+	_, err = parseSlackTs("abc")
+	assert.Error(t, err)
+}
+
+func TestUniqueName(t *testing.T) {
+	t.Parallel()
+
+	used := map[string]bool{}
+	assert.Equal(t, "bob_smith", uniqueName(sanitizeName("bob.smith", 32, "user"), 32, used))
+	assert.Equal(t, "Bob_smith_2", uniqueName("Bob_smith", 32, used))
+	assert.Equal(t, "user", uniqueName(sanitizeName("日本語", 32, "user"), 32, used))
+	assert.Equal(t, "abcdefghijklmnopqr_2", uniqueName("abcdefghijklmnopqrst", 20, map[string]bool{"abcdefghijklmnopqrst": true}))
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/audit"
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/utils/optional"
 )
@@ -154,6 +155,9 @@ func Install(repo repository.Repository, fm file.Manager, logger *zap.Logger, up
 				return err
 			}
 
+			if err := audit.RecordSystemAction(repo, model.AuditActionStampUpdated, s.ID.String(), model.AuditLogDetail{"fileId": meta.GetID()}); err != nil {
+				logger.Warn("failed to record audit log", zap.Error(err), zap.Stringer("stampId", s.ID))
+			}
 			logger.Info(fmt.Sprintf("stamp updated: %s (%s)", name, s.ID))
 		}
 	}
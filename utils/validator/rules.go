@@ -128,3 +128,26 @@ var ClipFolderNameRuleRequired = append([]vd.Rule{
 var ClipFolderDescriptionRule = []vd.Rule{
 	vd.RuneLength(0, 1000),
 }
+
+// BotCommandNameRule Botコマンド名バリデーションルール
+var BotCommandNameRule = []vd.Rule{
+	vd.Match(regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)).Error("must contain [a-zA-Z0-9_-] only"),
+	vd.RuneLength(1, 32),
+}
+
+// BotCommandNameRuleRequired Botコマンド名バリデーションルール with Required
+var BotCommandNameRuleRequired = append([]vd.Rule{
+	vd.Required,
+}, BotCommandNameRule...)
+
+// BotCommandArgNameRuleRequired Botコマンド引数名バリデーションルール with Required
+var BotCommandArgNameRuleRequired = []vd.Rule{
+	vd.Required,
+	vd.Match(regexp.MustCompile(`^[a-zA-Z0-9_]+$`)).Error("must contain [a-zA-Z0-9_] only"),
+	vd.RuneLength(1, 32),
+}
+
+// BotCommandDescriptionRule Botコマンド説明バリデーションルール
+var BotCommandDescriptionRule = []vd.Rule{
+	vd.RuneLength(0, 1000),
+}
@@ -47,6 +47,19 @@ var NotInternalURL = vd.By(func(value interface{}) error {
 	return nil
 })
 
+// HTTPSURL スキームがhttpsのURLである
+var HTTPSURL = vd.By(func(value interface{}) error {
+	s, _ := value.(string)
+	if len(s) == 0 {
+		return nil
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme != "https" {
+		return errors.New("must be https url")
+	}
+	return nil
+})
+
 // NotNilUUID uuid.Nilでない
 var NotNilUUID = vd.By(func(value interface{}) error {
 	switch u := value.(type) {
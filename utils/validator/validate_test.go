@@ -9,6 +9,23 @@ import (
 	"github.com/traPtitech/traQ/utils/optional"
 )
 
+func TestHTTPSURL(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ok (empty)", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, HTTPSURL.Validate(""))
+	})
+	t.Run("ok (https)", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, HTTPSURL.Validate("https://push.example.com/a"))
+	})
+	t.Run("ng (http)", func(t *testing.T) {
+		t.Parallel()
+		assert.Error(t, HTTPSURL.Validate("http://push.example.com/a"))
+	})
+}
+
 func TestNotNilUUID(t *testing.T) {
 	t.Parallel()
 
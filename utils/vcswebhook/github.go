@@ -0,0 +1,162 @@
+package vcswebhook
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+
+	"github.com/traPtitech/traQ/utils/hmac"
+)
+
+const githubSignaturePrefix = "sha256="
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+type githubRepository struct {
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+}
+
+type githubPush struct {
+	Ref     string `json:"ref"`
+	Compare string `json:"compare"`
+	Deleted bool   `json:"deleted"`
+	Forced  bool   `json:"forced"`
+	Commits []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+		URL     string `json:"url"`
+		Author  struct {
+			Name string `json:"name"`
+		} `json:"author"`
+	} `json:"commits"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubUser       `json:"sender"`
+}
+
+type githubPullRequest struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number  int        `json:"number"`
+		Title   string     `json:"title"`
+		HTMLURL string     `json:"html_url"`
+		Merged  bool       `json:"merged"`
+		User    githubUser `json:"user"`
+		Head    struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	} `json:"pull_request"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubUser       `json:"sender"`
+}
+
+type githubIssue struct {
+	Action string `json:"action"`
+	Issue  struct {
+		Number  int        `json:"number"`
+		Title   string     `json:"title"`
+		HTMLURL string     `json:"html_url"`
+		User    githubUser `json:"user"`
+	} `json:"issue"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubUser       `json:"sender"`
+}
+
+type githubRelease struct {
+	Action  string `json:"action"`
+	Release struct {
+		TagName    string `json:"tag_name"`
+		Name       string `json:"name"`
+		HTMLURL    string `json:"html_url"`
+		Prerelease bool   `json:"prerelease"`
+	} `json:"release"`
+	Repository githubRepository `json:"repository"`
+	Sender     githubUser       `json:"sender"`
+}
+
+type githubWorkflowRun struct {
+	Action      string `json:"action"`
+	WorkflowRun struct {
+		Name       string `json:"name"`
+		RunNumber  int    `json:"run_number"`
+		HeadBranch string `json:"head_branch"`
+		Conclusion string `json:"conclusion"`
+		HTMLURL    string `json:"html_url"`
+	} `json:"workflow_run"`
+	Repository githubRepository `json:"repository"`
+}
+
+var (
+	githubPushTemplate = mustParse("push", `
+**[{{.Repository.FullName}}]({{.Repository.HTMLURL}})** {{.Sender.Login}} が `+"`{{branch .Ref}}`"+` に{{if .Forced}}強制{{end}}プッシュしました ([比較]({{.Compare}}))
+{{range .Commits}}- [`+"`{{short .ID}}`"+`]({{.URL}}) {{firstLine .Message}} - {{.Author.Name}}
+{{end}}`)
+	githubPullRequestTemplate = mustParse("pull_request", `
+**[{{.Repository.FullName}}]({{.Repository.HTMLURL}})** {{.Sender.Login}} がプルリクエストを{{if eq .Action "opened"}}作成{{else if eq .Action "reopened"}}再オープン{{else if eq .Action "ready_for_review"}}レビュー可能に{{else if .PullRequest.Merged}}マージ{{else}}クローズ{{end}}しました
+[#{{.PullRequest.Number}} {{.PullRequest.Title}}]({{.PullRequest.HTMLURL}}) (`+"`{{.PullRequest.Head.Ref}}` → `{{.PullRequest.Base.Ref}}`"+`)`)
+	githubIssueTemplate = mustParse("issues", `
+**[{{.Repository.FullName}}]({{.Repository.HTMLURL}})** {{.Sender.Login}} がIssueを{{if eq .Action "opened"}}作成{{else if eq .Action "reopened"}}再オープン{{else}}クローズ{{end}}しました
+[#{{.Issue.Number}} {{.Issue.Title}}]({{.Issue.HTMLURL}})`)
+	githubReleaseTemplate = mustParse("release", `
+**[{{.Repository.FullName}}]({{.Repository.HTMLURL}})** {{if .Release.Prerelease}}プレ{{end}}リリース [{{if .Release.Name}}{{.Release.Name}}{{else}}{{.Release.TagName}}{{end}}]({{.Release.HTMLURL}}) が公開されました`)
+	githubWorkflowRunTemplate = mustParse("workflow_run", `
+**[{{.Repository.FullName}}]({{.Repository.HTMLURL}})** ワークフロー [{{.WorkflowRun.Name}} #{{.WorkflowRun.RunNumber}}]({{.WorkflowRun.HTMLURL}}) (`+"`{{.WorkflowRun.HeadBranch}}`"+`) が{{if eq .WorkflowRun.Conclusion "success"}}成功{{else if eq .WorkflowRun.Conclusion "cancelled"}}キャンセル{{else}}失敗{{end}}しました`)
+)
+
+// VerifyGitHubSignature X-Hub-Signature-256ヘッダーの値を検証します
+func VerifyGitHubSignature(body []byte, secret, signature string) bool {
+	if !strings.HasPrefix(signature, githubSignaturePrefix) {
+		return false
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, githubSignaturePrefix))
+	if err != nil || len(sig) == 0 {
+		return false
+	}
+	return subtle.ConstantTimeCompare(hmac.SHA256(body, secret), sig) == 1
+}
+
+// RenderGitHub GitHubのWebhookペイロードをメッセージ本文に変換します
+//
+// eventにはX-GitHub-Eventヘッダーの値を指定します。
+// 通知する必要の無いアクションの場合は空文字列を返します。
+func RenderGitHub(event string, body []byte) (string, error) {
+	switch event {
+	case "ping":
+		return "", nil
+	case "push":
+		return render(githubPushTemplate, body, func(p *githubPush) bool {
+			return p.Deleted || len(p.Commits) == 0
+		})
+	case "pull_request":
+		return render(githubPullRequestTemplate, body, func(p *githubPullRequest) bool {
+			switch p.Action {
+			case "opened", "closed", "reopened", "ready_for_review":
+				return false
+			}
+			return true
+		})
+	case "issues":
+		return render(githubIssueTemplate, body, func(p *githubIssue) bool {
+			switch p.Action {
+			case "opened", "closed", "reopened":
+				return false
+			}
+			return true
+		})
+	case "release":
+		return render(githubReleaseTemplate, body, func(p *githubRelease) bool {
+			return p.Action != "published"
+		})
+	case "workflow_run":
+		return render(githubWorkflowRunTemplate, body, func(p *githubWorkflowRun) bool {
+			return p.Action != "completed"
+		})
+	default:
+		return "", ErrUnsupportedEvent
+	}
+}
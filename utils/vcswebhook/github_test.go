@@ -0,0 +1,140 @@
+package vcswebhook
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+func TestVerifyGitHubSignature(t *testing.T) {
+	t.Parallel()
+
+	// https://docs.github.com/en/webhooks/using-webhooks/validating-webhook-deliveries
+	const (
+		secret = "It's a Secret to Everybody"
+		body   = "Hello, World!"
+		sig    = "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+	)
+
+	assert.True(t, VerifyGitHubSignature([]byte(body), secret, sig))
+	assert.False(t, VerifyGitHubSignature([]byte(body+"!"), secret, sig))
+	assert.False(t, VerifyGitHubSignature([]byte(body), "wrong", sig))
+	assert.False(t, VerifyGitHubSignature([]byte(body), secret, sig[len("sha256="):]))
+	assert.False(t, VerifyGitHubSignature([]byte(body), secret, "sha256=zz"))
+	assert.False(t, VerifyGitHubSignature([]byte(body), secret, ""))
+}
+
+func TestRenderGitHub(t *testing.T) {
+	t.Parallel()
+
+	const repo = `"repository":{"full_name":"traPtitech/traQ","html_url":"https://github.com/traPtitech/traQ"},"sender":{"login":"takashi_trap"}`
+
+	tests := []struct {
+		name    string
+		event   string
+		body    string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "ping",
+			event: "ping",
+			body:  `{"zen":"Keep it logically awesome."}`,
+			want:  "",
+		},
+		{
+			name:  "push",
+			event: "push",
+			body:  `{"ref":"refs/heads/master","compare":"https://github.com/traPtitech/traQ/compare/a...b","commits":[{"id":"0123456789abcdef","message":"fix bug\n\ndetail","url":"https://github.com/traPtitech/traQ/commit/0123456789abcdef","author":{"name":"takashi"}}],` + repo + `}`,
+			want: "**[traPtitech/traQ](https://github.com/traPtitech/traQ)** takashi_trap が `master` にプッシュしました ([比較](https://github.com/traPtitech/traQ/compare/a...b))\n" +
+				"- [`0123456`](https://github.com/traPtitech/traQ/commit/0123456789abcdef) fix bug - takashi",
+		},
+		{
+			name:  "push (branch deleted)",
+			event: "push",
+			body:  `{"ref":"refs/heads/feature","deleted":true,"commits":[],` + repo + `}`,
+			want:  "",
+		},
+		{
+			name:  "pull_request (merged)",
+			event: "pull_request",
+			body:  `{"action":"closed","pull_request":{"number":42,"title":"Add feature","html_url":"https://github.com/traPtitech/traQ/pull/42","merged":true,"head":{"ref":"feature"},"base":{"ref":"master"}},` + repo + `}`,
+			want: "**[traPtitech/traQ](https://github.com/traPtitech/traQ)** takashi_trap がプルリクエストをマージしました\n" +
+				"[#42 Add feature](https://github.com/traPtitech/traQ/pull/42) (`feature` → `master`)",
+		},
+		{
+			name:  "pull_request (synchronize)",
+			event: "pull_request",
+			body:  `{"action":"synchronize","pull_request":{"number":42},` + repo + `}`,
+			want:  "",
+		},
+		{
+			name:  "issues",
+			event: "issues",
+			body:  `{"action":"opened","issue":{"number":1,"title":"Bug","html_url":"https://github.com/traPtitech/traQ/issues/1"},` + repo + `}`,
+			want: "**[traPtitech/traQ](https://github.com/traPtitech/traQ)** takashi_trap がIssueを作成しました\n" +
+				"[#1 Bug](https://github.com/traPtitech/traQ/issues/1)",
+		},
+		{
+			name:  "release",
+			event: "release",
+			body:  `{"action":"published","release":{"tag_name":"v3.0.0","name":"","html_url":"https://github.com/traPtitech/traQ/releases/tag/v3.0.0"},` + repo + `}`,
+			want:  "**[traPtitech/traQ](https://github.com/traPtitech/traQ)** リリース [v3.0.0](https://github.com/traPtitech/traQ/releases/tag/v3.0.0) が公開されました",
+		},
+		{
+			name:  "workflow_run",
+			event: "workflow_run",
+			body:  `{"action":"completed","workflow_run":{"name":"CI","run_number":10,"head_branch":"master","conclusion":"failure","html_url":"https://github.com/traPtitech/traQ/actions/runs/1"},` + repo + `}`,
+			want:  "**[traPtitech/traQ](https://github.com/traPtitech/traQ)** ワークフロー [CI #10](https://github.com/traPtitech/traQ/actions/runs/1) (`master`) が失敗しました",
+		},
+		{
+			name:  "workflow_run (in progress)",
+			event: "workflow_run",
+			body:  `{"action":"requested","workflow_run":{"name":"CI"},` + repo + `}`,
+			want:  "",
+		},
+		{
+			name:    "unsupported event",
+			event:   "fork",
+			body:    `{}`,
+			wantErr: ErrUnsupportedEvent,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := RenderGitHub(tt.event, []byte(tt.body))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+		_, err := RenderGitHub("push", []byte("po"))
+		assert.Error(t, err)
+	})
+
+	t.Run("embed in payload", func(t *testing.T) {
+		t.Parallel()
+		const embed = `!{"type":"user","raw":"@takashi_trap","id":"dfdff0c9-5de0-46ee-9721-2525e8bb3d45"}`
+		// エスケープしなければメンションになることを確認
+		assert.Len(t, message.Parse(embed).Mentions, 1)
+
+		body := `{"action":"opened","issue":{"number":1,"title":` + strconv.Quote(embed) + `,"html_url":"https://github.com/traPtitech/traQ/issues/1"},` + repo + `}`
+		got, err := RenderGitHub("issues", []byte(body))
+		if assert.NoError(t, err) {
+			assert.Empty(t, message.Parse(got).Mentions)
+			assert.NotContains(t, got, "!{")
+		}
+	})
+}
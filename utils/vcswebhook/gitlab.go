@@ -0,0 +1,136 @@
+package vcswebhook
+
+import "crypto/subtle"
+
+type gitlabUser struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+type gitlabProject struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebURL            string `json:"web_url"`
+}
+
+type gitlabPush struct {
+	Ref         string  `json:"ref"`
+	CheckoutSHA *string `json:"checkout_sha"`
+	UserName    string  `json:"user_name"`
+	Commits     []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+		URL     string `json:"url"`
+		Author  struct {
+			Name string `json:"name"`
+		} `json:"author"`
+	} `json:"commits"`
+	TotalCommitsCount int           `json:"total_commits_count"`
+	Project           gitlabProject `json:"project"`
+}
+
+type gitlabMergeRequest struct {
+	User             gitlabUser    `json:"user"`
+	Project          gitlabProject `json:"project"`
+	ObjectAttributes struct {
+		IID          int    `json:"iid"`
+		Title        string `json:"title"`
+		URL          string `json:"url"`
+		Action       string `json:"action"`
+		SourceBranch string `json:"source_branch"`
+		TargetBranch string `json:"target_branch"`
+	} `json:"object_attributes"`
+}
+
+type gitlabIssue struct {
+	User             gitlabUser    `json:"user"`
+	Project          gitlabProject `json:"project"`
+	ObjectAttributes struct {
+		IID    int    `json:"iid"`
+		Title  string `json:"title"`
+		URL    string `json:"url"`
+		Action string `json:"action"`
+	} `json:"object_attributes"`
+}
+
+type gitlabRelease struct {
+	Action  string        `json:"action"`
+	Name    string        `json:"name"`
+	Tag     string        `json:"tag"`
+	URL     string        `json:"url"`
+	Project gitlabProject `json:"project"`
+}
+
+type gitlabPipeline struct {
+	User             gitlabUser    `json:"user"`
+	Project          gitlabProject `json:"project"`
+	ObjectAttributes struct {
+		ID     int    `json:"id"`
+		Ref    string `json:"ref"`
+		Status string `json:"status"`
+	} `json:"object_attributes"`
+}
+
+var (
+	gitlabPushTemplate = mustParse("Push Hook", `
+**[{{.Project.PathWithNamespace}}]({{.Project.WebURL}})** {{.UserName}} が `+"`{{branch .Ref}}`"+` に{{.TotalCommitsCount}}件のコミットをプッシュしました
+{{range .Commits}}- [`+"`{{short .ID}}`"+`]({{.URL}}) {{firstLine .Message}} - {{.Author.Name}}
+{{end}}`)
+	gitlabMergeRequestTemplate = mustParse("Merge Request Hook", `
+**[{{.Project.PathWithNamespace}}]({{.Project.WebURL}})** {{.User.Username}} がマージリクエストを{{if eq .ObjectAttributes.Action "open"}}作成{{else if eq .ObjectAttributes.Action "reopen"}}再オープン{{else if eq .ObjectAttributes.Action "merge"}}マージ{{else}}クローズ{{end}}しました
+[!{{.ObjectAttributes.IID}} {{.ObjectAttributes.Title}}]({{.ObjectAttributes.URL}}) (`+"`{{.ObjectAttributes.SourceBranch}}` → `{{.ObjectAttributes.TargetBranch}}`"+`)`)
+	gitlabIssueTemplate = mustParse("Issue Hook", `
+**[{{.Project.PathWithNamespace}}]({{.Project.WebURL}})** {{.User.Username}} がIssueを{{if eq .ObjectAttributes.Action "open"}}作成{{else if eq .ObjectAttributes.Action "reopen"}}再オープン{{else}}クローズ{{end}}しました
+[#{{.ObjectAttributes.IID}} {{.ObjectAttributes.Title}}]({{.ObjectAttributes.URL}})`)
+	gitlabReleaseTemplate = mustParse("Release Hook", `
+**[{{.Project.PathWithNamespace}}]({{.Project.WebURL}})** リリース [{{if .Name}}{{.Name}}{{else}}{{.Tag}}{{end}}]({{.URL}}) が公開されました`)
+	gitlabPipelineTemplate = mustParse("Pipeline Hook", `
+**[{{.Project.PathWithNamespace}}]({{.Project.WebURL}})** パイプライン [#{{.ObjectAttributes.ID}}]({{.Project.WebURL}}/-/pipelines/{{.ObjectAttributes.ID}}) (`+"`{{.ObjectAttributes.Ref}}`"+`) が{{if eq .ObjectAttributes.Status "success"}}成功{{else if eq .ObjectAttributes.Status "canceled"}}キャンセル{{else}}失敗{{end}}しました`)
+)
+
+// VerifyGitLabToken X-Gitlab-Tokenヘッダーの値を検証します
+func VerifyGitLabToken(secret, token string) bool {
+	return len(token) > 0 && subtle.ConstantTimeCompare([]byte(secret), []byte(token)) == 1
+}
+
+// RenderGitLab GitLabのWebhookペイロードをメッセージ本文に変換します
+//
+// eventにはX-Gitlab-Eventヘッダーの値を指定します。
+// 通知する必要の無いアクションの場合は空文字列を返します。
+func RenderGitLab(event string, body []byte) (string, error) {
+	switch event {
+	case "Push Hook":
+		return render(gitlabPushTemplate, body, func(p *gitlabPush) bool {
+			return p.CheckoutSHA == nil || len(p.Commits) == 0
+		})
+	case "Merge Request Hook":
+		return render(gitlabMergeRequestTemplate, body, func(p *gitlabMergeRequest) bool {
+			switch p.ObjectAttributes.Action {
+			case "open", "close", "reopen", "merge":
+				return false
+			}
+			return true
+		})
+	case "Issue Hook":
+		return render(gitlabIssueTemplate, body, func(p *gitlabIssue) bool {
+			switch p.ObjectAttributes.Action {
+			case "open", "close", "reopen":
+				return false
+			}
+			return true
+		})
+	case "Release Hook":
+		return render(gitlabReleaseTemplate, body, func(p *gitlabRelease) bool {
+			return p.Action != "create"
+		})
+	case "Pipeline Hook":
+		return render(gitlabPipelineTemplate, body, func(p *gitlabPipeline) bool {
+			switch p.ObjectAttributes.Status {
+			case "success", "failed", "canceled":
+				return false
+			}
+			return true
+		})
+	default:
+		return "", ErrUnsupportedEvent
+	}
+}
@@ -0,0 +1,102 @@
+package vcswebhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyGitLabToken(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, VerifyGitLabToken("secret", "secret"))
+	assert.False(t, VerifyGitLabToken("secret", "secre"))
+	assert.False(t, VerifyGitLabToken("secret", ""))
+	assert.False(t, VerifyGitLabToken("", ""))
+}
+
+func TestRenderGitLab(t *testing.T) {
+	t.Parallel()
+
+	const project = `"project":{"path_with_namespace":"trap/traq","web_url":"https://gitlab.example.com/trap/traq"}`
+
+	tests := []struct {
+		name    string
+		event   string
+		body    string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "push",
+			event: "Push Hook",
+			body:  `{"ref":"refs/heads/main","checkout_sha":"0123456789abcdef","user_name":"takashi","total_commits_count":1,"commits":[{"id":"0123456789abcdef","message":"fix bug\n","url":"https://gitlab.example.com/trap/traq/-/commit/0123456789abcdef","author":{"name":"takashi"}}],` + project + `}`,
+			want: "**[trap/traq](https://gitlab.example.com/trap/traq)** takashi が `main` に1件のコミットをプッシュしました\n" +
+				"- [`0123456`](https://gitlab.example.com/trap/traq/-/commit/0123456789abcdef) fix bug - takashi",
+		},
+		{
+			name:  "push (branch deleted)",
+			event: "Push Hook",
+			body:  `{"ref":"refs/heads/feature","checkout_sha":null,"commits":[],` + project + `}`,
+			want:  "",
+		},
+		{
+			name:  "merge request",
+			event: "Merge Request Hook",
+			body:  `{"user":{"username":"takashi"},"object_attributes":{"iid":3,"title":"Add feature","url":"https://gitlab.example.com/trap/traq/-/merge_requests/3","action":"merge","source_branch":"feature","target_branch":"main"},` + project + `}`,
+			want: "**[trap/traq](https://gitlab.example.com/trap/traq)** takashi がマージリクエストをマージしました\n" +
+				"[!3 Add feature](https://gitlab.example.com/trap/traq/-/merge_requests/3) (`feature` → `main`)",
+		},
+		{
+			name:  "merge request (update)",
+			event: "Merge Request Hook",
+			body:  `{"user":{"username":"takashi"},"object_attributes":{"action":"update"},` + project + `}`,
+			want:  "",
+		},
+		{
+			name:  "issue",
+			event: "Issue Hook",
+			body:  `{"user":{"username":"takashi"},"object_attributes":{"iid":5,"title":"Bug","url":"https://gitlab.example.com/trap/traq/-/issues/5","action":"close"},` + project + `}`,
+			want: "**[trap/traq](https://gitlab.example.com/trap/traq)** takashi がIssueをクローズしました\n" +
+				"[#5 Bug](https://gitlab.example.com/trap/traq/-/issues/5)",
+		},
+		{
+			name:  "release",
+			event: "Release Hook",
+			body:  `{"action":"create","name":"v1.0.0","tag":"v1.0.0","url":"https://gitlab.example.com/trap/traq/-/releases/v1.0.0",` + project + `}`,
+			want:  "**[trap/traq](https://gitlab.example.com/trap/traq)** リリース [v1.0.0](https://gitlab.example.com/trap/traq/-/releases/v1.0.0) が公開されました",
+		},
+		{
+			name:  "pipeline",
+			event: "Pipeline Hook",
+			body:  `{"object_attributes":{"id":100,"ref":"main","status":"success"},` + project + `}`,
+			want:  "**[trap/traq](https://gitlab.example.com/trap/traq)** パイプライン [#100](https://gitlab.example.com/trap/traq/-/pipelines/100) (`main`) が成功しました",
+		},
+		{
+			name:  "pipeline (running)",
+			event: "Pipeline Hook",
+			body:  `{"object_attributes":{"id":100,"ref":"main","status":"running"},` + project + `}`,
+			want:  "",
+		},
+		{
+			name:    "unsupported event",
+			event:   "Wiki Page Hook",
+			body:    `{}`,
+			wantErr: ErrUnsupportedEvent,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := RenderGitLab(tt.event, []byte(tt.body))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
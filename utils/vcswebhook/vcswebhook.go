@@ -0,0 +1,71 @@
+// Package vcswebhook GitHub・GitLabのWebhookペイロードをtraQのメッセージに変換します
+package vcswebhook
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"text/template"
+
+	jsonIter "github.com/json-iterator/go"
+)
+
+var json = jsonIter.ConfigFastest
+
+// ErrUnsupportedEvent 変換に対応していないイベントです
+var ErrUnsupportedEvent = errors.New("unsupported event")
+
+var funcs = template.FuncMap{
+	"short":     shortSHA,
+	"firstLine": firstLine,
+	"branch":    branchName,
+}
+
+func mustParse(name, text string) *template.Template {
+	return template.Must(template.New(name).Funcs(funcs).Parse(text))
+}
+
+// render payloadをデコードし、テンプレートを適用します
+//
+// skipがtrueを返した場合は空文字列を返します
+func render[T any](tmpl *template.Template, body []byte, skip func(p *T) bool) (string, error) {
+	var p T
+	if err := json.Unmarshal(body, &p); err != nil {
+		return "", err
+	}
+	if skip != nil && skip(&p) {
+		return "", nil
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, &p); err != nil {
+		return "", err
+	}
+	return escapeEmbed(strings.TrimSpace(b.String())), nil
+}
+
+// escapeEmbed メンションなどの埋め込みとして解釈されないように"!{"をエスケープします
+//
+// テンプレート自体は埋め込みを含まないため、出力全体をエスケープすることで
+// ペイロード由来の全てのフィールドとその連結を対象にします
+func escapeEmbed(s string) string {
+	return strings.ReplaceAll(s, "!{", "!\u200b{")
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return strings.TrimSpace(s[:i])
+	}
+	return strings.TrimSpace(s)
+}
+
+func branchName(ref string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(ref, "refs/heads/"), "refs/tags/")
+}
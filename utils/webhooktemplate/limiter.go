@@ -0,0 +1,59 @@
+package webhooktemplate
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"golang.org/x/time/rate"
+)
+
+// 削除を試みる保持リミッター数
+const pruneThreshold = 1000
+
+// Limiter Webhookごとのテンプレートの適用回数を制限します
+type Limiter struct {
+	mu       sync.Mutex
+	limiters map[uuid.UUID]*rate.Limiter
+	every    time.Duration
+	burst    int
+}
+
+// NewLimiter Limiterを生成します
+//
+// 各Webhookはeveryごとに1回、連続してburst回までテンプレートを適用できます。
+func NewLimiter(every time.Duration, burst int) *Limiter {
+	return &Limiter{
+		limiters: make(map[uuid.UUID]*rate.Limiter),
+		every:    every,
+		burst:    burst,
+	}
+}
+
+// Allow 指定したWebhookが今テンプレートを適用できるかどうかを返します
+func (l *Limiter) Allow(webhookID uuid.UUID) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lim, ok := l.limiters[webhookID]
+	if !ok {
+		l.prune()
+		lim = rate.NewLimiter(rate.Every(l.every), l.burst)
+		l.limiters[webhookID] = lim
+	}
+	return lim.Allow()
+}
+
+// prune トークンが満タンのリミッターを削除します
+//
+// 満タンのリミッターは新しく生成したものと同じ状態のため、削除しても制限は変わりません
+func (l *Limiter) prune() {
+	if len(l.limiters) < pruneThreshold {
+		return
+	}
+	for id, lim := range l.limiters {
+		if lim.Tokens() >= float64(l.burst) {
+			delete(l.limiters, id)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+// Package webhooktemplate Webhookに送信されたJSONをtext/templateでメッセージに変換します
+package webhooktemplate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+	"unicode/utf8"
+
+	jsonIter "github.com/json-iterator/go"
+)
+
+const (
+	// MaxTemplateLength テンプレートの最大文字数
+	MaxTemplateLength = 10000
+	// MaxOutputLength 出力の最大文字数
+	MaxOutputLength = 10000
+	// MaxBodyLength 適用するJSONの最大バイト数
+	MaxBodyLength = 1 << 20
+	// 出力および関数の結果の最大バイト数 (文字数検査の前に打ち切るため)
+	maxOutputBytes = MaxOutputLength * utf8.UTFMax
+	// テンプレートの適用にかけられる最大時間
+	renderTimeout = time.Second
+	// rangeの各繰り返しの先頭で呼び出す、適用の中断を確認する関数の名前
+	checkFuncName = "_check"
+)
+
+var json = jsonIter.Config{UseNumber: true}.Froze()
+
+var (
+	// ErrTemplateTooLong テンプレートが長すぎます
+	ErrTemplateTooLong = errors.New("template is too long")
+	// ErrOutputTooLong 出力が長すぎます
+	ErrOutputTooLong = errors.New("output is too long")
+	// ErrEmptyOutput 出力が空です
+	ErrEmptyOutput = errors.New("output is empty")
+	// ErrBodyTooLarge JSONが大きすぎます
+	ErrBodyTooLarge = errors.New("body is too large")
+	// ErrTemplateCallNotAllowed template, blockアクションは使用できません
+	ErrTemplateCallNotAllowed = errors.New("template and block actions are not allowed")
+	// ErrFuncNotAllowed 使用できない関数です
+	ErrFuncNotAllowed = errors.New("function is not allowed")
+	// ErrRenderTimeout テンプレートの適用が時間内に終わりませんでした
+	ErrRenderTimeout = errors.New("rendering timed out")
+)
+
+// allowedBuiltins 使用できるtext/templateの組み込み関数
+//
+// printf, html, js, urlquery等は結果の大きさを制限できないため使用できません。
+// printは結果の大きさを制限したものに置き換えます。
+var allowedBuiltins = map[string]bool{
+	"and":   true,
+	"or":    true,
+	"not":   true,
+	"eq":    true,
+	"ne":    true,
+	"lt":    true,
+	"le":    true,
+	"gt":    true,
+	"ge":    true,
+	"len":   true,
+	"index": true,
+	"slice": true,
+}
+
+func newFuncs(ctx context.Context) template.FuncMap {
+	return template.FuncMap{
+		checkFuncName: func() (string, error) {
+			return "", ctx.Err()
+		},
+		"print": func(args ...interface{}) (string, error) {
+			return limit(fmt.Sprint(args...))
+		},
+		"json": func(v interface{}) (string, error) {
+			s, err := json.MarshalToString(v)
+			if err != nil {
+				return "", err
+			}
+			return limit(s)
+		},
+		"join": func(sep string, v []interface{}) (string, error) {
+			s := make([]string, len(v))
+			for i, e := range v {
+				s[i] = fmt.Sprint(e)
+			}
+			return limit(strings.Join(s, sep))
+		},
+		"upper": func(s string) (string, error) {
+			return limit(strings.ToUpper(s))
+		},
+		"lower": func(s string) (string, error) {
+			return limit(strings.ToLower(s))
+		},
+		"trim": strings.TrimSpace,
+		"truncate": func(n int, s string) string {
+			if utf8.RuneCountInString(s) <= n {
+				return s
+			}
+			return string([]rune(s)[:n]) + "…"
+		},
+		"default": func(def interface{}, v interface{}) interface{} {
+			if v == nil || v == "" {
+				return def
+			}
+			return v
+		},
+	}
+}
+
+// limit 関数の結果が出力の上限を超えていればエラーを返します
+//
+// 変数への代入を繰り返して巨大な文字列を作れないようにするためのものです
+func limit(s string) (string, error) {
+	if len(s) > maxOutputBytes {
+		return "", ErrOutputTooLong
+	}
+	return s, nil
+}
+
+// Parse テンプレートを解析します
+func Parse(text string) (*template.Template, error) {
+	return parseWithContext(context.Background(), text)
+}
+
+func parseWithContext(ctx context.Context, text string) (*template.Template, error) {
+	if utf8.RuneCountInString(text) > MaxTemplateLength {
+		return nil, ErrTemplateTooLong
+	}
+	funcs := newFuncs(ctx)
+	tmpl, err := template.New("webhook").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	// defineされたテンプレートはtemplateアクションを禁止しているため呼び出されない
+	if tmpl.Tree != nil {
+		if err := sandbox(tmpl.Tree.Root, funcs); err != nil {
+			return nil, err
+		}
+	}
+	return tmpl, nil
+}
+
+// sandbox 使用できない関数やアクションがないかを検査し、rangeの各繰り返しの先頭に中断の確認を挿入します
+//
+// range以外の実行時間はテンプレートと関数の結果の大きさで制限されるため、
+// rangeで中断を確認すればテンプレートの適用は打ち切られた後すぐに終わります。
+func sandbox(node parse.Node, funcs template.FuncMap) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			if err := sandbox(c, funcs); err != nil {
+				return err
+			}
+		}
+	case *parse.TemplateNode:
+		return ErrTemplateCallNotAllowed
+	case *parse.ActionNode:
+		return sandbox(n.Pipe, funcs)
+	case *parse.IfNode:
+		return sandbox(&n.BranchNode, funcs)
+	case *parse.WithNode:
+		return sandbox(&n.BranchNode, funcs)
+	case *parse.RangeNode:
+		if err := sandbox(&n.BranchNode, funcs); err != nil {
+			return err
+		}
+		if n.List == nil {
+			n.List = &parse.ListNode{NodeType: parse.NodeList}
+		}
+		n.List.Nodes = append([]parse.Node{newCheckAction()}, n.List.Nodes...)
+	case *parse.BranchNode:
+		if err := sandbox(n.Pipe, funcs); err != nil {
+			return err
+		}
+		if err := sandbox(n.List, funcs); err != nil {
+			return err
+		}
+		return sandbox(n.ElseList, funcs)
+	case *parse.PipeNode:
+		if n == nil {
+			return nil
+		}
+		for _, cmd := range n.Cmds {
+			if err := sandbox(cmd, funcs); err != nil {
+				return err
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if err := sandbox(arg, funcs); err != nil {
+				return err
+			}
+		}
+	case *parse.ChainNode:
+		return sandbox(n.Node, funcs)
+	case *parse.IdentifierNode:
+		if _, ok := funcs[n.Ident]; !ok && !allowedBuiltins[n.Ident] {
+			return fmt.Errorf("%w: %s", ErrFuncNotAllowed, n.Ident)
+		}
+	}
+	return nil
+}
+
+// newCheckAction 適用の中断を確認する関数を呼び出すアクションを生成します
+func newCheckAction() *parse.ActionNode {
+	return &parse.ActionNode{
+		NodeType: parse.NodeAction,
+		Pipe: &parse.PipeNode{
+			NodeType: parse.NodePipe,
+			Cmds: []*parse.CommandNode{{
+				NodeType: parse.NodeCommand,
+				Args:     []parse.Node{parse.NewIdentifier(checkFuncName)},
+			}},
+		},
+	}
+}
+
+// Validate テンプレートが正しいかどうかを検証します
+func Validate(text string) error {
+	_, err := Parse(text)
+	return err
+}
+
+// Render JSONのbodyにテンプレートを適用します
+//
+// bodyのトップレベルの値がテンプレートの"."になります。
+// ctxがキャンセルされた場合や適用が時間内に終わらない場合は、その時点で適用を打ち切ります。
+func Render(ctx context.Context, text string, body []byte) (string, error) {
+	if len(body) > MaxBodyLength {
+		return "", ErrBodyTooLarge
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, renderTimeout)
+	defer cancel()
+
+	tmpl, err := parseWithContext(ctx, text)
+	if err != nil {
+		return "", err
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return "", err
+	}
+
+	w := &limitedWriter{limit: maxOutputBytes}
+	if err := tmpl.Execute(w, data); err != nil {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return "", ErrRenderTimeout
+		case errors.Is(err, context.Canceled):
+			return "", context.Canceled
+		case errors.Is(err, ErrOutputTooLong):
+			return "", ErrOutputTooLong
+		}
+		return "", err
+	}
+
+	out := strings.TrimSpace(w.buf.String())
+	if len(out) == 0 {
+		return "", ErrEmptyOutput
+	}
+	if utf8.RuneCountInString(out) > MaxOutputLength {
+		return "", ErrOutputTooLong
+	}
+	return out, nil
+}
+
+// limitedWriter 書き込めるバイト数に上限があるWriter
+type limitedWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, ErrOutputTooLong
+	}
+	return w.buf.Write(p)
+}
@@ -0,0 +1,154 @@
+package webhooktemplate
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, Validate(`{{.title}}`))
+	assert.NoError(t, Validate(`{{range .alerts}}- {{.labels.alertname | upper}}{{end}}`))
+	assert.Error(t, Validate(`{{.title`))
+	assert.Error(t, Validate(`{{unknown .title}}`))
+	assert.ErrorIs(t, Validate(strings.Repeat("a", MaxTemplateLength+1)), ErrTemplateTooLong)
+	assert.ErrorIs(t, Validate(`{{define "a"}}{{template "a"}}{{end}}{{template "a"}}`), ErrTemplateCallNotAllowed)
+	assert.ErrorIs(t, Validate(`{{block "a" .}}{{.}}{{end}}`), ErrTemplateCallNotAllowed)
+	assert.NoError(t, Validate(`{{range .a}}{{range .b}}{{range .c}}{{.}}{{end}}{{end}}{{end}}`))
+	assert.NoError(t, Validate(`{{if and .a (eq (len .b) 1)}}{{index .b 0 | print}}{{end}}`))
+	assert.ErrorIs(t, Validate(`{{printf "%09999999d" 1}}`), ErrFuncNotAllowed)
+	assert.ErrorIs(t, Validate(`{{range .a}}{{.f | call}}{{end}}`), ErrFuncNotAllowed)
+	assert.ErrorIs(t, Validate(`{{html .a}}`), ErrFuncNotAllowed)
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		template string
+		body     string
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "field",
+			template: `**{{.status | upper}}** {{.title}}`,
+			body:     `{"status":"firing","title":"High CPU"}`,
+			want:     "**FIRING** High CPU",
+		},
+		{
+			name:     "range",
+			template: "{{range .alerts}}- {{.name}}: {{.value}}\n{{end}}",
+			body:     `{"alerts":[{"name":"cpu","value":1000000},{"name":"mem","value":0.5}]}`,
+			want:     "- cpu: 1000000\n- mem: 0.5",
+		},
+		{
+			name:     "funcs",
+			template: `{{join ", " .tags}} {{truncate 3 .message}} {{default "none" .missing}} {{json .obj}}`,
+			body:     `{"tags":["a","b",1],"message":"abcdef","obj":{"k":"v"}}`,
+			want:     `a, b, 1 abc… none {"k":"v"}`,
+		},
+		{
+			name:     "empty output",
+			template: `{{if .ok}}ok{{end}}`,
+			body:     `{"ok":false}`,
+			wantErr:  ErrEmptyOutput,
+		},
+		{
+			name:     "output too long",
+			template: `{{range .items}}{{$.text}}{{end}}`,
+			body:     `{"items":[` + strings.Repeat("1,", 10) + `1],"text":"` + strings.Repeat("a", 1000) + `"}`,
+			wantErr:  ErrOutputTooLong,
+		},
+		{
+			name:     "nested range",
+			template: `{{range .a}}{{range $.a}}x{{end}}{{end}}`,
+			body:     `{"a":[1,2]}`,
+			want:     "xxxx",
+		},
+		{
+			name:     "function output too long",
+			template: `{{$s := .s}}{{range 20}}{{$s = print $s $s}}{{end}}ok`,
+			body:     `{"s":"aaaa"}`,
+			wantErr:  ErrOutputTooLong,
+		},
+		{
+			name:     "timeout",
+			template: `{{range 1000000000}}{{end}}ok`,
+			body:     `{}`,
+			wantErr:  ErrRenderTimeout,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Render(context.Background(), tt.template, []byte(tt.body))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+
+	t.Run("invalid json", func(t *testing.T) {
+		t.Parallel()
+		_, err := Render(context.Background(), `{{.}}`, []byte("po"))
+		assert.Error(t, err)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		t.Parallel()
+		_, err := Render(context.Background(), `{{.}}`, []byte(`"`+strings.Repeat("a", MaxBodyLength)+`"`))
+		assert.ErrorIs(t, err, ErrBodyTooLarge)
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		t.Parallel()
+		_, err := Render(context.Background(), `{{truncate 3 .num}}`, []byte(`{"num":1}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			_, err := Render(ctx, `{{range 1000000000}}{{range 1000000000}}{{end}}{{end}}ok`, []byte(`{}`))
+			done <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+
+		// タイムアウトより前に、適用していたgoroutineが終了する
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, context.Canceled)
+		case <-time.After(renderTimeout / 2):
+			t.Fatal("rendering did not stop after cancellation")
+		}
+	})
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(time.Hour, 2)
+	id1 := uuid.Must(uuid.NewV4())
+	id2 := uuid.Must(uuid.NewV4())
+
+	assert.True(t, l.Allow(id1))
+	assert.True(t, l.Allow(id1))
+	assert.False(t, l.Allow(id1))
+	assert.True(t, l.Allow(id2))
+}